@@ -2,9 +2,13 @@ package assets
 
 import (
 	"bytes"
-	_ "embed"
+	"errors"
+	"fmt"
 	"image"
 	_ "image/png"
+	"io/fs"
+	"log/slog"
+	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
@@ -19,25 +23,95 @@ var (
 	ScoreFont       *text.GoTextFace
 )
 
-//go:embed ball.png
-var ballPNG []byte
+// Theme is how the game's sprites are drawn
+type Theme string
 
-//go:embed bat.png
-var batPNG []byte
+const (
+	ThemeSprites Theme = "sprites" // Images, from the asset pack or built in
+	ThemeVector  Theme = "vector"  // Retro shapes drawn with vector primitives, needing no images
+)
+
+// fontFile is the name of a replacement font in an asset pack
+const fontFile = "font.ttf"
+
+// A pack's sprite may be this many times bigger or smaller than the built-in one
+const maxSizeRatio = 2.0
 
-//go:embed stumps.png
-var stumpsPNG []byte
+// sprite is an image the game draws, and where to find it
+type sprite struct {
+	file     string // Name in an asset pack
+	embedded []byte
+	size     image.Point // Of the built-in image
+	draw     func(*ebiten.Image)
+	scale    float64 // Applied to the image as loaded
+	target   **ebiten.Image
+}
 
-//go:embed stumps-out.png
-var stumpsOutPNG []byte
+var sprites = []sprite{
+	{file: "ball.png", embedded: ballPNG, size: image.Pt(133, 110), draw: drawVectorBall, scale: 0.7, target: &BallSprite}, // Make ball smaller (70% of original)
+	{file: "bat.png", embedded: batPNG, size: image.Pt(45, 298), draw: drawVectorBat, scale: 1.3, target: &BatSprite},      // Make bat bigger (130% of original)
+	{file: "stumps.png", embedded: stumpsPNG, size: image.Pt(136, 610), draw: drawVectorStumps, scale: 0.57, target: &StumpsSprite},
+	{file: "stumps-out.png", embedded: stumpsOutPNG, size: image.Pt(131, 479), draw: drawVectorStumpsOut, scale: 0.7, target: &StumpsOutSprite},
+}
+
+var (
+	theme = defaultTheme
+	// problems are what went wrong loading the sprites, kept for the game to show
+	problems []error
+)
 
 func init() {
-	BallSprite = scaleImage(loadPNG(ballPNG), 0.7) // Make ball smaller (70% of original)
-	BatSprite = scaleImage(loadPNG(batPNG), 1.3)   // Make bat bigger (130% of original)
-	StumpsSprite = scaleImage(loadPNG(stumpsPNG), 0.57)
-	StumpsOutSprite = scaleImage(loadPNG(stumpsOutPNG), 0.7)
+	loadSprites(nil)
+	loadFont(nil)
+}
+
+// SetTheme redraws the sprites in the given theme. An empty theme is the build's default.
+func SetTheme(name Theme) error {
+	switch name {
+	case "":
+		name = defaultTheme
+	case ThemeSprites, ThemeVector:
+	default:
+		return fmt.Errorf("unknown theme %q, use %s or %s", name, ThemeSprites, ThemeVector)
+	}
 
-	fontSource, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	theme = name
+	loadSprites(nil)
+	return nil
+}
+
+// CurrentTheme is the theme the sprites are drawn in
+func CurrentTheme() Theme {
+	return theme
+}
+
+// LoadPack swaps in the sprites and font found in an asset pack directory. Anything the
+// pack doesn't have, or has broken, keeps its built-in version. Loading a pack again picks
+// up changes to it.
+func LoadPack(dir string) {
+	pack := os.DirFS(dir)
+	loadSprites(pack)
+	loadFont(pack)
+}
+
+// loadFont loads the pack's font, or the built-in one if the pack has none that works
+func loadFont(pack fs.FS) {
+	data := goregular.TTF
+	if pack != nil {
+		packData, err := fs.ReadFile(pack, fontFile)
+		switch {
+		case err == nil:
+			if _, parseErr := text.NewGoTextFaceSource(bytes.NewReader(packData)); parseErr != nil {
+				report(fmt.Errorf("asset pack %s: %w, using the built-in font", fontFile, parseErr))
+			} else {
+				data = packData
+			}
+		case !errors.Is(err, fs.ErrNotExist):
+			report(fmt.Errorf("asset pack %s: %w, using the built-in font", fontFile, err))
+		}
+	}
+
+	fontSource, err := text.NewGoTextFaceSource(bytes.NewReader(data))
 	if err != nil {
 		panic(err)
 	}
@@ -47,21 +121,98 @@ func init() {
 	}
 }
 
-func loadPNG(data []byte) *ebiten.Image {
+// Problems returns what went wrong loading the sprites, if anything
+func Problems() []error {
+	return problems
+}
+
+// loadSprites loads every sprite, from the pack if there is one. Ebiten packs the images
+// into its own atlas, so they are drawn from one texture without any help.
+func loadSprites(pack fs.FS) {
+	problems = nil
+	for _, s := range sprites {
+		*s.target = scaleImage(s.load(pack), s.scale)
+	}
+}
+
+// load decodes the sprite from the pack, falling back to the built-in image if the pack's
+// is missing, broken or the wrong size. Without a usable image, or in the vector theme, the
+// sprite is drawn with vector shapes instead.
+func (s sprite) load(pack fs.FS) *ebiten.Image {
+	if theme == ThemeVector {
+		return s.vector()
+	}
+
+	if pack != nil {
+		data, err := fs.ReadFile(pack, s.file)
+		if err == nil {
+			var img image.Image
+			if img, err = decodePNG(data); err == nil {
+				if err = checkSize(img.Bounds().Size(), s.size); err == nil {
+					return ebiten.NewImageFromImage(img)
+				}
+			}
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			report(fmt.Errorf("asset pack %s: %w, using the built-in sprite", s.file, err))
+		}
+	}
+
+	if len(s.embedded) == 0 {
+		return s.vector()
+	}
+	embedded, err := decodePNG(s.embedded)
+	if err != nil {
+		report(fmt.Errorf("built-in %s: %w, drawing it instead", s.file, err))
+		return s.vector()
+	}
+	return ebiten.NewImageFromImage(embedded)
+}
+
+// vector draws the sprite with vector shapes, at the size of the built-in image
+func (s sprite) vector() *ebiten.Image {
+	img := ebiten.NewImage(s.size.X, s.size.Y)
+	s.draw(img)
+	return img
+}
+
+func decodePNG(data []byte) (image.Image, error) {
 	img, _, err := image.Decode(bytes.NewReader(data))
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	if img.Bounds().Empty() {
+		return nil, errors.New("image is empty")
 	}
-	return ebiten.NewImageFromImage(img)
+	return img, nil
+}
+
+// checkSize makes sure a replacement sprite is near enough the size of the built-in one
+// that the game's collisions and layout still work
+func checkSize(size, want image.Point) error {
+	ratioX := float64(size.X) / float64(want.X)
+	ratioY := float64(size.Y) / float64(want.Y)
+	for _, ratio := range []float64{ratioX, ratioY} {
+		if ratio > maxSizeRatio || ratio < 1/maxSizeRatio {
+			return fmt.Errorf("size %dx%d is too far from the expected %dx%d", size.X, size.Y, want.X, want.Y)
+		}
+	}
+	return nil
+}
+
+func report(err error) {
+	slog.Warn("problem loading sprite", "err", err)
+	problems = append(problems, err)
 }
 
 func scaleImage(img *ebiten.Image, scale float64) *ebiten.Image {
+	scale = Scale(scale)
 	bounds := img.Bounds()
 	newWidth := int(float64(bounds.Dx()) * scale)
 	newHeight := int(float64(bounds.Dy()) * scale)
 
 	scaledImg := ebiten.NewImage(newWidth, newHeight)
-	op := &ebiten.DrawImageOptions{}
+	op := &ebiten.DrawImageOptions{Filter: filter}
 	op.GeoM.Scale(scale, scale)
 	scaledImg.DrawImage(img, op)
 
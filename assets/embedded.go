@@ -0,0 +1,23 @@
+//go:build !vectoronly
+
+package assets
+
+import _ "embed"
+
+// The built-in sprites. Builds with the vectoronly tag leave them out, drawing every sprite
+// with vector shapes instead, which makes for a smaller download on the web.
+var (
+	//go:embed ball.png
+	ballPNG []byte
+
+	//go:embed bat.png
+	batPNG []byte
+
+	//go:embed stumps.png
+	stumpsPNG []byte
+
+	//go:embed stumps-out.png
+	stumpsOutPNG []byte
+)
+
+const defaultTheme = ThemeSprites
@@ -0,0 +1,7 @@
+//go:build vectoronly
+
+package assets
+
+var ballPNG, batPNG, stumpsPNG, stumpsOutPNG []byte
+
+const defaultTheme = ThemeVector
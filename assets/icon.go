@@ -0,0 +1,53 @@
+package assets
+
+import (
+	"image"
+	"image/color"
+
+	"golang.org/x/image/draw"
+)
+
+// Sizes the window icon is offered in, for the title bar, taskbar and dock to pick from
+var iconSizes = []int{16, 32, 48, 64, 128}
+
+var iconBadgeColor = color.RGBA{255, 220, 0, 255}
+
+// WindowIcons returns the ball at each icon size. A badged icon has a dot in the corner, to
+// catch the player's eye from the taskbar.
+func WindowIcons(badged bool) []image.Image {
+	source := iconSource()
+	icons := make([]image.Image, 0, len(iconSizes))
+	for _, size := range iconSizes {
+		icon := image.NewRGBA(image.Rect(0, 0, size, size))
+		draw.CatmullRom.Scale(icon, icon.Bounds(), source, source.Bounds(), draw.Over, nil)
+		if badged {
+			fillCircle(icon, size*3/4, size/4, size/4, iconBadgeColor)
+		}
+		icons = append(icons, icon)
+	}
+
+	return icons
+}
+
+// iconSource is the ball image, or a plain drawn ball when the build has no images
+func iconSource() image.Image {
+	if img, err := decodePNG(ballPNG); err == nil {
+		return img
+	}
+
+	const size = 128
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	fillCircle(img, size/2, size/2, size/2, vectorBallColor)
+	return img
+}
+
+func fillCircle(img *image.RGBA, centerX, centerY, radius int, c color.Color) {
+	for y := centerY - radius; y <= centerY+radius; y++ {
+		for x := centerX - radius; x <= centerX+radius; x++ {
+			dx, dy := x-centerX, y-centerY
+			if dx*dx+dy*dy <= radius*radius {
+				img.Set(x, y, c)
+			}
+		}
+	}
+}
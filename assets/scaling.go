@@ -0,0 +1,61 @@
+package assets
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Filtering is how sprites are smoothed when they are drawn at another size or angle
+type Filtering string
+
+const (
+	FilteringLinear  Filtering = "linear"  // Smooth, anti-aliased edges
+	FilteringNearest Filtering = "nearest" // Crisp, blocky pixels, for pixel art
+)
+
+var (
+	filter  = ebiten.FilterLinear
+	integer bool
+)
+
+// SetScaling changes how sprites are resized. Integer scaling only resizes them by whole
+// multiples, or whole fractions, so that every pixel of pixel art stays the same size. It
+// takes effect when the sprites are next loaded, as they are by SetTheme.
+func SetScaling(filtering Filtering, integerScaling bool) error {
+	switch filtering {
+	case "", FilteringLinear:
+		filter = ebiten.FilterLinear
+	case FilteringNearest:
+		filter = ebiten.FilterNearest
+	default:
+		return fmt.Errorf("unknown filtering %q, use %s or %s", filtering, FilteringLinear, FilteringNearest)
+	}
+
+	integer = integerScaling
+	return nil
+}
+
+// Filter is the filter to draw sprites with wherever they are scaled or rotated
+func Filter() ebiten.Filter {
+	return filter
+}
+
+// Scale is the scale to resize a sprite by, in place of the one asked for. With integer
+// scaling that is the nearest whole multiple or whole fraction.
+func Scale(scale float64) float64 {
+	if !integer || scale <= 0 {
+		return scale
+	}
+	if scale >= 1 {
+		return math.Round(scale)
+	}
+
+	// Whichever of 1/n and 1/(n+1) is nearer
+	n := math.Floor(1 / scale)
+	if scale-1/(n+1) < 1/n-scale {
+		return 1 / (n + 1)
+	}
+	return 1 / n
+}
@@ -0,0 +1,69 @@
+package assets
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var (
+	vectorBallColor   = color.RGBA{200, 30, 30, 255}
+	vectorSeamColor   = color.RGBA{255, 230, 230, 255}
+	vectorHandleColor = color.RGBA{90, 40, 30, 255}
+	vectorBladeColor  = color.RGBA{235, 200, 140, 255}
+	vectorWoodColor   = color.RGBA{220, 185, 150, 255}
+)
+
+const (
+	vectorStumpWidth = 12
+	vectorBailHeight = 10
+)
+
+// drawVectorBall draws a red ball with a seam across it, filling the image
+func drawVectorBall(img *ebiten.Image) {
+	width, height := float32(img.Bounds().Dx()), float32(img.Bounds().Dy())
+	radius := min(width, height)/2 - 1
+	centerX, centerY := width/2, height/2
+
+	vector.DrawFilledCircle(img, centerX, centerY, radius, vectorBallColor, true)
+	vector.StrokeLine(img, centerX-radius*0.7, centerY+radius*0.7, centerX+radius*0.7, centerY-radius*0.7, 3, vectorSeamColor, true)
+}
+
+// drawVectorBat draws a bat standing on its toe, with the handle at the top
+func drawVectorBat(img *ebiten.Image) {
+	width, height := float32(img.Bounds().Dx()), float32(img.Bounds().Dy())
+	handleLength, handleWidth := height*0.3, width*0.3
+
+	vector.DrawFilledRect(img, (width-handleWidth)/2, 0, handleWidth, handleLength, vectorHandleColor, true)
+	vector.DrawFilledRect(img, 1, handleLength, width-2, height-handleLength-1, vectorBladeColor, true)
+	vector.StrokeRect(img, 1, handleLength, width-2, height-handleLength-1, 2, vectorHandleColor, true)
+}
+
+// drawVectorStumps draws three upright stumps with the bails on top
+func drawVectorStumps(img *ebiten.Image) {
+	width, height := float32(img.Bounds().Dx()), float32(img.Bounds().Dy())
+
+	for _, x := range stumpPositions(width) {
+		vector.DrawFilledRect(img, x, vectorBailHeight, vectorStumpWidth, height-vectorBailHeight, vectorWoodColor, true)
+	}
+	vector.DrawFilledRect(img, 0, 0, width, vectorBailHeight-2, vectorWoodColor, true)
+}
+
+// drawVectorStumpsOut draws the stumps knocked back, with the bails flying off
+func drawVectorStumpsOut(img *ebiten.Image) {
+	width, height := float32(img.Bounds().Dx()), float32(img.Bounds().Dy())
+
+	for i, x := range stumpPositions(width) {
+		lean := float32(i+1) * width * 0.08
+		vector.StrokeLine(img, x+vectorStumpWidth/2+lean, height*0.15, x+vectorStumpWidth/2, height, vectorStumpWidth, vectorWoodColor, true)
+	}
+	vector.DrawFilledRect(img, 0, 0, width*0.3, vectorBailHeight-2, vectorWoodColor, true)
+	vector.DrawFilledRect(img, width*0.55, vectorBailHeight, width*0.3, vectorBailHeight-2, vectorWoodColor, true)
+}
+
+// stumpPositions are the left edges of the three stumps across an image of the given width
+func stumpPositions(width float32) []float32 {
+	gap := (width - 3*vectorStumpWidth) / 2
+	return []float32{0, vectorStumpWidth + gap, 2 * (vectorStumpWidth + gap)}
+}
@@ -0,0 +1,28 @@
+// Command lobby lists the online matches dedicated servers have open, for players to find
+// one and join it. It only passes addresses on; the matches are played on the servers.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/online"
+)
+
+const requestTimeout = 5 * time.Second
+
+func main() {
+	addr := flag.String("addr", ":7779", "address to serve the lobby at")
+	flag.Parse()
+
+	server := &http.Server{Addr: *addr, Handler: online.NewLobby().Handler(), ReadHeaderTimeout: requestTimeout}
+	slog.Info("serving lobby", "addr", *addr, "version", online.LobbyVersion)
+	if err := server.ListenAndServe(); err != nil {
+		fmt.Fprintf(os.Stderr, "lobby stopped: %s\n", err)
+		os.Exit(1)
+	}
+}
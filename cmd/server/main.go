@@ -0,0 +1,84 @@
+// Command server plays online matches as the one true copy of them. The game starts a
+// match and sends how the bat was held on each tick, and the server plays the ticks with
+// it and says where it has got to, for the game to check its own copy against. It runs
+// headless, without Ebiten, so it needs no display.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/online"
+)
+
+const requestTimeout = 5 * time.Second
+
+func main() {
+	addr := flag.String("addr", ":7778", "address to serve the match at")
+	configPath := flag.String("config", "", "path to a config file, instead of looking for one")
+	modeFlag := flag.String("mode", "", "game mode to play (defaults to the configured one)")
+	difficultyFlag := flag.String("difficulty", "", "difficulty preset name or YAML file (defaults to the configured one)")
+	seed := flag.Uint("seed", 0, "seed the deliveries are drawn from, which clients are given too (a new one each match if left out)")
+	lobbyURL := flag.String("lobby", "", "lobby to list the match in (defaults to the configured one)")
+	name := flag.String("name", "", "name to list the match under in the lobby, which lists it only if given")
+	publicAddr := flag.String("public-addr", "", "address players reach the server at, such as example.com:7778, for the lobby to give them")
+	flag.Parse()
+
+	// Per-tick debug logs would drown everything else
+	logger.SetLevel(slog.LevelWarn)
+
+	cfg, err := config.LoadFile("", *configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %s\n", err)
+		os.Exit(1)
+	}
+
+	modeName := *modeFlag
+	if len(modeName) == 0 {
+		modeName = cfg.GetMode()
+	}
+	presetName := *difficultyFlag
+	if len(presetName) == 0 {
+		presetName = cfg.GetDifficulty()
+	}
+	if len(presetName) == 0 {
+		presetName = difficulty.Default
+	}
+	preset, err := difficulty.Load(presetName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load difficulty preset: %s\n", err)
+		os.Exit(1)
+	}
+
+	matches, err := online.NewServer(cfg, preset, modeName, uint32(*seed))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to set up the match: %s\n", err)
+		os.Exit(1)
+	}
+	server := &http.Server{Addr: *addr, Handler: matches.Handler(), ReadHeaderTimeout: requestTimeout}
+
+	if len(*name) > 0 {
+		if len(*lobbyURL) == 0 {
+			*lobbyURL = cfg.GetLobbyURL()
+		}
+		if len(*lobbyURL) == 0 || len(*publicAddr) == 0 {
+			fmt.Fprintln(os.Stderr, "listing the match in a lobby needs -lobby, or one configured, and -public-addr")
+			os.Exit(1)
+		}
+		go matches.Advertise(context.Background(), online.NewLobbyClient(*lobbyURL), *name, *publicAddr)
+	}
+
+	slog.Warn("serving matches", "addr", *addr, "mode", modeName, "difficulty", preset.Name, "seed", *seed)
+	if err := server.ListenAndServe(); err != nil {
+		fmt.Fprintf(os.Stderr, "server stopped: %s\n", err)
+		os.Exit(1)
+	}
+}
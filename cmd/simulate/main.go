@@ -0,0 +1,167 @@
+// Command simulate plays many headless innings with the bot batsman at different skill
+// levels and prints score distributions, to help with tuning difficulty.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const maxOversReported = 10
+
+func main() {
+	innings := flag.Int("innings", 1000, "number of innings to simulate per skill level")
+	maxBalls := flag.Int("max-balls", 120, "maximum number of balls bowled in an innings")
+	skillsFlag := flag.String("skills", "0.25,0.5,0.75,1", "comma separated bot skill levels between 0 and 1")
+	difficultyFlag := flag.String("difficulty", "", "difficulty preset name or YAML file (defaults to the configured one)")
+	configPath := flag.String("config", "", "path to a config file, instead of looking for one")
+	flag.Parse()
+
+	skills, err := parseSkills(*skillsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid skills: %s\n", err)
+		os.Exit(1)
+	}
+
+	// Per-tick debug logs would drown the report
+	logger.SetLevel(slog.LevelWarn)
+
+	cfg, err := config.LoadFile("", *configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %s\n", err)
+		os.Exit(1)
+	}
+
+	presetName := *difficultyFlag
+	if len(presetName) == 0 {
+		presetName = cfg.GetDifficulty()
+	}
+	if len(presetName) == 0 {
+		presetName = difficulty.Default
+	}
+	preset, err := difficulty.Load(presetName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load difficulty preset: %s\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("difficulty %s\n", preset.Name)
+
+	for _, skill := range skills {
+		results := make([]sim.InningsResult, 0, *innings)
+		for i := 0; i < *innings; i++ {
+			result, err := sim.SimulateInnings(cfg, preset, skill, *maxBalls)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to simulate innings: %s\n", err)
+				os.Exit(1)
+			}
+			results = append(results, result)
+		}
+		printReport(skill, results)
+	}
+}
+
+func parseSkills(skillsFlag string) ([]float64, error) {
+	skills := make([]float64, 0)
+	for _, field := range strings.Split(skillsFlag, ",") {
+		skill, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, err
+		}
+		if skill < 0 || skill > 1 {
+			return nil, fmt.Errorf("skill %v is not between 0 and 1", skill)
+		}
+		skills = append(skills, skill)
+	}
+
+	return skills, nil
+}
+
+func printReport(skill float64, results []sim.InningsResult) {
+	scores := make([]float64, 0, len(results))
+	dismissals := make(map[string]int)
+	timing := make(map[stats.Timing]int)
+	shots := 0
+	overRuns := make([]int, maxOversReported)
+	oversBowled := make([]int, maxOversReported)
+
+	for _, result := range results {
+		scores = append(scores, float64(result.Score))
+		dismissals[result.Dismissal]++
+		for kind, count := range result.Timing {
+			timing[kind] += count
+			shots += count
+		}
+		for over, runs := range result.RunsPerOver {
+			if over >= maxOversReported {
+				break
+			}
+			overRuns[over] += runs
+			oversBowled[over]++
+		}
+	}
+	slices.Sort(scores)
+
+	fmt.Printf("skill %.2f (%d innings)\n", skill, len(results))
+	fmt.Printf("  score: mean %.2f, stddev %.2f, min %.0f, median %.0f, p90 %.0f, max %.0f\n",
+		mean(scores), stddev(scores), scores[0], percentile(scores, 0.5), percentile(scores, 0.9), scores[len(scores)-1])
+
+	fmt.Printf("  dismissals:")
+	dismissalTypes := make([]string, 0, len(dismissals))
+	for dismissal := range dismissals {
+		dismissalTypes = append(dismissalTypes, dismissal)
+	}
+	slices.Sort(dismissalTypes)
+	for _, dismissal := range dismissalTypes {
+		fmt.Printf(" %s %.1f%%", dismissal, 100*float64(dismissals[dismissal])/float64(len(results)))
+	}
+	fmt.Println()
+
+	fmt.Printf("  timing:")
+	for _, kind := range stats.Timings() {
+		fmt.Printf(" %s %.1f%%", kind, 100*float64(timing[kind])/float64(max(shots, 1)))
+	}
+	fmt.Println()
+
+	fmt.Printf("  run rate by over:")
+	for over := range overRuns {
+		if oversBowled[over] == 0 {
+			break
+		}
+		fmt.Printf(" %d:%.2f", over+1, float64(overRuns[over])/float64(oversBowled[over]))
+	}
+	fmt.Println()
+}
+
+func mean(values []float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
+
+func stddev(values []float64) float64 {
+	m := mean(values)
+	total := 0.0
+	for _, value := range values {
+		total += (value - m) * (value - m)
+	}
+	return math.Sqrt(total / float64(len(values)))
+}
+
+// percentile expects values to be sorted
+func percentile(values []float64, p float64) float64 {
+	return values[int(p*float64(len(values)-1))]
+}
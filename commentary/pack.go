@@ -0,0 +1,109 @@
+package commentary
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Event is a moment in the match that the commentators have something to say about
+type Event string
+
+const (
+	EventShot    Event = "shot"
+	EventBigHit  Event = "big_hit" // A lofted shot
+	EventLeave   Event = "leave"
+	EventWide    Event = "wide"
+	EventAppeal  Event = "appeal"
+	EventNotOut  Event = "not_out"
+	EventWicket  Event = "wicket"
+	EventVictory Event = "victory"
+)
+
+// Events lists every event a pack can have clips for, most worth talking about first
+func Events() []Event {
+	return []Event{EventVictory, EventWicket, EventNotOut, EventAppeal, EventBigHit, EventWide, EventShot, EventLeave}
+}
+
+const (
+	ManifestFile    = "pack.yaml"
+	maxClipsPerPack = 200
+	maxClipBytes    = 4 * 1024 * 1024
+)
+
+// Pack is a set of spoken clips keyed to the events they describe. A pack is a directory
+// with a manifest listing the WAV files for each event, such as:
+//
+//	name: Classic
+//	clips:
+//	  wicket: [gone.wav, timber.wav]
+//	  big_hit: [into-the-stands.wav]
+type Pack struct {
+	Name  string
+	clips map[Event][][]byte
+}
+
+type manifest struct {
+	Name  string             `yaml:"name"`
+	Clips map[Event][]string `yaml:"clips"`
+}
+
+// LoadDir loads the pack in a directory on disk
+func LoadDir(dir string) (*Pack, error) {
+	return Load(os.DirFS(dir))
+}
+
+// Load loads a pack from a file system, so that packs can be embedded in the game as well
+// as read from disk
+func Load(fsys fs.FS) (*Pack, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read commentary pack manifest: %w", err)
+	}
+
+	var m manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("could not parse commentary pack manifest: %w", err)
+	}
+	if len(m.Name) == 0 {
+		return nil, fmt.Errorf("commentary pack needs a name")
+	}
+
+	pack := &Pack{Name: m.Name, clips: make(map[Event][][]byte)}
+	count := 0
+	for event, files := range m.Clips {
+		if !slices.Contains(Events(), event) {
+			return nil, fmt.Errorf("commentary pack %s has clips for unknown event %q", m.Name, event)
+		}
+
+		for _, file := range files {
+			count++
+			if count > maxClipsPerPack {
+				return nil, fmt.Errorf("commentary pack %s has more than %d clips", m.Name, maxClipsPerPack)
+			}
+			if path.Ext(file) != ".wav" {
+				return nil, fmt.Errorf("commentary clip %s is not a WAV file", file)
+			}
+
+			clip, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return nil, fmt.Errorf("could not read commentary clip %s: %w", file, err)
+			}
+			if len(clip) > maxClipBytes {
+				return nil, fmt.Errorf("commentary clip %s is larger than %d bytes", file, maxClipBytes)
+			}
+			pack.clips[event] = append(pack.clips[event], clip)
+		}
+	}
+
+	return pack, nil
+}
+
+// Clips returns the WAV data of the pack's clips for an event
+func (p *Pack) Clips(event Event) [][]byte {
+	return p.clips[event]
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"bytes"
+	_ "embed"
 	"fmt"
 	"log/slog"
 	"os"
@@ -12,11 +14,29 @@ import (
 const keyEnv = "ENV"
 const envLocal = "local"
 
+// userConfigFile is looked for in the user's config directory, such as ~/.config/cricket2d
+const userConfigFile = "config.yaml"
+
+// defaultConfig is built into the game, so that an installed binary runs without any
+// config files around it
+//
+//go:embed config.local.yaml
+var defaultConfig []byte
+
 type Config struct {
 	config *viper.Viper
 }
 
+// Load loads the config without an explicit config file
 func Load(env string) (*Config, error) {
+	return LoadFile(env, "")
+}
+
+// LoadFile loads the config, with a config file laid over the built-in defaults and
+// environment variables over both. The file is the one at the given path if there is one,
+// otherwise config/config.<env>.yaml when running from a checkout of the game, otherwise
+// config.yaml in the user's config directory.
+func LoadFile(env string, path string) (*Config, error) {
 
 	if len(env) == 0 {
 		if env = os.Getenv(keyEnv); len(env) == 0 {
@@ -24,14 +44,24 @@ func Load(env string) (*Config, error) {
 		}
 	}
 
-	configPath, err := getConfigPath(env)
-
 	viperConfig := viper.New()
-	if err == nil {
+	viperConfig.SetConfigType("yaml")
+	if err := viperConfig.ReadConfig(bytes.NewReader(defaultConfig)); err != nil {
+		return nil, fmt.Errorf("could not read built-in config: %w", err)
+	}
+
+	if len(path) > 0 {
+		viperConfig.SetConfigFile(path)
+		if err := viperConfig.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("could not read config file %s: %w", path, err)
+		}
+	} else if configPath, err := findConfigFile(env); err == nil {
 		viperConfig.SetConfigFile(configPath)
-		if err := viperConfig.ReadInConfig(); err != nil {
+		if err := viperConfig.MergeInConfig(); err != nil {
 			slog.Warn(fmt.Sprintf("error reading config file, %s", err))
 		}
+	} else {
+		slog.Debug("no config file found, using built-in config", "err", err.Error())
 	}
 	viperConfig.AutomaticEnv()
 
@@ -42,6 +72,20 @@ func Load(env string) (*Config, error) {
 	return cfg, nil
 }
 
+// Clone is a copy of the config as it stands, which can be changed without changing this one
+func (c *Config) Clone() *Config {
+	clone := viper.New()
+	clone.SetConfigType("yaml")
+	// Every setting is copied as resolved, so that the copy reads the same whether a setting
+	// came from the file, the environment or a Set
+	for _, key := range c.config.AllKeys() {
+		clone.Set(key, c.config.Get(key))
+	}
+	clone.AutomaticEnv()
+
+	return &Config{config: clone}
+}
+
 func (c *Config) GetWindowWidth() float64 {
 	windowWidth := c.config.GetFloat64("WINDOW_WIDTH")
 	if windowWidth == 0 {
@@ -60,6 +104,33 @@ func (c *Config) GetWindowHeight() float64 {
 	return windowHeight
 }
 
+// SetWindowSize lays the game out at the given size, whatever the config files and
+// environment say
+func (c *Config) SetWindowSize(width, height float64) {
+	c.config.Set("WINDOW_WIDTH", width)
+	c.config.Set("WINDOW_HEIGHT", height)
+}
+
+// GetFieldWidth is the width of the playing field in pixels, 0 to match the window
+func (c *Config) GetFieldWidth() float64 {
+	fieldWidth := c.config.GetFloat64("FIELD_WIDTH")
+	if fieldWidth == 0 {
+		fieldWidth = c.config.GetFloat64("field.width")
+	}
+
+	return fieldWidth
+}
+
+// GetFieldHeight is the height of the playing field in pixels, 0 to match the window
+func (c *Config) GetFieldHeight() float64 {
+	fieldHeight := c.config.GetFloat64("FIELD_HEIGHT")
+	if fieldHeight == 0 {
+		fieldHeight = c.config.GetFloat64("field.height")
+	}
+
+	return fieldHeight
+}
+
 func (c *Config) GetWindowTitle() string {
 	windowTitle := c.config.GetString("WINDOW_TITLE")
 	if len(windowTitle) == 0 {
@@ -69,11 +140,15 @@ func (c *Config) GetWindowTitle() string {
 	return windowTitle
 }
 
+// GetDataDir is where saves are kept, the platform's data directory unless overridden
 func (c *Config) GetDataDir() string {
 	dataDir := c.config.GetString("DATA_DIR")
 	if len(dataDir) == 0 {
 		dataDir = c.config.GetString("data.dir")
 	}
+	if len(dataDir) == 0 {
+		dataDir = defaultDataDir()
+	}
 
 	return dataDir
 }
@@ -87,6 +162,166 @@ func (c *Config) GetScoreFilename() string {
 	return scoreFilename
 }
 
+func (c *Config) GetProfile() string {
+	profile := c.config.GetString("PROFILE")
+	if len(profile) == 0 {
+		profile = c.config.GetString("data.profile")
+	}
+
+	return profile
+}
+
+// GetOpponentProfile is the profile of the second player in versus matches
+func (c *Config) GetOpponentProfile() string {
+	profile := c.config.GetString("OPPONENT_PROFILE")
+	if len(profile) == 0 {
+		profile = c.config.GetString("data.opponent_profile")
+	}
+
+	return profile
+}
+
+// GetModsDir is where mods are loaded from, the mods directory in the data directory unless
+// overridden
+func (c *Config) GetModsDir() string {
+	modsDir := c.config.GetString("MODS_DIR")
+	if len(modsDir) == 0 {
+		modsDir = c.config.GetString("data.modsdir")
+	}
+	if len(modsDir) == 0 {
+		modsDir = filepath.Join(c.GetDataDir(), "mods")
+	}
+
+	return modsDir
+}
+
+// GetTheme is how sprites are drawn: sprites for images, vector for retro shapes, or empty
+// for the build's default
+func (c *Config) GetTheme() string {
+	theme := c.config.GetString("THEME")
+	if len(theme) == 0 {
+		theme = c.config.GetString("window.theme")
+	}
+
+	return theme
+}
+
+// SetTheme draws the sprites in the given theme, whatever the config files and environment say
+func (c *Config) SetTheme(theme string) {
+	c.config.Set("THEME", theme)
+}
+
+// GetFiltering is how sprites are smoothed when drawn at another size or angle: linear for
+// anti-aliased edges, nearest for crisp pixel art, or empty for linear
+func (c *Config) GetFiltering() string {
+	filtering := c.config.GetString("FILTERING")
+	if len(filtering) == 0 {
+		filtering = c.config.GetString("window.filtering")
+	}
+
+	return filtering
+}
+
+// GetIntegerScaling is true if sprites should only be resized by whole multiples or whole
+// fractions, so that every pixel of pixel art stays the same size
+func (c *Config) GetIntegerScaling() bool {
+	if c.config.IsSet("INTEGER_SCALING") {
+		return c.config.GetBool("INTEGER_SCALING")
+	}
+
+	return c.config.GetBool("window.integer_scaling")
+}
+
+// GetPostProcessing is the effects laid over the finished frame: auto for the theme's own,
+// none, or any of crt, bloom and vignette separated by commas
+func (c *Config) GetPostProcessing() string {
+	effects := c.config.GetString("POST_PROCESSING")
+	if len(effects) == 0 {
+		effects = c.config.GetString("window.post_processing")
+	}
+
+	return effects
+}
+
+// GetAssetsDir is a directory of replacement sprites, empty for the built-in ones
+func (c *Config) GetAssetsDir() string {
+	assetsDir := c.config.GetString("ASSETS_DIR")
+	if len(assetsDir) == 0 {
+		assetsDir = c.config.GetString("data.assetsdir")
+	}
+
+	return assetsDir
+}
+
+// GetHotReload is true when the asset pack should be reloaded as it changes, for artists
+// working on it
+func (c *Config) GetHotReload() bool {
+	if c.config.IsSet("HOT_RELOAD") {
+		return c.config.GetBool("HOT_RELOAD")
+	}
+
+	return c.config.GetBool("dev.hot_reload")
+}
+
+// GetLogRing is how many debug log entries to hold back in memory, written out only when a
+// batsman is dismissed, the game crashes or the control API asks. 0 writes them out as they
+// happen.
+func (c *Config) GetLogRing() int {
+	if c.config.IsSet("LOG_RING") {
+		return c.config.GetInt("LOG_RING")
+	}
+
+	return c.config.GetInt("dev.log_ring")
+}
+
+// GetFrameMonitor is true if frames that take far longer than usual should be logged, with
+// the part of the frame they spent their time in
+func (c *Config) GetFrameMonitor() bool {
+	if c.config.IsSet("FRAME_MONITOR") {
+		return c.config.GetBool("FRAME_MONITOR")
+	}
+
+	return c.config.GetBool("dev.frame_monitor")
+}
+
+// GetFrameGraph is true if a graph of recent frame times should be drawn in the corner
+func (c *Config) GetFrameGraph() bool {
+	if c.config.IsSet("FRAME_GRAPH") {
+		return c.config.GetBool("FRAME_GRAPH")
+	}
+
+	return c.config.GetBool("dev.frame_graph")
+}
+
+func (c *Config) GetDifficulty() string {
+	difficulty := c.config.GetString("DIFFICULTY")
+	if len(difficulty) == 0 {
+		difficulty = c.config.GetString("game.difficulty")
+	}
+
+	return difficulty
+}
+
+// SetDifficulty plays with the given preset, whatever the config files and environment say
+func (c *Config) SetDifficulty(difficulty string) {
+	c.config.Set("DIFFICULTY", difficulty)
+}
+
+func (c *Config) GetBowling() string {
+	bowling := c.config.GetString("BOWLING")
+	if len(bowling) == 0 {
+		bowling = c.config.GetString("game.bowling")
+	}
+
+	return bowling
+}
+
+// SetBowling picks the kind of bowler, whatever the config files and environment say
+func (c *Config) SetBowling(bowling string) {
+	c.config.Set("BOWLING", bowling)
+}
+
+// GetballSpawnTime overrides the difficulty preset's spawn interval when set
 func (c *Config) GetballSpawnTime() int {
 	ballSpawnTimeSeconds := c.config.GetInt("BALL_SPAWN_TIME_SECONDS")
 	if ballSpawnTimeSeconds == 0 {
@@ -96,6 +331,569 @@ func (c *Config) GetballSpawnTime() int {
 	return ballSpawnTimeSeconds
 }
 
+func (c *Config) GetMode() string {
+	mode := c.config.GetString("MODE")
+	if len(mode) == 0 {
+		mode = c.config.GetString("game.mode")
+	}
+
+	return mode
+}
+
+// SetMode plays the given mode, whatever the config files and environment say
+func (c *Config) SetMode(mode string) {
+	c.config.Set("MODE", mode)
+}
+
+func (c *Config) GetOvers() int {
+	overs := c.config.GetInt("OVERS")
+	if overs == 0 {
+		overs = c.config.GetInt("game.overs")
+	}
+
+	return overs
+}
+
+// SetOvers plays innings of the given length, whatever the config files and environment say
+func (c *Config) SetOvers(overs int) {
+	c.config.Set("OVERS", overs)
+}
+
+func (c *Config) GetChaseTarget() int {
+	chaseTarget := c.config.GetInt("CHASE_TARGET")
+	if chaseTarget == 0 {
+		chaseTarget = c.config.GetInt("game.chase_target")
+	}
+
+	return chaseTarget
+}
+
+// SetChaseTarget sets the target to chase, whatever the config files and environment say
+func (c *Config) SetChaseTarget(target int) {
+	c.config.Set("CHASE_TARGET", target)
+}
+
+// GetMaxOverSeconds is how long an over may take in play in overs mode before the bowling
+// side is penalised for a slow over rate. Time in play is counted in ticks, so pauses don't
+// count. 0 turns the penalty off.
+func (c *Config) GetMaxOverSeconds() float64 {
+	maxOverSeconds := c.config.GetFloat64("MAX_OVER_SECONDS")
+	if maxOverSeconds == 0 {
+		maxOverSeconds = c.config.GetFloat64("game.max_over_seconds")
+	}
+
+	return maxOverSeconds
+}
+
+// SetMaxOverSeconds sets how long an over may take, whatever the config files and
+// environment say
+func (c *Config) SetMaxOverSeconds(seconds float64) {
+	c.config.Set("MAX_OVER_SECONDS", seconds)
+}
+
+// GetArena is the arena the match is played in, which decides the edges balls bounce off
+func (c *Config) GetArena() string {
+	arena := c.config.GetString("ARENA")
+	if len(arena) == 0 {
+		arena = c.config.GetString("game.arena")
+	}
+
+	return arena
+}
+
+// GetChaosOvers is true if every 25 runs bring a chaos over, with three balls bowled at once
+func (c *Config) GetChaosOvers() bool {
+	if c.config.IsSet("CHAOS_OVERS") {
+		return c.config.GetBool("CHAOS_OVERS")
+	}
+	return c.config.GetBool("game.chaos_overs")
+}
+
+// GetFreeHit is true if being beaten or edging three balls in a row earns an easier
+// delivery. Purists can turn it off.
+func (c *Config) GetFreeHit() bool {
+	if c.config.IsSet("FREE_HIT") {
+		return c.config.GetBool("FREE_HIT")
+	}
+	if c.config.IsSet("game.free_hit") {
+		return c.config.GetBool("game.free_hit")
+	}
+
+	return true
+}
+
+// GetTargetRings is true if rings float up now and then, doubling the runs of a lofted shot
+// put through one
+func (c *Config) GetTargetRings() bool {
+	if c.config.IsSet("TARGET_RINGS") {
+		return c.config.GetBool("TARGET_RINGS")
+	}
+	return c.config.GetBool("game.target_rings")
+}
+
+// GetOldBallOvers is how many overs it takes the ball to wear out in limited overs modes,
+// 0 to wear out over the length of the innings
+func (c *Config) GetOldBallOvers() int {
+	oldBallOvers := c.config.GetInt("OLD_BALL_OVERS")
+	if oldBallOvers == 0 {
+		oldBallOvers = c.config.GetInt("game.old_ball_overs")
+	}
+
+	return oldBallOvers
+}
+
+// SetOldBallOvers sets how many overs it takes the ball to wear out, whatever the config
+// files and environment say
+func (c *Config) SetOldBallOvers(overs int) {
+	c.config.Set("OLD_BALL_OVERS", overs)
+}
+
+// GetScenario is a built-in scenario or a path to a scenario file, empty for a normal game
+func (c *Config) GetScenario() string {
+	scenario := c.config.GetString("SCENARIO")
+	if len(scenario) == 0 {
+		scenario = c.config.GetString("game.scenario")
+	}
+
+	return scenario
+}
+
+// SetScenario plays the given scenario, whatever the config files and environment say
+func (c *Config) SetScenario(scenario string) {
+	c.config.Set("SCENARIO", scenario)
+}
+
+// GetChallengeCode is a code shared by another player, which sets up their match with the
+// same deliveries, empty for a match of the player's own
+func (c *Config) GetChallengeCode() string {
+	code := c.config.GetString("CHALLENGE_CODE")
+	if len(code) == 0 {
+		code = c.config.GetString("game.challenge_code")
+	}
+
+	return code
+}
+
+// SetChallengeCode plays the match in the given code, whatever the config files and
+// environment say
+func (c *Config) SetChallengeCode(code string) {
+	c.config.Set("CHALLENGE_CODE", code)
+}
+
+// GetLoadout is the name of a saved loadout to start with, empty to start with the
+// settings as they are
+func (c *Config) GetLoadout() string {
+	loadout := c.config.GetString("LOADOUT")
+	if len(loadout) == 0 {
+		loadout = c.config.GetString("game.loadout")
+	}
+
+	return loadout
+}
+
+// SetLoadout starts with the given loadout, whatever the config files and environment say
+func (c *Config) SetLoadout(loadout string) {
+	c.config.Set("LOADOUT", loadout)
+}
+
+// GetChallenges is true if the game starts on the challenge menu
+func (c *Config) GetChallenges() bool {
+	if c.config.IsSet("CHALLENGES") {
+		return c.config.GetBool("CHALLENGES")
+	}
+	return c.config.GetBool("game.challenges")
+}
+
+// SetChallenges starts the game on the challenge menu, whatever the config files and
+// environment say
+func (c *Config) SetChallenges(challenges bool) {
+	c.config.Set("CHALLENGES", challenges)
+}
+
+// GetCalibrate is true if the endless mode should start with the calibration balls again,
+// even though the player's timing has already been measured
+func (c *Config) GetCalibrate() bool {
+	if c.config.IsSet("CALIBRATE") {
+		return c.config.GetBool("CALIBRATE")
+	}
+	return c.config.GetBool("game.calibrate")
+}
+
+// SetCalibrate asks for the calibration balls, whatever the config files and environment say
+func (c *Config) SetCalibrate(calibrate bool) {
+	c.config.Set("CALIBRATE", calibrate)
+}
+
+// GetDynamicAssist is true if a player who keeps getting out quickly should be helped with
+// slower deliveries and kinder timing, in matches that can't set a high score
+func (c *Config) GetDynamicAssist() bool {
+	if c.config.IsSet("DYNAMIC_ASSIST") {
+		return c.config.GetBool("DYNAMIC_ASSIST")
+	}
+	return c.config.GetBool("game.dynamic_assist")
+}
+
+// SetDynamicAssist turns the dynamic assist on or off, whatever the config files and
+// environment say
+func (c *Config) SetDynamicAssist(assist bool) {
+	c.config.Set("DYNAMIC_ASSIST", assist)
+}
+
+// GetMutators is true if the game starts on the mutators screen, to pick some just-for-fun
+// changes to the physics before the match
+func (c *Config) GetMutators() bool {
+	if c.config.IsSet("MUTATORS") {
+		return c.config.GetBool("MUTATORS")
+	}
+	return c.config.GetBool("game.mutators")
+}
+
+// SetMutators starts the game on the mutators screen, whatever the config files and
+// environment say
+func (c *Config) SetMutators(mutators bool) {
+	c.config.Set("MUTATORS", mutators)
+}
+
+func (c *Config) GetPracticeScript() string {
+	practiceScript := c.config.GetString("PRACTICE_SCRIPT")
+	if len(practiceScript) == 0 {
+		practiceScript = c.config.GetString("game.practice_script")
+	}
+
+	return practiceScript
+}
+
+// SetPracticeScript bowls the given practice script, whatever the config files and
+// environment say
+func (c *Config) SetPracticeScript(script string) {
+	c.config.Set("PRACTICE_SCRIPT", script)
+}
+
+// GetDragAreaRight is how far right of the stumps the bat can be dragged, as a fraction of the screen width
+func (c *Config) GetDragAreaRight() float64 {
+	dragAreaRight := c.config.GetFloat64("BAT_DRAG_AREA_RIGHT")
+	if dragAreaRight == 0 {
+		dragAreaRight = c.config.GetFloat64("bat.drag_area_right")
+	}
+
+	return dragAreaRight
+}
+
+// GetDragAreaUp is how far above the stumps the bat can be dragged, as a fraction of the screen height
+func (c *Config) GetDragAreaUp() float64 {
+	dragAreaUp := c.config.GetFloat64("BAT_DRAG_AREA_UP")
+	if dragAreaUp == 0 {
+		dragAreaUp = c.config.GetFloat64("bat.drag_area_up")
+	}
+
+	return dragAreaUp
+}
+
+// GetDragAreaDown is how far below the top of the stumps the bat can be dragged, as a fraction of the screen height
+func (c *Config) GetDragAreaDown() float64 {
+	dragAreaDown := c.config.GetFloat64("BAT_DRAG_AREA_DOWN")
+	if dragAreaDown == 0 {
+		dragAreaDown = c.config.GetFloat64("bat.drag_area_down")
+	}
+
+	return dragAreaDown
+}
+
+// SetDragArea changes how far the bat can be dragged from the stumps, as fractions of the
+// screen size, whatever the config files and environment say
+func (c *Config) SetDragArea(right, up, down float64) {
+	c.config.Set("BAT_DRAG_AREA_RIGHT", right)
+	c.config.Set("BAT_DRAG_AREA_UP", up)
+	c.config.Set("BAT_DRAG_AREA_DOWN", down)
+}
+
+// GetAudioEnabled is true unless sound has been turned off
+func (c *Config) GetAudioEnabled() bool {
+	if c.config.IsSet("AUDIO_ENABLED") {
+		return c.config.GetBool("AUDIO_ENABLED")
+	}
+	if c.config.IsSet("audio.enabled") {
+		return c.config.GetBool("audio.enabled")
+	}
+
+	return true
+}
+
+// GetSFXVolume is how loud sound effects play, from 0 for silent to 1 for full volume
+func (c *Config) GetSFXVolume() float64 {
+	return c.getVolume("SFX_VOLUME", "audio.sfx_volume")
+}
+
+// GetMusicVolume is how loud the background music plays, from 0 to 1
+func (c *Config) GetMusicVolume() float64 {
+	return c.getVolume("MUSIC_VOLUME", "audio.music_volume")
+}
+
+// GetCommentaryVolume is how loud spoken commentary plays, from 0 to 1
+func (c *Config) GetCommentaryVolume() float64 {
+	return c.getVolume("COMMENTARY_VOLUME", "audio.commentary_volume")
+}
+
+// getVolume reads a volume from the environment or the config file, at full volume unless set
+func (c *Config) getVolume(envKey, key string) float64 {
+	if c.config.IsSet(envKey) {
+		return c.config.GetFloat64(envKey)
+	}
+	if c.config.IsSet(key) {
+		return c.config.GetFloat64(key)
+	}
+
+	return 1
+}
+
+// GetCommentaryPack is the directory of a voice commentary pack, empty for text commentary only
+func (c *Config) GetCommentaryPack() string {
+	commentaryPack := c.config.GetString("COMMENTARY_PACK")
+	if len(commentaryPack) == 0 {
+		commentaryPack = c.config.GetString("commentary.pack")
+	}
+
+	return commentaryPack
+}
+
+// GetCommentaryText is true unless commentary that a voice pack speaks should be kept off screen
+func (c *Config) GetCommentaryText() bool {
+	if c.config.IsSet("COMMENTARY_TEXT") {
+		return c.config.GetBool("COMMENTARY_TEXT")
+	}
+	if c.config.IsSet("commentary.text") {
+		return c.config.GetBool("commentary.text")
+	}
+
+	return true
+}
+
+// GetRumble is true unless gamepad vibration has been turned off
+func (c *Config) GetRumble() bool {
+	if c.config.IsSet("RUMBLE") {
+		return c.config.GetBool("RUMBLE")
+	}
+	if c.config.IsSet("gamepad.rumble") {
+		return c.config.GetBool("gamepad.rumble")
+	}
+
+	return true
+}
+
+// SetRumble turns gamepad rumble on or off, whatever the config files and environment say
+func (c *Config) SetRumble(rumble bool) {
+	c.config.Set("RUMBLE", rumble)
+}
+
+// GetOnScreenKeyboard is true if names should always be typed on the on-screen keyboard,
+// and not only when there is a gamepad or a touch screen
+func (c *Config) GetOnScreenKeyboard() bool {
+	if c.config.IsSet("ON_SCREEN_KEYBOARD") {
+		return c.config.GetBool("ON_SCREEN_KEYBOARD")
+	}
+	return c.config.GetBool("gamepad.on_screen_keyboard")
+}
+
+// GetKeyboardLocale is the locale whose layout the on-screen keyboard has, such as fr or
+// de_DE, empty to follow the system's
+func (c *Config) GetKeyboardLocale() string {
+	locale := c.config.GetString("KEYBOARD_LOCALE")
+	if len(locale) == 0 {
+		locale = c.config.GetString("gamepad.keyboard_locale")
+	}
+
+	return locale
+}
+
+// GetLeaderboardURL is where scores are posted for the online leaderboard, empty for none
+func (c *Config) GetLeaderboardURL() string {
+	url := c.config.GetString("LEADERBOARD_URL")
+	if len(url) == 0 {
+		url = c.config.GetString("leaderboard.url")
+	}
+
+	return url
+}
+
+// GetLobbyURL is the lobby online matches are listed in and found through, empty for none
+func (c *Config) GetLobbyURL() string {
+	url := c.config.GetString("LOBBY_URL")
+	if len(url) == 0 {
+		url = c.config.GetString("online.lobby")
+	}
+
+	return url
+}
+
+// GetUpdateCheck is true unless the game shouldn't look online for newer releases
+func (c *Config) GetUpdateCheck() bool {
+	if c.config.IsSet("UPDATE_CHECK") {
+		return c.config.GetBool("UPDATE_CHECK")
+	}
+	if c.config.IsSet("updates.check") {
+		return c.config.GetBool("updates.check")
+	}
+
+	return true
+}
+
+// GetNightMatch is true for matches under floodlights
+func (c *Config) GetNightMatch() bool {
+	if c.config.IsSet("NIGHT_MATCH") {
+		return c.config.GetBool("NIGHT_MATCH")
+	}
+	return c.config.GetBool("game.night")
+}
+
+// GetReducedMotion is true if the player wants to avoid flashing and fast moving effects
+func (c *Config) GetReducedMotion() bool {
+	if c.config.IsSet("REDUCED_MOTION") {
+		return c.config.GetBool("REDUCED_MOTION")
+	}
+	return c.config.GetBool("accessibility.reduced_motion")
+}
+
+// GetNarration is true if the game should read out what is happening as plain text
+func (c *Config) GetNarration() bool {
+	if c.config.IsSet("NARRATION") {
+		return c.config.GetBool("NARRATION")
+	}
+	return c.config.GetBool("accessibility.narration")
+}
+
+// GetNarrationCommand is a speech or notification command that narrated lines are passed
+// to, empty to only print them
+func (c *Config) GetNarrationCommand() string {
+	command := c.config.GetString("NARRATION_COMMAND")
+	if len(command) == 0 {
+		command = c.config.GetString("accessibility.narration_command")
+	}
+
+	return command
+}
+
+// GetOverlayFile is where live match data is written for streaming overlays, empty for
+// no overlay
+func (c *Config) GetOverlayFile() string {
+	overlayFile := c.config.GetString("OVERLAY_FILE")
+	if len(overlayFile) == 0 {
+		overlayFile = c.config.GetString("streaming.overlay_file")
+	}
+
+	return overlayFile
+}
+
+// GetTwitchChannel is the Twitch channel whose chat plays the bowler
+func (c *Config) GetTwitchChannel() string {
+	channel := c.config.GetString("TWITCH_CHANNEL")
+	if len(channel) == 0 {
+		channel = c.config.GetString("twitch.channel")
+	}
+
+	return channel
+}
+
+// GetTwitchNick is the Twitch account the game reads chat as, if it has a token
+func (c *Config) GetTwitchNick() string {
+	nick := c.config.GetString("TWITCH_NICK")
+	if len(nick) == 0 {
+		nick = c.config.GetString("twitch.nick")
+	}
+
+	return nick
+}
+
+// GetTwitchToken is the OAuth token for the Twitch account, empty to read chat anonymously
+func (c *Config) GetTwitchToken() string {
+	token := c.config.GetString("TWITCH_TOKEN")
+	if len(token) == 0 {
+		token = c.config.GetString("twitch.token")
+	}
+
+	return token
+}
+
+// GetMetricsEnabled is true if the game should serve Prometheus metrics
+func (c *Config) GetMetricsEnabled() bool {
+	if c.config.IsSet("METRICS_ENABLED") {
+		return c.config.GetBool("METRICS_ENABLED")
+	}
+	return c.config.GetBool("metrics.enabled")
+}
+
+// GetMetricsAddr is the address metrics are served on
+func (c *Config) GetMetricsAddr() string {
+	addr := c.config.GetString("METRICS_ADDR")
+	if len(addr) == 0 {
+		addr = c.config.GetString("metrics.addr")
+	}
+
+	return addr
+}
+
+// GetBallByBall is true if a ball-by-ball log of every match is saved in the data directory
+func (c *Config) GetBallByBall() bool {
+	if c.config.IsSet("BALL_BY_BALL") {
+		return c.config.GetBool("BALL_BY_BALL")
+	}
+	return c.config.GetBool("data.ball_by_ball")
+}
+
+// GetReplays is true if a replay of every match is saved in the data directory
+func (c *Config) GetReplays() bool {
+	if c.config.IsSet("REPLAYS") {
+		return c.config.GetBool("REPLAYS")
+	}
+	return c.config.GetBool("data.replays")
+}
+
+// GetResultCard is true unless the game shouldn't save a result card PNG after every match
+func (c *Config) GetResultCard() bool {
+	if c.config.IsSet("RESULT_CARD") {
+		return c.config.GetBool("RESULT_CARD")
+	}
+	if c.config.IsSet("data.result_card") {
+		return c.config.GetBool("data.result_card")
+	}
+
+	return true
+}
+
+// GetResultCardClipboard is true if each result card should also be copied to the clipboard
+func (c *Config) GetResultCardClipboard() bool {
+	if c.config.IsSet("RESULT_CARD_CLIPBOARD") {
+		return c.config.GetBool("RESULT_CARD_CLIPBOARD")
+	}
+	return c.config.GetBool("data.result_card_clipboard")
+}
+
+// GetRetentionMaxFiles is how many files of each kind the game keeps adding, such as match
+// logs, are kept in the data directory before the oldest are deleted. 0 is no limit.
+func (c *Config) GetRetentionMaxFiles() int {
+	if c.config.IsSet("RETENTION_MAX_FILES") {
+		return c.config.GetInt("RETENTION_MAX_FILES")
+	}
+	return c.config.GetInt("data.retention.max_files")
+}
+
+// GetRetentionMaxMB is how many megabytes of each kind of kept file are allowed before the
+// oldest are deleted. 0 is no limit.
+func (c *Config) GetRetentionMaxMB() int {
+	if c.config.IsSet("RETENTION_MAX_MB") {
+		return c.config.GetInt("RETENTION_MAX_MB")
+	}
+	return c.config.GetInt("data.retention.max_mb")
+}
+
+// GetRetentionMaxAgeDays is how many days kept files last before they are deleted. 0 keeps
+// them however old they are.
+func (c *Config) GetRetentionMaxAgeDays() int {
+	if c.config.IsSet("RETENTION_MAX_AGE_DAYS") {
+		return c.config.GetInt("RETENTION_MAX_AGE_DAYS")
+	}
+	return c.config.GetInt("data.retention.max_age_days")
+}
+
 func getProjectRoot() (string, error) {
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -120,17 +918,43 @@ func getProjectRoot() (string, error) {
 	return "", fmt.Errorf("could not find project root (directory containing 'config' folder)")
 }
 
+// findConfigFile looks for the project's config file for the environment, then the user's
+func findConfigFile(env string) (string, error) {
+	configPath, projectErr := getConfigPath(env)
+	if projectErr == nil {
+		return configPath, nil
+	}
+
+	configPath, userErr := getUserConfigPath()
+	if userErr == nil {
+		return configPath, nil
+	}
+
+	return "", fmt.Errorf("%w; %w", projectErr, userErr)
+}
+
 func getConfigPath(env string) (string, error) {
 	configFile := fmt.Sprintf("config.%s.yaml", env)
 
 	projectRoot, err := getProjectRoot()
 	if err != nil {
-		slog.Warn("failed to find project root with config directory, will use environment variables instead", "err", err.Error())
 		return "", fmt.Errorf("failed to find project root: %w", err)
 	}
 	configPath := filepath.Join(projectRoot, "config", configFile)
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		slog.Warn("failed to find config file within config directory, will use environment variables instead", "err", err.Error())
+		return "", fmt.Errorf("config file does not exist: %s", configPath)
+	}
+
+	return configPath, nil
+}
+
+func getUserConfigPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find user config directory: %w", err)
+	}
+	configPath := filepath.Join(configDir, appName, userConfigFile)
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		return "", fmt.Errorf("config file does not exist: %s", configPath)
 	}
 
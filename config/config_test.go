@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestClone(t *testing.T) {
+	t.Setenv("OVERS", "7")
+	cfg, err := LoadFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.SetDifficulty("hard")
+
+	clone := cfg.Clone()
+	if got, want := clone.GetDifficulty(), "hard"; got != want {
+		t.Errorf("clone's difficulty is %q, want %q", got, want)
+	}
+	if got, want := clone.GetOvers(), 7; got != want {
+		t.Errorf("clone's overs are %d, want %d from the environment", got, want)
+	}
+	if got, want := clone.GetWindowWidth(), cfg.GetWindowWidth(); got != want {
+		t.Errorf("clone's window width is %v, want %v", got, want)
+	}
+
+	mode := cfg.GetMode()
+	clone.SetMode("versus")
+	clone.SetWindowSize(320, 200)
+	if got := cfg.GetMode(); got != mode {
+		t.Errorf("setting the clone's mode changed the config's from %q to %q", mode, got)
+	}
+	if got := cfg.GetWindowWidth(); got == 320 {
+		t.Error("setting the clone's window size changed the config's")
+	}
+	if got, want := clone.GetMode(), "versus"; got != want {
+		t.Errorf("clone's mode is %q, want %q", got, want)
+	}
+}
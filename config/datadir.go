@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+const appName = "cricket2d"
+
+// legacyDataDir is where saves were kept, relative to the working directory, before the
+// game used the platform's data directory
+var legacyDataDir = filepath.Join(".data", appName)
+
+// defaultDataDir is where the platform expects an application's saved data: under
+// $XDG_DATA_HOME (~/.local/share) on Linux and the BSDs, %AppData% on Windows and
+// ~/Library/Application Support on macOS. It falls back to the legacy directory if the
+// platform has no such place.
+func defaultDataDir() string {
+	if runtime.GOOS != "windows" && runtime.GOOS != "darwin" {
+		if dataHome := os.Getenv("XDG_DATA_HOME"); len(dataHome) > 0 {
+			return filepath.Join(dataHome, appName)
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ".local", "share", appName)
+		}
+	} else if configDir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(configDir, appName)
+	}
+
+	slog.Warn("no platform data directory, using the working directory", "dir", legacyDataDir)
+	return legacyDataDir
+}
+
+// MigrateLegacyData moves saves from the legacy data directory into the data directory, if
+// the data directory is still empty. It reports how many files were moved.
+func (c *Config) MigrateLegacyData() (int, error) {
+	dataDir := c.GetDataDir()
+	if sameDir(dataDir, legacyDataDir) {
+		return 0, nil
+	}
+	if _, err := os.Stat(legacyDataDir); errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if entries, err := os.ReadDir(dataDir); err == nil && len(entries) > 0 {
+		return 0, nil
+	}
+
+	moved := 0
+	err := filepath.WalkDir(legacyDataDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relative, err := filepath.Rel(legacyDataDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dataDir, relative)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := moveFile(path, target); err != nil {
+			return fmt.Errorf("could not move %s: %w", path, err)
+		}
+		moved++
+		return nil
+	})
+
+	return moved, err
+}
+
+// moveFile renames a file, copying it instead when the target is on another drive
+func moveFile(from, to string) error {
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(to, data, 0644); err != nil {
+		return err
+	}
+	return os.Remove(from)
+}
+
+func sameDir(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
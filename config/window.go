@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// windowPlacementFile is kept next to the user's config file, written by the game rather
+// than the player
+const windowPlacementFile = "window.yaml"
+
+// WindowPlacement is where the game's window was when the game was last closed
+type WindowPlacement struct {
+	Monitor string `yaml:"monitor"` // Name of the monitor the window was on
+	X       int    `yaml:"x"`       // Position relative to the monitor's top left corner
+	Y       int    `yaml:"y"`
+	Width   int    `yaml:"width"`
+	Height  int    `yaml:"height"`
+}
+
+// GetRememberWindow is true if the window should open where it was last closed
+func (c *Config) GetRememberWindow() bool {
+	if c.config.IsSet("REMEMBER_WINDOW") {
+		return c.config.GetBool("REMEMBER_WINDOW")
+	}
+	if c.config.IsSet("window.remember") {
+		return c.config.GetBool("window.remember")
+	}
+	return true
+}
+
+// LoadWindowPlacement reads where the window was last closed, reporting false if it has
+// never been saved or can't be read
+func (c *Config) LoadWindowPlacement() (WindowPlacement, bool) {
+	path, err := windowPlacementPath()
+	if err != nil {
+		return WindowPlacement{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return WindowPlacement{}, false
+	}
+
+	var placement WindowPlacement
+	if err := yaml.Unmarshal(data, &placement); err != nil || placement.Width <= 0 || placement.Height <= 0 {
+		return WindowPlacement{}, false
+	}
+	return placement, true
+}
+
+// SaveWindowPlacement remembers where the window is, for the next time the game starts
+func (c *Config) SaveWindowPlacement(placement WindowPlacement) error {
+	path, err := windowPlacementPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(placement)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func windowPlacementPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find user config directory: %w", err)
+	}
+
+	return filepath.Join(configDir, appName, windowPlacementFile), nil
+}
@@ -0,0 +1,184 @@
+package deliveries
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultIntervalSeconds = 2
+
+// Delivery describes a single ball as it leaves the bowler's hand. There is no line: the
+// field is seen side on, so a ball can't drift across the pitch, and its height and dip say
+// where it arrives instead.
+type Delivery struct {
+	Type            string  `yaml:"type" json:"type"`
+	Speed           float64 `yaml:"speed" json:"speed"`                       // Horizontal distance moved towards the batsman in a tick
+	Height          float64 `yaml:"height" json:"height"`                     // Release height as a fraction of screen height (0 is the top)
+	Dip             float64 `yaml:"dip" json:"dip"`                           // Downward distance moved in the first tick
+	IntervalSeconds float64 `yaml:"interval_seconds" json:"interval_seconds"` // Wait before this delivery is bowled
+	Bowler          string  `yaml:"bowler" json:"bowler,omitempty"`           // Who bowled it, if anyone in particular
+	Spin            float64 `yaml:"spin" json:"spin,omitempty"`               // Turns a second the ball makes in the air, clockwise if positive
+}
+
+// Script is a named sequence of deliveries, bowled in order
+type Script struct {
+	Name       string
+	Deliveries []Delivery
+}
+
+type scriptFile struct {
+	Name       string `yaml:"name" json:"name"`
+	Deliveries []Row  `yaml:"deliveries" json:"deliveries"`
+}
+
+// Row is a delivery as written in a script, which may be repeated and may leave out
+// anything its type already implies
+type Row struct {
+	Delivery `yaml:",inline"`
+	Repeat   int `yaml:"repeat" json:"repeat"`
+}
+
+// Defaults for each type of delivery, tuned for the default window size
+var types = map[string]Delivery{
+	"yorker":      {Speed: 20, Height: 0.6, Dip: 2},
+	"full":        {Speed: 16, Height: 0.45, Dip: 1},
+	"good_length": {Speed: 14, Height: 0.35, Dip: 0.5},
+	"bouncer":     {Speed: 24, Height: 0.2, Dip: 0},
+}
+
+// Types lists the known types of delivery in alphabetical order
+func Types() []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// OfType returns the default delivery of the given type
+func OfType(name string) (Delivery, bool) {
+	delivery, ok := types[name]
+	if ok {
+		delivery.Type = name
+		delivery.IntervalSeconds = defaultIntervalSeconds
+	}
+
+	return delivery, ok
+}
+
+//go:embed scripts/*.yaml
+var builtinScripts embed.FS
+
+// Load reads a script from a YAML or JSON file. A name without a file extension refers to
+// one of the scripts built into the game, such as "tutorial".
+func Load(name string) (*Script, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == "" {
+		ext = ".yaml"
+		data, err = builtinScripts.ReadFile("scripts/" + name + ext)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read delivery script %s: %w", name, err)
+	}
+
+	var file scriptFile
+	switch ext {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		err = fmt.Errorf("unsupported file type %s", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse delivery script %s: %w", name, err)
+	}
+
+	return file.toScript()
+}
+
+// NewScript builds a script from rows written elsewhere, such as in a scenario
+func NewScript(name string, rows []Row) (*Script, error) {
+	return scriptFile{Name: name, Deliveries: rows}.toScript()
+}
+
+func (f scriptFile) toScript() (*Script, error) {
+	script := &Script{
+		Name:       f.Name,
+		Deliveries: make([]Delivery, 0, len(f.Deliveries)),
+	}
+
+	for i, row := range f.Deliveries {
+		delivery, err := row.resolve()
+		if err != nil {
+			return nil, fmt.Errorf("delivery %d: %w", i+1, err)
+		}
+
+		repeat := max(row.Repeat, 1)
+		for range repeat {
+			script.Deliveries = append(script.Deliveries, delivery)
+		}
+	}
+
+	if len(script.Deliveries) == 0 {
+		return nil, fmt.Errorf("delivery script %q has no deliveries", f.Name)
+	}
+
+	return script, nil
+}
+
+// Resolve fills in whatever a delivery leaves out from the defaults for its type, and checks
+// what is left makes sense
+func Resolve(delivery Delivery) (Delivery, error) {
+	return Row{Delivery: delivery}.resolve()
+}
+
+// resolve fills in whatever the row left out from the defaults for its type
+func (r Row) resolve() (Delivery, error) {
+	delivery := r.Delivery
+
+	if delivery.Type != "" {
+		defaults, ok := types[delivery.Type]
+		if !ok {
+			return Delivery{}, fmt.Errorf("unknown delivery type %q", delivery.Type)
+		}
+		if delivery.Speed == 0 {
+			delivery.Speed = defaults.Speed
+		}
+		if delivery.Height == 0 {
+			delivery.Height = defaults.Height
+		}
+		if delivery.Dip == 0 {
+			delivery.Dip = defaults.Dip
+		}
+	}
+
+	if delivery.IntervalSeconds == 0 {
+		delivery.IntervalSeconds = defaultIntervalSeconds
+	}
+
+	if delivery.Speed <= 0 {
+		return Delivery{}, fmt.Errorf("speed must be positive")
+	}
+	if delivery.Height < 0 || delivery.Height > 1 {
+		return Delivery{}, fmt.Errorf("height must be between 0 and 1")
+	}
+
+	return delivery, nil
+}
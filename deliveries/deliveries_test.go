@@ -0,0 +1,150 @@
+package deliveries
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeScript writes a script to a fresh directory under the given file name
+func writeScript(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	yorker, _ := OfType("yorker")
+	bouncer, _ := OfType("bouncer")
+	slowBouncer := bouncer
+	slowBouncer.Speed = 12
+	slowBouncer.IntervalSeconds = 4
+
+	tests := []struct {
+		name     string
+		file     string
+		contents string
+		want     []Delivery
+	}{
+		{
+			name:     "repeats",
+			file:     "drill.yaml",
+			contents: "name: drill\ndeliveries:\n  - type: yorker\n    repeat: 2\n  - type: bouncer\n",
+			want:     []Delivery{yorker, yorker, bouncer},
+		},
+		{
+			name:     "overrides the type",
+			file:     "drill.yml",
+			contents: "name: drill\ndeliveries:\n  - {type: bouncer, speed: 12, interval_seconds: 4}\n",
+			want:     []Delivery{slowBouncer},
+		},
+		{
+			name:     "json",
+			file:     "drill.json",
+			contents: `{"name": "drill", "deliveries": [{"type": "yorker"}, {"type": "bouncer", "repeat": 1}]}`,
+			want:     []Delivery{yorker, bouncer},
+		},
+		{
+			name:     "without a type",
+			file:     "drill.yaml",
+			contents: "name: drill\ndeliveries:\n  - {speed: 9, height: 0.5, dip: 1, bowler: Anna, spin: -2}\n",
+			want:     []Delivery{{Speed: 9, Height: 0.5, Dip: 1, IntervalSeconds: defaultIntervalSeconds, Bowler: "Anna", Spin: -2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, err := Load(writeScript(t, tt.file, tt.contents))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if script.Name != "drill" {
+				t.Errorf("got name %q, want drill", script.Name)
+			}
+			if !reflect.DeepEqual(script.Deliveries, tt.want) {
+				t.Errorf("got %+v, want %+v", script.Deliveries, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRefused(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		contents string
+		want     string // Part of the error
+	}{
+		{
+			name:     "unknown type",
+			file:     "drill.yaml",
+			contents: "deliveries:\n  - type: googly\n",
+			want:     `delivery 1: unknown delivery type "googly"`,
+		},
+		{
+			name:     "no speed",
+			file:     "drill.yaml",
+			contents: "deliveries:\n  - type: yorker\n  - height: 0.5\n",
+			want:     "delivery 2: speed must be positive",
+		},
+		{
+			name:     "too high",
+			file:     "drill.yaml",
+			contents: "deliveries:\n  - {type: full, height: 1.5}\n",
+			want:     "height must be between 0 and 1",
+		},
+		{
+			name:     "no deliveries",
+			file:     "drill.yaml",
+			contents: "name: empty\n",
+			want:     `"empty" has no deliveries`,
+		},
+		{
+			name:     "not yaml",
+			file:     "drill.yaml",
+			contents: "deliveries: [type: yorker\n",
+			want:     "could not parse",
+		},
+		{
+			name:     "not json",
+			file:     "drill.json",
+			contents: `{"deliveries": [}`,
+			want:     "could not parse",
+		},
+		{
+			name:     "unsupported file type",
+			file:     "drill.txt",
+			contents: "deliveries:\n  - type: yorker\n",
+			want:     "unsupported file type .txt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeScript(t, tt.file, tt.contents))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinScripts(t *testing.T) {
+	entries, err := builtinScripts.ReadDir("scripts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if _, err := Load(name); err != nil {
+			t.Errorf("built-in script %s: %v", name, err)
+		}
+	}
+
+	if _, err := Load("no-such-script"); err == nil {
+		t.Error("loaded a built-in script that doesn't exist")
+	}
+}
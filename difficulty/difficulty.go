@@ -0,0 +1,127 @@
+package difficulty
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const Default = "normal"
+
+// Preset holds the numbers that make the game easier or harder
+type Preset struct {
+	Name                 string        `yaml:"name"`
+	SpawnIntervalSeconds float64       `yaml:"spawn_interval_seconds"`
+	Ball                 BallSettings  `yaml:"ball"`
+	Hit                  HitSettings   `yaml:"hit"`
+	Bowling              Bowling       `yaml:"bowling"`
+	HitWicket            HitWicket     `yaml:"hit_wicket"`
+	Pitch                Pitch         `yaml:"pitch"`
+	Fielders             []FielderSpot `yaml:"fielders"`
+}
+
+type BallSettings struct {
+	MinSpeed         float64 `yaml:"min_speed"`          // Slowest horizontal distance moved in a tick
+	MaxSpeed         float64 `yaml:"max_speed"`          // Fastest horizontal distance moved in a tick
+	Gravity          float64 `yaml:"gravity"`            // Downward distance added to the ball's velocity in a tick
+	MaxReleaseHeight float64 `yaml:"max_release_height"` // Lowest release point as a fraction of screen height
+}
+
+// HitSettings control how randomly the ball flies off the bat, in radians
+type HitSettings struct {
+	HandleRandomness float64 `yaml:"handle_randomness"`
+	BodyRandomness   float64 `yaml:"body_randomness"`
+}
+
+type Bowling struct {
+	Aggressiveness float64 `yaml:"aggressiveness"` // How hard an adaptive bowler goes after the batsman's weaknesses, from 0 to 1
+}
+
+// HitWicket decides when the bat touching the stumps gets the batsman out
+type HitWicket struct {
+	GraceSeconds  float64 `yaml:"grace_seconds"`   // The bat can follow through into the stumps for this long after a shot
+	MinOverlap    float64 `yaml:"min_overlap"`     // Pixels the bat must be inside the stumps to dislodge the bails by itself
+	MinSwingSpeed float64 `yaml:"min_swing_speed"` // Bat tip speed in pixels per tick that dislodges the bails on any contact
+}
+
+// Pitch decides how quickly the pitch breaks up and how much its cracks do to the ball
+type Pitch struct {
+	Deterioration float64 `yaml:"deterioration"` // 1 for an ordinary pitch, 0 for one that never cracks
+}
+
+// FielderSpot is where a fielder stands, as fractions of the screen size
+type FielderSpot struct {
+	Name string  `yaml:"name"`
+	X    float64 `yaml:"x"`
+	Y    float64 `yaml:"y"`
+}
+
+//go:embed presets/*.yaml
+var builtinPresets embed.FS
+
+// Names lists the built-in presets
+func Names() []string {
+	return []string{"kid", "easy", "normal", "hard"}
+}
+
+// Load reads a preset. A name without a file extension refers to a built-in preset,
+// anything else is a path to a YAML file, such as one shared by another player.
+func Load(name string) (*Preset, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if len(filepath.Ext(name)) == 0 {
+		data, err = builtinPresets.ReadFile("presets/" + strings.ToLower(name) + ".yaml")
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read difficulty preset %s: %w", name, err)
+	}
+
+	var preset Preset
+	if err := yaml.Unmarshal(data, &preset); err != nil {
+		return nil, fmt.Errorf("could not parse difficulty preset %s: %w", name, err)
+	}
+
+	if err := preset.validate(); err != nil {
+		return nil, fmt.Errorf("invalid difficulty preset %s: %w", name, err)
+	}
+
+	return &preset, nil
+}
+
+func (p *Preset) validate() error {
+	if p.SpawnIntervalSeconds <= 0 {
+		return fmt.Errorf("spawn_interval_seconds must be positive")
+	}
+	if p.Ball.MinSpeed <= 0 || p.Ball.MaxSpeed < p.Ball.MinSpeed {
+		return fmt.Errorf("ball speeds must be positive with min_speed no more than max_speed")
+	}
+	if p.Ball.Gravity < 0 {
+		return fmt.Errorf("ball gravity can't be negative")
+	}
+	if p.Ball.MaxReleaseHeight <= 0 || p.Ball.MaxReleaseHeight > 1 {
+		return fmt.Errorf("max_release_height must be between 0 and 1")
+	}
+	if p.Hit.HandleRandomness < 0 || p.Hit.BodyRandomness < 0 {
+		return fmt.Errorf("hit randomness can't be negative")
+	}
+	if p.Bowling.Aggressiveness < 0 || p.Bowling.Aggressiveness > 1 {
+		return fmt.Errorf("bowling aggressiveness must be between 0 and 1")
+	}
+	if p.HitWicket.GraceSeconds < 0 || p.HitWicket.MinOverlap < 0 || p.HitWicket.MinSwingSpeed < 0 {
+		return fmt.Errorf("hit wicket thresholds can't be negative")
+	}
+	if p.Pitch.Deterioration < 0 {
+		return fmt.Errorf("pitch deterioration can't be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// achievement is a milestone the player earns once, kept in their profile
+type achievement struct {
+	id     string
+	title  string
+	mode   string // The mode the achievement is earned in
+	earned func(state sim.MatchState) bool
+}
+
+// achievements lists every achievement in the game
+var achievements = []achievement{
+	survivalAchievement(30, "Trimmed"),
+	survivalAchievement(60, "Toothpick"),
+	survivalAchievement(100, "Matchstick Century"),
+}
+
+// survivalAchievement is earned by scoring a number of runs in a survival innings
+func survivalAchievement(runs int, title string) achievement {
+	return achievement{
+		id:     fmt.Sprintf("%s_%d", sim.ModeSurvival, runs),
+		title:  fmt.Sprintf("%s: %d runs in survival", title, runs),
+		mode:   sim.ModeSurvival,
+		earned: func(state sim.MatchState) bool { return state.Score >= runs },
+	}
+}
+
+// awardAchievements gives the player the achievements they earned in the innings just
+// finished, keeping the new ones to show on the game over screen
+func (g *Game) awardAchievements() {
+	g.newAchievements = g.newAchievements[:0]
+	if g.practiceScript != nil || g.world.Mutators.Any() {
+		return
+	}
+
+	state := g.world.MatchState()
+	for _, a := range achievements {
+		if a.mode != g.mode.Name() || !a.earned(state) {
+			continue
+		}
+		added, err := g.profileManager.AddAchievement(a.id)
+		if err != nil {
+			g.logger.Warn("could not save achievement", "id", a.id, "error", err)
+		}
+		if added {
+			g.logger.Info("achievement earned", "id", a.id)
+			g.newAchievements = append(g.newAchievements, a.title)
+		}
+	}
+}
+
+// drawAchievements lists the achievements earned in the innings just finished
+func (g *Game) drawAchievements(screen *ebiten.Image) {
+	if len(g.newAchievements) == 0 {
+		return
+	}
+
+	const (
+		achievementsX float64 = 20
+		achievementsY float64 = 30
+		spacing       float64 = 30
+	)
+	g.drawText(screen, "Achievement unlocked!", achievementsX, achievementsY, 1, 1, color.RGBA{255, 255, 0, 255})
+	for i, title := range g.newAchievements {
+		g.drawText(screen, title, achievementsX, achievementsY+float64(i+1)*spacing, 1, 1, color.White)
+	}
+}
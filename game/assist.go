@@ -0,0 +1,73 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// startAssist turns on dynamic assist, if the player wants it
+func (g *Game) startAssist() {
+	if g.cfg.GetDynamicAssist() {
+		g.assist = &sim.DynamicAssist{}
+	}
+}
+
+// ranked is true for a match that can set a high score
+func (g *Game) ranked() bool {
+	// The featured mode's mutators are part of its rules, with a high score of their own
+	_, featured := g.featuredMutators()
+	return g.practiceScript == nil && g.scenario == nil && (featured || !g.world.Mutators.Any()) && !g.world.Kid &&
+		g.world.Rules.Standard()
+}
+
+// updateAssist steps the assist up after repeated quick dismissals and down as the player
+// bats longer. It only helps in matches that can't set a high score.
+func (g *Game) updateAssist() {
+	a := g.assist
+	if a == nil || g.ranked() || g.calibration != nil {
+		g.world.Assist = 0
+		return
+	}
+
+	balls := g.world.LegalBalls()
+	if balls < a.LastBalls {
+		// A new innings
+		a.LastBalls = 0
+	}
+	a.Faced += balls - a.LastBalls
+	a.LastBalls = balls
+
+	for _, event := range g.world.Events {
+		if event != sim.EventWicket && event != sim.EventGivenOut {
+			continue
+		}
+		if a.Faced <= sim.QuickDismissalBalls {
+			a.QuickDismissals++
+		} else {
+			a.QuickDismissals = 0
+		}
+		a.Faced = 0
+		if a.QuickDismissals >= sim.AssistQuickDismissals {
+			a.QuickDismissals = 0
+			a.Level = min(a.Level+sim.AssistStep, 1)
+			g.logger.Debug("dynamic assist stepped up", "level", a.Level)
+		}
+	}
+
+	if a.Faced >= sim.AssistSteadyBalls && a.Level > 0 {
+		a.Faced = 0
+		a.Level = max(a.Level-sim.AssistStep, 0)
+		g.logger.Debug("dynamic assist stepped down", "level", a.Level)
+	}
+
+	g.world.Assist = a.Level
+}
+
+// assistHUD owns up to the assist on the scoreboard whenever it is helping
+func (g *Game) assistHUD() (string, bool) {
+	if g.world.Assist == 0 {
+		return "", false
+	}
+	return fmt.Sprintf("Dynamic assist: %d%% (slower balls, wider middle)", int(g.world.Assist*100)), true
+}
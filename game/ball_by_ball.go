@@ -0,0 +1,62 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const ballByBallDir = "ball_by_ball" // Under the data directory
+
+// logInnings adds the innings just finished to the match's ball-by-ball log, if the player
+// keeps one
+func (g *Game) logInnings() {
+	if !g.cfg.GetBallByBall() {
+		return
+	}
+
+	if g.ballByBall == nil {
+		g.ballByBall = stats.NewBallByBall(g.mode.Name(), g.mode.Rules().Overs, g.world.Rules.BallsPerOver, time.Now())
+	}
+	g.ballByBall.AddInnings(g.battingSide(), g.world.Innings)
+}
+
+// saveBallByBall writes the match's ball-by-ball log to the data directory, and starts a
+// fresh log for the next match
+func (g *Game) saveBallByBall() {
+	log := g.ballByBall
+	if log == nil {
+		return
+	}
+	g.ballByBall = nil
+
+	dir := filepath.Join(g.cfg.GetDataDir(), ballByBallDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		g.logger.Warn("could not create ball-by-ball directory", "dir", dir, "error", err)
+		return
+	}
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		g.logger.Warn("could not encode ball-by-ball log", "error", err)
+		return
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s.json", time.Now().Format("20060102_150405"), g.mode.Name()))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		g.logger.Warn("could not save ball-by-ball log", "path", path, "error", err)
+		return
+	}
+	g.logger.Info("ball-by-ball log saved", "path", path)
+}
+
+// battingSide is who is batting, for the scorecard
+func (g *Game) battingSide() string {
+	if m, ok := g.world.Mode.(headToHead); ok {
+		return m.Batting()
+	}
+	return g.profileManager.Name()
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+var gestureColor = color.RGBA{255, 255, 0, 160}
+
+// startBowlingMode has the player bowl and a computer batsman bat, if the mode is bowling
+func (g *Game) startBowlingMode(pb *sim.PlayerBowler) {
+	g.playerBowler = pb
+	g.botBatsman = sim.NewBotBatsman(sim.BowlingBotSkill)
+}
+
+// bowlingInput reads the player's bowling action off their input, if they are bowling, and
+// has the computer batsman bat in their place. Otherwise the player's input bats.
+func (g *Game) bowlingInput(input sim.BatInput) sim.BatInput {
+	if g.playerBowler == nil {
+		return input
+	}
+
+	g.playerBowler.Gesture(input, g.world.Width, g.world.Height)
+	return g.botBatsman.Input(g.world)
+}
+
+// drawBowlingGesture shows the drag the player is making, and whether a ball is ready to go
+func (g *Game) drawBowlingGesture(screen *ebiten.Image) {
+	pb := g.playerBowler
+	if pb == nil {
+		return
+	}
+
+	if pb.Dragging {
+		vector.StrokeLine(screen, float32(pb.Start.X), float32(pb.Start.Y), float32(pb.Cursor.X), float32(pb.Cursor.Y), 3, gestureColor, true)
+	}
+	if pb.Pending != nil {
+		var (
+			readyX float64 = g.cfg.GetWindowWidth() - 250
+			readyY float64 = 30
+		)
+		g.drawText(screen, "Ball ready", readyX, readyY, 1, 1, gestureColor)
+	}
+}
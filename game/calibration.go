@@ -0,0 +1,134 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	calibrationScript = "calibration" // The built-in delivery script bowled to measure the player
+
+	// A player who hits this many of the calibration balls, no further out on average than
+	// the frames given, is recommended the difficulty
+	calibrationHardHits     = 8
+	calibrationHardFrames   = 2.0
+	calibrationNormalHits   = 5
+	calibrationNormalFrames = 5.0
+)
+
+// Calibration is what the calibration balls showed of the player's timing
+type Calibration struct {
+	Balls      int     `json:"balls"`       // Calibration balls bowled
+	Hits       int     `json:"hits"`        // Of those, the ones the player hit
+	MeanFrames float64 `json:"mean_frames"` // How far out the hits were timed on average, early or late
+	Difficulty string  `json:"difficulty"`  // The difficulty recommended
+}
+
+// calibrator is implemented by modes that can open with the calibration balls, as their
+// innings has room for them
+type calibrator interface {
+	Calibrates() bool
+}
+
+// calibration bowls the calibration balls in place of the player's usual bowler, who takes
+// over once the game restarts
+type calibration struct {
+	bowler sim.Bowler
+}
+
+// calibrationDue is true for a game in a mode with room for the calibration balls, with
+// nothing else in charge of the deliveries, that is the player's first or one they asked to
+// be calibrated on. A replay is played back as it was saved, and an online match as the
+// server plays it, without them.
+func calibrationDue(g *Game) bool {
+	if c, ok := g.mode.(calibrator); !ok || !c.Calibrates() {
+		return false
+	}
+	if g.practiceScript != nil || g.scenario != nil || g.replayPlayback != nil || g.online != nil {
+		return false
+	}
+	if g.cfg.GetChallenges() || g.cfg.GetMutators() || g.world.Kid || len(g.cfg.GetChallengeCode()) > 0 {
+		return false
+	}
+	return g.cfg.GetCalibrate() || g.profileManager.Calibration() == nil
+}
+
+// startCalibration bowls the calibration balls before the player's usual bowler, if due
+func (g *Game) startCalibration() {
+	if !calibrationDue(g) {
+		return
+	}
+
+	script, err := deliveries.Load(calibrationScript)
+	if err != nil {
+		g.logger.Warn("could not load calibration balls", "error", err)
+		return
+	}
+
+	g.calibration = &calibration{bowler: g.world.Bowler}
+	g.practiceScript = script
+	g.world.Bowler = sim.NewScriptedBowler(script)
+	g.world.PrepareNextDelivery()
+	g.world.Announce(fmt.Sprintf("CALIBRATION: %d BALLS TO MEASURE YOUR TIMING", len(script.Deliveries)))
+	g.logger.Info("calibrating", "balls", len(script.Deliveries))
+}
+
+// finishCalibration recommends a difficulty from the calibration balls and keeps it with
+// the profile
+func (g *Game) finishCalibration() {
+	if g.calibration == nil {
+		return
+	}
+
+	result := calibrate(g.world.Innings.Events(), len(g.practiceScript.Deliveries))
+	if err := g.profileManager.SetCalibration(result); err != nil {
+		g.logger.Warn("could not save calibration", "error", err)
+	}
+	g.userMessage = fmt.Sprintf("CALIBRATED! TRY %s", strings.ToUpper(result.Difficulty))
+	g.logger.Info("calibrated", "hits", result.Hits, "balls", result.Balls, "mean_frames", result.MeanFrames, "difficulty", result.Difficulty)
+}
+
+// endCalibration hands the bowling back to the player's usual bowler
+func (g *Game) endCalibration() {
+	if g.calibration == nil {
+		return
+	}
+
+	g.world.Bowler = g.calibration.bowler
+	g.practiceScript = nil
+	g.calibration = nil
+}
+
+// calibrate measures the player's timing over the calibration balls. Balls the innings
+// ended before count as misses.
+func calibrate(events []stats.BallEvent, balls int) Calibration {
+	result := Calibration{Balls: balls}
+
+	var frames int
+	for _, event := range events {
+		if event.Outcome != stats.OutcomeHit {
+			continue
+		}
+		result.Hits++
+		frames += int(math.Abs(float64(event.TimingFrames)))
+	}
+	if result.Hits > 0 {
+		result.MeanFrames = float64(frames) / float64(result.Hits)
+	}
+
+	switch {
+	case result.Hits >= calibrationHardHits && result.MeanFrames <= calibrationHardFrames:
+		result.Difficulty = "hard"
+	case result.Hits >= calibrationNormalHits && result.MeanFrames <= calibrationNormalFrames:
+		result.Difficulty = "normal"
+	default:
+		result.Difficulty = "easy"
+	}
+
+	return result
+}
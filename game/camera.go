@@ -0,0 +1,106 @@
+package game
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	cameraFollowFactor = 0.15 // How quickly the camera catches up with the ball, per tick
+	boundaryInset      = 10   // How far inside the edge of the field the rope is drawn
+)
+
+var boundaryColor = color.RGBA{255, 255, 255, 120}
+
+// camera is the window's view onto a field that may be bigger than it. After a hit it
+// follows the ball towards the boundary, and it snaps back to the batsman once the ball
+// is dead.
+type camera struct {
+	rest     geometry.Rect // The view with the batsman in it
+	field    geometry.Rect
+	position geometry.Vector // Top left of the view, in field coordinates
+	canvas   *ebiten.Image   // The whole field, drawn before the view is cut from it
+}
+
+func newCamera(rest, field geometry.Rect) *camera {
+	c := &camera{
+		rest:     rest,
+		field:    field,
+		position: geometry.Vector{X: rest.X, Y: rest.Y},
+	}
+
+	if c.scrolls() {
+		c.canvas = ebiten.NewImageWithOptions(image.Rect(int(field.X), int(field.Y), int(field.MaxX()), int(field.MaxY())), nil)
+	}
+
+	return c
+}
+
+// scrolls is true if the field is too big to see all at once
+func (c *camera) scrolls() bool {
+	return c.field.Width > c.rest.Width || c.field.Height > c.rest.Height
+}
+
+// update moves the camera towards the ball most recently hit. It goes back to rest once no
+// hit ball is in play, or as soon as the next delivery is on its way.
+func (c *camera) update(w *sim.World) {
+	var followed *sim.Ball
+	incoming := false
+	for _, b := range w.Balls {
+		switch {
+		case !b.Active:
+		case !b.IsHit:
+			incoming = true
+		case followed == nil || b.Number > followed.Number:
+			followed = b
+		}
+	}
+
+	if followed == nil || incoming {
+		c.position = geometry.Vector{X: c.rest.X, Y: c.rest.Y}
+		return
+	}
+
+	center, _ := followed.CenterAndRadius()
+	target := geometry.Vector{
+		X: clampValue(center.X-c.rest.Width/2, c.field.X, c.field.MaxX()-c.rest.Width),
+		Y: clampValue(center.Y-c.rest.Height/2, c.field.Y, c.field.MaxY()-c.rest.Height),
+	}
+	c.position = c.position.Add(target.Subtract(c.position).Scale(cameraFollowFactor))
+}
+
+// toScreen converts a point on the field to where it is in the window
+func (c *camera) toScreen(p geometry.Vector) geometry.Vector {
+	return p.Subtract(c.position)
+}
+
+// draw shows the camera's view of the world in the window
+func (c *camera) draw(screen *ebiten.Image, w *sim.World, cosmetics looks, withBalls bool) {
+	if !c.scrolls() {
+		drawWorld(screen, w, cosmetics, withBalls)
+		return
+	}
+
+	c.canvas.Clear()
+	drawWorld(c.canvas, w, cosmetics, withBalls)
+
+	x, y := int(c.position.X), int(c.position.Y)
+	view := image.Rect(x, y, x+int(c.rest.Width), y+int(c.rest.Height))
+	screen.DrawImage(c.canvas.SubImage(view).(*ebiten.Image), &ebiten.DrawImageOptions{Filter: assets.Filter()})
+}
+
+// drawBoundary marks the edge of the field with a rope, if it is bigger than the view
+func drawBoundary(screen *ebiten.Image, w *sim.World) {
+	if w.Field == w.View() {
+		return
+	}
+
+	vector.StrokeRect(screen, float32(w.Field.X+boundaryInset), float32(w.Field.Y+boundaryInset),
+		float32(w.Field.Width-2*boundaryInset), float32(w.Field.Height-2*boundaryInset), 3, boundaryColor, true)
+}
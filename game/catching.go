@@ -0,0 +1,152 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/sound"
+)
+
+const (
+	catchingBalls        = 6                         // Skied in the catching practice
+	catchingBonusRuns    = 2                         // For each catch taken
+	catchingLaunchTicks  = 3 * ebiten.DefaultTPS / 2 // Between skied balls
+	catchingGravity      = 0.25                      // Stronger than in play, so the balls come down in a second or two
+	catchingMinSpeedX    = 4.0                       // Pixels a tick the skied balls travel out into the field
+	catchingMaxSpeedX    = 9.0
+	catchingMinClimb     = 9.0 // Pixels a tick the skied balls go up at first
+	catchingMaxClimb     = 12.0
+	catchingFielderSpeed = 12.0 // Most the fielder runs in a tick, so the mouse can't teleport them
+	catchingResultTicks  = ebiten.DefaultTPS
+)
+
+// catchingPractice is the fielding game between the innings of a versus match. The player
+// about to bat runs a fielder along the ground with the mouse, catching skied balls, and
+// each catch adds to the runs they start their innings with.
+type catchingPractice struct {
+	player      string
+	fielder     geometry.Vector
+	balls       map[*sim.Ball]struct{}
+	launched    int
+	untilLaunch int
+	catches     int
+	message     string // How the last ball went
+	doneTicks   int    // Ticks left showing the result, once every ball is down
+}
+
+// startCatching sends the next batsman out to field for the catching practice
+func (g *Game) startCatching(player string) {
+	g.catching = &catchingPractice{
+		player:      player,
+		fielder:     geometry.Vector{X: g.world.Width / 2, Y: g.world.GroundLevel()},
+		balls:       make(map[*sim.Ball]struct{}),
+		untilLaunch: catchingLaunchTicks,
+	}
+	g.userMessage = ""
+	g.state = GameStateCatching
+}
+
+func (g *Game) updateCatching() {
+	cp := g.catching
+	if g.keyJustPressed(ebiten.KeyEnter) {
+		g.finishCatching()
+		return
+	}
+
+	if cp.doneTicks > 0 {
+		cp.doneTicks--
+		if cp.doneTicks == 0 {
+			g.finishCatching()
+		}
+		return
+	}
+
+	cursorX, _ := ebiten.CursorPosition()
+	step := clampValue(float64(cursorX)-cp.fielder.X, -catchingFielderSpeed, catchingFielderSpeed)
+	cp.fielder.X = clampValue(cp.fielder.X+step, 0, g.world.Width)
+
+	if cp.launched < catchingBalls {
+		cp.untilLaunch--
+		if cp.untilLaunch <= 0 {
+			cp.balls[g.skyBall()] = struct{}{}
+			cp.launched++
+			cp.untilLaunch = catchingLaunchTicks
+		}
+	}
+
+	for b := range cp.balls {
+		b.Update(g.world.View(), 0)
+		center, _ := b.CenterAndRadius()
+		switch {
+		case b.Velocity.Y > 0 && center.Subtract(cp.fielder).Magnitude() <= sim.CatchRadius:
+			cp.catches++
+			cp.message = "Caught!"
+			delete(cp.balls, b)
+			g.sound.Play(sound.ClipHit)
+		case !b.Active || center.Y > g.world.GroundLevel()+sim.CatchRadius:
+			cp.message = "Dropped!"
+			delete(cp.balls, b)
+		}
+	}
+
+	if cp.launched == catchingBalls && len(cp.balls) == 0 {
+		cp.doneTicks = catchingResultTicks
+	}
+}
+
+// skyBall sends a ball high into the air off the bat, out into the field
+func (g *Game) skyBall() *sim.Ball {
+	b := sim.NewBall(g.world.Width, g.world.Height, deliveries.Delivery{}, catchingGravity)
+	b.Position = g.world.Bat.Position
+	b.Velocity = geometry.Vector{
+		X: catchingMinSpeedX + (catchingMaxSpeedX-catchingMinSpeedX)*g.world.RNG.Extras.Float64(),
+		Y: -(catchingMinClimb + (catchingMaxClimb-catchingMinClimb)*g.world.RNG.Extras.Float64()),
+	}
+	b.IsHit = true
+	return b
+}
+
+// finishCatching starts the second innings with the runs the catches earned
+func (g *Game) finishCatching() {
+	bonus := g.catching.catches * catchingBonusRuns
+	g.world.Score += bonus
+	if c, ok := g.world.Mode.(chaser); ok {
+		g.world.Announce(fmt.Sprintf("%s NEEDS %d, STARTING ON %d", strings.ToUpper(g.catching.player), c.Target(), bonus))
+	}
+	g.logger.Info("catching practice over", "player", g.catching.player, "catches", g.catching.catches, "bonus", bonus)
+	g.catching = nil
+	g.state = GameStatePlaying
+}
+
+func (g *Game) drawCatching(screen *ebiten.Image) {
+	const (
+		titleX      float64 = 20
+		titleY      float64 = 30
+		linesX      float64 = 20
+		linesY      float64 = 90
+		lineSpacing float64 = 30
+	)
+
+	cp := g.catching
+	g.drawField(screen, false)
+	for b := range cp.balls {
+		drawBall(screen, b)
+	}
+	vector.StrokeCircle(screen, float32(cp.fielder.X), float32(cp.fielder.Y), sim.CatchRadius, 2, color.White, true)
+
+	g.drawText(screen, "CATCHING PRACTICE: "+cp.player, titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+	lines := []string{
+		"Move the mouse to run under the skied balls. Enter skips.",
+		fmt.Sprintf("Catches: %d of %d, +%d runs each", cp.catches, catchingBalls, catchingBonusRuns),
+		cp.message,
+	}
+	for i, line := range lines {
+		g.drawText(screen, line, linesX, linesY+float64(i)*lineSpacing, 1, 1, color.White)
+	}
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/commentary"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/sound"
+)
+
+const gameEndMessageChampions = "CHAMPIONS!"
+
+// winner is implemented by modes the batsman can win, such as by completing a chase
+type winner interface {
+	Won(state sim.MatchState) bool
+}
+
+// celebration is the victory scene: fireworks and a replay of the winning shot over the
+// scorecard
+type celebration struct {
+	summary   string
+	fireworks *fireworks // nil when the player has asked for reduced motion
+	highlight *highlight
+}
+
+// startCelebration celebrates if the match that just ended was won
+func (g *Game) startCelebration() {
+	g.celebration = nil
+
+	state := g.world.MatchState()
+	w, ok := g.world.Mode.(winner)
+	if !ok || !w.Won(state) {
+		return
+	}
+
+	if g.seasonChampion() {
+		g.userMessage = gameEndMessageChampions
+	}
+
+	g.celebration = &celebration{
+		summary:   g.winSummary(state),
+		highlight: newHighlight(g.highlights, g.world),
+	}
+	if !g.cfg.GetReducedMotion() {
+		g.celebration.fireworks = newFireworks(g.cfg.GetWindowWidth(), g.cfg.GetWindowHeight())
+		g.celebration.fireworks.colors = g.cosmetics.celebration
+	}
+	g.sound.Play(sound.ClipVictory)
+	g.say(commentary.EventVictory)
+	g.logger.Info("celebrating win", "summary", g.celebration.summary)
+}
+
+// winSummary describes the margin of a successful chase, the way scorecards do
+func (g *Game) winSummary(state sim.MatchState) string {
+	rules := g.world.Mode.Rules()
+	summary := "Won"
+	if rules.Wickets > 0 {
+		summary += fmt.Sprintf(" by %d wickets", rules.Wickets-state.Wickets)
+	}
+	if g.world.MaxBalls > 0 {
+		summary += fmt.Sprintf(" with %d balls to spare", g.world.MaxBalls-state.BallsBowled)
+	}
+	return summary
+}
+
+func (g *Game) updateCelebration() {
+	if g.celebration == nil {
+		return
+	}
+	if g.celebration.fireworks != nil {
+		g.celebration.fireworks.update()
+	}
+	g.celebration.highlight.update()
+}
+
+// drawCelebration replays the winning shot in place of the field, with fireworks over it
+func (g *Game) drawCelebration(screen *ebiten.Image) {
+	c := g.celebration
+	c.highlight.draw(screen, g.world.Stumps)
+	if c.fireworks != nil {
+		c.fireworks.draw(screen)
+	}
+
+	var (
+		replayX  float64 = g.cfg.GetWindowWidth()/2 - 100
+		replayY  float64 = 30
+		summaryX float64 = g.cfg.GetWindowWidth()/2 - 100
+		summaryY float64 = 60
+	)
+	if !c.highlight.done() {
+		g.drawText(screen, "REPLAY", replayX, replayY, 1, 1, color.RGBA{255, 255, 0, 255})
+	}
+	g.drawText(screen, c.summary, summaryX, summaryY, 1, 1, color.White)
+}
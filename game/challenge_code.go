@@ -0,0 +1,266 @@
+package game
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	// challengeCodeVersion is bumped whenever what a code holds changes, such as the order of
+	// allMutators, so that old codes are turned away rather than read wrongly
+	challengeCodeVersion = 1
+	challengeCodeBytes   = 7 // Version, mutators, overs and a four byte seed
+	maxCodeEntry         = 64
+)
+
+// seeded is implemented by modes a code can set up, as their matches follow from the seed
+// and the settings the code carries. The others draw on more, such as a season's fixtures
+// or a second player.
+type seeded interface {
+	FollowsSeed() bool
+}
+
+// codeMode is true if a code can set up the mode
+func codeMode(mode sim.Mode) bool {
+	s, ok := mode.(seeded)
+	return ok && s.FollowsSeed()
+}
+
+// checkMode refuses a code for a mode that codes can't set up
+func (c challengeCode) checkMode(cfg *config.Config) error {
+	mode, err := sim.NewMode(c.mode, cfg)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errNotChallengeCode, err)
+	}
+	if !codeMode(mode) {
+		return fmt.Errorf("%w: no challenges in mode %q", errNotChallengeCode, c.mode)
+	}
+	return nil
+}
+
+var challengeCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+var errNotChallengeCode = errors.New("not a challenge code")
+
+// challengeCode is what it takes to play another player's match ball for ball: the mode,
+// difficulty, overs and mutators, and the seed their deliveries came from. It is written as
+// mode/difficulty/payload, such as overs/normal/AEAAKLCYVF4A, and can end a longer link.
+type challengeCode struct {
+	mode       string
+	difficulty string
+	overs      int
+	mutators   sim.Mutators
+	seed       uint32
+}
+
+func (c challengeCode) String() string {
+	var mask byte
+	for i, entry := range sim.AllMutators {
+		if c.mutators[entry.Mutator] {
+			mask |= 1 << i
+		}
+	}
+
+	payload := make([]byte, 0, challengeCodeBytes)
+	payload = append(payload, challengeCodeVersion, mask, byte(c.overs))
+	payload = binary.BigEndian.AppendUint32(payload, c.seed)
+
+	return c.mode + "/" + c.difficulty + "/" + challengeCodeEncoding.EncodeToString(payload)
+}
+
+// parseChallengeCode reads a code as it was shared, ignoring any link it ends
+func parseChallengeCode(text string) (challengeCode, error) {
+	parts := strings.Split(strings.Trim(strings.TrimSpace(text), "/"), "/")
+	if len(parts) < 3 {
+		return challengeCode{}, fmt.Errorf("%w: %q", errNotChallengeCode, text)
+	}
+	parts = parts[len(parts)-3:]
+
+	code := challengeCode{
+		mode:       strings.ToLower(parts[0]),
+		difficulty: strings.ToLower(parts[1]),
+		mutators:   make(sim.Mutators),
+	}
+	if !slices.Contains(sim.ModeNames(), code.mode) {
+		return challengeCode{}, fmt.Errorf("%w: unknown mode %q", errNotChallengeCode, code.mode)
+	}
+	if !slices.Contains(difficulty.Names(), code.difficulty) {
+		return challengeCode{}, fmt.Errorf("%w: unknown difficulty %q", errNotChallengeCode, code.difficulty)
+	}
+
+	payload, err := challengeCodeEncoding.DecodeString(strings.ToUpper(parts[2]))
+	if err != nil || len(payload) != challengeCodeBytes {
+		return challengeCode{}, fmt.Errorf("%w: %q", errNotChallengeCode, text)
+	}
+	if payload[0] != challengeCodeVersion {
+		return challengeCode{}, fmt.Errorf("%w: made by a different version of the game", errNotChallengeCode)
+	}
+
+	mask := payload[1]
+	for i, entry := range sim.AllMutators {
+		if mask&(1<<i) != 0 {
+			code.mutators[entry.Mutator] = true
+			mask &^= 1 << i
+		}
+	}
+	if mask != 0 {
+		return challengeCode{}, fmt.Errorf("%w: unknown mutators", errNotChallengeCode)
+	}
+	code.overs = int(payload[2])
+	code.seed = binary.BigEndian.Uint32(payload[3:])
+
+	return code, nil
+}
+
+// challengeCode is the code for the match just played, if another player could play it
+// again from one: a built-in difficulty and the random bowler in a mode that follows from
+// the seed, with nothing scripted
+func (g *Game) challengeCode() (challengeCode, bool) {
+	if g.practiceScript != nil || g.scenario != nil || g.superOver != nil || g.world.Kid || !g.world.Rules.Standard() {
+		return challengeCode{}, false
+	}
+	if bowling := g.cfg.GetBowling(); bowling != "" && bowling != sim.BowlingRandom {
+		return challengeCode{}, false
+	}
+
+	code := challengeCode{
+		mode:       g.mode.Name(),
+		difficulty: strings.ToLower(g.world.Preset.Name),
+		overs:      g.mode.Rules().Overs,
+		mutators:   g.world.Mutators,
+		seed:       g.world.RNG.Seed,
+	}
+	if !codeMode(g.mode) || !slices.Contains(difficulty.Names(), code.difficulty) || code.overs > math.MaxUint8 {
+		return challengeCode{}, false
+	}
+
+	return code, true
+}
+
+// useChallengeCode sets the config up for the match in the config's challenge code, if it
+// has one, before anything is made from it
+func useChallengeCode(cfg *config.Config) (*challengeCode, error) {
+	text := cfg.GetChallengeCode()
+	if len(text) == 0 {
+		return nil, nil
+	}
+
+	code, err := parseChallengeCode(text)
+	if err == nil {
+		err = code.checkMode(cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The code picks everything the menus would
+	cfg.SetScenario("")
+	cfg.SetChallenges(false)
+	cfg.SetMutators(false)
+	cfg.SetMode(code.mode)
+	cfg.SetDifficulty(code.difficulty)
+	cfg.SetBowling(sim.BowlingRandom)
+	if code.overs > 0 {
+		cfg.SetOvers(code.overs)
+	}
+
+	return &code, nil
+}
+
+// toggleCodeEntry opens the box for typing a friend's challenge code from the pause screen,
+// and closes it again
+func (g *Game) toggleCodeEntry() {
+	switch {
+	case g.state == GameStatePaused && g.keyJustPressed(ebiten.KeyC):
+		g.codeEntry = ""
+		g.codeEntryMessage = ""
+		g.state = GameStateCodeEntry
+	case g.state == GameStateCodeEntry && g.keyJustPressed(ebiten.KeyEscape):
+		g.state = GameStatePaused
+	}
+}
+
+func (g *Game) updateCodeEntry() {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r > ' ' && r <= '~' && len(g.codeEntry) < maxCodeEntry {
+			g.codeEntry += string(r)
+		}
+	}
+	if g.keyJustPressed(ebiten.KeyBackspace) && len(g.codeEntry) > 0 {
+		g.codeEntry = g.codeEntry[:len(g.codeEntry)-1]
+	}
+	if !g.keyJustPressed(ebiten.KeyEnter) {
+		return
+	}
+
+	code, err := parseChallengeCode(g.codeEntry)
+	if err == nil {
+		err = code.checkMode(g.cfg)
+	}
+	if err != nil {
+		g.codeEntryMessage = "That isn't a challenge code"
+		g.logger.Info("could not read challenge code", "code", g.codeEntry, "error", err)
+		return
+	}
+	g.codeEntryMessage = g.playChallengeCode(code)
+}
+
+// playChallengeCode starts the match in the code from its first ball, and again on every
+// restart. A match in a different mode, or with different overs, needs the game started
+// with the code instead. It returns why the code couldn't be played, if it couldn't.
+func (g *Game) playChallengeCode(code challengeCode) string {
+	if g.world.Kid {
+		return "Turn kid mode off to play a challenge code"
+	}
+	if !g.world.Rules.Standard() {
+		return "Go back to the standard rules to play a challenge code"
+	}
+	current, ok := g.challengeCode()
+	if !ok || current.mode != code.mode || current.overs != code.overs {
+		return fmt.Sprintf("Start the game with -code %s to play this one", code)
+	}
+
+	if current.difficulty != code.difficulty {
+		g.cfg.SetDifficulty(code.difficulty)
+		preset, err := loadDifficulty(g.cfg, nil)
+		if err != nil {
+			g.logger.Warn("could not load challenge difficulty", "difficulty", code.difficulty, "error", err)
+			return "Could not load the difficulty in the code"
+		}
+		// Everything on the field shares the preset, so it is changed in place
+		*g.world.Preset = *preset
+		g.basePreset = *preset
+	}
+	g.world.SetMutators(code.mutators)
+	g.world.RNG.Fix(code.seed)
+	g.reset()
+	g.logger.Info("playing challenge code", "code", code.String())
+
+	return ""
+}
+
+func (g *Game) drawCodeEntry(screen *ebiten.Image) {
+	const (
+		titleX       float64 = 20
+		titleY       float64 = 30
+		instructionX float64 = 20
+		instructionY float64 = 70
+		entryY       float64 = 130
+		messageY     float64 = 180
+	)
+
+	g.drawText(screen, "PLAY A CHALLENGE CODE", titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+	g.drawText(screen, "Type a friend's code and press Enter, Esc to go back", instructionX, instructionY, 1, 1, color.White)
+	g.drawText(screen, g.codeEntry+"_", instructionX, entryY, 1, 1, color.White)
+	g.drawText(screen, g.codeEntryMessage, instructionX, messageY, 0.8, 0.8, color.RGBA{255, 150, 150, 255})
+}
@@ -0,0 +1,46 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+func TestChallengeCodeModes(t *testing.T) {
+	cfg, err := config.LoadFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		mode string
+		ok   bool
+	}{
+		{sim.ModeEndless, true},
+		{sim.ModeOvers, true},
+		{sim.ModeBlitz, true},
+		{sim.ModeChase, false},
+		{sim.ModeSeason, false},
+		{"versus", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			code := challengeCode{mode: tt.mode, difficulty: "normal", overs: 2, mutators: make(sim.Mutators), seed: 7}
+			parsed, err := parseChallengeCode("https://example.com/play/" + code.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.mode != tt.mode || parsed.seed != code.seed || parsed.overs != code.overs {
+				t.Errorf("read back %+v from %s", parsed, code)
+			}
+			if err := parsed.checkMode(cfg); (err == nil) != tt.ok {
+				t.Errorf("got %v, want a code: %v", err, tt.ok)
+			}
+		})
+	}
+
+	if _, err := parseChallengeCode("nosuchmode/normal/AEAAKLCYVF4A"); !errors.Is(err, errNotChallengeCode) {
+		t.Errorf("a code for an unknown mode: got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/scenario"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// challengeMenu is the screen for picking one of the built-in challenges, and keeps track
+// of the one being played
+type challengeMenu struct {
+	challenges []scenario.Challenge
+	scenarios  []*scenario.Scenario // Loaded for each challenge
+	bowler     sim.Bowler           // The usual bowler, for challenges without deliveries of their own
+	cursor     int
+	playing    int // Index of the challenge being played, -1 on the menu
+	stars      int // Earned on the challenge last played
+	best       bool
+}
+
+// startChallengeMenu shows the challenge menu, if the player asked for challenges
+func (g *Game) startChallengeMenu() bool {
+	if !g.cfg.GetChallenges() {
+		return false
+	}
+
+	if g.challengeMenu == nil {
+		challenges, err := scenario.Challenges()
+		if err != nil {
+			g.logger.Error("could not load challenges", "error", err)
+			return false
+		}
+		menu := &challengeMenu{challenges: challenges, bowler: g.world.Bowler}
+		for _, challenge := range challenges {
+			s, err := scenario.Load(challenge.Scenario)
+			if err != nil {
+				g.logger.Error("could not load challenge", "scenario", challenge.Scenario, "error", err)
+				return false
+			}
+			menu.scenarios = append(menu.scenarios, s)
+		}
+		g.challengeMenu = menu
+	}
+
+	g.challengeMenu.playing = -1
+	g.userMessage = ""
+	g.state = GameStateChallenges
+	return true
+}
+
+func (g *Game) updateChallengeMenu() {
+	menu := g.challengeMenu
+
+	switch {
+	case g.keyJustPressed(ebiten.KeyUp):
+		menu.cursor = (menu.cursor + len(menu.challenges) - 1) % len(menu.challenges)
+	case g.keyJustPressed(ebiten.KeyDown):
+		menu.cursor = (menu.cursor + 1) % len(menu.challenges)
+	case g.keyJustPressed(ebiten.KeyEnter):
+		if !scenario.Unlocked(menu.challenges, g.profileManager.ChallengeStars())[menu.cursor] {
+			g.userMessage = "Complete the challenges above to unlock this one"
+			return
+		}
+		g.playChallenge(menu.cursor)
+	}
+}
+
+// playChallenge starts a challenge's scenario in place of the mode
+func (g *Game) playChallenge(i int) {
+	menu := g.challengeMenu
+	s := menu.scenarios[i]
+
+	menu.playing = i
+	g.scenario = s
+	g.mode = sim.ScenarioMode{Scenario: s}
+	g.world.Bowler = menu.bowler
+	if s.Deliveries != nil {
+		g.world.Bowler = sim.NewScriptedBowler(s.Deliveries)
+	}
+	g.world.StartInnings(g.mode)
+	g.world.RelayPitch()
+	g.userMessage = ""
+	g.state = GameStatePlaying
+	g.logger.Info("challenge started", "scenario", menu.challenges[i].Scenario)
+}
+
+// recordChallenge keeps the stars earned on the challenge just played
+func (g *Game) recordChallenge() {
+	menu := g.challengeMenu
+	if menu == nil || menu.playing < 0 {
+		return
+	}
+
+	name := menu.challenges[menu.playing].Scenario
+	menu.stars = g.scenario.Stars(sim.ScenarioProgress(g.world.MatchState()))
+	best, err := g.profileManager.RecordChallenge(name, menu.stars)
+	if err != nil {
+		g.logger.Warn("could not save challenge stars", "error", err)
+	}
+	menu.best = best
+	g.logger.Info("challenge finished", "scenario", name, "stars", menu.stars, "best", best)
+}
+
+// updateChallengeReturn goes back to the challenge menu from the end of a challenge
+func (g *Game) updateChallengeReturn() {
+	if g.challengeMenu != nil && (g.keyJustPressed(ebiten.KeyC) || g.keyJustPressed(ebiten.KeyEscape)) {
+		g.startChallengeMenu()
+	}
+}
+
+// starsText shows stars earned out of those on offer, such as [**-]
+func starsText(earned, most int) string {
+	return "[" + strings.Repeat("*", earned) + strings.Repeat("-", max(most-earned, 0)) + "]"
+}
+
+func (g *Game) drawChallengeMenu(screen *ebiten.Image) {
+	const (
+		titleX       float64 = 20
+		titleY       float64 = 30
+		instructionX float64 = 20
+		instructionY float64 = 70
+		rowsX        float64 = 20
+		rowsY        float64 = 120
+		rowSpacing   float64 = 60
+	)
+
+	menu := g.challengeMenu
+	stars := g.profileManager.ChallengeStars()
+	unlocked := scenario.Unlocked(menu.challenges, stars)
+
+	g.drawText(screen, "CHALLENGES", titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+	g.drawText(screen, "Up/Down to move, Enter to play", instructionX, instructionY, 1, 1, color.White)
+
+	for i, s := range menu.scenarios {
+		cursor := "  "
+		if i == menu.cursor {
+			cursor = "> "
+		}
+
+		textColor := color.Color(color.White)
+		row := fmt.Sprintf("%s%s %s", cursor, starsText(stars[menu.challenges[i].Scenario], s.MaxStars()), s.Name)
+		description := "    " + s.Description
+		if !unlocked[i] {
+			textColor = color.RGBA{150, 150, 150, 255}
+			row = fmt.Sprintf("%s[locked] %s", cursor, s.Name)
+			if needed := menu.challenges[i].RequiresStars; needed > 0 {
+				description = fmt.Sprintf("    Needs the challenge above and %d stars", needed)
+			}
+		}
+		g.drawText(screen, row, rowsX, rowsY+float64(i)*rowSpacing, 1, 1, textColor)
+		g.drawText(screen, description, rowsX, rowsY+float64(i)*rowSpacing+25, 1, 1, textColor)
+	}
+
+	var (
+		userMessageX float64 = 20
+		userMessageY float64 = rowsY + float64(len(menu.scenarios))*rowSpacing + 20
+	)
+	g.drawText(screen, g.userMessage, userMessageX, userMessageY, 1, 1, color.RGBA{255, 50, 50, 255})
+}
+
+// drawChallengeResult shows the stars earned on the challenge just played
+func (g *Game) drawChallengeResult(screen *ebiten.Image) {
+	menu := g.challengeMenu
+	if menu == nil || menu.playing < 0 {
+		return
+	}
+
+	const (
+		resultX float64 = 20
+		resultY float64 = 30
+		spacing float64 = 30
+	)
+	result := "Stars: " + starsText(menu.stars, g.scenario.MaxStars())
+	if menu.best {
+		result += " New best!"
+	}
+	g.drawText(screen, result, resultX, resultY, 1, 1, color.RGBA{255, 255, 0, 255})
+	g.drawText(screen, "Press C for the challenge menu", resultX, resultY+spacing, 1, 1, color.White)
+}
@@ -0,0 +1,20 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawChaosBanner warns that a chaos over is coming, until its balls are bowled
+func (g *Game) drawChaosBanner(screen *ebiten.Image) {
+	if !g.world.ChaosDue {
+		return
+	}
+
+	var (
+		bannerX float64 = g.cfg.GetWindowWidth()/2 - 180
+		bannerY float64 = g.cfg.GetWindowHeight()/2 - 120
+	)
+	g.drawText(screen, "CHAOS OVER INCOMING", bannerX, bannerY, 2, 2, color.RGBA{255, 50, 50, 255})
+}
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/meghashyamc/cricket2d/commentary"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/sound"
+)
+
+// commentator speaks lines from a voice commentary pack as things happen on the field
+type commentator struct {
+	clips    map[commentary.Event][]sound.Clip
+	showText bool   // Whether spoken commentary also shows on screen
+	spoken   string // The announcement on screen when the commentator last spoke
+	logger   logger.Logger
+}
+
+// loadCommentary loads the configured voice commentary pack, if any. A pack that fails to
+// load leaves the game with text commentary only.
+func (g *Game) loadCommentary() {
+	dir := g.cfg.GetCommentaryPack()
+	if len(dir) == 0 {
+		return
+	}
+
+	pack, err := commentary.LoadDir(dir)
+	if err != nil {
+		g.logger.Warn("could not load commentary pack", "dir", dir, "error", err)
+		return
+	}
+
+	c := &commentator{
+		clips:    make(map[commentary.Event][]sound.Clip),
+		showText: g.cfg.GetCommentaryText(),
+		logger:   logger.New(),
+	}
+	for _, event := range commentary.Events() {
+		for i, data := range pack.Clips(event) {
+			clip := sound.Clip(fmt.Sprintf("commentary/%s/%d", event, i))
+			if err := g.sound.AddWAV(clip, sound.BusCommentary, data); err != nil {
+				c.logger.Warn("skipping commentary clip", "event", event, "error", err)
+				continue
+			}
+			c.clips[event] = append(c.clips[event], clip)
+		}
+	}
+
+	g.commentator = c
+	g.logger.Info("commentary pack loaded", "name", pack.Name)
+}
+
+// commentaryEvent is what the commentators talk about when something happens on the field
+func commentaryEvent(event sim.FieldEvent) (commentary.Event, bool) {
+	switch event {
+	case sim.EventHit:
+		return commentary.EventShot, true
+	case sim.EventLoftedHit:
+		return commentary.EventBigHit, true
+	case sim.EventLeft:
+		return commentary.EventLeave, true
+	case sim.EventWide:
+		return commentary.EventWide, true
+	case sim.EventAppeal:
+		return commentary.EventAppeal, true
+	case sim.EventGivenNotOut:
+		return commentary.EventNotOut, true
+	case sim.EventWicket, sim.EventGivenOut:
+		return commentary.EventWicket, true
+	default:
+		return "", false
+	}
+}
+
+// comment speaks a line about the most notable of the tick's field events
+func (g *Game) comment(events []sim.FieldEvent) {
+	if g.commentator == nil {
+		return
+	}
+
+	happened := make(map[commentary.Event]bool)
+	for _, event := range events {
+		if e, ok := commentaryEvent(event); ok {
+			happened[e] = true
+		}
+	}
+	for _, event := range commentary.Events() {
+		if happened[event] && g.say(event) {
+			return
+		}
+	}
+}
+
+// say plays one of the pack's clips for an event, reporting whether the pack had one
+func (g *Game) say(event commentary.Event) bool {
+	if g.commentator == nil {
+		return false
+	}
+
+	clips := g.commentator.clips[event]
+	if len(clips) == 0 {
+		return false
+	}
+	g.sound.Play(clips[rand.IntN(len(clips))])
+	g.commentator.spoken = g.world.Announcement
+	return true
+}
+
+// showAnnouncement is false for an announcement the commentators have already spoken, if
+// the player only wants to hear it
+func (g *Game) showAnnouncement() bool {
+	c := g.commentator
+	return c == nil || c.showText || c.spoken != g.world.Announcement
+}
@@ -0,0 +1,278 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	controlCommandBacklog = 64
+	controlReplyTimeout   = 2 * time.Second // How long a request waits for the game to get to it
+	maxHeldInputTicks     = 600             // Longest an injected input can be held, 10 seconds
+)
+
+// controlAPI serves a local HTTP API for bots, tests and trainers to watch and drive the
+// game. Requests are turned into commands run on the game's own goroutine, between ticks.
+type controlAPI struct {
+	server    *http.Server
+	commands  chan func(*Game)
+	held      sim.BatInput
+	heldTicks int
+	injected  []deliveries.Delivery // Deliveries to bowl before the bowler's own, oldest first
+	upcoming  bool                  // The delivery being run up to was injected
+}
+
+// StartControlAPI serves the control API at the given address, which has to be on this
+// machine. The endpoints are:
+//
+//	GET  /state       the game as a ControlState
+//	GET  /summary     the innings so far as a stats.Summary
+//	POST /input       a ControlInput to move the bat
+//	POST /delivery    a delivery, such as {"type": "yorker"}, bowled next
+//	POST /pause       pause the match
+//	POST /resume      resume the match
+//	POST /logs/flush  write out the debug log entries held in memory
+func (g *Game) StartControlAPI(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return fmt.Errorf("control API address %s is not on this machine", addr)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	api := &controlAPI{commands: make(chan func(*Game), controlCommandBacklog)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /state", api.handleState)
+	mux.HandleFunc("GET /summary", api.handleSummary)
+	mux.HandleFunc("POST /input", api.handleInput)
+	mux.HandleFunc("POST /delivery", api.handleDelivery)
+	mux.HandleFunc("POST /pause", api.handlePause)
+	mux.HandleFunc("POST /resume", api.handleResume)
+	mux.HandleFunc("POST /logs/flush", api.handleFlushLogs)
+	api.server = &http.Server{Handler: mux, ReadHeaderTimeout: controlReplyTimeout}
+
+	g.controlAPI = api
+	g.world.Bowler = &injectingBowler{Bowler: g.world.Bowler, api: api}
+	go func() {
+		if err := api.server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			g.logger.Error("control API stopped", "error", err)
+		}
+	}()
+
+	g.logger.Info("control API listening", "addr", listener.Addr().String())
+	return nil
+}
+
+// runControlCommands runs the commands that have come in since the last tick
+func (g *Game) runControlCommands() {
+	if g.controlAPI == nil {
+		return
+	}
+
+	for {
+		select {
+		case command := <-g.controlAPI.commands:
+			command(g)
+		default:
+			return
+		}
+	}
+}
+
+// controlInput is the input injected through the API, if it is holding the bat
+func (g *Game) controlInput() (sim.BatInput, bool) {
+	api := g.controlAPI
+	if api == nil || api.heldTicks == 0 {
+		return sim.BatInput{}, false
+	}
+
+	api.heldTicks--
+	return api.held, true
+}
+
+// run has the game run a command, and waits for it to be done
+func (api *controlAPI) run(ctx context.Context, command func(*Game)) error {
+	ctx, cancel := context.WithTimeout(ctx, controlReplyTimeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	select {
+	case api.commands <- func(g *Game) { command(g); close(done) }:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (api *controlAPI) handleState(w http.ResponseWriter, r *http.Request) {
+	var state sim.ControlState
+	if err := api.run(r.Context(), func(g *Game) { state = g.controlState() }); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+func (api *controlAPI) handleSummary(w http.ResponseWriter, r *http.Request) {
+	var summary stats.Summary
+	err := api.run(r.Context(), func(g *Game) {
+		summary = g.world.Innings.Summary(g.world.Rules.BallsPerOver)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
+
+func (api *controlAPI) handleInput(w http.ResponseWriter, r *http.Request) {
+	var input sim.ControlInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ticks := clampValue(input.Ticks, 1, maxHeldInputTicks)
+
+	err := api.run(r.Context(), func(*Game) {
+		api.held = sim.BatInput{Cursor: geometry.Vector{X: input.X, Y: input.Y}, Dragging: input.Drag, Blocking: input.Block}
+		api.heldTicks = ticks
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *controlAPI) handleDelivery(w http.ResponseWriter, r *http.Request) {
+	var delivery deliveries.Delivery
+	if err := json.NewDecoder(r.Body).Decode(&delivery); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	delivery, err := deliveries.Resolve(delivery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := api.run(r.Context(), func(g *Game) { g.injectDelivery(delivery) }); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *controlAPI) handlePause(w http.ResponseWriter, r *http.Request) {
+	api.changeState(w, r, GameStatePlaying, GameStatePaused)
+}
+
+func (api *controlAPI) handleResume(w http.ResponseWriter, r *http.Request) {
+	api.changeState(w, r, GameStatePaused, GameStatePlaying)
+}
+
+func (api *controlAPI) handleFlushLogs(w http.ResponseWriter, r *http.Request) {
+	logger.FlushRing()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// changeState moves the game between playing and paused, as the P key does
+func (api *controlAPI) changeState(w http.ResponseWriter, r *http.Request, from, to GameState) {
+	changed := false
+	err := api.run(r.Context(), func(g *Game) {
+		if g.state != from {
+			return
+		}
+		g.state = to
+		changed = true
+	})
+	switch {
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	case !changed:
+		http.Error(w, "the game is not in a state that can change that way", http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// injectDelivery has the delivery bowled next. If a run up has already started, the
+// delivery takes the place of the one about to be bowled.
+func (g *Game) injectDelivery(delivery deliveries.Delivery) {
+	w := g.world
+	if w.HasUpcoming && len(g.controlAPI.injected) == 0 {
+		delivery.Bowler = w.Upcoming.Bowler
+		w.Upcoming = delivery
+		g.controlAPI.upcoming = true
+		return
+	}
+	g.controlAPI.injected = append(g.controlAPI.injected, delivery)
+}
+
+func (g *Game) controlState() sim.ControlState {
+	state := g.world.ControlState()
+	state.Score = g.matchScore()
+	state.State = reportedState(g.state)
+
+	return state
+}
+
+// injectingBowler bowls deliveries injected through the control API ahead of its own
+type injectingBowler struct {
+	sim.Bowler
+	api *controlAPI
+}
+
+func (ib *injectingBowler) SetOverLength(balls int) {
+	if planner, ok := ib.Bowler.(sim.OverPlanner); ok {
+		planner.SetOverLength(balls)
+	}
+}
+
+// Release bowls an injected delivery as it was planned, and leaves any other to the bowler
+// it wraps, which may decide it on release
+func (ib *injectingBowler) Release(planned deliveries.Delivery) (deliveries.Delivery, bool) {
+	if rb, ok := ib.Bowler.(sim.ReleasingBowler); ok && !ib.api.upcoming {
+		return rb.Release(planned)
+	}
+	ib.api.upcoming = false
+	return planned, true
+}
+
+func (ib *injectingBowler) NextDelivery(history *stats.Innings) (deliveries.Delivery, bool) {
+	if len(ib.api.injected) == 0 {
+		ib.api.upcoming = false
+		return ib.Bowler.NextDelivery(history)
+	}
+
+	delivery := ib.api.injected[0]
+	ib.api.injected = ib.api.injected[1:]
+	ib.api.upcoming = true
+	return delivery, true
+}
@@ -0,0 +1,171 @@
+package game
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// newTestWorld sets up a headless field with the normal preset
+func newTestWorld(t *testing.T) *sim.World {
+	t.Helper()
+	logger.SetLevel(slog.LevelWarn)
+
+	preset, err := difficulty.Load(difficulty.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	area := sim.DragArea{Right: 400, Up: 200, Down: 100}
+	rng := sim.NewMatchRand()
+	return sim.NewWorld(1200, 800, preset, sim.NewRandomBowler(preset, rng.Deliveries), sim.EndlessMode{}, area, rng)
+}
+
+// bowlGesture drags the player's input from one point to another over the given ticks, and
+// lets go
+func bowlGesture(pb *sim.PlayerBowler, w *sim.World, from, to geometry.Vector, ticks int) {
+	pb.Gesture(sim.BatInput{Cursor: from}, w.Width, w.Height)
+	for i := range ticks {
+		step := to.Subtract(from).Scale(float64(i) / float64(ticks))
+		pb.Gesture(sim.BatInput{Cursor: from.Add(step), Dragging: true}, w.Width, w.Height)
+	}
+	pb.Gesture(sim.BatInput{Cursor: to}, w.Width, w.Height)
+}
+
+// controlRequest sends a request to one of the control API's handlers, running the game's
+// side of it as the game loop would between ticks
+func controlRequest(g *Game, handler http.HandlerFunc, body string) int {
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		handler(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			return rec.Code
+		case command := <-g.controlAPI.commands:
+			command(g)
+		}
+	}
+}
+
+func TestControlAPIRequests(t *testing.T) {
+	w := newTestWorld(t)
+	api := &controlAPI{commands: make(chan func(*Game), controlCommandBacklog)}
+	w.Bowler = &injectingBowler{Bowler: w.Bowler, api: api}
+	g := &Game{world: w, controlAPI: api, state: GameStatePlaying}
+
+	// An input is held for the ticks asked for
+	if code := controlRequest(g, api.handleInput, `{"x": 300, "y": 400, "drag": true, "ticks": 2}`); code != http.StatusNoContent {
+		t.Fatalf("input answered %d", code)
+	}
+	want := sim.BatInput{Cursor: geometry.Vector{X: 300, Y: 400}, Dragging: true}
+	for tick := range 2 {
+		if input, ok := g.controlInput(); !ok || input != want {
+			t.Errorf("tick %d: got %+v, %v, want %+v", tick, input, ok, want)
+		}
+	}
+	if _, ok := g.controlInput(); ok {
+		t.Error("input held for longer than asked")
+	}
+	if code := controlRequest(g, api.handleInput, `{"x": `); code != http.StatusBadRequest {
+		t.Errorf("a broken input answered %d", code)
+	}
+
+	// A delivery is checked before it is bowled next
+	if code := controlRequest(g, api.handleDelivery, `{"type": "googly"}`); code != http.StatusBadRequest {
+		t.Errorf("an unknown delivery answered %d", code)
+	}
+	w.HasUpcoming = false
+	if code := controlRequest(g, api.handleDelivery, `{"type": "bouncer"}`); code != http.StatusNoContent {
+		t.Fatalf("delivery answered %d", code)
+	}
+	bouncer, _ := deliveries.OfType("bouncer")
+	if next, _ := w.Bowler.NextDelivery(w.Innings); next != bouncer {
+		t.Errorf("bowled %+v, want %+v", next, bouncer)
+	}
+
+	// Pausing only works while playing, and resuming only while paused
+	for _, step := range []struct {
+		handler http.HandlerFunc
+		code    int
+		state   GameState
+	}{
+		{api.handlePause, http.StatusNoContent, GameStatePaused},
+		{api.handlePause, http.StatusConflict, GameStatePaused},
+		{api.handleResume, http.StatusNoContent, GameStatePlaying},
+		{api.handleResume, http.StatusConflict, GameStatePlaying},
+	} {
+		if code := controlRequest(g, step.handler, ""); code != step.code || g.state != step.state {
+			t.Errorf("answered %d in state %d, want %d in state %d", code, g.state, step.code, step.state)
+		}
+	}
+}
+
+// TestInjectDuringRunUp checks that a delivery injected once the run up has started takes
+// the place of the one about to be bowled, and keeps its bowler
+func TestInjectDuringRunUp(t *testing.T) {
+	w := newTestWorld(t)
+	api := &controlAPI{}
+	w.Bowler = &injectingBowler{Bowler: w.Bowler, api: api}
+	g := &Game{world: w, controlAPI: api}
+
+	w.HasUpcoming = true
+	w.Upcoming = deliveries.Delivery{Type: "full", Speed: 16, Bowler: "Anna"}
+	g.injectDelivery(deliveries.Delivery{Type: "yorker", Speed: 20})
+	if want := (deliveries.Delivery{Type: "yorker", Speed: 20, Bowler: "Anna"}); w.Upcoming != want {
+		t.Errorf("about to bowl %+v, want %+v", w.Upcoming, want)
+	}
+	if len(api.injected) != 0 {
+		t.Error("the injected delivery is bowled again after the run up")
+	}
+}
+
+func TestControlAPIOnlyLocal(t *testing.T) {
+	g := &Game{}
+	for _, addr := range []string{"0.0.0.0:7777", "192.168.1.2:7777", "example.com:7777", "7777"} {
+		if err := g.StartControlAPI(addr); err == nil {
+			t.Errorf("served the control API at %s", addr)
+		}
+	}
+}
+
+// TestReleaseThroughControlAPI checks that the player still decides deliveries with the
+// control API on, and that a delivery injected through it is bowled as it is
+func TestReleaseThroughControlAPI(t *testing.T) {
+	w := newTestWorld(t)
+	pb := sim.NewPlayerBowler(w.Preset)
+	api := &controlAPI{}
+	w.Bowler = &injectingBowler{Bowler: pb, api: api}
+
+	w.Upcoming, _ = w.Bowler.NextDelivery(w.Innings)
+	if w.Released() {
+		t.Fatal("released before the player bowled")
+	}
+	bowlGesture(pb, w, geometry.Vector{X: 1000, Y: 300}, geometry.Vector{X: 800, Y: 300}, 20)
+	if !w.Released() {
+		t.Fatal("not released once the player bowled")
+	}
+	if w.Upcoming.Speed == 0 {
+		t.Error("the player's delivery was not bowled")
+	}
+
+	injected := deliveries.Delivery{Type: "yorker", Speed: 9}
+	api.injected = append(api.injected, injected)
+	w.Upcoming, _ = w.Bowler.NextDelivery(w.Innings)
+	if !w.Released() {
+		t.Fatal("an injected delivery waited for the player")
+	}
+	if w.Upcoming != injected {
+		t.Errorf("bowled %+v, want %+v", w.Upcoming, injected)
+	}
+}
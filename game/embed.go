@@ -0,0 +1,131 @@
+package game
+
+import (
+	"errors"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/online"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+var errEmbedded = errors.New("an embedded game is run by the app it is embedded in")
+
+// Option changes how NewGame sets up the game, for programs that play it as part of their
+// own rather than run it on its own
+type Option func(*options)
+
+type options struct {
+	mode   string
+	seed   uint32
+	seeded bool
+	input  InputProvider
+	target *ebiten.Image
+	replay *replay.Replay
+	client *online.Client
+	match  *online.Match
+}
+
+// InputProvider moves the bat in place of the mouse, for an app with input of its own
+type InputProvider interface {
+	// BatInput is how the bat is played on this tick. Ticks is ignored, as it is asked for
+	// again on every tick.
+	BatInput() sim.ControlInput
+}
+
+// WithMode plays the given mode, such as ModeFeatured, whatever the config says
+func WithMode(name string) Option {
+	return func(o *options) {
+		o.mode = name
+	}
+}
+
+// WithSeed bowls the deliveries that follow from the given seed, for this match and every
+// restart, as a challenge code does
+func WithSeed(seed uint32) Option {
+	return func(o *options) {
+		o.seed = seed
+		o.seeded = true
+	}
+}
+
+// WithInputProvider moves the bat as the provider says instead of with the mouse
+func WithInputProvider(input InputProvider) Option {
+	return func(o *options) {
+		o.input = input
+	}
+}
+
+// WithRenderTarget embeds the game in another Ebiten app. The game is laid out at the size
+// of the target and draws every frame into it, for the app to draw wherever it likes. The
+// app calls the game's Update and Draw from its own, and the game leaves the window alone.
+func WithRenderTarget(target *ebiten.Image) Option {
+	return func(o *options) {
+		o.target = target
+	}
+}
+
+// WithReplay plays a saved replay back: its mode, difficulty and deliveries, with the bat
+// held as it was, by the rules it kept. Replays saved before the rules were kept play by
+// the config's, and play out the same only if those are the same too.
+func WithReplay(r *replay.Replay) Option {
+	return func(o *options) {
+		o.replay = r
+	}
+}
+
+// WithOnlineMatch plays a match started on a dedicated server. The game sets its field up
+// by the server's rules and sends the server how the bat is held on every tick, showing
+// the score the server has alongside its own.
+func WithOnlineMatch(client *online.Client, match online.Match) Option {
+	return func(o *options) {
+		o.client = client
+		o.match = &match
+	}
+}
+
+// header is the match the game has to play, from a replay or a server, nil if it is the
+// game's own
+func (o options) header() *replay.Header {
+	switch {
+	case o.replay != nil:
+		return &o.replay.Header
+	case o.match != nil:
+		return &o.match.Header
+	}
+	return nil
+}
+
+func (o options) onlineMatch() *onlineMatch {
+	if o.match == nil {
+		return nil
+	}
+	return newOnlineMatch(o.client, *o.match)
+}
+
+// onlineChat is the chat of the online match, as the given player, if its server has one
+func (o options) onlineChat(name string) *onlineChat {
+	if o.match == nil || !o.client.Has(online.FeatureChat) {
+		return nil
+	}
+	return newOnlineChat(o.client, name)
+}
+
+func (o options) replayPlayback() *replayPlayback {
+	if o.replay == nil {
+		return nil
+	}
+	return newReplayPlayback(o.replay)
+}
+
+// embedded is true if the game is played inside another app, which owns the window
+func (g *Game) embedded() bool {
+	return g.target != nil
+}
+
+// providedInput is the input from the app's input provider
+func (g *Game) providedInput() sim.BatInput {
+	input := g.input.BatInput()
+	return sim.BatInput{Cursor: geometry.Vector{X: input.X, Y: input.Y}, Dragging: input.Drag, Blocking: input.Block}
+}
@@ -0,0 +1,20 @@
+package game
+
+import (
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// featuredMutators are the mutators the week's rule set plays with, if the match is in the
+// featured mode
+func (g *Game) featuredMutators() (sim.Mutators, bool) {
+	featured, ok := g.mode.(sim.FeaturedMode)
+	if !ok {
+		return nil, false
+	}
+
+	m := make(sim.Mutators, len(featured.Set.Mutators))
+	for _, mutator := range featured.Set.Mutators {
+		m[mutator] = true
+	}
+	return m, true
+}
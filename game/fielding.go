@@ -0,0 +1,40 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+var (
+	fielderColor  = color.RGBA{255, 255, 255, 80}
+	fieldMapColor = color.RGBA{0, 60, 0, 220}
+)
+
+func drawFielders(screen *ebiten.Image, w *sim.World) {
+	for _, f := range w.Fielders {
+		vector.StrokeCircle(screen, float32(f.Position.X), float32(f.Position.Y), sim.CatchRadius, 2, fielderColor, true)
+	}
+}
+
+// drawFieldMap shows a small map of the field, with the batsman at the left edge
+func drawFieldMap(screen *ebiten.Image, w *sim.World, x, y, scale float64) {
+	if w.FieldMapTicks == 0 || len(w.Fielders) == 0 {
+		return
+	}
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(w.Width*scale), float32(w.Height*scale), fieldMapColor, false)
+	toMap := func(p geometry.Vector) (float32, float32) {
+		return float32(x + p.X*scale), float32(y + p.Y*scale)
+	}
+
+	batX, batY := toMap(w.Bat.Position)
+	vector.DrawFilledCircle(screen, batX, batY, 4, color.RGBA{255, 255, 0, 255}, true)
+	for _, f := range w.Fielders {
+		fielderX, fielderY := toMap(f.Position)
+		vector.DrawFilledCircle(screen, fielderX, fielderY, 4, color.White, true)
+	}
+}
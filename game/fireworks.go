@@ -0,0 +1,95 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"math/rand/v2"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/geometry"
+)
+
+const (
+	fireworkLaunchInterval = 20 // Ticks between rockets
+	fireworkSparks         = 40 // Sparks in each burst
+	fireworkSparkSpeed     = 4
+	fireworkSparkLife      = 60 // Ticks a spark lasts
+	fireworkGravity        = 0.05
+)
+
+var fireworkColors = []color.RGBA{
+	{255, 80, 80, 255},
+	{255, 220, 60, 255},
+	{80, 200, 255, 255},
+	{140, 255, 120, 255},
+	{255, 120, 255, 255},
+}
+
+// spark is a single particle from a firework burst
+type spark struct {
+	position geometry.Vector
+	velocity geometry.Vector
+	life     int
+	color    color.RGBA
+}
+
+// fireworks launches bursts of sparks across the top of the screen
+type fireworks struct {
+	width, height float64
+	sparks        []*spark
+	ticks         int
+	rockets       bool         // Launches bursts of its own, rather than only bursting where told to
+	colors        []color.RGBA // Each burst is one of these, or one of fireworkColors if empty
+}
+
+func newFireworks(width, height float64) *fireworks {
+	return &fireworks{width: width, height: height, rockets: true}
+}
+
+func (f *fireworks) update() {
+	if f.rockets && f.ticks%fireworkLaunchInterval == 0 {
+		f.burst(geometry.Vector{
+			X: f.width * (0.2 + 0.6*rand.Float64()),
+			Y: f.height * (0.1 + 0.3*rand.Float64()),
+		})
+	}
+	f.ticks++
+
+	alive := f.sparks[:0]
+	for _, s := range f.sparks {
+		s.velocity.Y += fireworkGravity
+		s.position = s.position.Add(s.velocity)
+		s.life--
+		if s.life > 0 {
+			alive = append(alive, s)
+		}
+	}
+	f.sparks = alive
+}
+
+func (f *fireworks) burst(at geometry.Vector) {
+	colors := f.colors
+	if len(colors) == 0 {
+		colors = fireworkColors
+	}
+	burstColor := colors[rand.IntN(len(colors))]
+	for i := range fireworkSparks {
+		angle := 2 * math.Pi * float64(i) / fireworkSparks
+		speed := fireworkSparkSpeed * (0.5 + 0.5*rand.Float64())
+		f.sparks = append(f.sparks, &spark{
+			position: at,
+			velocity: geometry.Vector{X: math.Cos(angle) * speed, Y: math.Sin(angle) * speed},
+			life:     fireworkSparkLife,
+			color:    burstColor,
+		})
+	}
+}
+
+func (f *fireworks) draw(screen *ebiten.Image) {
+	for _, s := range f.sparks {
+		sparkColor := s.color
+		sparkColor.A = uint8(255 * s.life / fireworkSparkLife)
+		vector.DrawFilledCircle(screen, float32(s.position.X), float32(s.position.Y), 2, sparkColor, true)
+	}
+}
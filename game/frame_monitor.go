@@ -0,0 +1,206 @@
+package game
+
+import (
+	"image/color"
+	"runtime/metrics"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// framePhase is a part of the frame that a stutter can be blamed on
+type framePhase int
+
+const (
+	phaseSpawn     framePhase = iota // Bowling new deliveries
+	phaseCollision                   // Moving the balls and checking what they hit
+	phaseDraw
+	phaseGC
+	phaseOther // Anything the monitor doesn't time, such as the OS holding up the game
+	framePhases
+)
+
+func (p framePhase) String() string {
+	switch p {
+	case phaseSpawn:
+		return "spawn"
+	case phaseCollision:
+		return "collision"
+	case phaseDraw:
+		return "draw"
+	case phaseGC:
+		return "gc"
+	default:
+		return "other"
+	}
+}
+
+const (
+	frameHistory     = 120  // Frames the graph shows
+	frameSmoothing   = 0.05 // Weight of each new frame in the running average
+	spikeFactor      = 2.5  // A frame taking this many times the average is a spike
+	minSpikeDuration = 25 * time.Millisecond
+	gcCyclesMetric   = "/gc/cycles/total:gc-cycles"
+
+	frameGraphMargin   = 20
+	frameGraphHeight   = 60
+	frameGraphBarWidth = 2
+	frameGraphPixelsMs = 2.0 // Height of a bar for every millisecond the frame took
+)
+
+var (
+	frameGraphColor = color.RGBA{80, 200, 120, 200}
+	frameSpikeColor = color.RGBA{255, 80, 80, 220}
+	frameGraphLine  = color.RGBA{255, 255, 255, 120} // At the time a frame should take
+)
+
+// frameMonitor watches how long frames take, to track down the hitching players report on
+// slow machines. It keeps a smoothed average, logs the phase a frame spent its time in
+// whenever one takes far longer than the average, and can graph recent frames.
+type frameMonitor struct {
+	last     time.Time
+	average  time.Duration
+	history  [frameHistory]time.Duration
+	next     int                        // Where the next frame goes in the history
+	phases   [framePhases]time.Duration // Time spent in each phase since the last tick
+	gcCycles uint64
+	gcSample []metrics.Sample
+	graph    bool
+	logger   logger.Logger
+}
+
+// startFrameMonitor watches frame times, if the player wants spikes logged or graphed
+func (g *Game) startFrameMonitor() {
+	graph := g.cfg.GetFrameGraph()
+	if !g.cfg.GetFrameMonitor() && !graph {
+		return
+	}
+
+	m := &frameMonitor{
+		gcSample: []metrics.Sample{{Name: gcCyclesMetric}},
+		graph:    graph,
+		logger:   logger.New(),
+	}
+	metrics.Read(m.gcSample)
+	if m.gcSample[0].Value.Kind() == metrics.KindUint64 {
+		m.gcCycles = m.gcSample[0].Value.Uint64()
+	}
+
+	g.frames = m
+	g.world.Timer = m
+	g.logger.Info("monitoring frame times", "graph", graph)
+}
+
+// TimePhase counts the time a phase of the field's tick took against the frame
+func (m *frameMonitor) TimePhase(phase sim.Phase, took time.Duration) {
+	switch phase {
+	case sim.PhaseSpawn:
+		m.phases[phaseSpawn] += took
+	case sim.PhaseCollision:
+		m.phases[phaseCollision] += took
+	}
+}
+
+func (m *frameMonitor) add(phase framePhase, started time.Time) {
+	if m == nil {
+		return
+	}
+	m.phases[phase] += time.Since(started)
+}
+
+// tick closes the last frame, at the start of an update, and looks for a spike in it. A
+// slowed game isn't watched, as its frames are meant to be long.
+func (m *frameMonitor) tick(throttled bool) {
+	if m == nil {
+		return
+	}
+
+	now := time.Now()
+	frame := now.Sub(m.last)
+	watching := !m.last.IsZero() && !throttled
+	m.last = now
+	if !watching {
+		m.phases = [framePhases]time.Duration{}
+		return
+	}
+
+	m.history[m.next] = frame
+	m.next = (m.next + 1) % frameHistory
+
+	gcRan := false
+	metrics.Read(m.gcSample)
+	if m.gcSample[0].Value.Kind() == metrics.KindUint64 {
+		cycles := m.gcSample[0].Value.Uint64()
+		gcRan = cycles > m.gcCycles
+		m.gcCycles = cycles
+	}
+
+	if m.average > 0 && frame >= minSpikeDuration && float64(frame) >= spikeFactor*float64(m.average) {
+		phase := m.blame(frame-m.average, gcRan)
+		m.logger.Warn("frame time spike",
+			"frame_ms", milliseconds(frame), "average_ms", milliseconds(m.average), "phase", phase.String(),
+			"spawn_ms", milliseconds(m.phases[phaseSpawn]), "collision_ms", milliseconds(m.phases[phaseCollision]),
+			"draw_ms", milliseconds(m.phases[phaseDraw]), "gc", gcRan)
+	}
+
+	if m.average == 0 {
+		m.average = frame
+	} else {
+		m.average += time.Duration(frameSmoothing * float64(frame-m.average))
+	}
+	m.phases = [framePhases]time.Duration{}
+}
+
+// blame picks the phase behind a frame that ran over the average by the given excess: the
+// timed phase that took longest, unless it accounts for less than half the excess, in which
+// case a garbage collection gets the blame if one ran
+func (m *frameMonitor) blame(excess time.Duration, gcRan bool) framePhase {
+	worst := phaseSpawn
+	for phase := phaseSpawn; phase <= phaseDraw; phase++ {
+		if m.phases[phase] > m.phases[worst] {
+			worst = phase
+		}
+	}
+
+	switch {
+	case m.phases[worst] >= excess/2:
+		return worst
+	case gcRan:
+		return phaseGC
+	default:
+		return phaseOther
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// drawFrameGraph shows recent frame times as bars in the bottom right corner, oldest on
+// the left, with spikes in red and a line at the time a frame should take
+func (g *Game) drawFrameGraph(screen *ebiten.Image) {
+	m := g.frames
+	if m == nil || !m.graph {
+		return
+	}
+
+	var (
+		left   = float32(g.cfg.GetWindowWidth()) - frameGraphMargin - frameHistory*frameGraphBarWidth
+		bottom = float32(g.cfg.GetWindowHeight()) - frameGraphMargin
+	)
+	for i := range frameHistory {
+		frame := m.history[(m.next+i)%frameHistory]
+		height := min(float32(milliseconds(frame)*frameGraphPixelsMs), frameGraphHeight)
+		barColor := frameGraphColor
+		if m.average > 0 && frame >= minSpikeDuration && float64(frame) >= spikeFactor*float64(m.average) {
+			barColor = frameSpikeColor
+		}
+		vector.DrawFilledRect(screen, left+float32(i*frameGraphBarWidth), bottom-height, frameGraphBarWidth, height, barColor, false)
+	}
+
+	target := float32(1000 / float64(ebiten.DefaultTPS) * frameGraphPixelsMs)
+	vector.StrokeLine(screen, left, bottom-target, left+frameHistory*frameGraphBarWidth, bottom-target, 1, frameGraphLine, false)
+}
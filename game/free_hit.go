@@ -0,0 +1,36 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const freeHitRingMargin = 4 // Gap between a free hit and the ring drawn around it
+
+var freeHitColor = color.RGBA{80, 255, 120, 255}
+
+// drawFreeHitRing marks a free hit on its way in, so that the batsman can see it coming
+func drawFreeHitRing(screen *ebiten.Image, b *sim.Ball) {
+	if !b.FreeHit || b.IsHit || !b.Active {
+		return
+	}
+
+	center, radius := b.CenterAndRadius()
+	vector.StrokeCircle(screen, float32(center.X), float32(center.Y), float32(radius+freeHitRingMargin), 2, freeHitColor, true)
+}
+
+// drawFreeHitBanner warns that a free hit is coming, until it is bowled
+func (g *Game) drawFreeHitBanner(screen *ebiten.Image) {
+	if !g.world.FreeHitDue {
+		return
+	}
+
+	var (
+		bannerX float64 = g.cfg.GetWindowWidth()/2 - 80
+		bannerY float64 = g.cfg.GetWindowHeight()/2 - 160
+	)
+	g.drawText(screen, "FREE HIT", bannerX, bannerY, 2, 2, freeHitColor)
+}
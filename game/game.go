@@ -3,17 +3,29 @@ package game
 import (
 	"fmt"
 	"image/color"
+	"math"
+	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
-	"github.com/meghashyamc/cricket2d/config"
-	"github.com/meghashyamc/cricket2d/logger"
-
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/leaderboard"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/rating"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/scenario"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/sound"
+	"github.com/meghashyamc/cricket2d/stats"
+	"github.com/meghashyamc/cricket2d/team"
+	"github.com/meghashyamc/cricket2d/version"
 )
 
 type GameState int
@@ -23,75 +35,419 @@ const (
 	GameStateGameOver
 	GameStateNameInput
 	GameStatePaused
+	GameStateTeamSelection
+	GameStatePreMatch
+	GameStateChallenges
+	GameStateMutators
+	GameStateCatching
+	GameStateHelp
+	GameStateManageData
+	GameStateCodeEntry
+	GameStateShop
+	GameStateLoadouts
+	GameStatePhoto
+	GameStateHouseRules
+	GameStateInningsBreak
 )
 
-const (
-	gameEndMessageHitWicket = "HIT WICKET!"
-	gameEndMessageBowled    = "BOWLED!"
-)
+// isPaused is true in the states where the match is on hold for a screen in front of it,
+// which is every state but playing and the match being over
+func isPaused(state GameState) bool {
+	switch state {
+	case GameStatePaused, GameStateTeamSelection, GameStatePreMatch, GameStateChallenges, GameStateMutators,
+		GameStateCatching, GameStateHelp, GameStateManageData, GameStateCodeEntry, GameStateShop,
+		GameStateLoadouts, GameStatePhoto, GameStateHouseRules, GameStateInningsBreak:
+		return true
+	}
+	return false
+}
+
+// reportedState is how the state is reported outside the game: playing, paused or game_over
+func reportedState(state GameState) string {
+	switch {
+	case isPaused(state):
+		return "paused"
+	case state == GameStateGameOver, state == GameStateNameInput:
+		return "game_over"
+	}
+	return "playing"
+}
 
 const (
-	gameInstructions = "Move mouse to swing. Drag to move. Press P to pause."
+	gameInstructions = "Move mouse to swing. Drag to move. Hold right click or space to block. R to replay the last ball, P to pause, H for help."
 )
 
+const gameEndMessageDrillDone = "DRILL COMPLETE!"
+
 const (
 	sleepTimeBeforeShowingHighScore = 1 * time.Second
 )
 
 type Game struct {
 	cfg              *config.Config
-	bat              *bat
-	balls            map[*ball]struct{}
-	stumps           *stumps
-	ballSpawnTimer   *time.Ticker
-	score            int
+	mode             sim.Mode
+	world            *sim.World
+	cosmetics        looks // The cosmetics the player has equipped
 	state            GameState
 	highScoreManager *HighScoreManager
+	profileManager   *ProfileManager
+	sound            *sound.Manager
+	commentator      *commentator           // Nil without a voice commentary pack
+	updates          <-chan version.Release // The background update check, nil once it is done
+	availableUpdate  *version.Release
+	hud              *hudLayer
+	labels           map[labelKey]*ebiten.Image // Text that doesn't change, laid out once
+	assetWatcher     *assetWatcher              // nil unless hot reloading the asset pack
+	needsAttention   bool                       // A match was paused in the background and the player hasn't come back
+	throttled        bool                       // Running slowly and silently while the match waits in the background
+	ticked           bool                       // There has been a tick since the last frame was drawn
+	frames           *frameMonitor              // nil unless frame times are being watched
+	hawkEyeView      *ebiten.Image              // Ball tracking is drawn in, made when it is first shown
+	postEffects      *postEffects               // nil unless effects are laid over each frame
+	windowPlacement  *config.WindowPlacement    // Where the window was last noted, nil until it has been
+	windowTicks      int
+	narrator         *narrator         // nil unless narration is turned on
+	overlay          *overlay          // nil unless a streaming overlay file is configured
+	chatBowler       *sim.ChatBowler   // nil unless chat plays the bowler
+	controlAPI       *controlAPI       // nil unless the control API has been started
+	metrics          *gameMetrics      // nil unless metrics are turned on
+	ballByBall       *stats.BallByBall // The match's innings so far, nil unless a log is kept
 	logger           logger.Logger
 	userMessage      string
 	nameInput        string
 	nameInputTimer   *time.Timer
+	practiceScript   *deliveries.Script // nil unless deliveries come from a practice script
+	calibration      *calibration       // nil unless the calibration balls are being bowled
+	assist           *sim.DynamicAssist // nil unless dynamic assist is turned on
+	basePreset       difficulty.Preset  // The configured preset, for when kid mode is turned off
+	hitFireworks     *fireworks         // Set off by every hit in kid mode, nil otherwise
+	scenario         *scenario.Scenario // nil unless a scenario is being played
+	challengeMenu    *challengeMenu     // nil unless the player is playing challenges
+	newAchievements  []string           // Titles of the achievements earned in the last innings
+	pickedMutators   sim.Mutators       // Turned on so far on the mutators screen
+	mutatorCursor    int
+	catching         *catchingPractice  // nil except between the innings of a versus match
+	help             []helpSection      // What the help screen shows, put together when it is opened
+	helpReturn       GameState          // The state to go back to when the help is closed
+	manageData       *manageData        // nil unless the manage data screen is open
+	leaderboard      *leaderboard.Queue // nil without an online leaderboard
+	resultCardPath   string             // Where the last match's result card was saved, empty if it wasn't
+	ratedMatch       *rating.Match      // How the last match moved the player's rating, nil if it wasn't rated
+	codeEntry        string             // Typed so far on the challenge code screen
+	codeEntryMessage string             // Why the challenge code typed couldn't be played
+	shopCursor       int                // Row picked in the shop
+	shopMessage      string             // What happened to the last thing bought or equipped in the shop
+	loadouts         *loadoutScreen     // nil unless the loadouts screen is open
+	photo            *photoMode         // nil unless a picture is being lined up in photo mode
+	screenshotDue    bool               // F12 has been pressed since the last frame was drawn
+	screenshotPath   string             // Where the last screenshot was saved, empty if it wasn't
+	deliveries       *deliveryRecorder  // The last two deliveries, for the instant replay
+	instantReplay    *instantReplay     // nil unless the last delivery is being replayed
+	houseRules       *houseRulesScreen  // nil unless the house rules screen is open
+	bestShots        *bestShotRecorder  // The best shot of the innings so far, for the innings break
+	inningsBreak     *inningsBreak      // nil unless the match has stopped for an innings break
+	pad              *padInput          // The gamepads, and what has been picked on screen with them
+	keyboard         *onScreenKeyboard  // nil until a name is first typed on screen
+	hitNumbers       *hitNumbers        // The runs of recent shots, floating off the bat
+	input            InputProvider      // nil unless an app moves the bat in place of the mouse
+	target           *ebiten.Image      // nil unless the game is embedded in an app, and draws into this
+	matchReplay      *replay.Replay     // The match's inputs so far, nil unless replays are kept
+	replayPlayback   *replayPlayback    // nil unless a saved replay is being played back
+	online           *onlineMatch       // nil unless the match is played against a dedicated server
+	chat             *onlineChat        // nil unless the server has a chat
+	playerBowler     *sim.PlayerBowler  // nil unless the player is bowling
+	botBatsman       *sim.BotBatsman    // Bats in place of the player, if not nil
+	teamSelection    *teamSelection     // nil unless the mode plays with a team
+	superOver        *sim.SuperOver     // nil unless a tied match is being settled
+	seasonMatch      *seasonMatch       // nil unless a season fixture is being played
+	versusMatch      *versusMatch       // nil unless two players are having a versus match
+	macro            *practiceMacro
+	camera           *camera
+	highlights       *highlightRecorder
+	celebration      *celebration // nil unless a win is being celebrated
+	night            *nightMatch  // nil unless the match is under floodlights
+	crash            error        // A panic while drawing, for the next Update to stop the game with
 }
 
-func NewGame(cfg *config.Config) (*Game, error) {
-	highScoreManager, err := NewHighScoreManager(cfg)
+func NewGame(cfg *config.Config, opts ...Option) (*Game, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	// The game changes its config as it goes, for the options, challenge codes and loadouts,
+	// and none of that is for the caller to see
+	cfg = cfg.Clone()
+	if h := o.header(); h != nil {
+		o.mode, o.seed, o.seeded = h.Mode, h.Seed, true
+		cfg.SetDifficulty(h.Difficulty)
+		if h.Rules != nil {
+			replayConfig(cfg, h.Rules)
+		}
+	}
+	if len(o.mode) > 0 {
+		cfg.SetMode(o.mode)
+	}
+	if o.target != nil {
+		bounds := o.target.Bounds()
+		cfg.SetWindowSize(float64(bounds.Dx()), float64(bounds.Dy()))
+	}
+
+	var (
+		mode         sim.Mode
+		gameScenario *scenario.Scenario
+		err          error
+	)
+	challenge, err := useChallengeCode(cfg)
 	if err != nil {
+		logger.New().Error("could not use challenge code", "code", cfg.GetChallengeCode(), "error", err)
 		return nil, err
 	}
+	if scenarioName := cfg.GetScenario(); len(scenarioName) > 0 {
+		gameScenario, err = scenario.Load(scenarioName)
+		if err != nil {
+			logger.New().Error("could not load scenario", "scenario", scenarioName, "error", err)
+			return nil, err
+		}
+		mode = sim.ScenarioMode{Scenario: gameScenario}
+	} else {
+		modeName := cfg.GetMode()
+		if len(modeName) == 0 {
+			modeName = sim.ModeEndless
+		}
+		mode, err = sim.NewMode(modeName, cfg)
+		if err != nil {
+			logger.New().Error("could not create game mode", "mode", modeName, "error", err)
+			return nil, err
+		}
+	}
+
+	highScoreManager, err := NewHighScoreManager(cfg, mode.Rules().HighScoreKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sprites have to be in place before anything on the field is made
+	if err := assets.SetScaling(assets.Filtering(cfg.GetFiltering()), cfg.GetIntegerScaling()); err != nil {
+		logger.New().Warn("could not set sprite scaling, using the default", "filtering", cfg.GetFiltering(), "error", err)
+	}
+	if err := assets.SetTheme(assets.Theme(cfg.GetTheme())); err != nil {
+		logger.New().Warn("could not set theme, using the default", "theme", cfg.GetTheme(), "error", err)
+	}
+	if assetsDir := cfg.GetAssetsDir(); len(assetsDir) > 0 {
+		assets.LoadPack(assetsDir)
+	}
+	sim.SetSizes(spriteSizes())
+
+	profileManager, err := NewProfileManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	preset, err := loadDifficulty(cfg, profileManager.Calibration())
+	if err != nil {
+		highScoreManager.logger.Error("could not load difficulty preset", "difficulty", cfg.GetDifficulty(), "error", err)
+		return nil, err
+	}
+
+	rng := sim.NewMatchRand()
+	if challenge != nil {
+		rng.Fix(challenge.seed)
+	}
+	if o.seeded {
+		rng.Fix(o.seed)
+	}
+	bowler, err := sim.NewBowler(cfg, preset, rng)
+	if err != nil {
+		highScoreManager.logger.Error("could not create bowler", "error", err)
+		return nil, err
+	}
+
+	// Viewers voting on deliveries need to see the vote, wherever the bowler ends up
+	chatBowler, _ := bowler.(*sim.ChatBowler)
+
+	var practiceScript *deliveries.Script
+	if scriptName := cfg.GetPracticeScript(); len(scriptName) > 0 {
+		practiceScript, err = deliveries.Load(scriptName)
+		if err != nil {
+			highScoreManager.logger.Error("could not load practice script", "script", scriptName, "error", err)
+			return nil, err
+		}
+		bowler = sim.NewScriptedBowler(practiceScript)
+	} else if gameScenario != nil && gameScenario.Deliveries != nil {
+		bowler = sim.NewScriptedBowler(gameScenario.Deliveries)
+	} else if supplier, ok := mode.(sim.BowlerSupplier); ok {
+		bowler = supplier.Bowler(preset)
+	} else {
+		bowler = sim.NewBowlingAttack(bowler, team.Attack(), preset, mode.Rules().Overs)
+	}
+
+	volumes := sound.Volumes{
+		SFX:        cfg.GetSFXVolume(),
+		Music:      cfg.GetMusicVolume(),
+		Commentary: cfg.GetCommentaryVolume(),
+	}
 
 	g := &Game{
-		cfg:              cfg,
-		bat:              newBat(),
-		balls:            make(map[*ball]struct{}),
-		stumps:           newStumps(float64(cfg.GetWindowHeight())),
-		ballSpawnTimer:   time.NewTicker(time.Duration(cfg.GetballSpawnTime()) * time.Second),
-		score:            0,
+		cfg:  cfg,
+		mode: mode,
+		world: sim.NewWorld(cfg.GetWindowWidth(), cfg.GetWindowHeight(), preset, bowler, mode,
+			sim.NewDragArea(cfg, cfg.GetWindowWidth(), cfg.GetWindowHeight()), rng),
+		practiceScript:   practiceScript,
+		scenario:         gameScenario,
+		chatBowler:       chatBowler,
 		state:            GameStatePlaying,
 		highScoreManager: highScoreManager,
+		profileManager:   profileManager,
+		sound:            sound.NewManager(cfg.GetAudioEnabled(), volumes),
+		macro:            &practiceMacro{},
+		highlights:       &highlightRecorder{},
+		deliveries:       &deliveryRecorder{},
+		bestShots:        &bestShotRecorder{},
+		pad:              &padInput{},
+		hitNumbers:       &hitNumbers{},
+		input:            o.input,
+		target:           o.target,
+		replayPlayback:   o.replayPlayback(),
+		online:           o.onlineMatch(),
+		chat:             o.onlineChat(profileManager.Name()),
+		hud:              &hudLayer{},
+		labels:           make(map[labelKey]*ebiten.Image),
 		logger:           logger.New(),
 		userMessage:      "",
 	}
 
-	g.logger.Info("game initialized", "ball_spawn_time_seconds", cfg.GetballSpawnTime())
+	g.world.SetField(cfg.GetFieldWidth(), cfg.GetFieldHeight())
+	g.camera = newCamera(g.world.View(), g.world.Field)
+	if cfg.GetNightMatch() {
+		g.night = newNightMatch(cfg.GetWindowWidth(), cfg.GetWindowHeight(), cfg.GetReducedMotion())
+	}
+
+	if position, ok := profileManager.BatPosition(cfg.GetWindowWidth(), cfg.GetWindowHeight()); ok {
+		g.world.SetBatHome(position)
+	}
+	g.world.ArenaWalls = sim.ArenaWalls(cfg.GetArena())
+	g.world.ChaosOvers = cfg.GetChaosOvers()
+	g.world.FreeHits = cfg.GetFreeHit()
+	g.world.TargetRings = cfg.GetTargetRings()
+	if pb, ok := bowler.(*sim.PlayerBowler); ok {
+		g.startBowlingMode(pb)
+	}
+	g.basePreset = *preset
+	if m, ok := g.featuredMutators(); ok {
+		g.world.SetMutators(m)
+	}
+	if challenge != nil {
+		g.world.SetMutators(challenge.mutators)
+		g.logger.Info("playing challenge code", "code", challenge.String())
+	} else if profileManager.KidMode() {
+		g.applyKidMode(true)
+	}
+	g.startCalibration()
+	g.startAssist()
+	g.applyCosmetics()
+	g.startLoadout()
+	g.startHouseRules()
+	g.playByReplay()
+
+	g.housekeeping()
+	g.loadMods()
+	g.loadCommentary()
+	g.startUpdateCheck()
+	g.startLeaderboard()
+	g.startAssetWatcher()
+	g.startNarrator()
+	g.startOverlay()
+	g.startMetrics()
+	g.startFrameMonitor()
+	g.startPostEffects()
+	g.sound.StartMusic()
+	g.startSeasonMatch()
+	g.startVersusMatch()
+	g.startTeamSelection()
+	g.startChallengeMenu()
+	g.startMutatorMenu()
+
+	g.logger.Info("game initialized", "mode", mode.Name(), "difficulty", preset.Name, "ball_spawn_time_seconds", preset.SpawnIntervalSeconds)
 	return g, nil
 }
 
+// loadDifficulty loads the configured difficulty preset, or the one the player's calibration
+// recommended if none is configured, applying any spawn time override
+func loadDifficulty(cfg *config.Config, calibration *Calibration) (*difficulty.Preset, error) {
+	name := cfg.GetDifficulty()
+	if len(name) == 0 && calibration != nil {
+		name = calibration.Difficulty
+	}
+	if len(name) == 0 {
+		name = difficulty.Default
+	}
+
+	preset, err := difficulty.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if spawnTime := cfg.GetballSpawnTime(); spawnTime > 0 {
+		preset.SpawnIntervalSeconds = float64(spawnTime)
+	}
+
+	return preset, nil
+}
+
 func (g *Game) Run() error {
+	if g.embedded() {
+		return errEmbedded
+	}
 	g.logger.Info("starting game")
 	g.setupWindow()
 
 	// Running the game calls Update() on every 'tick'
-	return ebiten.RunGame(g)
+	err := ebiten.RunGame(g)
+	g.saveWindow()
+	return err
 }
 
 func (g *Game) setupWindow() {
 	ebiten.SetWindowSize(int(g.cfg.GetWindowWidth()), int(g.cfg.GetWindowHeight()))
 	ebiten.SetWindowTitle(g.cfg.GetWindowTitle())
-	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeDisabled)
+	ebiten.SetWindowIcon(assets.WindowIcons(false))
+	// The game scales to whatever size the window is dragged to, as smoothly as the sprites
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetScreenFilterEnabled(assets.Filter() == ebiten.FilterLinear)
+	g.restoreWindow()
 }
 
-func (g *Game) Update() error {
+// recoverCrash turns a panic in Update or Draw into an error for Ebiten to stop on, once the
+// debug entries leading up to it have been written out. Ebiten calls them on a goroutine of
+// its own, so a recover in main never sees the panic.
+func (g *Game) recoverCrash(err *error) {
+	if r := recover(); r != nil {
+		logger.FlushRing()
+		*err = fmt.Errorf("game crashed: %v\n%s", r, debug.Stack())
+	}
+}
+
+func (g *Game) Update() (err error) {
+	defer g.recoverCrash(&err)
+	if g.crash != nil {
+		return g.crash
+	}
+	started := time.Now()
+	g.ticked = true
+	g.frames.tick(g.throttled)
+	if !g.embedded() {
+		g.checkFocus()
+		g.trackWindow()
+	}
+	g.pad.update()
+	g.updateKeyboard()
+	g.updateChat()
 	g.updateGameStateRequestFromUser()
+	g.checkScreenshotKey()
+	g.updateMacroKeys()
+	g.pollUpdateCheck()
+	g.checkAssetReload()
+	g.runControlCommands()
 
 	switch g.state {
 	case GameStatePlaying:
@@ -99,16 +455,72 @@ func (g *Game) Update() error {
 
 	case GameStateGameOver:
 		g.checkHighScore()
+		g.updateCelebration()
+		g.updateStandingsSort()
+		g.updateChallengeReturn()
 
 	case GameStateNameInput:
 		g.updateNameInput()
 
+	case GameStateTeamSelection:
+		g.updateTeamSelection()
+
+	case GameStatePreMatch:
+		g.updatePreMatch()
+
+	case GameStateChallenges:
+		g.updateChallengeMenu()
+
+	case GameStateMutators:
+		g.updateMutatorMenu()
+
+	case GameStateCatching:
+		g.updateCatching()
+
+	case GameStateManageData:
+		g.updateManageData()
+
+	case GameStateCodeEntry:
+		g.updateCodeEntry()
+
+	case GameStateShop:
+		g.updateShop()
+
+	case GameStateLoadouts:
+		g.updateLoadouts()
+
+	case GameStatePhoto:
+		g.updatePhotoMode()
+
+	case GameStateHouseRules:
+		g.updateHouseRules()
+
+	case GameStateInningsBreak:
+		g.updateInningsBreak()
+
 	}
 
+	g.updateNarrator()
+	g.updateOverlay()
+	g.sound.Update()
+	g.observeUpdate(started)
 	return nil
 }
 
-func (g *Game) Draw(screen *ebiten.Image) {
+func (g *Game) Draw(window *ebiten.Image) {
+	defer g.recoverCrash(&g.crash)
+	if !g.frameDue() {
+		return
+	}
+	if g.embedded() {
+		window = g.target
+	}
+	defer g.frames.add(phaseDraw, time.Now())
+
+	g.keepingUp()
+	effects := g.frameEffects()
+	screen := effects.target(window)
+
 	// Clear screen with black background (terminal-like)
 	screen.Fill(color.RGBA{0, 0, 0, 255})
 
@@ -121,108 +533,215 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		g.drawNameInput(screen)
 	case GameStatePaused:
 		g.drawPaused(screen)
+	case GameStateTeamSelection:
+		g.drawTeamSelection(screen)
+	case GameStatePreMatch:
+		g.drawPreMatch(screen)
+	case GameStateChallenges:
+		g.drawChallengeMenu(screen)
+	case GameStateMutators:
+		g.drawMutatorMenu(screen)
+	case GameStateCatching:
+		g.drawCatching(screen)
+	case GameStateHelp:
+		g.drawHelp(screen)
+	case GameStateManageData:
+		g.drawManageData(screen)
+	case GameStateCodeEntry:
+		g.drawCodeEntry(screen)
+	case GameStateShop:
+		g.drawShop(screen)
+	case GameStateLoadouts:
+		g.drawLoadouts(screen)
+	case GameStatePhoto:
+		g.drawPhotoMode(screen)
+	case GameStateHouseRules:
+		g.drawHouseRules(screen)
+	case GameStateInningsBreak:
+		g.drawInningsBreak(screen)
 	}
+
+	effects.apply(window)
+	g.takeScreenshot(window)
+	g.drawPhotoHint(window)
+	g.drawFrameGraph(window)
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
 	return int(g.cfg.GetWindowWidth()), int(g.cfg.GetWindowHeight())
 }
+
 func (g *Game) updatePlaying() {
-	g.bat.update(g.stumps.position)
-
-	select {
-	// New balls should come in at regular intervals
-	case <-g.ballSpawnTimer.C:
-		newball := newBall(float64(g.cfg.GetWindowWidth()), float64(g.cfg.GetWindowHeight()))
-		g.balls[newball] = struct{}{}
-		g.logger.Debug("new ball spawned", "ballCount", len(g.balls), "ballPosition", newball.position)
-
-	// On every tick, check if the wicket has been hit by the bat
-	default:
-		if g.stumps.checkCollision(nil, g.bat) {
-			g.logger.Debug("bat collided with stumps", "score", g.score)
-			g.stumps.fall()
-			g.endGame(gameEndMessageHitWicket)
+	if g.chatBowler != nil {
+		g.chatBowler.Poll()
+	}
+	input := g.bowlingInput(g.playedInput())
+	g.world.Update(input)
+	g.playOnline(input)
+	g.camera.update(g.world)
+	g.updateHitFireworks()
+	g.hitNumbers.update()
+	g.highlights.record(g.world)
+	g.deliveries.record(g.world)
+	g.bestShots.record(g.world, g.deliveries)
+	g.updateInstantReplay()
+	if g.night != nil {
+		g.night.update(g.world)
+	}
+	g.handleFieldEvents()
+	g.updateMusic()
+	g.updateAssist()
+	g.observeField()
+
+	state := g.world.MatchState()
+	if over, message := g.world.Mode.End(state); over {
+		if g.changeInnings() || g.settleTie(state) {
 			return
 		}
+		g.endGame(message)
+		return
 	}
 
-	g.updateballs()
+	if g.halfwayBreakDue() {
+		g.startInningsBreak("HALFWAY", false, func() {})
+		return
+	}
 
+	if g.practiceScript != nil && g.world.BowlingComplete() {
+		g.endGame(gameEndMessageDrillDone)
+	}
 }
 
-func (g *Game) updateballs() {
-	ballsToDeactivate := make([]*ball, 0)
+// handleFieldEvents plays sounds for, comments on and remembers whatever happened on the
+// last tick
+func (g *Game) handleFieldEvents() {
+	g.comment(g.world.Events)
+	for _, event := range g.world.Events {
+		switch event {
+		case sim.EventHit:
+			g.sound.Play(sound.ClipHit)
+			g.rumbleForHit()
+			g.celebrateHit()
+			g.showHitNumber()
+		case sim.EventWicket:
+			g.rumble(wicketRumbleDuration, 1)
+			g.sound.CutMusic()
+			logger.FlushRing()
+		case sim.EventAppeal:
+			g.sound.Play(sound.ClipAppeal)
+		case sim.EventChaos:
+			g.sound.Play(sound.ClipSting)
+		case sim.EventRingBonus:
+			g.sound.Play(sound.ClipHit)
+		case sim.EventGivenOut:
+			g.sound.Play(sound.ClipOut)
+		case sim.EventBatPlaced:
+			if err := g.profileManager.SetBatPosition(g.world.Bat.Position, g.cfg.GetWindowWidth(), g.cfg.GetWindowHeight()); err != nil {
+				g.logger.Warn("could not save bat position", "error", err)
+			}
+		}
+	}
+}
 
-	for ball := range g.balls {
-		ball.update(g.cfg.GetWindowWidth(), g.cfg.GetWindowHeight())
+// drawAppeal shows the fielders' appeal, growing and shrinking until the umpire decides
+func (g *Game) drawAppeal(screen *ebiten.Image) {
+	if g.world.PendingAppeal == nil {
+		return
+	}
 
-		if !ball.active {
-			// Remove inactive balls
-			ballsToDeactivate = append(ballsToDeactivate, ball)
-			continue
-		}
+	var (
+		appealX float64 = g.world.Stumps.Position.X + 60
+		appealY float64 = g.world.Stumps.Position.Y - 60
+	)
+	elapsed := float64(sim.AppealDecisionTicks-g.world.PendingAppeal.TicksLeft) / ebiten.DefaultTPS
+	scale := 1.5 + 0.25*math.Sin(2*math.Pi*2*elapsed)
 
-		collisionZone := g.bat.checkCollision(ball)
-		if collisionZone != noCollision {
-			if ball.hit(g.bat, collisionZone) {
-				g.score++
-				g.logger.Debug("ball hit successfully", "new_score", g.score, "collision_zone", collisionZone, "ball_velocityy", ball.velocity)
-			}
-			continue
-		}
+	g.drawText(screen, "HOWZAT?", appealX, appealY, scale, scale, color.RGBA{255, 140, 0, 255})
+}
 
-		// Check ball's collision with stumps
-		if g.stumps.checkCollision(ball, nil) {
-			g.logger.Debug("ball collided with stumps", "ballPosition", ball.position, "score", g.score)
-			g.stumps.fall()
-			g.endGame(gameEndMessageBowled)
-			break
-		}
+// drawTiming shows how well the last shot was timed, next to the bat
+func (g *Game) drawTiming(screen *ebiten.Image) {
+	if g.world.TimingTicks == 0 {
+		return
 	}
 
-	for _, ball := range ballsToDeactivate {
-		delete(g.balls, ball)
+	const (
+		timingOffsetX float64 = 40
+		timingOffsetY float64 = -40
+	)
+	position := g.camera.toScreen(g.world.Bat.Position)
+	label := g.world.LastTiming.String()
+	if shot := string(g.world.LastShot); len(shot) > 0 {
+		label = strings.ToUpper(shot[:1]) + shot[1:] + "! " + label
 	}
+	g.drawText(screen, label, position.X+timingOffsetX, position.Y+timingOffsetY, 1, 1, color.RGBA{255, 255, 0, 255})
+}
+
+// drawHawkEye shows ball tracking for the last LBW decision, with its verdict underneath
+func (g *Game) drawHawkEye(screen *ebiten.Image) {
+	h := g.world.HawkEye
+	if h == nil || !h.Visible() {
+		return
+	}
+
+	if g.hawkEyeView == nil {
+		g.hawkEyeView = ebiten.NewImage(hawkEyeViewSize, hawkEyeViewSize)
+	}
+	drawHawkEye(screen, g.hawkEyeView, h, g.world.Stumps, g.cfg.GetWindowWidth())
+
+	var (
+		verdictX float64 = g.cfg.GetWindowWidth() - hawkEyeViewSize - hawkEyeMargin
+		verdictY float64 = hawkEyeMargin + hawkEyeViewSize + 10
+	)
+	g.drawText(screen, h.Verdict(), verdictX, verdictY, 1, 1, color.White)
 }
 
 func (g *Game) updateGameStateRequestFromUser() {
 
 	// User wants to reset game
-	if ebiten.IsKeyPressed(ebiten.KeyControl) && ebiten.IsKeyPressed(ebiten.KeyR) {
+	if ebiten.IsKeyPressed(ebiten.KeyControl) && ebiten.IsKeyPressed(ebiten.KeyR) || g.padRestart() {
 		g.reset()
 		return
 	}
 
+	g.updatePauseFocus()
+
 	// User wants to pause/unpause game
-	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+	if g.keyJustPressed(ebiten.KeyP) {
 		if g.state == GameStatePlaying {
 			g.state = GameStatePaused
-			g.ballSpawnTimer.Stop()
 			return
 		}
 
 		if g.state == GameStatePaused {
 			g.state = GameStatePlaying
-			g.ballSpawnTimer.Reset(time.Duration(g.cfg.GetballSpawnTime()) * time.Second)
 			return
 		}
 	}
+
+	g.toggleHelp()
+	g.toggleKidMode()
+	g.toggleManageData()
+	g.toggleCodeEntry()
+	g.toggleShop()
+	g.toggleLoadouts()
+	g.togglePhotoMode()
+	g.toggleHouseRules()
 }
 
 func (g *Game) updateNameInput() {
 
 	// Accumulate new input characters
-	newChars := string(ebiten.AppendInputChars(nil))
+	newChars := string(g.inputChars())
 	g.nameInput += newChars
 
 	// Handle backspace
-	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(g.nameInput) > 0 {
+	if g.keyJustPressed(ebiten.KeyBackspace) && len(g.nameInput) > 0 {
 		g.nameInput = g.nameInput[:len(g.nameInput)-1]
 	}
 
 	// Handle enter to submit name
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+	if g.keyJustPressed(ebiten.KeyEnter) {
 		finalName := g.nameInput
 		if finalName == "" {
 			finalName = "Anonymous"
@@ -235,7 +754,7 @@ func (g *Game) updateNameInput() {
 			return -1
 		}, finalName)
 
-		g.highScoreManager.SetHighScore(g.score, cleanName)
+		g.highScoreManager.SetHighScore(g.matchScore(), cleanName)
 		g.userMessage = "High score saved!"
 	}
 
@@ -243,8 +762,24 @@ func (g *Game) updateNameInput() {
 
 func (g *Game) endGame(message string) {
 	g.userMessage = message
-
-	g.logger.Info("game over", "score", g.score, "current_high_score", g.highScoreManager.highScore)
+	g.recordSeasonMatch()
+	g.recordVersusMatch()
+	g.finishMacro()
+	g.saveDismissals()
+	g.saveLongestSix()
+	g.logInnings()
+	g.saveBallByBall()
+	g.saveReplay()
+	g.saveResultCard()
+	g.recordChallenge()
+	g.submitScore()
+	g.recordRating()
+	g.earnRuns()
+	g.finishCalibration()
+	g.awardAchievements()
+	g.startCelebration()
+
+	g.logger.Info("game over", "score", g.matchScore(), "current_high_score", g.highScoreManager.highScore, "time_in_play", g.world.Elapsed())
 	g.state = GameStateGameOver
 
 }
@@ -252,38 +787,94 @@ func (g *Game) endGame(message string) {
 func (g *Game) drawPlaying(screen *ebiten.Image) {
 
 	// Draw stumps, bat and ball
-	g.stumps.draw(screen)
-	g.bat.draw(screen)
-
-	for ball := range g.balls {
-		ball.draw(screen)
-	}
+	g.drawField(screen, true)
+	g.drawGhostBat(screen)
 
 	// Draw other text that shows up in the game
-	const (
-		scoreX float64 = 20
-		scoreY float64 = 30
-	)
-
-	const (
-		highScoreX float64 = 20
-		highScoreY float64 = 60
-	)
-
 	var (
 		instructionX float64 = 20
 		instructionY float64 = g.cfg.GetWindowHeight() - 30
 	)
 
-	g.drawText(screen, fmt.Sprintf("%s%d", "Score: ", g.score), scoreX, scoreY, 1, 1, color.White)
-	g.drawText(screen, g.highScoreManager.GetHighScoreText("High Score: "), highScoreX, highScoreY, 1, 1, color.White)
-	g.drawText(screen, gameInstructions, instructionX, instructionY, 1, 1, color.White)
+	g.drawLabel(screen, gameInstructions, instructionX, instructionY, color.White)
+
+	// Lines that depend on the mode and practice drill go under the high score
+	extraLines := []string{
+		fmt.Sprintf("%s%d", "Score: ", g.world.Score),
+		g.highScoreManager.GetHighScoreText("High Score: "),
+	}
+	extraLines = append(extraLines, g.world.Mode.HUD(g.world.MatchState())...)
+	// With more than one batsman to come, how the over is going matters
+	if g.world.Mode.Rules().Wickets != 1 {
+		extraLines = append(extraLines, "This over: "+overSummary(g.world.Innings.ThisOver(g.world.Rules.BallsPerOver)))
+	}
+	if g.practiceScript != nil {
+		extraLines = append(extraLines, "Practice: "+g.practiceScript.Name, g.macroHUD())
+	}
+	if line, ok := g.world.BallAgeHUD(); ok {
+		extraLines = append(extraLines, line)
+	}
+	if line, ok := g.mutatorsHUD(); ok {
+		extraLines = append(extraLines, line)
+	}
+	if line, ok := g.houseRulesHUD(); ok {
+		extraLines = append(extraLines, line)
+	}
+	if line, ok := g.kidModeHUD(); ok {
+		extraLines = append(extraLines, line)
+	}
+	if line, ok := g.assistHUD(); ok {
+		extraLines = append(extraLines, line)
+	}
+	if line, ok := g.world.RingsHUD(); ok {
+		extraLines = append(extraLines, line)
+	}
+	extraLines = append(extraLines, g.onlineHUD()...)
+	if g.chatBowler != nil {
+		extraLines = append(extraLines, g.chatBowler.HUD(g.world.TicksUntilSpawn)...)
+	}
+	if len(g.world.Lineup) > 0 {
+		extraLines = append(extraLines, "Batsman: "+g.world.CurrentBatsman().Name)
+	}
+	if figures, ok := g.world.CurrentBowlerFigures(); ok {
+		extraLines = append(extraLines, fmt.Sprintf("Bowler: %s (%d/%d)", figures.Bowler, figures.Wickets, figures.Runs))
+	}
+	g.hud.draw(g, screen, extraLines)
+	g.drawChat(screen)
+
+	g.drawAppeal(screen)
+	g.drawTiming(screen)
+	g.drawHitFireworks(screen)
+	g.drawHitNumbers(screen)
+	g.drawChaosBanner(screen)
+	g.drawFreeHitBanner(screen)
+	g.drawBowlingGesture(screen)
+
+	const fieldMapScale = 0.2
+	var (
+		fieldMapX float64 = g.cfg.GetWindowWidth()*(1-fieldMapScale) - 20
+		fieldMapY float64 = g.cfg.GetWindowHeight()*(1-fieldMapScale) - 60
+	)
+	drawFieldMap(screen, g.world, fieldMapX, fieldMapY, fieldMapScale)
+	g.drawHawkEye(screen)
+	g.drawInstantReplay(screen)
+
+	if g.world.AnnouncementTicks > 0 && g.showAnnouncement() {
+		var (
+			announcementX float64 = g.cfg.GetWindowWidth()/2 - 100
+			announcementY float64 = 30
+		)
+		g.drawText(screen, g.world.Announcement, announcementX, announcementY, 1, 1, color.RGBA{255, 255, 0, 255})
+	}
 
 }
 
 func (g *Game) drawGameOver(screen *ebiten.Image) {
-	g.stumps.draw(screen)
-	g.bat.draw(screen)
+	if g.celebration != nil {
+		g.drawCelebration(screen)
+	} else {
+		g.drawField(screen, false)
+	}
 
 	// Draw OUT, final score, high score and restart text
 	var (
@@ -300,15 +891,151 @@ func (g *Game) drawGameOver(screen *ebiten.Image) {
 		highScoreY float64 = g.cfg.GetWindowHeight()/2 - 10
 	)
 
+	var (
+		sessionX float64 = g.cfg.GetWindowWidth()/2 + 50
+		sessionY float64 = g.cfg.GetWindowHeight()/2 + 20
+	)
+
+	var (
+		timingX float64 = g.cfg.GetWindowWidth()/2 + 50
+		timingY float64 = g.cfg.GetWindowHeight()/2 + 50
+	)
+
+	var (
+		longestSixX float64 = g.cfg.GetWindowWidth()/2 + 50
+		longestSixY float64 = g.cfg.GetWindowHeight()/2 + 80
+	)
+
+	var (
+		shotsX float64 = g.cfg.GetWindowWidth()/2 + 50
+		shotsY float64 = g.cfg.GetWindowHeight()/2 + 110
+	)
+
 	var (
 		restartX float64 = g.cfg.GetWindowWidth()/2 + 50
-		restartY float64 = g.cfg.GetWindowHeight()/2 + 30
+		restartY float64 = g.cfg.GetWindowHeight()/2 + 140
 	)
 	g.drawText(screen, g.userMessage, outX, outY, 2, 2, color.RGBA{255, 50, 50, 255})
-	g.drawText(screen, fmt.Sprintf("Final Score: %d", g.score), finalScoreX, finalScoreY, 1, 1, color.White)
+	finalScore := fmt.Sprintf("Final Score: %d", g.matchScore())
+	if g.superOver != nil {
+		finalScore += fmt.Sprintf(" (super over %d v %d)", g.world.Score, g.superOver.Mode.OppositionRuns)
+	}
+	g.drawText(screen, finalScore, finalScoreX, finalScoreY, 1, 1, color.White)
 	g.drawText(screen, g.highScoreManager.GetHighScoreText("High Score: "), highScoreX, highScoreY, 1, 1, color.White)
+	g.drawText(screen, g.sessionText(), sessionX, sessionY, 1, 1, color.White)
+	g.drawText(screen, sim.TimingSummary(g.world.Innings.TimingDistribution()), timingX, timingY, 1, 1, color.White)
+	g.drawText(screen, g.longestSixText(), longestSixX, longestSixY, 1, 1, color.White)
+	g.drawText(screen, sim.ShotSummary(g.world.Innings.ShotTally()), shotsX, shotsY, 1, 1, color.White)
 	g.drawText(screen, "Press Ctrl+R to restart", restartX, restartY, 1, 1, color.White)
 
+	// Bowling figures, as overs-runs-wickets
+	var (
+		figuresX       float64 = g.cfg.GetWindowWidth()/2 + 50
+		figuresY       float64 = g.cfg.GetWindowHeight()/2 + 190
+		figuresSpacing float64 = 30
+	)
+	bowlerFigures := g.world.Innings.BowlerFigures()
+	for i, figures := range bowlerFigures {
+		line := fmt.Sprintf("%s %s-%d-%d", figures.Bowler, sim.FormatOvers(figures.Balls, g.world.Rules.BallsPerOver), figures.Runs, figures.Wickets)
+		g.drawText(screen, line, figuresX, figuresY+float64(i)*figuresSpacing, 1, 1, color.White)
+	}
+	g.drawNotices(screen, figuresX, figuresY+float64(len(bowlerFigures))*figuresSpacing)
+
+	g.drawSeason(screen)
+	g.drawRivalry(screen)
+	g.drawChallengeResult(screen)
+	g.drawAchievements(screen)
+
+	var (
+		heatmapX float64 = 20
+		heatmapY float64 = g.cfg.GetWindowHeight() - 200
+	)
+	g.drawDismissalHeatmap(screen, heatmapX, heatmapY)
+
+	// How a practice macro played back compared with the recording
+	const (
+		macroX       float64 = 20
+		macroY       float64 = 30
+		macroSpacing float64 = 30
+	)
+	for i, line := range g.macroComparison() {
+		g.drawText(screen, line, macroX, macroY+float64(i)*macroSpacing, 1, 1, color.White)
+	}
+}
+
+// notices are the small print at the end of a match: where its result went, how to share
+// it and what it did to the player's rating
+func (g *Game) notices() []string {
+	var notices []string
+	if status, ok := g.leaderboardStatus(); ok {
+		notices = append(notices, status)
+	}
+	if len(g.resultCardPath) > 0 {
+		notices = append(notices, "Result card: "+g.resultCardPath)
+	}
+	if code, ok := g.challengeCode(); ok {
+		notices = append(notices, "Challenge code: "+code.String())
+	}
+	return append(notices, g.ratingNotices()...)
+}
+
+func (g *Game) drawNotices(screen *ebiten.Image, x, y float64) {
+	const noticeSpacing float64 = 25
+	for i, notice := range g.notices() {
+		g.drawText(screen, notice, x, y+float64(i)*noticeSpacing, 0.7, 0.7, color.RGBA{150, 150, 150, 255})
+	}
+}
+
+// overSummary writes an over the way scorers do, such as "• 1 W wd •"
+func overSummary(events []stats.BallEvent) string {
+	symbols := make([]string, 0, len(events))
+	for _, event := range events {
+		switch {
+		case event.Outcome.Wicket():
+			symbols = append(symbols, "W")
+		case event.Outcome == stats.OutcomeWide:
+			symbols = append(symbols, "wd")
+		case event.Runs == 0:
+			symbols = append(symbols, "•")
+		default:
+			symbols = append(symbols, strconv.Itoa(event.Runs))
+		}
+	}
+
+	return strings.Join(symbols, " ")
+}
+
+// sessionText is how long the innings lasted, how quickly it was bowled and how many
+// balls went by without scoring
+func (g *Game) sessionText() string {
+	elapsed := g.world.Elapsed()
+	ballsPerMinute := stats.BallsPerMinute(g.world.BallsBowled, elapsed)
+	return fmt.Sprintf("Time: %d:%02d, %.1f balls/min, %d dot balls, %d wides",
+		int(elapsed.Minutes()), int(elapsed.Seconds())%60, ballsPerMinute, g.world.Innings.DotBalls(), g.world.Wides)
+}
+
+// saveLongestSix keeps the innings' longest six in the profile if it is the player's best
+func (g *Game) saveLongestSix() {
+	if g.practiceScript != nil {
+		return
+	}
+
+	longest := g.world.Innings.LongestCarry()
+	record, err := g.profileManager.UpdateLongestSix(longest)
+	if err != nil {
+		g.logger.Warn("could not save longest six", "error", err)
+	}
+	if record {
+		g.logger.Info("new longest six", "metres", longest)
+	}
+}
+
+func (g *Game) longestSixText() string {
+	best := g.profileManager.LongestSix()
+	if best == 0 {
+		return "Longest six: none yet"
+	}
+	return fmt.Sprintf("Longest six: %s (your best %s)", sim.FormatCarry(g.world.Innings.LongestCarry()), sim.FormatCarry(best))
 }
 
 func (g *Game) drawNameInput(screen *ebiten.Image) {
@@ -344,36 +1071,24 @@ func (g *Game) drawNameInput(screen *ebiten.Image) {
 	)
 
 	g.drawText(screen, "NEW HIGH SCORE!", congratsX, congratsY, 1, 1, color.White)
-	g.drawText(screen, fmt.Sprintf("Score: %d", g.score), scoreX, scoreY, 1, 1, color.White)
+	g.drawText(screen, fmt.Sprintf("Score: %d", g.matchScore()), scoreX, scoreY, 1, 1, color.White)
 	g.drawText(screen, "Enter your name and press return", namePromptX, namePromptY, 1, 1, color.White)
 	g.drawText(screen, g.nameInput, nameInputX, nameInputY, 1, 1, color.White)
 	g.drawText(screen, "Press Ctrl+R to restart", restartX, restartY, 1, 1, color.White)
 	g.drawText(screen, g.userMessage, userMessageX, userMessageY, 1, 1, color.White)
-
+	g.drawNotices(screen, userMessageX, userMessageY+40)
+	g.drawKeyboard(screen)
 }
 
 func (g *Game) drawPaused(screen *ebiten.Image) {
 	// Draw the current game state (stumps, bat, balls) in background
-	g.stumps.draw(screen)
-	g.bat.draw(screen)
-
-	for ball := range g.balls {
-		ball.draw(screen)
-	}
+	g.drawField(screen, true)
 
 	// Draw score and high score in their normal positions
-	const (
-		scoreX float64 = 20
-		scoreY float64 = 30
-	)
-
-	const (
-		highScoreX float64 = 20
-		highScoreY float64 = 60
-	)
-
-	g.drawText(screen, fmt.Sprintf("%s%d", "Score: ", g.score), scoreX, scoreY, 1, 1, color.White)
-	g.drawText(screen, g.highScoreManager.GetHighScoreText("High Score: "), highScoreX, highScoreY, 1, 1, color.White)
+	g.hud.draw(g, screen, []string{
+		fmt.Sprintf("%s%d", "Score: ", g.world.Score),
+		g.highScoreManager.GetHighScoreText("High Score: "),
+	})
 
 	// Draw pause overlay in center
 	var (
@@ -386,18 +1101,89 @@ func (g *Game) drawPaused(screen *ebiten.Image) {
 		resumeY float64 = g.cfg.GetWindowHeight()/2 + 30
 	)
 
+	var (
+		updateX float64 = 20
+		updateY float64 = g.cfg.GetWindowHeight() - 120
+	)
+
 	g.drawText(screen, "PAUSED", pausedX, pausedY, 2, 2, color.RGBA{255, 255, 0, 255})
-	g.drawText(screen, "Press P to resume", resumeX, resumeY, 1, 1, color.White)
+	var (
+		kidModeX float64 = g.cfg.GetWindowWidth()/2 - 50
+		kidModeY float64 = g.cfg.GetWindowHeight()/2 + 60
+	)
+
+	g.drawLabel(screen, "Press P to resume", resumeX, resumeY, color.White)
+	g.drawPauseFocus(screen, resumeX, resumeY, 30)
+	var (
+		manageDataX float64 = g.cfg.GetWindowWidth()/2 - 50
+		manageDataY float64 = g.cfg.GetWindowHeight()/2 + 90
+	)
+
+	g.drawLabel(screen, kidToggleLabel, kidModeX, kidModeY, color.White)
+	var (
+		codeEntryX float64 = g.cfg.GetWindowWidth()/2 - 50
+		codeEntryY float64 = g.cfg.GetWindowHeight()/2 + 120
+	)
+
+	g.drawLabel(screen, "Press D to manage saved data", manageDataX, manageDataY, color.White)
+	var (
+		shopX float64 = g.cfg.GetWindowWidth()/2 - 50
+		shopY float64 = g.cfg.GetWindowHeight()/2 + 150
+	)
+
+	g.drawLabel(screen, "Press C to play a challenge code", codeEntryX, codeEntryY, color.White)
+	var (
+		loadoutsX float64 = g.cfg.GetWindowWidth()/2 - 50
+		loadoutsY float64 = g.cfg.GetWindowHeight()/2 + 180
+	)
+
+	g.drawLabel(screen, "Press S for the shop", shopX, shopY, color.White)
+	var (
+		photoX float64 = g.cfg.GetWindowWidth()/2 - 50
+		photoY float64 = g.cfg.GetWindowHeight()/2 + 210
+	)
+
+	var (
+		houseRulesX float64 = g.cfg.GetWindowWidth()/2 - 50
+		houseRulesY float64 = g.cfg.GetWindowHeight()/2 + 240
+	)
+
+	g.drawLabel(screen, "Press L for loadouts", loadoutsX, loadoutsY, color.White)
+	g.drawLabel(screen, "Press F for photo mode", photoX, photoY, color.White)
+	g.drawLabel(screen, "Press O for house rules", houseRulesX, houseRulesY, color.White)
+	g.drawUpdateNotice(screen, updateX, updateY)
+	g.drawAssetProblems(screen)
 }
 
 func (g *Game) reset() {
 	g.logger.Debug("resetting game")
-	g.bat = newBat()
-	g.balls = make(map[*ball]struct{})
-	g.stumps.reset()
-	g.ballSpawnTimer.Reset(time.Duration(g.cfg.GetballSpawnTime()) * time.Second)
-	g.score = 0
+	// A restart in the middle of a drill spoils any macro being recorded or played back
+	g.macro.recorder = false
+	g.macro.playback = nil
+	g.highlights.reset()
+	g.deliveries.reset()
+	g.bestShots.reset()
+	g.hitNumbers.reset()
+	g.sound.SetIntensity(0)
+	g.instantReplay = nil
+	g.inningsBreak = nil
+	g.celebration = nil
+	g.ballByBall = nil
+	g.restartReplay()
+	g.restartOnline()
+	g.catching = nil
+	g.endCalibration()
+	if g.superOver != nil {
+		g.superOver = nil
+		g.world.StartInnings(g.mode)
+	} else {
+		g.world.Reset()
+	}
+	g.world.RelayPitch()
 	g.state = GameStatePlaying
+	g.startSeasonMatch()
+	g.startVersusMatch()
+	g.startTeamSelection()
 	g.logger.Debug("game reset complete", "state", g.state)
 }
 
@@ -410,14 +1196,15 @@ func (g *Game) drawText(screen *ebiten.Image, textToDraw string, posX, posY, sca
 }
 
 func (g *Game) checkHighScore() {
-	if g.highScoreManager.IsNewHighScore(g.score) {
+	// Practice drills and scenarios don't count towards the high score
+	if g.ranked() && g.highScoreManager.IsNewHighScore(g.matchScore()) {
 		if g.nameInputTimer == nil {
 			g.nameInputTimer = time.NewTimer(sleepTimeBeforeShowingHighScore)
 		}
 
 		select {
 		case <-g.nameInputTimer.C:
-			g.logger.Info("new high score achieved", "score", g.score)
+			g.logger.Info("new high score achieved", "score", g.matchScore())
 			g.state = GameStateNameInput
 			g.nameInputTimer.Stop()
 			g.nameInputTimer = nil
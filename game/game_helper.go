@@ -5,6 +5,7 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/sim"
 )
 
 func getCurrentMousePosition() *geometry.Vector {
@@ -13,6 +14,15 @@ func getCurrentMousePosition() *geometry.Vector {
 	return currentMousePos
 }
 
+// readBatInput reads the player's mouse and keyboard state for this tick
+func readBatInput() sim.BatInput {
+	return sim.BatInput{
+		Cursor:   *getCurrentMousePosition(),
+		Dragging: ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft),
+		Blocking: ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) || ebiten.IsKeyPressed(ebiten.KeySpace),
+	}
+}
+
 // clampValue clamps the value between min and max if the value is < min or > max
 func clampValue[T cmp.Ordered](value T, min T, max T) T {
 	if value > max {
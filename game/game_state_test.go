@@ -0,0 +1,36 @@
+package game
+
+import "testing"
+
+func TestReportedState(t *testing.T) {
+	want := map[GameState]string{
+		GameStatePlaying:       "playing",
+		GameStateGameOver:      "game_over",
+		GameStateNameInput:     "game_over",
+		GameStatePaused:        "paused",
+		GameStateTeamSelection: "paused",
+		GameStatePreMatch:      "paused",
+		GameStateChallenges:    "paused",
+		GameStateMutators:      "paused",
+		GameStateCatching:      "paused",
+		GameStateHelp:          "paused",
+		GameStateManageData:    "paused",
+		GameStateCodeEntry:     "paused",
+		GameStateShop:          "paused",
+		GameStateLoadouts:      "paused",
+		GameStatePhoto:         "paused",
+		GameStateHouseRules:    "paused",
+		GameStateInningsBreak:  "paused",
+	}
+	// Every state is listed, so that a new one has to be placed
+	for state := GameStatePlaying; state <= GameStateInningsBreak; state++ {
+		if _, ok := want[state]; !ok {
+			t.Errorf("state %d is not listed", state)
+		}
+	}
+	for state, reported := range want {
+		if got := reportedState(state); got != reported {
+			t.Errorf("state %d reported as %q, want %q", state, got, reported)
+		}
+	}
+}
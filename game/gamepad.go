@@ -0,0 +1,134 @@
+package game
+
+import (
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// padKeys are the buttons of a standard gamepad that stand in for each key, so that every
+// screen can be worked from a gamepad alone
+var padKeys = map[ebiten.Key][]ebiten.StandardGamepadButton{
+	ebiten.KeyUp:        {ebiten.StandardGamepadButtonLeftTop},
+	ebiten.KeyDown:      {ebiten.StandardGamepadButtonLeftBottom},
+	ebiten.KeyLeft:      {ebiten.StandardGamepadButtonLeftLeft},
+	ebiten.KeyRight:     {ebiten.StandardGamepadButtonLeftRight},
+	ebiten.KeyEnter:     {ebiten.StandardGamepadButtonRightBottom},
+	ebiten.KeyEscape:    {ebiten.StandardGamepadButtonRightRight},
+	ebiten.KeyBackspace: {ebiten.StandardGamepadButtonRightLeft},
+	ebiten.KeyDelete:    {ebiten.StandardGamepadButtonRightLeft},
+	ebiten.KeySpace:     {ebiten.StandardGamepadButtonRightTop},
+	ebiten.KeyE:         {ebiten.StandardGamepadButtonRightTop}, // Effects in photo mode
+	ebiten.KeyN:         {ebiten.StandardGamepadButtonRightTop}, // A new loadout
+	ebiten.KeyP:         {ebiten.StandardGamepadButtonCenterRight},
+	ebiten.KeyH:         {ebiten.StandardGamepadButtonCenterLeft},
+	ebiten.KeyR:         {ebiten.StandardGamepadButtonFrontTopLeft},
+	ebiten.KeyTab:       {ebiten.StandardGamepadButtonFrontTopRight},
+	ebiten.KeyMinus:     {ebiten.StandardGamepadButtonFrontBottomLeft},
+	ebiten.KeyEqual:     {ebiten.StandardGamepadButtonFrontBottomRight},
+}
+
+// pauseMenuKeys are the keys for the lines of the pause screen, top to bottom, which the
+// focus moves between
+var pauseMenuKeys = []ebiten.Key{
+	ebiten.KeyP, ebiten.KeyK, ebiten.KeyD, ebiten.KeyC, ebiten.KeyS, ebiten.KeyL, ebiten.KeyF, ebiten.KeyO,
+}
+
+// padInput is the gamepads connected on this tick, and the keys picked and typed on screen
+// with them
+type padInput struct {
+	ids        []ebiten.GamepadID
+	pressed    []ebiten.Key // Picked on screen, as if they had just been pressed
+	used       []ebiten.Key // Already acted on this tick, and so ignored from here on
+	typed      []rune       // Typed on the on-screen keyboard
+	touched    bool         // The screen has been touched, so there may be no keyboard
+	pauseFocus int          // Line of the pause screen with the focus
+}
+
+// update finds the gamepads and forgets what was picked on the last tick
+func (p *padInput) update() {
+	p.ids = ebiten.AppendGamepadIDs(p.ids[:0])
+	p.pressed = p.pressed[:0]
+	p.used = p.used[:0]
+	p.typed = p.typed[:0]
+	if len(ebiten.AppendTouchIDs(nil)) > 0 {
+		p.touched = true
+	}
+}
+
+func (p *padInput) connected() bool {
+	return len(p.ids) > 0
+}
+
+// buttonJustPressed is true on the tick a button of any gamepad is pressed
+func (p *padInput) buttonJustPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range p.ids {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) && inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *padInput) buttonPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range p.ids {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) && ebiten.IsStandardGamepadButtonPressed(id, button) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyJustPressed is true on the tick the key, or a gamepad button standing in for it, is
+// pressed, or the key is picked on screen
+func (g *Game) keyJustPressed(key ebiten.Key) bool {
+	p := g.pad
+	switch {
+	case slices.Contains(p.pressed, key):
+		return true
+	case slices.Contains(p.used, key):
+		return false
+	case inpututil.IsKeyJustPressed(key):
+		return true
+	}
+	return slices.ContainsFunc(padKeys[key], p.buttonJustPressed)
+}
+
+// keyPressed is true while the key, or a gamepad button standing in for it, is held down
+func (g *Game) keyPressed(key ebiten.Key) bool {
+	return ebiten.IsKeyPressed(key) || slices.ContainsFunc(padKeys[key], g.pad.buttonPressed)
+}
+
+// updatePauseFocus moves the focus up and down the pause screen, and picks the line it is
+// on with Enter, as if its key had been pressed
+func (g *Game) updatePauseFocus() {
+	if g.state != GameStatePaused {
+		return
+	}
+
+	p := g.pad
+	switch {
+	case g.keyJustPressed(ebiten.KeyUp):
+		p.pauseFocus = (p.pauseFocus + len(pauseMenuKeys) - 1) % len(pauseMenuKeys)
+	case g.keyJustPressed(ebiten.KeyDown):
+		p.pauseFocus = (p.pauseFocus + 1) % len(pauseMenuKeys)
+	case g.keyJustPressed(ebiten.KeyEnter):
+		// The screen it opens mustn't take the same press as its own
+		p.used = append(p.used, ebiten.KeyEnter)
+		p.pressed = append(p.pressed, pauseMenuKeys[p.pauseFocus])
+	}
+}
+
+// drawPauseFocus marks the line of the pause screen with the focus, the first of which is
+// at y
+func (g *Game) drawPauseFocus(screen *ebiten.Image, x, y, lineSpacing float64) {
+	g.drawText(screen, ">", x-20, y+float64(g.pad.pauseFocus)*lineSpacing, 1, 1, color.RGBA{255, 255, 0, 255})
+}
+
+// padRestart is true when Start is pressed on the game over screen, which starts again as
+// Ctrl+R does
+func (g *Game) padRestart() bool {
+	return g.state == GameStateGameOver && g.pad.buttonJustPressed(ebiten.StandardGamepadButtonCenterRight)
+}
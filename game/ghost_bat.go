@@ -0,0 +1,39 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+var ghostBatMeetColor = color.RGBA{255, 255, 255, 160}
+
+// drawGhostBat overlays the ideal swing on the field in practice drills, to show where the
+// bat should be and when
+func (g *Game) drawGhostBat(screen *ebiten.Image) {
+	if g.practiceScript == nil {
+		return
+	}
+	swing, ok := g.world.IdealSwing()
+	alpha := g.world.GhostBatAlpha()
+	if !ok || alpha <= 0 {
+		return
+	}
+
+	sprite := batSprite(g.world.Bat.Scale)
+	handle := g.camera.toScreen(swing.Handle)
+	op := &ebiten.DrawImageOptions{Filter: assets.Filter()}
+	op.GeoM.Translate(-float64(sprite.Bounds().Dx())/2, 0)
+	op.GeoM.Rotate(swing.Angle)
+	op.GeoM.Translate(handle.X, handle.Y)
+	op.ColorScale.ScaleAlpha(alpha)
+	screen.DrawImage(sprite, op)
+
+	meet := g.camera.toScreen(swing.Meet)
+	meetColor := ghostBatMeetColor
+	meetColor.A = uint8(float32(meetColor.A) * alpha / sim.GhostBatMaxAlpha)
+	vector.StrokeCircle(screen, float32(meet.X), float32(meet.Y), 8, 1, meetColor, true)
+}
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	hawkEyeZoom     = 3   // How much the tracking view is magnified
+	hawkEyeViewSize = 240 // Width and height of the tracking view in pixels
+	hawkEyeMargin   = 20  // Gap between the tracking view and the edge of the screen
+)
+
+var (
+	hawkEyeTrackedColor   = color.RGBA{255, 255, 255, 255}
+	hawkEyeProjectedColor = color.RGBA{120, 180, 255, 255}
+	hawkEyeHittingColor   = color.RGBA{255, 60, 60, 200}
+	hawkEyeMissingColor   = color.RGBA{60, 220, 60, 200}
+)
+
+// drawHawkEye shows a magnified view of the stumps in the top right corner of the screen,
+// centred on where the ball crossed their line. The view is drawn into first.
+func drawHawkEye(screen, view *ebiten.Image, h *sim.HawkEye, s *sim.Stumps, screenWidth float64) {
+	if !h.Visible() {
+		return
+	}
+	view.Fill(color.RGBA{0, 40, 0, 255})
+
+	// Everything in the view is relative to the impact point, magnified
+	toView := func(p geometry.Vector) (float32, float32) {
+		return float32((p.X-h.Impact.X)*hawkEyeZoom + hawkEyeViewSize/2),
+			float32((p.Y-h.Impact.Y)*hawkEyeZoom + hawkEyeViewSize/2)
+	}
+
+	stumpsOptions := &ebiten.DrawImageOptions{Filter: assets.Filter()}
+	stumpsOptions.GeoM.Translate(s.Position.X-h.Impact.X, s.Position.Y-h.Impact.Y)
+	stumpsOptions.GeoM.Scale(hawkEyeZoom, hawkEyeZoom)
+	stumpsOptions.GeoM.Translate(hawkEyeViewSize/2, hawkEyeViewSize/2)
+	view.DrawImage(scaledSprite(assets.StumpsSprite, s.Scale), stumpsOptions)
+
+	drawPath := func(path []geometry.Vector, clr color.Color) {
+		for i := 1; i < len(path); i++ {
+			x0, y0 := toView(path[i-1])
+			x1, y1 := toView(path[i])
+			vector.StrokeLine(view, x0, y0, x1, y1, 3, clr, true)
+		}
+	}
+	drawPath(h.Tracked, hawkEyeTrackedColor)
+	if len(h.Tracked) > 0 {
+		drawPath(append([]geometry.Vector{h.Tracked[len(h.Tracked)-1]}, h.Projected...), hawkEyeProjectedColor)
+	}
+
+	impactColor := hawkEyeMissingColor
+	if h.Hitting {
+		impactColor = hawkEyeHittingColor
+	}
+	impactX, impactY := toView(h.Impact)
+	vector.DrawFilledCircle(view, impactX, impactY, float32(h.BallRadius*hawkEyeZoom), impactColor, true)
+	vector.StrokeRect(view, 0, 0, hawkEyeViewSize, hawkEyeViewSize, 2, color.White, false)
+
+	viewX := screenWidth - hawkEyeViewSize - hawkEyeMargin
+	viewOptions := &ebiten.DrawImageOptions{}
+	viewOptions.GeoM.Translate(viewX, hawkEyeMargin)
+	screen.DrawImage(view, viewOptions)
+}
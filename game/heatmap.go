@@ -0,0 +1,62 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	heatmapRows     = 6
+	heatmapCols     = 6
+	heatmapCellSize = 18
+)
+
+// saveDismissals adds the deliveries the player got out to in this innings to their profile
+func (g *Game) saveDismissals() {
+	if g.practiceScript != nil {
+		return
+	}
+
+	var dismissals []stats.BallEvent
+	for _, event := range g.world.Innings.Events() {
+		if event.Outcome.Wicket() {
+			dismissals = append(dismissals, event)
+		}
+	}
+	if len(dismissals) == 0 {
+		return
+	}
+
+	if err := g.profileManager.AddDismissals(dismissals); err != nil {
+		g.logger.Warn("could not save dismissals", "error", err)
+	}
+}
+
+// drawDismissalHeatmap shows where the deliveries that got the player out arrived, by
+// height down the side and speed along the bottom
+func (g *Game) drawDismissalHeatmap(screen *ebiten.Image, x, y float64) {
+	dismissals := g.profileManager.Dismissals()
+	if len(dismissals) == 0 {
+		return
+	}
+
+	preset := g.world.Preset
+	heatmap := stats.NewHeatmap(dismissals, heatmapRows, heatmapCols, preset.Ball.MinSpeed, preset.Ball.MaxSpeed)
+
+	const labelSpacing float64 = 30
+	g.drawText(screen, "How you get out (high to low, slow to fast)", x, y, 1, 1, color.White)
+	gridY := y + labelSpacing
+
+	for row := range heatmap.Rows() {
+		for col := range heatmap.Cols() {
+			heat := float64(heatmap.Count(row, col)) / float64(heatmap.Max())
+			cellX := float32(x) + float32(col*heatmapCellSize)
+			cellY := float32(gridY) + float32(row*heatmapCellSize)
+			vector.DrawFilledRect(screen, cellX, cellY, heatmapCellSize-1, heatmapCellSize-1,
+				color.RGBA{uint8(40 + 215*heat), 40, 40, 255}, false)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	helpTextScale   = 0.65
+	helpLineSpacing = 19
+	helpSectionGap  = 8
+)
+
+var helpShade = color.RGBA{0, 0, 0, 210}
+
+// helpControls are the keys and mouse moves the game answers to while batting
+var helpControls = []string{
+	"Move the mouse to swing the bat. Drag the bat to move it.",
+	"Hold right click or space to block.",
+	"R replays the last ball in the corner at double speed, while play goes on.",
+	"P pauses, H shows or hides this help, Ctrl+R starts again, F12 saves a screenshot.",
+	"K on the pause screen turns kid mode on or off, D manages saved data,",
+	"C plays a friend's challenge code, S opens the shop and L switches loadouts.",
+	"F freezes the match for a picture: arrows pan, +/- zoom and E turns effects off.",
+	"O on the pause screen sets house rules: balls an over, wides, no balls and runs a shot.",
+	"Online, T chats, 1-3 clap, appeal or shout shot, M mutes chat and I ignores the last to speak.",
+	"On a gamepad the D-pad moves, A picks, B goes back, Start pauses and Back shows this help.",
+}
+
+// helpDismissals explains every way a batsman can get out, in the order the help shows them
+var helpDismissals = []struct {
+	how         sim.Dismissal
+	explanation string
+}{
+	{sim.Bowled, "the ball hits the stumps"},
+	{sim.HitWicket, "the bat knocks the bails off"},
+	{sim.LBW, "the ball would have hit the stumps but for the batsman, given on appeal"},
+	{sim.CaughtBehind, "an edge carries to the keeper"},
+	{sim.Caught, "a lofted shot is taken by a fielder"},
+}
+
+// helpSection is a heading on the help screen with the lines under it
+type helpSection struct {
+	title string
+	lines []string
+}
+
+// toggleHelp shows the help over a match in play or paused, and puts the match back as it
+// was when the help is closed
+func (g *Game) toggleHelp() {
+	if !g.keyJustPressed(ebiten.KeyH) {
+		return
+	}
+
+	switch g.state {
+	case GameStatePlaying, GameStatePaused:
+		g.helpReturn = g.state
+		g.help = g.helpSections()
+		g.state = GameStateHelp
+	case GameStateHelp:
+		g.state = g.helpReturn
+	}
+}
+
+// helpSections builds the help from the registered modes, so that it covers every mode the
+// game has without being kept up to date by hand
+func (g *Game) helpSections() []helpSection {
+	dismissals := make([]string, 0, len(helpDismissals))
+	for _, d := range helpDismissals {
+		dismissals = append(dismissals, fmt.Sprintf("%s: %s", strings.ToUpper(d.how.String()), d.explanation))
+	}
+
+	sections := []helpSection{
+		{title: "CONTROLS", lines: helpControls},
+		{title: "SCORING", lines: []string{
+			"Every ball the bat connects with scores the runs the mode gives for a hit.",
+			"Time the swing to the ball's arrival for cleaner, longer shots.",
+		}},
+		{title: "DISMISSALS", lines: dismissals},
+		{title: "THIS MODE: " + g.world.Mode.Name(), lines: append([]string{g.world.Mode.Description()}, rulesHelp(g.world.Mode.Rules(), g.world.Rules.BallsPerOver)...)},
+	}
+
+	modes := helpSection{title: "ALL MODES"}
+	for _, name := range sim.ModeNames() {
+		mode, err := sim.NewMode(name, g.cfg)
+		if err != nil {
+			continue
+		}
+		modes.lines = append(modes.lines, fmt.Sprintf("%s: %s", name, mode.Description()))
+	}
+
+	return append(sections, modes)
+}
+
+// rulesHelp spells out the limits of a mode's innings, with overs of the given length
+func rulesHelp(rules sim.ModeRules, perOver int) []string {
+	lines := make([]string, 0, 3)
+	switch balls := rules.MaxBalls(perOver); {
+	case balls == 0:
+		lines = append(lines, "No limit on balls.")
+	case balls%perOver == 0:
+		lines = append(lines, fmt.Sprintf("%d overs.", balls/perOver))
+	default:
+		lines = append(lines, fmt.Sprintf("%d balls.", balls))
+	}
+
+	if rules.Wickets == 0 {
+		lines[0] += " No limit on wickets."
+	} else {
+		lines[0] += fmt.Sprintf(" %d wickets.", rules.Wickets)
+	}
+	if rules.Fielders {
+		lines[0] += " Fielders are out, so lofted shots can be caught."
+	}
+	if rules.DismissalPenalty > 0 {
+		lines = append(lines, fmt.Sprintf("Every dismissal costs %d runs.", rules.DismissalPenalty))
+	}
+
+	return lines
+}
+
+// drawHelp shows the help over the shaded field
+func (g *Game) drawHelp(screen *ebiten.Image) {
+	const (
+		titleX float64 = 20
+		titleY float64 = 20
+		linesX float64 = 30
+	)
+
+	g.drawField(screen, true)
+	bounds := screen.Bounds()
+	vector.DrawFilledRect(screen, float32(bounds.Min.X), float32(bounds.Min.Y), float32(bounds.Dx()), float32(bounds.Dy()), helpShade, false)
+
+	g.drawText(screen, "HELP (press H to close)", titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+	y := titleY + 40
+	for _, section := range g.help {
+		g.drawText(screen, section.title, titleX, y, helpTextScale, helpTextScale, color.RGBA{255, 255, 0, 255})
+		y += helpLineSpacing
+		for _, line := range section.lines {
+			g.drawText(screen, line, linesX, y, helpTextScale, helpTextScale, color.White)
+			y += helpLineSpacing
+		}
+		y += helpSectionGap
+	}
+}
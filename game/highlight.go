@@ -0,0 +1,120 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	highlightLeadInTicks = ebiten.DefaultTPS * 3 / 2 // How much of the play before the shot is kept
+	highlightFlightTicks = ebiten.DefaultTPS * 2     // How long the ball is followed after the shot
+)
+
+// highlightFrame is what was on the field on one tick, enough to draw it again
+type highlightFrame struct {
+	batPosition geometry.Vector
+	batAngle    float64
+	balls       []geometry.Vector
+}
+
+// highlightRecorder keeps the last few seconds of play, so that a shot can be replayed. It
+// keeps the field as drawn rather than the inputs, as a replay would, since inputs only
+// play back from the start of the match.
+type highlightRecorder struct {
+	frames []highlightFrame
+}
+
+func newHighlightFrame(w *sim.World) highlightFrame {
+	frame := highlightFrame{batPosition: w.Bat.Position, batAngle: w.Bat.CurrentAngle}
+	for _, b := range w.Balls {
+		if b.Active {
+			frame.balls = append(frame.balls, b.Position)
+		}
+	}
+	return frame
+}
+
+func (f highlightFrame) draw(screen *ebiten.Image, bat *ebiten.Image) {
+	op := &ebiten.DrawImageOptions{Filter: assets.Filter()}
+	op.GeoM.Translate(-float64(bat.Bounds().Dx())/2, 0)
+	op.GeoM.Rotate(f.batAngle)
+	op.GeoM.Translate(f.batPosition.X, f.batPosition.Y)
+	screen.DrawImage(bat, op)
+
+	for _, position := range f.balls {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(position.X, position.Y)
+		screen.DrawImage(assets.BallSprite, op)
+	}
+}
+
+func (r *highlightRecorder) record(w *sim.World) {
+	if len(r.frames) == highlightLeadInTicks {
+		r.frames = r.frames[1:]
+	}
+	r.frames = append(r.frames, newHighlightFrame(w))
+}
+
+func (r *highlightRecorder) reset() {
+	r.frames = r.frames[:0]
+}
+
+// highlight replays the last shot: the play leading up to it as recorded, then the ball's
+// flight worked out from where it was heading when play stopped
+type highlight struct {
+	frames []highlightFrame
+	bat    *ebiten.Image
+	tick   int
+}
+
+func newHighlight(r *highlightRecorder, w *sim.World) *highlight {
+	h := &highlight{
+		frames: append([]highlightFrame(nil), r.frames...),
+		bat:    batSprite(w.Bat.Scale),
+	}
+	if len(h.frames) == 0 {
+		return h
+	}
+
+	// Follow the balls in the air on from where they were
+	type flight struct{ position, velocity geometry.Vector }
+	var flights []*flight
+	for _, b := range w.Balls {
+		if b.Active && b.IsHit {
+			flights = append(flights, &flight{position: b.Position, velocity: b.Velocity})
+		}
+	}
+
+	last := h.frames[len(h.frames)-1]
+	for range highlightFlightTicks {
+		frame := highlightFrame{batPosition: last.batPosition, batAngle: last.batAngle}
+		for _, f := range flights {
+			f.velocity.Y += w.Gravity()
+			f.position = f.position.Add(f.velocity)
+			frame.balls = append(frame.balls, f.position)
+		}
+		h.frames = append(h.frames, frame)
+	}
+
+	return h
+}
+
+func (h *highlight) update() {
+	if h.tick < len(h.frames)-1 {
+		h.tick++
+	}
+}
+
+func (h *highlight) done() bool {
+	return h.tick >= len(h.frames)-1
+}
+
+func (h *highlight) draw(screen *ebiten.Image, s *sim.Stumps) {
+	drawStumps(screen, s)
+	if len(h.frames) == 0 {
+		return
+	}
+	h.frames[h.tick].draw(screen, h.bat)
+}
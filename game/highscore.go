@@ -1,15 +1,22 @@
 package game
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/meghashyamc/cricket2d/config"
 	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/persist"
 )
 
+// highScoreSchema is the layout of a high score file. A migration is added to it whenever
+// the layout changes.
+var highScoreSchema = persist.Schema{Name: "high score"}
+
 type HighScore struct {
 	Score int    `json:"score"`
 	Name  string `json:"name"`
@@ -21,14 +28,21 @@ type HighScoreManager struct {
 	logger    logger.Logger
 }
 
-func NewHighScoreManager(cfg *config.Config) (*HighScoreManager, error) {
+// NewHighScoreManager loads the high score kept under the given key. Each key has its own
+// file, with an empty key using the configured score file as it is.
+func NewHighScoreManager(cfg *config.Config, key string) (*HighScoreManager, error) {
 	logger := logger.New()
 	if err := os.MkdirAll(cfg.GetDataDir(), 0755); err != nil {
 		logger.Error("could not create data directory", "error", err)
 		return nil, err
 	}
 
-	scoreFilePath := filepath.Join(cfg.GetDataDir(), cfg.GetScoreFilename())
+	scoreFilename := cfg.GetScoreFilename()
+	if len(key) > 0 {
+		ext := filepath.Ext(scoreFilename)
+		scoreFilename = fmt.Sprintf("%s_%s%s", strings.TrimSuffix(scoreFilename, ext), key, ext)
+	}
+	scoreFilePath := filepath.Join(cfg.GetDataDir(), scoreFilename)
 
 	hsm := &HighScoreManager{
 		filePath: scoreFilePath,
@@ -46,16 +60,13 @@ func NewHighScoreManager(cfg *config.Config) (*HighScoreManager, error) {
 
 func (hsm *HighScoreManager) Load() {
 	hsm.logger.Debug("attempting to load high score", "file_path", hsm.filePath)
-	data, err := os.ReadFile(hsm.filePath)
-	if err != nil {
-		// File doesn't exist or can't be read, use default values
-		hsm.logger.Debug("high score file not found or unreadable, using defaults", "error", err)
-		return
-	}
-
 	var loadedScore HighScore
-	if err := json.Unmarshal(data, &loadedScore); err != nil {
-		hsm.logger.Debug("invalid JSON in high score file, using defaults", "error", err)
+	if err := highScoreSchema.Load(hsm.filePath, &loadedScore); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			hsm.logger.Debug("high score file not found, using defaults")
+		} else {
+			hsm.logger.Warn("could not load high score, using defaults", "error", err)
+		}
 		return
 	}
 
@@ -65,14 +76,7 @@ func (hsm *HighScoreManager) Load() {
 
 func (hsm *HighScoreManager) Save() error {
 	hsm.logger.Debug("attempting to save high score", "score", hsm.highScore.Score, "name", hsm.highScore.Name)
-	data, err := json.Marshal(hsm.highScore)
-	if err != nil {
-		hsm.logger.Debug("failed to marshal high score", "error", err)
-		return err
-	}
-
-	err = os.WriteFile(hsm.filePath, data, 0644)
-	if err != nil {
+	if err := highScoreSchema.Save(hsm.filePath, hsm.highScore); err != nil {
 		hsm.logger.Debug("failed to write high score file", "error", err)
 		return err
 	}
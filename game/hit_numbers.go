@@ -0,0 +1,109 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	hitNumberTicks    = ebiten.DefaultTPS // How long a number takes to rise and fade away
+	hitNumberRise     = 1.2               // Pixels a tick
+	hitNumberPoolSize = 8                 // Numbers on screen at once, the oldest making way for new ones
+	hitNumberTexts    = 37                // Runs a shot can be worth and still have its text made up front
+)
+
+var (
+	hitNumberPerfect  = color.RGBA{255, 215, 0, 255}
+	hitNumberOnTime   = color.RGBA{255, 255, 255, 255}
+	hitNumberMistimed = color.RGBA{170, 170, 170, 255}
+)
+
+// hitNumberText is "+0", "+1" and so on, made once so that showing them doesn't allocate
+var hitNumberText = func() []string {
+	texts := make([]string, hitNumberTexts)
+	for runs := range texts {
+		texts[runs] = fmt.Sprintf("+%d", runs)
+	}
+	return texts
+}()
+
+// hitNumber is the runs a shot scored, rising and fading from where the bat met the ball
+type hitNumber struct {
+	text      string
+	position  geometry.Vector // On screen
+	scale     float64
+	color     color.RGBA
+	ticksLeft int
+}
+
+// hitNumbers is a fixed pool of hit numbers, reused oldest first
+type hitNumbers struct {
+	pool [hitNumberPoolSize]hitNumber
+	next int
+}
+
+// show sets a number rising from the contact point, bigger and brighter the better the shot
+// was timed
+func (h *hitNumbers) show(runs int, at geometry.Vector, timing stats.Timing, edged bool) {
+	n := &h.pool[h.next]
+	h.next = (h.next + 1) % len(h.pool)
+
+	if runs < len(hitNumberText) {
+		n.text = hitNumberText[runs]
+	} else {
+		n.text = fmt.Sprintf("+%d", runs)
+	}
+	n.position = at
+	n.ticksLeft = hitNumberTicks
+	switch {
+	case edged:
+		n.scale, n.color = 1, hitNumberMistimed
+	case timing == stats.TimingPerfect:
+		n.scale, n.color = 2, hitNumberPerfect
+	case timing == stats.TimingOnTime:
+		n.scale, n.color = 1.5, hitNumberOnTime
+	default:
+		n.scale, n.color = 1, hitNumberMistimed
+	}
+}
+
+func (h *hitNumbers) update() {
+	for i := range h.pool {
+		n := &h.pool[i]
+		if n.ticksLeft > 0 {
+			n.ticksLeft--
+			n.position.Y -= hitNumberRise
+		}
+	}
+}
+
+func (h *hitNumbers) reset() {
+	for i := range h.pool {
+		h.pool[i].ticksLeft = 0
+	}
+}
+
+// showHitNumber pops the runs of the shot just played off the bat
+func (g *Game) showHitNumber() {
+	w := g.world
+	contact := w.LastContact
+	if contact.Runs <= 0 {
+		return
+	}
+	g.hitNumbers.show(contact.Runs, g.camera.toScreen(contact.At), w.LastTiming.Kind(), contact.Edged)
+}
+
+func (g *Game) drawHitNumbers(screen *ebiten.Image) {
+	for i := range g.hitNumbers.pool {
+		n := &g.hitNumbers.pool[i]
+		if n.ticksLeft <= 0 {
+			continue
+		}
+		c := color.NRGBA{R: n.color.R, G: n.color.G, B: n.color.B, A: uint8(255 * n.ticksLeft / hitNumberTicks)}
+		g.drawText(screen, n.text, n.position.X, n.position.Y, n.scale, n.scale, c)
+	}
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"maps"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const assetPollTicks = ebiten.DefaultTPS // How often the asset pack is checked for changes
+
+// assetWatcher notices changes to the asset pack while the game runs, so that artists can
+// see their work without restarting
+type assetWatcher struct {
+	dir      string
+	modTimes map[string]time.Time // When each file in the pack last changed
+	ticks    int
+}
+
+// startAssetWatcher watches the asset pack for changes, if hot reloading is turned on
+func (g *Game) startAssetWatcher() {
+	dir := g.cfg.GetAssetsDir()
+	if !g.cfg.GetHotReload() || len(dir) == 0 {
+		return
+	}
+
+	g.assetWatcher = &assetWatcher{dir: dir, modTimes: packModTimes(dir)}
+	g.logger.Info("watching asset pack for changes", "dir", dir)
+}
+
+// packModTimes reads when each file in the pack last changed. An unreadable pack looks empty.
+func packModTimes(dir string) map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return modTimes
+	}
+
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && !entry.IsDir() {
+			modTimes[entry.Name()] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+// checkAssetReload reloads the asset pack if any of its files have changed
+func (g *Game) checkAssetReload() {
+	aw := g.assetWatcher
+	if aw == nil {
+		return
+	}
+
+	aw.ticks++
+	if aw.ticks < assetPollTicks {
+		return
+	}
+	aw.ticks = 0
+
+	modTimes := packModTimes(aw.dir)
+	if maps.Equal(modTimes, aw.modTimes) {
+		return
+	}
+	aw.modTimes = modTimes
+
+	assets.LoadPack(aw.dir)
+	g.refreshSprites()
+	g.logger.Info("asset pack reloaded", "dir", aw.dir, "problems", len(assets.Problems()))
+}
+
+// refreshSprites drops everything scaled from the old sprites, sizes the field to the
+// freshly loaded ones and has the font picked up again
+func (g *Game) refreshSprites() {
+	clear(batSprites)
+	clear(scaledSprites)
+	sim.SetSizes(spriteSizes())
+
+	clear(g.labels)
+	g.hud.lines = nil
+}
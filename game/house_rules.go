@@ -0,0 +1,168 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// houseRuleRow is a rule on the house rules screen, and how to show and change it
+type houseRuleRow struct {
+	name   string
+	value  func(r sim.HouseRules) string
+	change func(r *sim.HouseRules, by int)
+}
+
+var houseRuleRows = []houseRuleRow{
+	{
+		name:  "Balls per over",
+		value: func(r sim.HouseRules) string { return fmt.Sprint(r.BallsPerOver) },
+		change: func(r *sim.HouseRules, by int) {
+			r.BallsPerOver = clampValue(r.BallsPerOver+by, sim.MinBallsPerOver, sim.MaxBallsPerOver)
+		},
+	},
+	{
+		name:   "Wides",
+		value:  func(r sim.HouseRules) string { return onOff(r.Wides) },
+		change: func(r *sim.HouseRules, by int) { r.Wides = !r.Wides },
+	},
+	{
+		name:   "No balls",
+		value:  func(r sim.HouseRules) string { return onOff(r.NoBalls) },
+		change: func(r *sim.HouseRules, by int) { r.NoBalls = !r.NoBalls },
+	},
+	{
+		name:   "Runs for a shot along the ground",
+		value:  func(r sim.HouseRules) string { return fmt.Sprint(r.GroundRuns) },
+		change: func(r *sim.HouseRules, by int) { r.GroundRuns = clampValue(r.GroundRuns+by, 1, sim.MaxShotRuns) },
+	},
+	{
+		name:   "Runs for a lofted shot",
+		value:  func(r sim.HouseRules) string { return fmt.Sprint(r.LoftedRuns) },
+		change: func(r *sim.HouseRules, by int) { r.LoftedRuns = clampValue(r.LoftedRuns+by, 1, sim.MaxShotRuns) },
+	},
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// houseRulesScreen is the house rules screen: the rules as they are being changed, and
+// which is picked
+type houseRulesScreen struct {
+	rules  sim.HouseRules
+	cursor int
+}
+
+// startHouseRules plays the match by the rules the profile last played by, unless a
+// challenge code is being played, which comes with the standard ones
+func (g *Game) startHouseRules() {
+	rules := g.profileManager.HouseRules()
+	if len(g.cfg.GetChallengeCode()) > 0 {
+		rules = sim.StandardRules
+	}
+	g.world.SetHouseRules(rules)
+	if !rules.Standard() {
+		g.logger.Info("playing by house rules", "rules", rules)
+	}
+}
+
+// toggleHouseRules opens the house rules screen from the pause screen, and goes back to it
+// without changing anything
+func (g *Game) toggleHouseRules() {
+	if !g.keyJustPressed(ebiten.KeyO) && !(g.state == GameStateHouseRules && g.keyJustPressed(ebiten.KeyEscape)) {
+		return
+	}
+
+	switch g.state {
+	case GameStatePaused:
+		g.houseRules = &houseRulesScreen{rules: g.world.Rules}
+		g.state = GameStateHouseRules
+	case GameStateHouseRules:
+		g.houseRules = nil
+		g.state = GameStatePaused
+	}
+}
+
+func (g *Game) updateHouseRules() {
+	s := g.houseRules
+	if s == nil {
+		return
+	}
+
+	switch {
+	case g.keyJustPressed(ebiten.KeyUp):
+		s.cursor = (s.cursor + len(houseRuleRows) - 1) % len(houseRuleRows)
+	case g.keyJustPressed(ebiten.KeyDown):
+		s.cursor = (s.cursor + 1) % len(houseRuleRows)
+	case g.keyJustPressed(ebiten.KeyLeft):
+		houseRuleRows[s.cursor].change(&s.rules, -1)
+	case g.keyJustPressed(ebiten.KeyRight), g.keyJustPressed(ebiten.KeySpace):
+		houseRuleRows[s.cursor].change(&s.rules, 1)
+	case g.keyJustPressed(ebiten.KeyBackspace):
+		s.rules = sim.StandardRules
+	case g.keyJustPressed(ebiten.KeyEnter):
+		if err := g.profileManager.SetHouseRules(s.rules); err != nil {
+			g.logger.Warn("could not save house rules", "error", err)
+		}
+		g.world.SetHouseRules(s.rules)
+		g.houseRules = nil
+		g.reset()
+		g.logger.Info("house rules changed", "rules", s.rules)
+	}
+}
+
+// houseRulesHUD owns up to house rules on the scoreboard, as scores made with them don't
+// count
+func (g *Game) houseRulesHUD() (string, bool) {
+	r := g.world.Rules
+	if r.Standard() {
+		return "", false
+	}
+	return fmt.Sprintf("House rules: %d-ball overs, wides %s, no balls %s, %d/%d a shot (unranked)",
+		r.BallsPerOver, onOff(r.Wides), onOff(r.NoBalls), r.GroundRuns, r.LoftedRuns), true
+}
+
+func (g *Game) drawHouseRules(screen *ebiten.Image) {
+	const (
+		titleX       float64 = 20
+		titleY       float64 = 30
+		instructionX float64 = 20
+		instructionY float64 = 70
+		rowsX        float64 = 20
+		rowsY        float64 = 130
+		rowSpacing   float64 = 40
+	)
+
+	s := g.houseRules
+	if s == nil {
+		return
+	}
+
+	g.drawText(screen, "HOUSE RULES", titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+	g.drawText(screen, "Up/Down to move, Left/Right to change, Backspace for the standard rules, Enter to play by them, O to go back",
+		instructionX, instructionY, 0.8, 0.8, color.White)
+
+	for i, row := range houseRuleRows {
+		cursor := "  "
+		if i == s.cursor {
+			cursor = "> "
+		}
+		g.drawText(screen, fmt.Sprintf("%s%s: %s", cursor, row.name, row.value(s.rules)), rowsX, rowsY+float64(i)*rowSpacing, 1, 1, color.White)
+	}
+
+	var (
+		noteX float64 = 20
+		noteY float64 = rowsY + float64(len(houseRuleRows))*rowSpacing + 20
+	)
+	note := "These are the standard rules"
+	if !s.rules.Standard() {
+		note = "Scores under house rules don't count towards high scores"
+	}
+	g.drawText(screen, note, noteX, noteY, 1, 1, color.RGBA{150, 150, 150, 255})
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"path/filepath"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/persist"
+)
+
+const (
+	bytesPerMB   = 1 << 20
+	hoursPerDay  = 24
+	dataRowSpace = 50
+)
+
+// keptData is a kind of file the game keeps adding to the data directory. Each kind is held
+// to the retention limits, and can be cleared from the manage data screen.
+type keptData struct {
+	name    string
+	dir     string // Under the data directory
+	pattern string
+}
+
+var keptDataKinds = []keptData{
+	{name: "Ball-by-ball logs", dir: ballByBallDir, pattern: "*.json"},
+	{name: "Result cards", dir: resultCardDir, pattern: "*.png"},
+	{name: "Screenshots", dir: screenshotDir, pattern: "*.png"},
+	{name: "Replays", dir: replayDir, pattern: "*" + replayExt},
+	{name: "Unreadable saves, moved aside", pattern: "*.corrupt-*"},
+	{name: "Saves from before an upgrade", pattern: "*.bak"},
+}
+
+// manageData is the manage data screen: what each kind of kept file is using, and which
+// kind is picked
+type manageData struct {
+	usage      []persist.Usage
+	cursor     int
+	confirming bool // Delete has been pressed once on the picked kind
+}
+
+func (g *Game) keptDataDir(kind keptData) string {
+	return filepath.Join(g.cfg.GetDataDir(), kind.dir)
+}
+
+// housekeeping deletes the oldest kept files of each kind past the retention limits, so
+// that the data directory doesn't grow without end
+func (g *Game) housekeeping() {
+	retention := persist.Retention{
+		MaxFiles: g.cfg.GetRetentionMaxFiles(),
+		MaxBytes: int64(g.cfg.GetRetentionMaxMB()) * bytesPerMB,
+		MaxAge:   time.Duration(g.cfg.GetRetentionMaxAgeDays()) * hoursPerDay * time.Hour,
+	}
+
+	for _, kind := range keptDataKinds {
+		deleted, err := persist.Prune(g.keptDataDir(kind), kind.pattern, retention, time.Now())
+		if err != nil {
+			g.logger.Warn("could not clean up kept files", "kind", kind.name, "error", err)
+		}
+		if deleted.Files > 0 {
+			g.logger.Info("cleaned up kept files", "kind", kind.name, "files", deleted.Files, "bytes", deleted.Bytes)
+		}
+	}
+}
+
+// toggleManageData opens the manage data screen from the pause screen, and goes back to it
+func (g *Game) toggleManageData() {
+	if !g.keyJustPressed(ebiten.KeyD) && !(g.state == GameStateManageData && g.keyJustPressed(ebiten.KeyEscape)) {
+		return
+	}
+
+	switch g.state {
+	case GameStatePaused:
+		g.manageData = &manageData{}
+		g.measureData()
+		g.state = GameStateManageData
+	case GameStateManageData:
+		g.manageData = nil
+		g.state = GameStatePaused
+	}
+}
+
+// measureData finds what each kind of kept file is using
+func (g *Game) measureData() {
+	m := g.manageData
+	m.usage = make([]persist.Usage, len(keptDataKinds))
+	for i, kind := range keptDataKinds {
+		usage, err := persist.DirUsage(g.keptDataDir(kind), kind.pattern)
+		if err != nil {
+			g.logger.Warn("could not measure kept files", "kind", kind.name, "error", err)
+		}
+		m.usage[i] = usage
+	}
+}
+
+func (g *Game) updateManageData() {
+	m := g.manageData
+	if m == nil {
+		return
+	}
+
+	switch {
+	case g.keyJustPressed(ebiten.KeyUp):
+		m.cursor = (m.cursor + len(keptDataKinds) - 1) % len(keptDataKinds)
+		m.confirming = false
+	case g.keyJustPressed(ebiten.KeyDown):
+		m.cursor = (m.cursor + 1) % len(keptDataKinds)
+		m.confirming = false
+	case g.keyJustPressed(ebiten.KeyDelete), g.keyJustPressed(ebiten.KeyBackspace):
+		if !m.confirming {
+			m.confirming = m.usage[m.cursor].Files > 0
+			return
+		}
+		kind := keptDataKinds[m.cursor]
+		deleted, err := persist.Clear(g.keptDataDir(kind), kind.pattern)
+		if err != nil {
+			g.logger.Warn("could not delete kept files", "kind", kind.name, "error", err)
+		}
+		g.logger.Info("deleted kept files", "kind", kind.name, "files", deleted.Files, "bytes", deleted.Bytes)
+		m.confirming = false
+		g.measureData()
+	}
+}
+
+func (g *Game) drawManageData(screen *ebiten.Image) {
+	const (
+		titleX       float64 = 20
+		titleY       float64 = 30
+		instructionX float64 = 20
+		instructionY float64 = 70
+		dirY         float64 = 100
+		rowsX        float64 = 20
+		rowsY        float64 = 150
+	)
+
+	m := g.manageData
+	if m == nil {
+		return
+	}
+
+	g.drawText(screen, "MANAGE DATA", titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+	g.drawText(screen, "Up/Down to move, Delete to delete, D to go back", instructionX, instructionY, 1, 1, color.White)
+	g.drawText(screen, g.cfg.GetDataDir(), instructionX, dirY, 0.7, 0.7, color.RGBA{150, 150, 150, 255})
+
+	var total persist.Usage
+	for i, kind := range keptDataKinds {
+		usage := m.usage[i]
+		total.Files += usage.Files
+		total.Bytes += usage.Bytes
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		row := fmt.Sprintf("%s%s: %d files, %s", cursor, kind.name, usage.Files, formatBytes(usage.Bytes))
+		if i == m.cursor && m.confirming {
+			row += " - press Delete again to delete them"
+		}
+		g.drawText(screen, row, rowsX, rowsY+float64(i)*dataRowSpace, 1, 1, color.White)
+	}
+
+	totalY := rowsY + float64(len(keptDataKinds))*dataRowSpace + 20
+	g.drawText(screen, fmt.Sprintf("Total: %d files, %s", total.Files, formatBytes(total.Bytes)), rowsX, totalY, 1, 1, color.RGBA{150, 150, 150, 255})
+}
+
+// formatBytes writes a size the way a player would read it
+func formatBytes(bytes int64) string {
+	switch {
+	case bytes >= bytesPerMB:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/bytesPerMB)
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%d bytes", bytes)
+	}
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/meghashyamc/cricket2d/assets"
+)
+
+const (
+	hudX           float64 = 20
+	hudY           float64 = 30
+	hudLineSpacing float64 = 30
+)
+
+// hudLayer holds the HUD's lines of text drawn onto an image of their own. Laying out text
+// costs more than drawing an image, so the lines are only laid out again when one changes.
+type hudLayer struct {
+	image *ebiten.Image
+	lines []string // What the image shows
+}
+
+// draw shows the lines down the top left of the screen, redrawing them only if they differ
+// from the last frame's
+func (h *hudLayer) draw(g *Game, screen *ebiten.Image, lines []string) {
+	bounds := screen.Bounds()
+	if h.image == nil || h.image.Bounds() != bounds {
+		h.image = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+		h.lines = nil
+	}
+
+	if h.lines == nil || !slices.Equal(h.lines, lines) {
+		h.image.Clear()
+		for i, line := range lines {
+			g.drawText(h.image, line, hudX, hudY+float64(i)*hudLineSpacing, 1, 1, color.White)
+		}
+		h.lines = slices.Clone(lines)
+	}
+
+	screen.DrawImage(h.image, nil)
+}
+
+// drawAssetProblems lists any sprites that couldn't be loaded, so that whoever made the
+// asset pack can see what to fix
+func (g *Game) drawAssetProblems(screen *ebiten.Image) {
+	const (
+		problemsX       float64 = 20
+		problemsY       float64 = 120
+		problemsSpacing float64 = 25
+	)
+
+	for i, problem := range assets.Problems() {
+		g.drawText(screen, problem.Error(), problemsX, problemsY+float64(i)*problemsSpacing, 1, 1, color.RGBA{255, 0, 255, 255})
+	}
+}
+
+// labelKey identifies a rendered label by its text and colour
+type labelKey struct {
+	text  string
+	color color.RGBA
+}
+
+// drawLabel draws text that stays the same from frame to frame, such as instructions,
+// laying it out once and reusing the image after that
+func (g *Game) drawLabel(screen *ebiten.Image, label string, posX, posY float64, textColor color.Color) {
+	r, gr, b, a := textColor.RGBA()
+	key := labelKey{text: label, color: color.RGBA{uint8(r >> 8), uint8(gr >> 8), uint8(b >> 8), uint8(a >> 8)}}
+
+	image, ok := g.labels[key]
+	if !ok {
+		width, height := text.Measure(label, assets.ScoreFont, assets.ScoreFont.Size)
+		image = ebiten.NewImage(max(int(math.Ceil(width)), 1), max(int(math.Ceil(height)), 1))
+		g.drawText(image, label, 0, 0, 1, 1, textColor)
+		g.labels[key] = image
+	}
+
+	options := &ebiten.DrawImageOptions{}
+	options.GeoM.Translate(posX, posY)
+	screen.DrawImage(image, options)
+}
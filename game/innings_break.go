@@ -0,0 +1,207 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	inningsBreakMinOvers   = 2   // Innings shorter than this go straight through without a halfway break
+	inningsBreakReplayView = 0.4 // Scale of the best shot replay
+)
+
+// bestShotRecorder keeps every tick of the best shot of the innings so far, so that it can
+// be shown again at the break
+type bestShotRecorder struct {
+	number int // Of the ball the frames are of, 0 if there are none
+	frames []highlightFrame
+}
+
+// record takes a copy of the last delivery once it is over, if it was the best shot so far
+func (r *bestShotRecorder) record(w *sim.World, d *deliveryRecorder) {
+	best, ok := w.Innings.BestShot()
+	if !ok || best.Number == r.number || best.Number != d.bowled-1 || len(d.last) == 0 {
+		return
+	}
+	r.number = best.Number
+	r.frames = append(r.frames[:0], d.last...)
+}
+
+// bestShot is the best shot of the innings so far, which may be the delivery just bowled
+func (r *bestShotRecorder) bestShot(w *sim.World, d *deliveryRecorder) (stats.BallEvent, []highlightFrame, bool) {
+	best, ok := w.Innings.BestShot()
+	switch {
+	case !ok:
+		return stats.BallEvent{}, nil, false
+	case best.Number == d.bowled:
+		return best, append([]highlightFrame(nil), d.current...), true
+	case best.Number == r.number:
+		return best, append([]highlightFrame(nil), r.frames...), true
+	}
+	return best, nil, true
+}
+
+func (r *bestShotRecorder) reset() {
+	r.number = 0
+	r.frames = r.frames[:0]
+}
+
+// inningsBreak is the analysis shown halfway through a limited overs innings, or between
+// the innings of a versus match
+type inningsBreak struct {
+	title     string
+	score     int
+	balls     int // Legal balls bowled
+	ballsLeft int
+	overLen   int
+	events    []stats.BallEvent
+	best      stats.BallEvent
+	hasBest   bool
+	replay    *instantReplay // nil if the best shot's frames weren't kept
+	then      func()         // Carries on with the match once the break is over
+}
+
+// runRate is the runs scored an over so far
+func (b *inningsBreak) runRate() float64 {
+	if b.balls == 0 {
+		return 0
+	}
+	return float64(b.score) * float64(b.overLen) / float64(b.balls)
+}
+
+// projected is the score the innings ends on if the rest of it goes at the same rate
+func (b *inningsBreak) projected() int {
+	return b.score + int(math.Round(b.runRate()*float64(b.ballsLeft)/float64(b.overLen)))
+}
+
+// halfwayBreakDue is true once half the overs are bowled and the ball is dead, in an
+// innings long enough to have a break
+func (g *Game) halfwayBreakDue() bool {
+	w := g.world
+	return !w.BreakTaken && g.practiceScript == nil &&
+		w.MaxBalls >= inningsBreakMinOvers*w.Rules.BallsPerOver &&
+		w.LegalBalls() >= w.MaxBalls/2 && len(w.Balls) == 0
+}
+
+// startInningsBreak stops the match to show how the innings has gone, and calls then once
+// the player is ready to go on. Nothing is projected for an innings that is over.
+func (g *Game) startInningsBreak(title string, over bool, then func()) {
+	w := g.world
+	w.BreakTaken = true
+
+	b := &inningsBreak{
+		title:     title,
+		score:     w.Score,
+		balls:     w.LegalBalls(),
+		ballsLeft: max(w.MaxBalls-w.LegalBalls(), 0),
+		overLen:   w.Rules.BallsPerOver,
+		events:    append([]stats.BallEvent(nil), w.Innings.Events()...),
+		then:      then,
+	}
+	if over {
+		b.ballsLeft = 0
+	}
+	var frames []highlightFrame
+	b.best, frames, b.hasBest = g.bestShots.bestShot(w, g.deliveries)
+	if len(frames) > 0 {
+		b.replay = &instantReplay{frames: frames, bat: batSprite(w.Bat.Scale)}
+	}
+
+	g.inningsBreak = b
+	g.instantReplay = nil
+	g.userMessage = ""
+	g.state = GameStateInningsBreak
+	g.logger.Info("innings break", "title", title, "score", b.score, "balls", b.balls, "projected", b.projected())
+}
+
+// updateInningsBreak loops the best shot until Enter carries on with the match
+func (g *Game) updateInningsBreak() {
+	b := g.inningsBreak
+	if b == nil {
+		return
+	}
+
+	if r := b.replay; r != nil {
+		r.tick = (r.tick + 1) % len(r.frames)
+	}
+
+	if g.keyJustPressed(ebiten.KeyEnter) {
+		if b.replay != nil && b.replay.view != nil {
+			b.replay.view.Deallocate()
+		}
+		g.inningsBreak = nil
+		g.state = GameStatePlaying
+		b.then()
+	}
+}
+
+func (g *Game) drawInningsBreak(screen *ebiten.Image) {
+	const (
+		titleX      float64 = 20
+		titleY      float64 = 30
+		linesX      float64 = 20
+		linesY      float64 = 90
+		lineSpacing float64 = 30
+	)
+
+	b := g.inningsBreak
+	if b == nil {
+		return
+	}
+
+	g.drawText(screen, b.title, titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+
+	lines := []string{
+		fmt.Sprintf("Score: %d off %s overs", b.score, sim.FormatOvers(b.balls, b.overLen)),
+		fmt.Sprintf("Run rate: %.2f an over", b.runRate()),
+	}
+	if b.ballsLeft > 0 {
+		lines = append(lines, fmt.Sprintf("Projected score: %d, at this rate over the last %s overs", b.projected(), sim.FormatOvers(b.ballsLeft, b.overLen)))
+	}
+	if b.hasBest {
+		lines = append(lines, "Best shot: "+bestShotText(b.best))
+	} else {
+		lines = append(lines, "Best shot: nothing hit yet")
+	}
+	for i, line := range lines {
+		g.drawText(screen, line, linesX, linesY+float64(i)*lineSpacing, 1, 1, color.White)
+	}
+
+	var (
+		wheelX = float32(linesX) + wagonWheelRadius
+		wheelY = float32(linesY+float64(len(lines))*lineSpacing) + wagonWheelRadius + 20
+	)
+	drawWagonWheel(screen, b.events, wheelX, wheelY)
+	g.drawBestShotReplay(screen, float64(wheelX)+wagonWheelRadius+40, float64(wheelY)-wagonWheelRadius)
+
+	g.drawText(screen, "Press Enter to carry on", linesX, g.cfg.GetWindowHeight()-60, 1, 1, color.RGBA{150, 150, 150, 255})
+}
+
+// drawBestShotReplay loops the best shot at the break, with its top left corner at x, y
+func (g *Game) drawBestShotReplay(screen *ebiten.Image, x, y float64) {
+	r := g.inningsBreak.replay
+	if r == nil {
+		return
+	}
+
+	view := g.world.View()
+	if r.view == nil {
+		r.view = ebiten.NewImage(int(view.Width), int(view.Height))
+	}
+	r.view.Fill(instantReplayBackground)
+	drawStumps(r.view, g.world.Stumps)
+	r.frames[r.tick].draw(r.view, r.bat)
+	vector.StrokeRect(r.view, 0, 0, float32(view.Width), float32(view.Height), 6, color.White, false)
+
+	op := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+	op.GeoM.Scale(inningsBreakReplayView, inningsBreakReplayView)
+	op.GeoM.Translate(x, y)
+	screen.DrawImage(r.view, op)
+	g.drawText(screen, "BEST SHOT", x+8, y+8, 0.7, 0.7, color.RGBA{255, 255, 0, 255})
+}
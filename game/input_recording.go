@@ -0,0 +1,50 @@
+package game
+
+import (
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// inputRecording is the bat input for every tick of play, in order, kept as a replay keeps
+// it. The world only moves on from its input, so feeding a recording back against the same
+// deliveries plays the same shots.
+type inputRecording struct {
+	inputs []replay.Input
+}
+
+func (r *inputRecording) record(input sim.BatInput) {
+	r.inputs = append(r.inputs, sim.ReplayInput(input))
+}
+
+func (r *inputRecording) ticks() int {
+	return len(r.inputs)
+}
+
+// at is the input recorded on the given tick. Before the first tick the bat waits where it
+// starts, and after the last it stays where it finished.
+func (r *inputRecording) at(tick int) sim.BatInput {
+	if len(r.inputs) == 0 {
+		return sim.BatInput{}
+	}
+	if tick < 0 {
+		return sim.BatInput{Cursor: sim.RecordedInput(r.inputs[0]).Cursor}
+	}
+	if tick >= len(r.inputs) {
+		return sim.BatInput{Cursor: sim.RecordedInput(r.inputs[len(r.inputs)-1]).Cursor}
+	}
+	return sim.RecordedInput(r.inputs[tick])
+}
+
+// inputPlayback feeds a recording back a tick at a time. A positive offset plays every
+// input that many ticks later than it was recorded, and a negative one earlier.
+type inputPlayback struct {
+	recording *inputRecording
+	tick      int
+	offset    int
+}
+
+func (p *inputPlayback) next() sim.BatInput {
+	input := p.recording.at(p.tick - p.offset)
+	p.tick++
+	return input
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	instantReplaySpeed    = 2                      // Recorded ticks shown every tick
+	instantReplayMaxTicks = ebiten.DefaultTPS * 10 // The most of a delivery kept
+	instantReplayScale    = 0.3                    // Of the view
+	instantReplayMargin   = 20                     // Gap between the replay and the edge of the screen
+	instantReplayBelow    = hawkEyeViewSize + 40   // How far down the replay goes to keep clear of Hawk-Eye
+)
+
+var instantReplayBackground = color.RGBA{0, 40, 0, 255}
+
+// deliveryRecorder keeps every tick of the delivery in play and of the one before it, so
+// that the last delivery can be watched again without stopping the match
+type deliveryRecorder struct {
+	bowled  int // Balls bowled when the delivery in play came out
+	current []highlightFrame
+	last    []highlightFrame
+}
+
+func (r *deliveryRecorder) record(w *sim.World) {
+	if w.BallsBowled != r.bowled {
+		r.bowled = w.BallsBowled
+		r.last, r.current = r.current, r.last[:0]
+	}
+	if len(r.current) < instantReplayMaxTicks {
+		r.current = append(r.current, newHighlightFrame(w))
+	}
+}
+
+func (r *deliveryRecorder) reset() {
+	r.bowled = 0
+	r.current = r.current[:0]
+	r.last = r.last[:0]
+}
+
+// lastDelivery is the delivery in play once it is dead, and the one before it while it is
+// still live
+func (r *deliveryRecorder) lastDelivery(w *sim.World) []highlightFrame {
+	for _, b := range w.Balls {
+		if b.Active {
+			return r.last
+		}
+	}
+	return r.current
+}
+
+// instantReplay plays the last delivery again at double speed in the corner of the screen,
+// while the match goes on behind it
+type instantReplay struct {
+	frames []highlightFrame
+	bat    *ebiten.Image
+	view   *ebiten.Image
+	tick   int
+}
+
+// updateInstantReplay starts the replay when R is pressed without Ctrl, and moves it on
+func (g *Game) updateInstantReplay() {
+	if g.keyJustPressed(ebiten.KeyR) && !ebiten.IsKeyPressed(ebiten.KeyControl) {
+		if frames := g.deliveries.lastDelivery(g.world); len(frames) > 0 {
+			g.instantReplay = &instantReplay{
+				frames: append([]highlightFrame(nil), frames...),
+				bat:    batSprite(g.world.Bat.Scale),
+			}
+		}
+	}
+
+	r := g.instantReplay
+	if r == nil {
+		return
+	}
+	r.tick += instantReplaySpeed
+	if r.tick >= len(r.frames) {
+		if r.view != nil {
+			r.view.Deallocate()
+		}
+		g.instantReplay = nil
+	}
+}
+
+// drawInstantReplay shows the replay in the top right corner, under Hawk-Eye if it is up
+func (g *Game) drawInstantReplay(screen *ebiten.Image) {
+	r := g.instantReplay
+	if r == nil {
+		return
+	}
+
+	view := g.world.View()
+	if r.view == nil {
+		r.view = ebiten.NewImage(int(view.Width), int(view.Height))
+	}
+	r.view.Fill(instantReplayBackground)
+	drawStumps(r.view, g.world.Stumps)
+	r.frames[min(r.tick, len(r.frames)-1)].draw(r.view, r.bat)
+	vector.StrokeRect(r.view, 0, 0, float32(view.Width), float32(view.Height), 6, color.White, false)
+
+	x := g.cfg.GetWindowWidth() - view.Width*instantReplayScale - instantReplayMargin
+	y := float64(instantReplayMargin)
+	if h := g.world.HawkEye; h != nil && h.Visible() {
+		y += instantReplayBelow
+	}
+	op := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+	op.GeoM.Scale(instantReplayScale, instantReplayScale)
+	op.GeoM.Translate(x, y)
+	screen.DrawImage(r.view, op)
+	g.drawText(screen, "REPLAY", x+8, y+8, 0.7, 0.7, color.RGBA{255, 255, 0, 255})
+}
@@ -0,0 +1,252 @@
+package game
+
+import (
+	"image/color"
+	"os"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	keyboardKeySize         = 40 // Pixels a side of a key
+	keyboardKeyGap          = 4
+	keyboardMargin          = 20 // Between the keyboard and the bottom of the window
+	defaultKeyboardLanguage = "en"
+)
+
+var (
+	keyboardKeyColor   = color.RGBA{60, 60, 60, 255}
+	keyboardFocusColor = color.RGBA{255, 255, 0, 255}
+)
+
+// keyboardLayouts are the rows of characters on the on-screen keyboard for each language,
+// as its hardware keyboards have them
+var keyboardLayouts = map[string][]string{
+	"en": {"1234567890", "QWERTYUIOP", "ASDFGHJKL-", "ZXCVBNM_."},
+	"fr": {"1234567890", "AZERTYUIOP", "QSDFGHJKLM", "WXCVBN-_."},
+	"de": {"1234567890", "QWERTZUIOP", "ASDFGHJKL-", "YXCVBNM_."},
+}
+
+// keyAction is what picking a key of the on-screen keyboard does
+type keyAction int
+
+const (
+	keyType keyAction = iota
+	keySpace
+	keyCase // Switches between capitals and small letters
+	keyErase
+	keyDone
+)
+
+type keyboardKey struct {
+	label  string
+	action keyAction
+	width  int // In keys
+}
+
+// onScreenKeyboard types names with the D-pad and A, or by touching or clicking its keys,
+// for players without a keyboard to hand
+type onScreenKeyboard struct {
+	rows     [][]keyboardKey
+	row, col int // The key with the focus
+	lower    bool
+}
+
+func newOnScreenKeyboard(locale string) *onScreenKeyboard {
+	k := &onScreenKeyboard{}
+	for _, chars := range keyboardLayouts[keyboardLanguage(locale)] {
+		var row []keyboardKey
+		for _, r := range chars {
+			row = append(row, keyboardKey{label: string(r), action: keyType, width: 1})
+		}
+		k.rows = append(k.rows, row)
+	}
+	k.rows = append(k.rows, []keyboardKey{
+		{label: "abc", action: keyCase, width: 2},
+		{label: "SPACE", action: keySpace, width: 4},
+		{label: "DEL", action: keyErase, width: 2},
+		{label: "DONE", action: keyDone, width: 2},
+	})
+	return k
+}
+
+// keyboardLanguage is the language of a locale such as fr_FR.UTF-8, or of the system's
+// locale if none is given, if there is a layout for it
+func keyboardLanguage(locale string) string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if len(locale) > 0 {
+			break
+		}
+		locale = os.Getenv(name)
+	}
+
+	language, _, _ := strings.Cut(strings.ToLower(locale), "_")
+	language, _, _ = strings.Cut(language, "-")
+	language, _, _ = strings.Cut(language, ".")
+	if _, ok := keyboardLayouts[language]; ok {
+		return language
+	}
+	return defaultKeyboardLanguage
+}
+
+// label is what a key shows, in small letters if the keyboard is switched to them
+func (k *onScreenKeyboard) label(key keyboardKey) string {
+	switch {
+	case key.action == keyType && k.lower:
+		return strings.ToLower(key.label)
+	case key.action == keyCase && k.lower:
+		return "ABC"
+	}
+	return key.label
+}
+
+// move moves the focus by rows and keys, wrapping around at the edges
+func (k *onScreenKeyboard) move(rows, keys int) {
+	k.row = (k.row + rows + len(k.rows)) % len(k.rows)
+	n := len(k.rows[k.row])
+	k.col = (min(k.col, n-1) + keys + n) % n
+}
+
+// press picks the key with the focus, typing it or pressing the key it stands for
+func (k *onScreenKeyboard) press(p *padInput) {
+	key := k.rows[k.row][k.col]
+	switch key.action {
+	case keyType:
+		p.typed = append(p.typed, []rune(k.label(key))...)
+	case keySpace:
+		p.typed = append(p.typed, ' ')
+	case keyCase:
+		k.lower = !k.lower
+	case keyErase:
+		p.pressed = append(p.pressed, ebiten.KeyBackspace)
+	case keyDone:
+		p.pressed = append(p.pressed, ebiten.KeyEnter)
+	}
+}
+
+// origin is the top left corner of the keyboard, at the bottom of the window in the middle
+func (k *onScreenKeyboard) origin(windowWidth, windowHeight float64) (float64, float64) {
+	widest := 0
+	for _, row := range k.rows {
+		width := 0
+		for _, key := range row {
+			width += key.width
+		}
+		widest = max(widest, width)
+	}
+	x := (windowWidth - float64(widest*(keyboardKeySize+keyboardKeyGap))) / 2
+	y := windowHeight - float64(len(k.rows)*(keyboardKeySize+keyboardKeyGap)) - keyboardMargin
+	return x, y
+}
+
+// keyAt finds the key under a point on the screen
+func (k *onScreenKeyboard) keyAt(x, y, originX, originY float64) (row, col int, ok bool) {
+	const pitch = keyboardKeySize + keyboardKeyGap
+	row = int((y - originY) / pitch)
+	if y < originY || row >= len(k.rows) {
+		return 0, 0, false
+	}
+
+	left := originX
+	for col, key := range k.rows[row] {
+		right := left + float64(key.width*pitch)
+		if x >= left && x < right-keyboardKeyGap {
+			return row, col, true
+		}
+		left = right
+	}
+	return 0, 0, false
+}
+
+// typingName is true while the player is asked to type a name
+func (g *Game) typingName() bool {
+	return g.state == GameStateNameInput || g.state == GameStateLoadouts && g.loadouts != nil && g.loadouts.naming
+}
+
+// showKeyboard is true if names are typed on the on-screen keyboard, which they are when
+// there is a gamepad or the screen has been touched, and there may be no keyboard
+func (g *Game) showKeyboard() bool {
+	return g.typingName() && (g.cfg.GetOnScreenKeyboard() || g.pad.connected() || g.pad.touched)
+}
+
+// updateKeyboard works the on-screen keyboard, ahead of the screen the name is typed on,
+// which takes what it types as if it had been typed on a keyboard
+func (g *Game) updateKeyboard() {
+	if !g.showKeyboard() {
+		return
+	}
+	if g.keyboard == nil {
+		g.keyboard = newOnScreenKeyboard(g.cfg.GetKeyboardLocale())
+	}
+
+	k := g.keyboard
+	switch {
+	case g.keyJustPressed(ebiten.KeyUp):
+		k.move(-1, 0)
+	case g.keyJustPressed(ebiten.KeyDown):
+		k.move(1, 0)
+	case g.keyJustPressed(ebiten.KeyLeft):
+		k.move(0, -1)
+	case g.keyJustPressed(ebiten.KeyRight):
+		k.move(0, 1)
+	case g.keyJustPressed(ebiten.KeyEnter):
+		// Enter types the key with the focus, rather than finishing the name
+		g.pad.used = append(g.pad.used, ebiten.KeyEnter)
+		k.press(g.pad)
+	}
+
+	var taps [][2]int
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		x, y := ebiten.TouchPosition(id)
+		taps = append(taps, [2]int{x, y})
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		taps = append(taps, [2]int{x, y})
+	}
+	originX, originY := k.origin(g.cfg.GetWindowWidth(), g.cfg.GetWindowHeight())
+	for _, tap := range taps {
+		if row, col, ok := k.keyAt(float64(tap[0]), float64(tap[1]), originX, originY); ok {
+			k.row, k.col = row, col
+			k.press(g.pad)
+		}
+	}
+}
+
+// inputChars is what has been typed on this tick, on the keyboard or the on-screen one
+func (g *Game) inputChars() []rune {
+	return append(ebiten.AppendInputChars(nil), g.pad.typed...)
+}
+
+// drawKeyboard draws the on-screen keyboard at the bottom of the window, if names are typed
+// on it
+func (g *Game) drawKeyboard(screen *ebiten.Image) {
+	if !g.showKeyboard() || g.keyboard == nil {
+		return
+	}
+
+	k := g.keyboard
+	originX, originY := k.origin(g.cfg.GetWindowWidth(), g.cfg.GetWindowHeight())
+	const pitch = keyboardKeySize + keyboardKeyGap
+	for r, row := range k.rows {
+		x := originX
+		y := originY + float64(r*pitch)
+		for c, key := range row {
+			width := float32(key.width*pitch - keyboardKeyGap)
+			vector.DrawFilledRect(screen, float32(x), float32(y), width, keyboardKeySize, keyboardKeyColor, false)
+			if r == k.row && c == k.col {
+				vector.StrokeRect(screen, float32(x), float32(y), width, keyboardKeySize, 2, keyboardFocusColor, false)
+			}
+			label := k.label(key)
+			scale := 0.8
+			if key.width > 1 {
+				scale = 0.6
+			}
+			g.drawText(screen, label, x+8, y+8, scale, scale, color.White)
+			x += float64(key.width * pitch)
+		}
+	}
+}
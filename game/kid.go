@@ -0,0 +1,75 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	kidModeOnText  = "Kid mode: slow balls, a big ball and nobody gets out"
+	kidToggleLabel = "Press K to turn kid mode on or off"
+)
+
+// applyKidMode sets the match up for young players, with the kid preset's slow deliveries,
+// a bigger ball, no dismissals and fireworks for every hit, or puts it back as it was
+func (g *Game) applyKidMode(on bool) {
+	if on {
+		preset, err := difficulty.Load(sim.KidPreset)
+		if err != nil {
+			g.logger.Warn("could not load kid preset", "error", err)
+			return
+		}
+		// Everything on the field shares the preset, so it is changed in place
+		*g.world.Preset = *preset
+		if !g.cfg.GetReducedMotion() {
+			g.hitFireworks = &fireworks{width: g.cfg.GetWindowWidth(), height: g.cfg.GetWindowHeight()}
+		}
+	} else {
+		*g.world.Preset = g.basePreset
+		g.hitFireworks = nil
+	}
+
+	g.world.Kid = on
+	g.world.SizeBall()
+}
+
+// toggleKidMode turns kid mode on or off from the pause screen, remembering the choice for
+// the profile and starting the match again under the new settings
+func (g *Game) toggleKidMode() {
+	if g.state != GameStatePaused || !g.keyJustPressed(ebiten.KeyK) {
+		return
+	}
+
+	on := !g.world.Kid
+	g.applyKidMode(on)
+	if err := g.profileManager.SetKidMode(on); err != nil {
+		g.logger.Warn("could not save kid mode", "error", err)
+	}
+	g.logger.Info("kid mode changed", "on", on)
+	g.reset()
+}
+
+// celebrateHit sets off fireworks at the bat for every hit in kid mode
+func (g *Game) celebrateHit() {
+	if g.hitFireworks != nil {
+		g.hitFireworks.burst(g.camera.toScreen(g.world.Bat.Position))
+	}
+}
+
+// kidModeHUD reminds the player that kid mode is on
+func (g *Game) kidModeHUD() (string, bool) {
+	return kidModeOnText, g.world.Kid
+}
+
+func (g *Game) updateHitFireworks() {
+	if g.hitFireworks != nil {
+		g.hitFireworks.update()
+	}
+}
+
+func (g *Game) drawHitFireworks(screen *ebiten.Image) {
+	if g.hitFireworks != nil {
+		g.hitFireworks.draw(screen)
+	}
+}
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/leaderboard"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/version"
+)
+
+const (
+	leaderboardQueueFile = "leaderboard_queue.json" // Under the data directory
+	leaderboardTimeout   = 10 * time.Second
+)
+
+// startLeaderboard loads the scores still waiting to reach the online leaderboard, if there
+// is one, and tries sending any that are due
+func (g *Game) startLeaderboard() {
+	url := g.cfg.GetLeaderboardURL()
+	if len(url) == 0 {
+		return
+	}
+
+	queue, err := leaderboard.NewQueue(url, filepath.Join(g.cfg.GetDataDir(), leaderboardQueueFile), http.DefaultClient)
+	if err != nil {
+		g.logger.Warn("could not load leaderboard queue, starting afresh", "error", err)
+	}
+	g.leaderboard = queue
+	if pending, _ := queue.Pending(); pending > 0 {
+		g.logger.Info("scores waiting for the leaderboard", "pending", pending)
+	}
+	g.flushLeaderboard()
+}
+
+// submitScore queues the match's score for the leaderboard and tries sending it. Only
+// matches that can set a high score are sent.
+func (g *Game) submitScore() {
+	if g.leaderboard == nil || !g.ranked() {
+		return
+	}
+
+	entry := leaderboard.Entry{
+		Name:     g.profileManager.Name(),
+		Score:    g.matchScore(),
+		Mode:     g.mode.Name(),
+		Version:  version.Current(),
+		PlayedAt: time.Now(),
+	}
+	if featured, ok := g.mode.(sim.FeaturedMode); ok {
+		entry.Week = featured.Week
+	}
+	if err := g.leaderboard.Add(entry); err != nil {
+		g.logger.Warn("could not queue score for the leaderboard", "error", err)
+	}
+	g.flushLeaderboard()
+}
+
+// flushLeaderboard sends the scores that are due in the background
+func (g *Game) flushLeaderboard() {
+	queue := g.leaderboard
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), leaderboardTimeout)
+		defer cancel()
+
+		sent, err := queue.Flush(ctx, time.Now())
+		if err != nil {
+			g.logger.Debug("could not send scores to the leaderboard, will try again later", "error", err)
+		}
+		if sent > 0 {
+			g.logger.Info("scores sent to the leaderboard", "sent", sent)
+		}
+	}()
+}
+
+// leaderboardStatus owns up to scores that haven't reached the leaderboard yet
+func (g *Game) leaderboardStatus() (string, bool) {
+	if g.leaderboard == nil {
+		return "", false
+	}
+
+	pending, next := g.leaderboard.Pending()
+	switch {
+	case pending == 0:
+		return "", false
+	case next.After(time.Now()):
+		return fmt.Sprintf("Leaderboard: %d score(s) waiting to be sent, next try %s", pending, next.Format("15:04")), true
+	default:
+		return fmt.Sprintf("Leaderboard: sending %d score(s)", pending), true
+	}
+}
@@ -0,0 +1,308 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"path/filepath"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/persist"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	loadoutsFile     = "loadouts.json" // Under the data directory
+	maxLoadoutName   = 24
+	loadoutRowSpace  = 40
+	loadoutRowsShown = 10
+)
+
+// loadoutsSchema is the layout of the loadouts file. A migration is added to it whenever
+// the layout changes.
+var loadoutsSchema = persist.Schema{Name: "loadouts"}
+
+// Loadout is a named set of settings to switch to before a match, so that players sharing
+// a machine can each get theirs back quickly. The loadouts are kept for the machine, not
+// the profile.
+type Loadout struct {
+	Name          string            `json:"name"`
+	Theme         string            `json:"theme,omitempty"`
+	Cosmetics     map[string]string `json:"cosmetics,omitempty"` // Equipped cosmetic by slot
+	DynamicAssist bool              `json:"dynamic_assist,omitempty"`
+	Rumble        bool              `json:"rumble"`
+	// How far the bat can be dragged from the stumps, as fractions of the screen size
+	DragAreaRight float64  `json:"drag_area_right,omitempty"`
+	DragAreaUp    float64  `json:"drag_area_up,omitempty"`
+	DragAreaDown  float64  `json:"drag_area_down,omitempty"`
+	Mutators      []string `json:"mutators,omitempty"`
+}
+
+// loadoutScreen is the loadouts screen: the saved loadouts, which is picked, and the name
+// of a new one as it is typed
+type loadoutScreen struct {
+	loadouts []Loadout
+	cursor   int
+	naming   bool
+	name     string
+	message  string
+}
+
+func (g *Game) loadoutsPath() string {
+	return filepath.Join(g.cfg.GetDataDir(), loadoutsFile)
+}
+
+func loadLoadouts(path string) ([]Loadout, error) {
+	var file struct {
+		Loadouts []Loadout `json:"loadouts"`
+	}
+	if err := loadoutsSchema.Load(path, &file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return file.Loadouts, nil
+}
+
+func saveLoadouts(path string, loadouts []Loadout) error {
+	return loadoutsSchema.Save(path, struct {
+		Loadouts []Loadout `json:"loadouts"`
+	}{loadouts})
+}
+
+// startLoadout switches to the loadout the config names, if any
+func (g *Game) startLoadout() {
+	name := g.cfg.GetLoadout()
+	if len(name) == 0 {
+		return
+	}
+
+	loadouts, err := loadLoadouts(g.loadoutsPath())
+	if err != nil {
+		g.logger.Warn("could not load loadouts", "error", err)
+		return
+	}
+	i := slices.IndexFunc(loadouts, func(l Loadout) bool { return l.Name == name })
+	if i < 0 {
+		g.logger.Warn("no such loadout, starting with the settings as they are", "loadout", name)
+		return
+	}
+	g.applyLoadout(loadouts[i])
+}
+
+// currentLoadout is the settings in play, under the given name
+func (g *Game) currentLoadout(name string) Loadout {
+	return Loadout{
+		Name:  name,
+		Theme: string(assets.CurrentTheme()),
+		Cosmetics: map[string]string{
+			slotBat:         g.equipped(slotBat).id,
+			slotTrail:       g.equipped(slotTrail).id,
+			slotCelebration: g.equipped(slotCelebration).id,
+		},
+		DynamicAssist: g.cfg.GetDynamicAssist(),
+		Rumble:        g.cfg.GetRumble(),
+		DragAreaRight: g.cfg.GetDragAreaRight(),
+		DragAreaUp:    g.cfg.GetDragAreaUp(),
+		DragAreaDown:  g.cfg.GetDragAreaDown(),
+		Mutators:      g.world.Mutators.Names(),
+	}
+}
+
+// applyLoadout switches to a loadout's settings. Cosmetics the profile doesn't own are left
+// as they are, and so are the mutators of a featured or challenge match.
+func (g *Game) applyLoadout(l Loadout) {
+	if l.Theme != string(assets.CurrentTheme()) {
+		if err := assets.SetTheme(assets.Theme(l.Theme)); err != nil {
+			g.logger.Warn("could not set loadout theme", "theme", l.Theme, "error", err)
+		} else {
+			g.cfg.SetTheme(l.Theme)
+			if assetsDir := g.cfg.GetAssetsDir(); len(assetsDir) > 0 {
+				assets.LoadPack(assetsDir)
+			}
+			g.refreshSprites()
+		}
+	}
+
+	for _, c := range cosmetics {
+		if l.Cosmetics[c.slot] != c.id || !g.owns(c) {
+			continue
+		}
+		if err := g.profileManager.Equip(c.slot, c.id); err != nil {
+			g.logger.Warn("could not save cosmetic equipped", "id", c.id, "error", err)
+		}
+	}
+	g.applyCosmetics()
+
+	g.cfg.SetDynamicAssist(l.DynamicAssist)
+	g.assist = nil
+	g.startAssist()
+	g.cfg.SetRumble(l.Rumble)
+
+	g.cfg.SetDragArea(l.DragAreaRight, l.DragAreaUp, l.DragAreaDown)
+	area := sim.NewDragArea(g.cfg, g.cfg.GetWindowWidth(), g.cfg.GetWindowHeight())
+	g.world.DragArea = area
+	g.world.Bat.DragArea = area
+
+	// The featured mode and challenge codes come with mutators of their own
+	if _, featured := g.featuredMutators(); !featured && len(g.cfg.GetChallengeCode()) == 0 {
+		picked := make(sim.Mutators, len(l.Mutators))
+		for _, entry := range sim.AllMutators {
+			if slices.Contains(l.Mutators, string(entry.Mutator)) {
+				picked[entry.Mutator] = true
+			}
+		}
+		g.world.SetMutators(picked)
+	}
+
+	g.logger.Info("loadout applied", "loadout", l.Name)
+}
+
+// toggleLoadouts opens the loadouts screen from the pause screen, and goes back to it
+func (g *Game) toggleLoadouts() {
+	if g.loadouts != nil && g.loadouts.naming {
+		return
+	}
+	if !g.keyJustPressed(ebiten.KeyL) && !(g.state == GameStateLoadouts && g.keyJustPressed(ebiten.KeyEscape)) {
+		return
+	}
+
+	switch g.state {
+	case GameStatePaused:
+		loadouts, err := loadLoadouts(g.loadoutsPath())
+		if err != nil {
+			g.logger.Warn("could not load loadouts", "error", err)
+		}
+		g.loadouts = &loadoutScreen{loadouts: loadouts}
+		g.state = GameStateLoadouts
+	case GameStateLoadouts:
+		g.loadouts = nil
+		g.state = GameStatePaused
+	}
+}
+
+func (g *Game) updateLoadouts() {
+	s := g.loadouts
+	if s == nil {
+		return
+	}
+	if s.naming {
+		g.updateLoadoutName()
+		return
+	}
+
+	switch {
+	case g.keyJustPressed(ebiten.KeyUp) && len(s.loadouts) > 0:
+		s.cursor = (s.cursor + len(s.loadouts) - 1) % len(s.loadouts)
+	case g.keyJustPressed(ebiten.KeyDown) && len(s.loadouts) > 0:
+		s.cursor = (s.cursor + 1) % len(s.loadouts)
+	case g.keyJustPressed(ebiten.KeyN):
+		s.naming = true
+		s.name = ""
+		s.message = ""
+	case g.keyJustPressed(ebiten.KeyDelete) && len(s.loadouts) > 0:
+		removed := s.loadouts[s.cursor]
+		s.loadouts = slices.Delete(s.loadouts, s.cursor, s.cursor+1)
+		s.cursor = min(s.cursor, max(len(s.loadouts)-1, 0))
+		g.saveLoadouts(fmt.Sprintf("Deleted %s", removed.Name))
+	case g.keyJustPressed(ebiten.KeyEnter) && len(s.loadouts) > 0:
+		g.applyLoadout(s.loadouts[s.cursor])
+		g.loadouts = nil
+		g.reset()
+	}
+}
+
+// updateLoadoutName takes the name for the settings in play as it is typed, and saves them
+// under it, over any loadout of the same name
+func (g *Game) updateLoadoutName() {
+	s := g.loadouts
+	for _, r := range g.inputChars() {
+		if r >= ' ' && r <= '~' && len(s.name) < maxLoadoutName {
+			s.name += string(r)
+		}
+	}
+
+	switch {
+	case g.keyJustPressed(ebiten.KeyBackspace) && len(s.name) > 0:
+		s.name = s.name[:len(s.name)-1]
+	case g.keyJustPressed(ebiten.KeyEscape):
+		s.naming = false
+	case g.keyJustPressed(ebiten.KeyEnter) && len(s.name) > 0:
+		loadout := g.currentLoadout(s.name)
+		if i := slices.IndexFunc(s.loadouts, func(l Loadout) bool { return l.Name == s.name }); i >= 0 {
+			s.loadouts[i] = loadout
+			s.cursor = i
+		} else {
+			s.loadouts = append(s.loadouts, loadout)
+			s.cursor = len(s.loadouts) - 1
+		}
+		s.naming = false
+		g.saveLoadouts(fmt.Sprintf("Saved %s", s.name))
+	}
+}
+
+// saveLoadouts keeps the loadouts on the screen, saying so if it worked
+func (g *Game) saveLoadouts(message string) {
+	if err := saveLoadouts(g.loadoutsPath(), g.loadouts.loadouts); err != nil {
+		g.logger.Warn("could not save loadouts", "error", err)
+		g.loadouts.message = "Could not save the loadouts"
+		return
+	}
+	g.loadouts.message = message
+}
+
+func (g *Game) drawLoadouts(screen *ebiten.Image) {
+	const (
+		titleX       float64 = 20
+		titleY       float64 = 30
+		instructionX float64 = 20
+		instructionY float64 = 70
+		rowsX        float64 = 20
+		rowsY        float64 = 130
+	)
+
+	s := g.loadouts
+	if s == nil {
+		return
+	}
+
+	g.drawText(screen, "LOADOUTS", titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+	instruction := "Up/Down to move, Enter to switch and restart, N to save the settings now, Delete to delete, L to go back"
+	if s.naming {
+		instruction = "Type a name and press Enter to save the settings now, Esc to cancel"
+	}
+	g.drawText(screen, instruction, instructionX, instructionY, 0.8, 0.8, color.White)
+
+	if len(s.loadouts) == 0 && !s.naming {
+		g.drawText(screen, "No loadouts yet", rowsX, rowsY, 1, 1, color.RGBA{150, 150, 150, 255})
+	}
+	first := max(s.cursor-loadoutRowsShown+1, 0)
+	for i, l := range s.loadouts[first:min(first+loadoutRowsShown, len(s.loadouts))] {
+		cursor := "  "
+		if first+i == s.cursor && !s.naming {
+			cursor = "> "
+		}
+		g.drawText(screen, cursor+loadoutSummary(l), rowsX, rowsY+float64(i)*loadoutRowSpace, 1, 1, color.White)
+	}
+
+	belowY := rowsY + loadoutRowsShown*loadoutRowSpace
+	if s.naming {
+		g.drawText(screen, "Name: "+s.name+"_", rowsX, belowY, 1, 1, color.White)
+		g.drawKeyboard(screen)
+		return
+	}
+	g.drawText(screen, s.message, rowsX, belowY, 1, 1, color.RGBA{255, 255, 0, 255})
+}
+
+// loadoutSummary is a loadout's name and the settings it picks, for the loadouts screen
+func loadoutSummary(l Loadout) string {
+	summary := fmt.Sprintf("%s: %s theme", l.Name, l.Theme)
+	if l.DynamicAssist {
+		summary += ", assist"
+	}
+	if len(l.Mutators) > 0 {
+		summary += fmt.Sprintf(", %d mutators", len(l.Mutators))
+	}
+	return summary
+}
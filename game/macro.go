@@ -0,0 +1,153 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const maxMacroOffsetTicks = ebiten.DefaultTPS // How far a macro can be shifted either way
+
+// practiceMacro records the player's bat movements through a practice drill and plays them
+// back against the same deliveries, optionally shifted in time, to compare what happens
+type practiceMacro struct {
+	recording *inputRecording // The last complete or in progress recording
+	recorder  bool            // Recording the drill now being played
+	playback  *inputPlayback  // nil unless the recording is being played back
+	offset    int             // Ticks to shift the next playback by
+
+	recorded *drillResult // How the recorded drill went
+	replayed *drillResult // How the last playback went
+}
+
+// drillResult is how a run through a practice drill went
+type drillResult struct {
+	score    int
+	outcomes []stats.Outcome
+	offset   int
+}
+
+func newDrillResult(w *sim.World, offset int) *drillResult {
+	result := &drillResult{score: w.Score, offset: offset}
+	for _, event := range w.Innings.Events() {
+		result.outcomes = append(result.outcomes, event.Outcome)
+	}
+	return result
+}
+
+// updateMacroKeys lets the player record a drill with F5, play it back with F6 and shift the
+// playback earlier or later with [ and ]
+func (g *Game) updateMacroKeys() {
+	if g.practiceScript == nil {
+		return
+	}
+	m := g.macro
+
+	switch {
+	case g.keyJustPressed(ebiten.KeyF5):
+		g.reset()
+		m.recording = &inputRecording{}
+		m.recorder = true
+		m.playback = nil
+		m.recorded, m.replayed = nil, nil
+		g.logger.Info("recording practice macro", "drill", g.practiceScript.Name)
+
+	case g.keyJustPressed(ebiten.KeyF6) && m.recording != nil && m.recording.ticks() > 0:
+		g.reset()
+		m.recorder = false
+		m.playback = &inputPlayback{recording: m.recording, offset: m.offset}
+		g.logger.Info("playing back practice macro", "ticks", m.recording.ticks(), "offset", m.offset)
+
+	case g.keyJustPressed(ebiten.KeyLeftBracket):
+		m.offset = max(m.offset-1, -maxMacroOffsetTicks)
+
+	case g.keyJustPressed(ebiten.KeyRightBracket):
+		m.offset = min(m.offset+1, maxMacroOffsetTicks)
+	}
+}
+
+// playerInput is the player's input for this tick, from the macro being played back if there
+// is one, recording it if a macro is being recorded
+func (g *Game) playerInput() sim.BatInput {
+	if input, ok := g.controlInput(); ok {
+		return input
+	}
+	if g.input != nil {
+		return g.providedInput()
+	}
+
+	m := g.macro
+	if m.playback != nil {
+		return m.playback.next()
+	}
+
+	input := readBatInput()
+	if m.recorder {
+		m.recording.record(input)
+	}
+	return input
+}
+
+// finishMacro keeps the result of a drill that was recorded or played back
+func (g *Game) finishMacro() {
+	m := g.macro
+	switch {
+	case m.recorder:
+		m.recorded = newDrillResult(g.world, 0)
+		m.recorder = false
+	case m.playback != nil:
+		m.replayed = newDrillResult(g.world, m.playback.offset)
+		m.playback = nil
+	}
+}
+
+// macroHUD describes what the macro is doing while the drill is played
+func (g *Game) macroHUD() string {
+	m := g.macro
+	switch {
+	case m.recorder:
+		return "Recording macro (F6 plays it back)"
+	case m.playback != nil:
+		return fmt.Sprintf("Playing macro %s", formatOffset(m.playback.offset))
+	case m.recording != nil:
+		return fmt.Sprintf("F6 plays macro %s, [ and ] shift it", formatOffset(m.offset))
+	default:
+		return "F5 records a macro"
+	}
+}
+
+// macroComparison compares the recorded drill with the last playback of it
+func (g *Game) macroComparison() []string {
+	m := g.macro
+	if m.recorded == nil {
+		return nil
+	}
+
+	lines := []string{fmt.Sprintf("Recorded: %d runs", m.recorded.score)}
+	if m.replayed == nil {
+		return lines
+	}
+
+	changed := 0
+	for i := range max(len(m.recorded.outcomes), len(m.replayed.outcomes)) {
+		if i >= len(m.recorded.outcomes) || i >= len(m.replayed.outcomes) || m.recorded.outcomes[i] != m.replayed.outcomes[i] {
+			changed++
+		}
+	}
+
+	return append(lines, fmt.Sprintf("Played back %s: %d runs, %d balls turned out differently",
+		formatOffset(m.replayed.offset), m.replayed.score, changed))
+}
+
+func formatOffset(ticks int) string {
+	switch {
+	case ticks < 0:
+		return fmt.Sprintf("%d frames early", -ticks)
+	case ticks > 0:
+		return fmt.Sprintf("%d frames late", ticks)
+	default:
+		return "as recorded"
+	}
+}
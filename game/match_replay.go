@@ -0,0 +1,167 @@
+package game
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	replayDir = "replays" // Under the data directory
+	replayExt = ".c2dr"
+)
+
+// replayPlayback plays a saved replay's inputs back, a tick at a time, against the match it
+// was saved from
+type replayPlayback struct {
+	replay *replay.Replay
+	inputs *inputPlayback
+}
+
+func newReplayPlayback(r *replay.Replay) *replayPlayback {
+	return &replayPlayback{replay: r, inputs: &inputPlayback{recording: &inputRecording{inputs: r.Inputs}}}
+}
+
+// playedInput is the player's input for this tick, played back or recorded
+func (g *Game) playedInput() sim.BatInput {
+	if g.replayPlayback != nil {
+		return g.replayPlayback.inputs.next()
+	}
+	input := g.playerInput()
+	g.recordReplay(input)
+	return input
+}
+
+// recordReplay adds a tick's input to the match's replay, if the player keeps them. The
+// calibration balls aren't a match, and aren't kept.
+func (g *Game) recordReplay(input sim.BatInput) {
+	if !g.cfg.GetReplays() || g.calibration != nil {
+		return
+	}
+	if g.matchReplay == nil {
+		g.matchReplay = replay.New(g.mode.Name(), g.basePreset.Name, g.world.RNG.Seed, g.replayRules())
+	}
+	g.matchReplay.Record(sim.ReplayInput(input))
+}
+
+// replayRules are the rules the match is being played by, for its replay
+func (g *Game) replayRules() replay.Rules {
+	rules := g.world.ReplayRules()
+	rules.Bowling = g.cfg.GetBowling()
+	rules.Overs = g.cfg.GetOvers()
+	rules.ChaseTarget = g.cfg.GetChaseTarget()
+	rules.MaxOverSeconds = g.cfg.GetMaxOverSeconds()
+	rules.OldBallOvers = g.cfg.GetOldBallOvers()
+	rules.SpawnSeconds = g.basePreset.SpawnIntervalSeconds
+	rules.Scenario = g.cfg.GetScenario()
+	rules.PracticeScript = g.cfg.GetPracticeScript()
+	rules.DynamicAssist = g.cfg.GetDynamicAssist()
+	if a := g.assist; a != nil {
+		rules.Assist = replay.Assist{Level: a.Level, QuickDismissals: a.QuickDismissals, Faced: a.Faced}
+	}
+	rules.Loadout = g.cfg.GetLoadout()
+	return rules
+}
+
+// replayConfig sets the config up as it was for the match a replay was saved from. The
+// menus that would pick the rules before the match are kept shut, as the replay has them.
+func replayConfig(cfg *config.Config, r *replay.Rules) {
+	cfg.SetWindowSize(r.View.X, r.View.Y)
+	cfg.SetBowling(r.Bowling)
+	cfg.SetOvers(r.Overs)
+	cfg.SetChaseTarget(r.ChaseTarget)
+	cfg.SetMaxOverSeconds(r.MaxOverSeconds)
+	cfg.SetOldBallOvers(r.OldBallOvers)
+	cfg.SetScenario(r.Scenario)
+	cfg.SetPracticeScript(r.PracticeScript)
+	cfg.SetDynamicAssist(r.DynamicAssist)
+	// The loadout's settings are in the rest of the rules
+	cfg.SetLoadout("")
+	cfg.SetChallengeCode("")
+	cfg.SetChallenges(false)
+	cfg.SetMutators(false)
+}
+
+// playsBy are the rules of the replay being played back, or of the match the server is
+// playing online, nil if the game is playing by its own
+func (g *Game) playsBy() *replay.Rules {
+	switch {
+	case g.replayPlayback != nil:
+		return g.replayPlayback.replay.Header.Rules
+	case g.online != nil:
+		return g.online.header.Rules
+	}
+	return nil
+}
+
+// playByReplay sets the match up by the rules of the replay being played back, or of the
+// server's match, over whatever the profile and loadout set
+func (g *Game) playByReplay() {
+	rules := g.playsBy()
+	if rules == nil {
+		return
+	}
+
+	sim.SetSizes(sim.Sizes{Ball: rules.Ball, Bat: rules.Bat, Stumps: rules.Stumps})
+	g.basePreset.SpawnIntervalSeconds = rules.SpawnSeconds
+	*g.world.Preset = g.basePreset
+	g.world.PlayBy(rules)
+	g.applyKidMode(rules.Kid)
+	g.replayAssist()
+}
+
+// replayAssist picks the dynamic assist up from where it had got to when the replay being
+// played back was saved, as it carries on from the matches before
+func (g *Game) replayAssist() {
+	rules := g.playsBy()
+	if rules == nil || g.assist == nil {
+		return
+	}
+	a := rules.Assist
+	*g.assist = sim.DynamicAssist{Level: a.Level, QuickDismissals: a.QuickDismissals, Faced: a.Faced}
+}
+
+// saveReplay writes the match's replay to the data directory, and starts a fresh one for
+// the next match. A replay being played back is checked against the score it finished on.
+func (g *Game) saveReplay() {
+	if p := g.replayPlayback; p != nil {
+		if score := g.matchScore(); score != p.replay.Header.Score {
+			g.logger.Warn("replay played back to a different score", "score", score, "saved_score", p.replay.Header.Score)
+		}
+		return
+	}
+
+	r := g.matchReplay
+	if r == nil {
+		return
+	}
+	g.matchReplay = nil
+	r.Header.Score = g.matchScore()
+
+	dir := filepath.Join(g.cfg.GetDataDir(), replayDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		g.logger.Warn("could not create replay directory", "dir", dir, "error", err)
+		return
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s%s", time.Now().Format("20060102_150405"), g.mode.Name(), replayExt))
+	if err := replay.Save(path, r); err != nil {
+		g.logger.Warn("could not save replay", "path", path, "error", err)
+		return
+	}
+	g.logger.Info("replay saved", "path", path, "ticks", len(r.Inputs))
+}
+
+// restartReplay plays a replay being played back from the start again, as the match has
+// been restarted
+func (g *Game) restartReplay() {
+	g.matchReplay = nil
+	if g.replayPlayback != nil {
+		g.replayPlayback.inputs.tick = 0
+		g.replayAssist()
+	}
+}
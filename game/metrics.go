@@ -0,0 +1,100 @@
+package game
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/metrics"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const metricsPath = "/metrics"
+
+// Upper bounds of the update duration histogram's buckets, in seconds. A tick has about
+// 16.7ms before it holds up the next one.
+var updateDurationBuckets = []float64{0.0005, 0.001, 0.002, 0.004, 0.008, 0.0167, 0.033, 0.1}
+
+// gameMetrics are what a kiosk install's monitoring watches
+type gameMetrics struct {
+	fps            *metrics.Gauge
+	tps            *metrics.Gauge
+	ballsSpawned   *metrics.Counter
+	hits           *metrics.Counter
+	dismissals     *metrics.CounterVec
+	updateDuration *metrics.Histogram
+	ballsBowled    int // The world's balls bowled when last looked at
+}
+
+// startMetrics serves metrics on the configured address, if metrics are turned on
+func (g *Game) startMetrics() {
+	if !g.cfg.GetMetricsEnabled() {
+		return
+	}
+
+	registry := metrics.NewRegistry()
+	m := &gameMetrics{
+		fps:            registry.Gauge("cricket2d_frames_per_second", "Frames drawn per second."),
+		tps:            registry.Gauge("cricket2d_ticks_per_second", "Game updates per second."),
+		ballsSpawned:   registry.Counter("cricket2d_balls_spawned_total", "Balls bowled."),
+		hits:           registry.Counter("cricket2d_hits_total", "Balls hit by the bat."),
+		dismissals:     registry.CounterVec("cricket2d_dismissals_total", "Batsmen dismissed, by how they got out.", "how"),
+		updateDuration: registry.Histogram("cricket2d_update_duration_seconds", "Time taken by a game update.", updateDurationBuckets),
+	}
+
+	addr := g.cfg.GetMetricsAddr()
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		g.logger.Warn("could not serve metrics", "addr", addr, "error", err)
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET "+metricsPath, registry)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			g.logger.Error("metrics server stopped", "error", err)
+		}
+	}()
+
+	g.metrics = m
+	g.logger.Info("serving metrics", "addr", listener.Addr().String(), "path", metricsPath)
+}
+
+// observeField counts what happened on the field in the last tick
+func (g *Game) observeField() {
+	m := g.metrics
+	if m == nil {
+		return
+	}
+
+	// A new match starts the count of balls bowled again
+	if g.world.BallsBowled < m.ballsBowled {
+		m.ballsBowled = 0
+	}
+	m.ballsSpawned.Add(float64(g.world.BallsBowled - m.ballsBowled))
+	m.ballsBowled = g.world.BallsBowled
+
+	for _, event := range g.world.Events {
+		switch event {
+		case sim.EventHit:
+			m.hits.Inc()
+		case sim.EventWicket:
+			m.dismissals.Inc(g.world.Dismissal.String())
+		}
+	}
+}
+
+// observeUpdate records how long an update took, and how fast the game is running
+func (g *Game) observeUpdate(started time.Time) {
+	m := g.metrics
+	if m == nil {
+		return
+	}
+
+	m.updateDuration.Observe(time.Since(started).Seconds())
+	m.fps.Set(ebiten.ActualFPS())
+	m.tps.Set(ebiten.ActualTPS())
+}
@@ -0,0 +1,24 @@
+package game
+
+import (
+	"github.com/meghashyamc/cricket2d/mods"
+)
+
+// loadMods loads any mods from the mods directory into the world. A broken mod is logged
+// and skipped rather than stopping the game.
+func (g *Game) loadMods() {
+	modsDir := g.cfg.GetModsDir()
+	if len(modsDir) == 0 {
+		return
+	}
+
+	loadedMods, err := mods.LoadDir(modsDir)
+	if err != nil {
+		g.logger.Warn("some mods could not be loaded", "mods_dir", modsDir, "error", err)
+	}
+
+	for _, mod := range loadedMods {
+		g.logger.Info("mod loaded", "name", mod.Name)
+	}
+	g.world.ActiveMods = loadedMods
+}
@@ -0,0 +1,49 @@
+package game
+
+const (
+	milestoneRuns      = 50  // Fifties, hundreds and so on
+	milestoneBuildUp   = 10  // Runs short of a milestone the music starts building from
+	chaseBuildUpRuns   = 12  // Runs short of a target the music starts building from
+	chaseBuildUpOvers  = 1   // Overs left in a chase the music starts building from
+	milestoneIntensity = 0.8 // A milestone builds to nearly as tense as a close chase
+)
+
+// chaser is implemented by modes with a score to chase
+type chaser interface {
+	Target() int // 0 while there is nothing to chase
+}
+
+// musicIntensity is how tense the music should be, from 0 to 1: building as the batsman
+// closes in on a fifty or a hundred, or as a chase gets close in runs or in balls
+func (g *Game) musicIntensity() float64 {
+	state := g.world.MatchState()
+	intensity := 0.0
+
+	if runsShort := milestoneRuns - state.Score%milestoneRuns; runsShort <= milestoneBuildUp {
+		intensity = milestoneIntensity * buildUp(runsShort, milestoneBuildUp)
+	}
+
+	c, ok := g.world.Mode.(chaser)
+	if !ok || c.Target() <= 0 || state.Score >= c.Target() {
+		return intensity
+	}
+	intensity = max(intensity, buildUp(c.Target()-state.Score, chaseBuildUpRuns))
+	if g.world.MaxBalls > 0 {
+		ballsLeft := g.world.MaxBalls - state.BallsBowled
+		intensity = max(intensity, buildUp(ballsLeft, chaseBuildUpOvers*state.BallsPerOver))
+	}
+	return intensity
+}
+
+// buildUp goes from 0 when left is as much as from up to 1 when nothing is left
+func buildUp(left, from int) float64 {
+	if left >= from {
+		return 0
+	}
+	return 1 - float64(max(left, 0))/float64(from)
+}
+
+// updateMusic sets the music to the tension of the match
+func (g *Game) updateMusic() {
+	g.sound.SetIntensity(g.musicIntensity())
+}
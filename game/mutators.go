@@ -0,0 +1,87 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// startMutatorMenu shows the mutators screen, if the player asked for it and nothing else
+// needs to happen before the match
+func (g *Game) startMutatorMenu() bool {
+	if _, featured := g.featuredMutators(); featured || !g.cfg.GetMutators() || g.state != GameStatePlaying {
+		return false
+	}
+
+	g.pickedMutators = make(sim.Mutators)
+	g.mutatorCursor = 0
+	g.userMessage = ""
+	g.state = GameStateMutators
+	return true
+}
+
+func (g *Game) updateMutatorMenu() {
+	switch {
+	case g.keyJustPressed(ebiten.KeyUp):
+		g.mutatorCursor = (g.mutatorCursor + len(sim.AllMutators) - 1) % len(sim.AllMutators)
+	case g.keyJustPressed(ebiten.KeyDown):
+		g.mutatorCursor = (g.mutatorCursor + 1) % len(sim.AllMutators)
+	case g.keyJustPressed(ebiten.KeySpace):
+		m := sim.AllMutators[g.mutatorCursor].Mutator
+		g.pickedMutators[m] = !g.pickedMutators[m]
+	case g.keyJustPressed(ebiten.KeyEnter):
+		g.world.SetMutators(g.pickedMutators)
+		g.world.Reset()
+		g.state = GameStatePlaying
+		g.logger.Info("match started with mutators", "mutators", g.pickedMutators.Names())
+	}
+}
+
+// mutatorsHUD flags the mutators in play on the scoreboard, as scores made with them
+// don't count
+func (g *Game) mutatorsHUD() (string, bool) {
+	if !g.world.Mutators.Any() {
+		return "", false
+	}
+	if g.ranked() {
+		return "Mutators: " + strings.Join(g.world.Mutators.Names(), ", "), true
+	}
+	return "Mutators: " + strings.Join(g.world.Mutators.Names(), ", ") + " (unranked)", true
+}
+
+func (g *Game) drawMutatorMenu(screen *ebiten.Image) {
+	const (
+		titleX       float64 = 20
+		titleY       float64 = 30
+		instructionX float64 = 20
+		instructionY float64 = 70
+		rowsX        float64 = 20
+		rowsY        float64 = 120
+		rowSpacing   float64 = 60
+	)
+
+	g.drawText(screen, "MUTATORS", titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+	g.drawText(screen, "Up/Down to move, Space to turn on or off, Enter to play", instructionX, instructionY, 1, 1, color.White)
+
+	for i, entry := range sim.AllMutators {
+		cursor := "  "
+		if i == g.mutatorCursor {
+			cursor = "> "
+		}
+		state := "[ ]"
+		if g.pickedMutators[entry.Mutator] {
+			state = "[x]"
+		}
+		g.drawText(screen, fmt.Sprintf("%s%s %s", cursor, state, entry.Mutator), rowsX, rowsY+float64(i)*rowSpacing, 1, 1, color.White)
+		g.drawText(screen, "    "+entry.Description, rowsX, rowsY+float64(i)*rowSpacing+25, 1, 1, color.White)
+	}
+
+	var (
+		noteX float64 = 20
+		noteY float64 = rowsY + float64(len(sim.AllMutators))*rowSpacing + 20
+	)
+	g.drawText(screen, "Scores with mutators on don't count towards high scores", noteX, noteY, 1, 1, color.RGBA{150, 150, 150, 255})
+}
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	narrationIntervalTicks = ebiten.DefaultTPS      // Least time between narrated lines, so a listener can keep up
+	narrationScoreTicks    = 10 * ebiten.DefaultTPS // How often the score is read out while it is changing
+	maxNarrationQueue      = 4                      // Lines waiting beyond this are dropped, oldest first
+)
+
+// narrator reads out what is happening in the game as plain text, for players using a screen
+// reader and for tools following the game. Lines go to standard output and, if configured,
+// to a speech or notification command such as spd-say or say.
+type narrator struct {
+	out     io.Writer
+	command string // Run with each line as its only argument, empty for none
+
+	queue            []string
+	ticks            int
+	scoreTicks       int
+	lastState        GameState
+	lastScore        int
+	lastWickets      int
+	lastAnnouncement int // The world's announcement ticks, to notice a fresh announcement
+}
+
+// startNarrator starts narrating the game, if the player has turned narration on
+func (g *Game) startNarrator() {
+	if !g.cfg.GetNarration() {
+		return
+	}
+
+	g.narrator = &narrator{
+		out:       os.Stdout,
+		command:   g.cfg.GetNarrationCommand(),
+		lastState: g.state,
+	}
+	g.logger.Info("narration turned on", "command", g.narrator.command)
+}
+
+// updateNarrator notices what has changed in the game and reads out the next line when
+// enough time has passed since the last one
+func (g *Game) updateNarrator() {
+	n := g.narrator
+	if n == nil {
+		return
+	}
+
+	if g.state != n.lastState {
+		n.narrateState(g)
+		n.lastState = g.state
+	}
+	if g.state == GameStatePlaying {
+		n.narratePlay(g)
+	}
+
+	n.ticks++
+	if n.ticks < narrationIntervalTicks || len(n.queue) == 0 {
+		return
+	}
+	n.ticks = 0
+	line := n.queue[0]
+	n.queue = n.queue[1:]
+	n.say(line, g)
+}
+
+// narrateState reads out a change of game state
+func (n *narrator) narrateState(g *Game) {
+	switch g.state {
+	case GameStatePlaying:
+		if n.lastState == GameStatePaused || n.lastState == GameStateHelp {
+			n.add("Resumed.")
+		} else {
+			n.add("Match started.")
+		}
+	case GameStatePaused:
+		n.add("Paused.")
+	case GameStateHelp:
+		n.add("Help. Press H to go back.")
+	case GameStateManageData:
+		n.add("Manage data. Press D to go back.")
+	case GameStateCodeEntry:
+		n.add("Challenge code. Type a friend's code and press Enter, or Escape to go back.")
+	case GameStateLoadouts:
+		n.add("Loadouts. Press Enter to switch to the picked one, or L to go back.")
+	case GameStateHouseRules:
+		n.add("House rules. Press Enter to play by the rules shown, or O to go back.")
+	case GameStateInningsBreak:
+		n.add("Innings break. Press Enter to carry on.")
+	case GameStatePhoto:
+		n.add("Photo mode. Arrows pan, plus and minus zoom, F12 saves a picture, F goes back.")
+	case GameStateShop:
+		n.add(fmt.Sprintf("Shop. %d runs to spend. Press S to go back.", g.profileManager.Runs()))
+	case GameStateGameOver:
+		n.add(fmt.Sprintf("Game over. %s Final score %d.", g.userMessage, g.matchScore()))
+	case GameStateNameInput:
+		n.add("New high score. Type your name and press Enter.")
+	case GameStateTeamSelection:
+		n.add("Pick your team.")
+	}
+}
+
+// narratePlay reads out announcements as they are made, and the score every so often while
+// it is changing
+func (n *narrator) narratePlay(g *Game) {
+	w := g.world
+	if w.AnnouncementTicks > n.lastAnnouncement && len(w.Announcement) > 0 {
+		n.add(w.Announcement)
+	}
+	n.lastAnnouncement = w.AnnouncementTicks
+
+	n.scoreTicks++
+	if n.scoreTicks < narrationScoreTicks {
+		return
+	}
+	n.scoreTicks = 0
+	state := w.MatchState()
+	if state.Score == n.lastScore && state.Wickets == n.lastWickets {
+		return
+	}
+	n.lastScore, n.lastWickets = state.Score, state.Wickets
+	n.add(fmt.Sprintf("Score %d for %d after %s overs.", state.Score, state.Wickets, sim.FormatOvers(state.BallsBowled, state.BallsPerOver)))
+}
+
+// add queues a line, dropping the oldest waiting lines if the game is outpacing the listener
+func (n *narrator) add(line string) {
+	n.queue = append(n.queue, line)
+	if len(n.queue) > maxNarrationQueue {
+		n.queue = n.queue[len(n.queue)-maxNarrationQueue:]
+	}
+}
+
+func (n *narrator) say(line string, g *Game) {
+	fmt.Fprintln(n.out, line)
+	if len(n.command) == 0 {
+		return
+	}
+
+	cmd := exec.Command(n.command, line)
+	if err := cmd.Start(); err != nil {
+		g.logger.Warn("could not run narration command, turning it off", "command", n.command, "error", err)
+		n.command = ""
+		return
+	}
+	go cmd.Wait()
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	glareChance         = 0.2                   // Chance of any one delivery coming out of the glare
+	glareWarningTicks   = ebiten.DefaultTPS / 2 // How long the floodlight flickers before a glare delivery is bowled
+	glareTicks          = 8                     // How long the glare hides the ball for
+	glarePoint          = 0.6                   // How far across the screen, from the left, the ball meets the glare
+	glareRadius         = 40
+	floodlightRadius    = 12
+	floodlightFlickerHz = 6
+)
+
+var (
+	nightShade      = color.RGBA{0, 0, 30, 110}
+	floodlightColor = color.RGBA{255, 250, 220, 255}
+)
+
+// nightMatch is play under floodlights. Now and then a delivery comes out of the glare
+// of one of the lights, which hides the ball for a few frames. The light flickers just
+// before such a delivery is bowled, so the batsman knows it is coming.
+type nightMatch struct {
+	lights        []geometry.Vector
+	reducedMotion bool
+
+	rolledFor   int // Number of the last ball chosen for, or not chosen for, glare
+	glareBall   int // Number of the next ball to come out of the glare, 0 if none
+	glareLight  int // Which light the glare comes from
+	glareCenter geometry.Vector
+	glareLeft   int // Ticks the glare has left on screen
+}
+
+func newNightMatch(width, height float64, reducedMotion bool) *nightMatch {
+	return &nightMatch{
+		lights: []geometry.Vector{
+			{X: width * 0.1, Y: height * 0.05},
+			{X: width * 0.4, Y: height * 0.05},
+			{X: width * 0.6, Y: height * 0.05},
+			{X: width * 0.9, Y: height * 0.05},
+		},
+		reducedMotion: reducedMotion,
+	}
+}
+
+// drawField draws the world through the camera, under floodlights in a night match
+func (g *Game) drawField(screen *ebiten.Image, withBalls bool) {
+	g.camera.draw(screen, g.world, g.cosmetics, withBalls)
+	if g.night != nil {
+		g.night.draw(screen, g.world)
+	}
+}
+
+// update decides which deliveries come out of the glare and when the glare shows
+func (n *nightMatch) update(w *sim.World) {
+	next := w.BallsBowled + 1
+	if w.HasUpcoming && n.rolledFor != next && w.TicksUntilSpawn <= glareWarningTicks {
+		n.rolledFor = next
+		if w.RNG.Lights.Float64() < glareChance {
+			n.glareBall = next
+			n.glareLight = w.RNG.Lights.IntN(len(n.lights))
+		}
+	}
+
+	if n.glareLeft > 0 {
+		n.glareLeft--
+	}
+
+	if n.glareBall == 0 {
+		return
+	}
+	for _, b := range w.Balls {
+		if b.Number != n.glareBall || !b.Active || b.IsHit {
+			continue
+		}
+		center, _ := b.CenterAndRadius()
+		if center.X <= w.Width*glarePoint {
+			n.glareCenter = center
+			n.glareLeft = glareTicks
+			n.glareBall = 0
+		}
+	}
+}
+
+// warning is true while the floodlight is telegraphing a glare delivery
+func (n *nightMatch) warning(w *sim.World) bool {
+	return n.glareBall != 0 && n.glareBall == w.BallsBowled+1
+}
+
+// draw darkens the field and draws the floodlights and any glare over it
+func (n *nightMatch) draw(screen *ebiten.Image, w *sim.World) {
+	bounds := screen.Bounds()
+	vector.DrawFilledRect(screen, float32(bounds.Min.X), float32(bounds.Min.Y), float32(bounds.Dx()), float32(bounds.Dy()), nightShade, false)
+
+	for i, light := range n.lights {
+		lightColor := floodlightColor
+		if i == n.glareLight && n.warning(w) {
+			if n.reducedMotion {
+				// A steady ring in place of the flicker
+				vector.StrokeCircle(screen, float32(light.X), float32(light.Y), floodlightRadius*2, 2, floodlightColor, true)
+			} else {
+				flicker := math.Sin(2 * math.Pi * floodlightFlickerHz * float64(w.Ticks) / ebiten.DefaultTPS)
+				lightColor.A = uint8(155 + 100*flicker)
+			}
+		}
+		vector.DrawFilledCircle(screen, float32(light.X), float32(light.Y), floodlightRadius, lightColor, true)
+	}
+
+	if n.glareLeft == 0 {
+		return
+	}
+
+	// The glare swells and fades, or holds steady with reduced motion
+	strength := 1.0
+	if !n.reducedMotion {
+		strength = math.Sin(math.Pi * float64(n.glareLeft) / glareTicks)
+	}
+	for ring := 3; ring >= 1; ring-- {
+		glareColor := floodlightColor
+		glareColor.A = uint8(float64(80*(4-ring)) * strength)
+		vector.DrawFilledCircle(screen, float32(n.glareCenter.X), float32(n.glareCenter.Y),
+			float32(glareRadius*ring)/3, glareColor, true)
+	}
+}
@@ -0,0 +1,281 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/online"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	onlineBacklog      = 10 * sim.TPS // Ticks of inputs that can wait to be sent
+	onlineHistoryTicks = 10 * sim.TPS // Ticks of the field kept to check the server's against
+	onlineRetryDelay   = time.Second  // After the server couldn't be reached
+	slowPing           = 150 * time.Millisecond
+)
+
+// onlineMatch plays the match against a dedicated server, which plays it too as the one
+// true copy. The game's own copy is set up by the server's rules, and its inputs are sent
+// to the server in order, on a goroutine of their own so the game never waits on it. The
+// game plays on ahead of the server by however long the inputs take to get there, and
+// checks each tick the server says it has played against its own. The two only differ if
+// the server had to play on without the game's inputs, or saw a shot differently, and then
+// the game plays the match again with the server's inputs and carries on from there.
+type onlineMatch struct {
+	client      *online.Client
+	header      replay.Header
+	tick        int            // Ticks played in this match so far
+	generation  int            // Matches started, so a reply about the one before a restart is ignored
+	sent        []replay.Input // Every tick's input, as the game played it
+	confirmed   []replay.Input // Every tick's input, as the server played it
+	history     []sim.ControlState
+	historyFrom int // Ticks played when the first state in the history was kept
+	outbox      chan onlineMessage
+	replies     chan onlineReply
+	played      *online.Played // Where the server had got to when it last said, nil until it has
+	ping        time.Duration  // How long the last request to the server took
+	logger      logger.Logger
+}
+
+// onlineMessage is an input for the server, or a restart of the match
+type onlineMessage struct {
+	tick    int
+	input   replay.Input
+	restart bool
+}
+
+type onlineReply struct {
+	generation int
+	played     online.Played
+	confirmed  []replay.Input // The inputs the server played since its last reply
+	ping       time.Duration
+}
+
+// pendingInput is an input the server hasn't played yet
+type pendingInput struct {
+	tick  int
+	input replay.Input
+}
+
+func newOnlineMatch(client *online.Client, match online.Match) *onlineMatch {
+	o := &onlineMatch{
+		client:  client,
+		header:  match.Header,
+		outbox:  make(chan onlineMessage, onlineBacklog),
+		replies: make(chan onlineReply, onlineBacklog),
+		logger:  logger.New(),
+	}
+	go o.send()
+	return o
+}
+
+// playOnline sends the server how the bat was held on the tick just played, and checks
+// whatever the server has said since the last against the field
+func (g *Game) playOnline(input sim.BatInput) {
+	o := g.online
+	if o == nil {
+		return
+	}
+
+	recorded := sim.ReplayInput(input)
+	o.post(onlineMessage{tick: o.tick, input: recorded})
+	o.sent = append(o.sent, recorded)
+	o.tick++
+	o.history = append(o.history, g.world.PlayState())
+	if len(o.history) > onlineHistoryTicks {
+		o.history = o.history[1:]
+		o.historyFrom++
+	}
+
+	for {
+		select {
+		case reply := <-o.replies:
+			if reply.generation != o.generation {
+				continue
+			}
+			o.played = &reply.played
+			o.ping = reply.ping
+			o.confirmed = append(o.confirmed, reply.confirmed...)
+			if !o.agrees(reply.played) {
+				g.reconcileOnline()
+			}
+		default:
+			return
+		}
+	}
+}
+
+// agrees is true if the field was as the server has it when it got to the same tick
+func (o *onlineMatch) agrees(played online.Played) bool {
+	if played.Tick > o.tick {
+		return false
+	}
+	i := played.Tick - o.historyFrom - 1
+	if i < 0 {
+		// Too long ago to check
+		return true
+	}
+	return reflect.DeepEqual(o.history[i], played.State)
+}
+
+// reconcileOnline plays the match again from the start as the server played it, then on
+// with the inputs it hasn't played yet, and carries on from there
+func (g *Game) reconcileOnline() {
+	o := g.online
+	score := g.world.Score
+	if len(o.sent) < len(o.confirmed) {
+		// The server played on without the game, which catches up
+		o.sent = append(o.sent[:0], o.confirmed...)
+		o.tick = len(o.sent)
+	}
+
+	if o.header.Rules != nil {
+		g.world.PlayBy(o.header.Rules)
+	}
+	g.world.Reset()
+	for i, input := range o.sent {
+		if i < len(o.confirmed) {
+			input = o.confirmed[i]
+		}
+		if over, _ := g.world.Mode.End(g.world.MatchState()); over {
+			break
+		}
+		g.world.Update(sim.RecordedInput(input))
+	}
+
+	g.logger.Info("field put right by the server's", "tick", o.played.Tick, "score", g.world.Score, "local_score", score)
+	o.history, o.historyFrom = o.history[:0], o.tick
+}
+
+// restartOnline starts the match again on the server too, from the same seed
+func (g *Game) restartOnline() {
+	o := g.online
+	if o == nil {
+		return
+	}
+
+	o.generation++
+	o.tick = 0
+	o.sent, o.confirmed = o.sent[:0], o.confirmed[:0]
+	o.history, o.historyFrom = o.history[:0], 0
+	o.played = nil
+	o.post(onlineMessage{restart: true})
+}
+
+// onlineHUD is the score as the server has it, and how long the server takes to answer
+func (g *Game) onlineHUD() []string {
+	o := g.online
+	switch {
+	case o == nil:
+		return nil
+	case o.played == nil:
+		return []string{"Server: connecting"}
+	}
+
+	ping := fmt.Sprintf("Ping: %d ms", o.ping.Milliseconds())
+	if o.ping > slowPing {
+		ping += " (slow)"
+	}
+	return []string{fmt.Sprintf("Server: %d/%d", o.played.State.Score, o.played.State.Wickets), ping}
+}
+
+// post queues a message for the server. A server so far behind that the backlog is full
+// has the input dropped, and plays that tick with the bat held as it was.
+func (o *onlineMatch) post(message onlineMessage) {
+	select {
+	case o.outbox <- message:
+	default:
+		if message.restart {
+			o.outbox <- message
+			return
+		}
+		o.logger.Warn("dropped an input for the server", "tick", message.tick)
+	}
+}
+
+// send sends the server the queued messages in order. Inputs are sent together, as many as
+// have been queued while the last request was out.
+func (o *onlineMatch) send() {
+	var (
+		pending    []pendingInput
+		generation int
+		serverTick int          // Ticks the server has said it has played
+		held       replay.Input // The last input the server played
+	)
+	for message := range o.outbox {
+		messages := []onlineMessage{message}
+	drain:
+		for {
+			select {
+			case message := <-o.outbox:
+				messages = append(messages, message)
+			default:
+				break drain
+			}
+		}
+
+		for _, message := range messages {
+			if !message.restart {
+				pending = append(pending, pendingInput{tick: message.tick, input: message.input})
+				continue
+			}
+			pending = pending[:0]
+			generation++
+			serverTick, held = 0, replay.Input{}
+			start := online.Start{Seed: o.header.Seed, Seeded: true}
+			if _, err := o.client.Start(context.Background(), start); err != nil {
+				o.logger.Warn("could not restart the match on the server", "error", err)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		inputs := online.Inputs{Tick: pending[0].tick, Inputs: make([]replay.Input, 0, len(pending))}
+		for _, p := range pending {
+			inputs.Inputs = append(inputs.Inputs, p.input)
+		}
+		sentAt := time.Now()
+		played, err := o.client.Send(context.Background(), inputs)
+		if err != nil {
+			o.logger.Warn("could not send inputs to the server", "error", err)
+			time.Sleep(onlineRetryDelay)
+			continue
+		}
+		ping := time.Since(sentAt)
+
+		// The server played the inputs it had up to its tick, and held the bat as it was on
+		// any ticks it played without them. Inputs before its tick are done with.
+		confirmed := make([]replay.Input, 0, max(played.Tick-serverTick, 0))
+		for tick := serverTick; tick < played.Tick; tick++ {
+			for len(pending) > 0 && pending[0].tick < tick {
+				pending = pending[1:]
+			}
+			if !heldOn(played.Held, tick) && len(pending) > 0 && pending[0].tick == tick {
+				held = pending[0].input
+			}
+			confirmed = append(confirmed, held)
+		}
+		for len(pending) > 0 && pending[0].tick < played.Tick {
+			pending = pending[1:]
+		}
+		serverTick = max(serverTick, played.Tick)
+
+		o.replies <- onlineReply{generation: generation, played: played, confirmed: confirmed, ping: ping}
+	}
+}
+
+// heldOn is true if the server held the bat as it was on the tick, without an input
+func heldOn(spans []online.Span, tick int) bool {
+	for _, span := range spans {
+		if tick >= span.From && tick < span.To {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+package game
+
+import (
+	"context"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/online"
+)
+
+const (
+	chatPollEvery   = time.Second
+	chatBacklog     = 64 // Lines heard or to say, waiting on the game or the server
+	chatLinesShown  = 5
+	chatShownFor    = 15 * time.Second // A line goes from the chat box after this, unless typing
+	chatTextScale   = 0.6
+	chatLineSpacing = 18
+	chatBoxWidth    = 460
+	defaultChatName = "Player"
+)
+
+var chatShade = color.RGBA{0, 0, 0, 160}
+
+// emoteKeys send the emotes, in the order online.Emotes has them
+var emoteKeys = []ebiten.Key{ebiten.KeyDigit1, ebiten.KeyDigit2, ebiten.KeyDigit3}
+
+// emoteText is how an emote reads in the chat box, after the name of who sent it
+var emoteText = map[online.Emote]string{
+	online.EmoteClap:   "claps",
+	online.EmoteAppeal: "appeals: HOWZAT!",
+	online.EmoteShot:   "shouts: Shot!",
+}
+
+// onlineChat is the chat of an online match, with the players connected to the same server.
+// Lines are sent and fetched on a goroutine of their own, so the game never waits on it.
+// T types a line, the number keys send emotes, M mutes the chat and I ignores whoever
+// said the last line.
+type onlineChat struct {
+	client  *online.Client
+	name    string
+	lines   []chatLine // Newest last
+	heard   chan chatLine
+	outbox  chan online.Say
+	typing  bool
+	draft   []rune
+	muted   bool
+	ignored map[string]bool // By name
+	logger  logger.Logger
+}
+
+// chatLine is a line of the chat, or a note for the player alone if it has no name
+type chatLine struct {
+	online.Line
+	at time.Time
+}
+
+func newOnlineChat(client *online.Client, name string) *onlineChat {
+	if len(name) == 0 {
+		name = defaultChatName
+	}
+	c := &onlineChat{
+		client:  client,
+		name:    name,
+		heard:   make(chan chatLine, chatBacklog),
+		outbox:  make(chan online.Say, chatBacklog),
+		ignored: make(map[string]bool),
+		logger:  logger.New(),
+	}
+	go c.run()
+	return c
+}
+
+// run sends what the player says, and fetches what has been said every little while
+func (c *onlineChat) run() {
+	ticker := time.NewTicker(chatPollEvery)
+	defer ticker.Stop()
+	after := 0
+	for {
+		select {
+		case say := <-c.outbox:
+			// Too soon after the last, or too long, the server says why
+			if _, err := c.client.Say(context.Background(), say); err != nil {
+				c.heard <- chatLine{Line: online.Line{Text: "Not sent: " + err.Error()}, at: time.Now()}
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		chat, err := c.client.Chat(context.Background(), after)
+		if err != nil {
+			c.logger.Warn("could not fetch the chat", "error", err)
+			continue
+		}
+		for _, line := range chat.Lines {
+			after = line.ID
+			c.heard <- chatLine{Line: line, at: time.Now()}
+		}
+	}
+}
+
+// listen takes in whatever has been heard since the last tick
+func (c *onlineChat) listen() {
+	for {
+		select {
+		case line := <-c.heard:
+			c.lines = append(c.lines, line)
+			if len(c.lines) > chatBacklog {
+				c.lines = c.lines[1:]
+			}
+		default:
+			return
+		}
+	}
+}
+
+// say sends a line or an emote as the player
+func (c *onlineChat) say(say online.Say) {
+	say.From = c.name
+	select {
+	case c.outbox <- say:
+	default:
+		c.note("Not sent: the server hasn't caught up with the last")
+	}
+}
+
+// note is a line for the player alone
+func (c *onlineChat) note(text string) {
+	c.lines = append(c.lines, chatLine{Line: online.Line{Text: text}, at: time.Now()})
+}
+
+// ignoreLast stops showing lines from whoever else said the last one
+func (c *onlineChat) ignoreLast() {
+	for i := len(c.lines) - 1; i >= 0; i-- {
+		from := c.lines[i].From
+		if len(from) == 0 || from == c.name || c.ignored[from] {
+			continue
+		}
+		c.ignored[from] = true
+		c.note("Ignoring " + from)
+		return
+	}
+}
+
+// shown are the latest lines that haven't gone yet, without those from ignored players, or
+// only notes while the chat is muted
+func (c *onlineChat) shown(now time.Time) []string {
+	var shown []string
+	for i := len(c.lines) - 1; i >= 0 && len(shown) < chatLinesShown; i-- {
+		line := c.lines[i]
+		switch {
+		case !c.typing && now.Sub(line.at) > chatShownFor:
+			return shown
+		case len(line.From) > 0 && (c.muted || c.ignored[line.From]):
+			continue
+		}
+		shown = append([]string{line.String()}, shown...)
+	}
+	return shown
+}
+
+func (l chatLine) String() string {
+	switch {
+	case len(l.From) == 0:
+		return l.Text
+	case len(l.Emote) > 0:
+		return l.From + " " + emoteText[l.Emote]
+	}
+	return l.From + ": " + l.Text
+}
+
+// updateChat takes in what has been said, and works the chat's keys while batting. Keys
+// typed into the chat are used up, so they don't also pause the game or the like.
+func (g *Game) updateChat() {
+	c := g.chat
+	if c == nil {
+		return
+	}
+	c.listen()
+	if g.state != GameStatePlaying {
+		c.typing = false
+		return
+	}
+
+	if c.typing {
+		switch {
+		case g.keyJustPressed(ebiten.KeyEnter):
+			if len(c.draft) > 0 {
+				c.say(online.Say{Text: string(c.draft)})
+			}
+			c.typing = false
+		case g.keyJustPressed(ebiten.KeyEscape):
+			c.typing = false
+		case g.keyJustPressed(ebiten.KeyBackspace):
+			if len(c.draft) > 0 {
+				c.draft = c.draft[:len(c.draft)-1]
+			}
+		default:
+			c.draft = append(c.draft, g.inputChars()...)
+			c.draft = c.draft[:min(len(c.draft), online.MaxChatText)]
+		}
+		g.pad.used = inpututil.AppendJustPressedKeys(g.pad.used)
+		return
+	}
+
+	switch {
+	case g.keyJustPressed(ebiten.KeyT):
+		c.typing = true
+		c.draft = c.draft[:0]
+	case g.keyJustPressed(ebiten.KeyM):
+		c.muted = !c.muted
+		if c.muted {
+			c.note("Chat muted, M to hear it again")
+		} else {
+			c.note("Chat on")
+		}
+	case g.keyJustPressed(ebiten.KeyI):
+		c.ignoreLast()
+	}
+	for i, key := range emoteKeys {
+		if g.keyJustPressed(key) {
+			c.say(online.Say{Emote: online.Emotes[i]})
+		}
+	}
+}
+
+// drawChat draws the chat box in the bottom left, over the instructions
+func (g *Game) drawChat(screen *ebiten.Image) {
+	c := g.chat
+	if c == nil {
+		return
+	}
+
+	lines := c.shown(time.Now())
+	if c.typing {
+		lines = append(lines, "Say: "+string(c.draft)+"_")
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	x := 20.0
+	y := g.cfg.GetWindowHeight() - 40 - float64(len(lines)*chatLineSpacing)
+	vector.DrawFilledRect(screen, float32(x-6), float32(y-4), chatBoxWidth, float32(len(lines)*chatLineSpacing+8), chatShade, false)
+	for i, line := range lines {
+		g.drawText(screen, line, x, y+float64(i*chatLineSpacing), chatTextScale, chatTextScale, color.White)
+	}
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/online"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// TestOnlineMatchFollowsServer plays an online match's field alongside the server's
+// simulation, which has to stay the same as it tick for tick
+func TestOnlineMatchFollowsServer(t *testing.T) {
+	g, cfg, preset, _ := newOnlineTestGame(t)
+	simulation, err := sim.NewSimulation(cfg, preset, sim.ModeOvers, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for tick := range 60 * sim.TPS {
+		if over, _ := g.world.Mode.End(g.world.MatchState()); over {
+			break
+		}
+		// Swing through and leave the bat somewhere new, every two seconds
+		input := sim.ControlInput{X: 700 + float64(tick%50), Y: 450, Drag: tick%120 < 60}
+		g.world.Update(sim.BatInput{Cursor: geometry.Vector{X: input.X, Y: input.Y}, Dragging: input.Drag})
+		simulation.Step(input)
+
+		played, want := g.world.ControlState(), simulation.State()
+		want.State = played.State
+		if !reflect.DeepEqual(played, want) {
+			t.Fatalf("tick %d: field is %+v, server's is %+v", tick, played, want)
+		}
+	}
+}
+
+// TestOnlineMatchReconciles puts the field right after the server played some ticks
+// without the game's inputs
+func TestOnlineMatchReconciles(t *testing.T) {
+	g, cfg, preset, _ := newOnlineTestGame(t)
+	o := g.online
+	for tick := range 20 * sim.TPS {
+		input := replay.Input{X: 700 + float64(tick%50), Y: 450, Drag: tick%120 < 60}
+		g.world.Update(sim.RecordedInput(input))
+		o.sent = append(o.sent, input)
+		o.tick++
+	}
+
+	// The server held the bat still for the first ten seconds
+	o.confirmed = make([]replay.Input, 10*sim.TPS)
+	o.played = &online.Played{Tick: len(o.confirmed)}
+	g.reconcileOnline()
+
+	simulation, err := sim.NewSimulation(cfg, preset, sim.ModeOvers, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, input := range o.confirmed {
+		simulation.Step(sim.ReplayControl(input))
+	}
+	for _, input := range o.sent[len(o.confirmed):] {
+		simulation.Step(sim.ReplayControl(input))
+	}
+	if played, want := g.world.PlayState(), simulation.State(); !reflect.DeepEqual(played, want) {
+		t.Errorf("field is %+v, server's is %+v", played, want)
+	}
+	if o.tick != len(o.sent) {
+		t.Errorf("at tick %d, want %d", o.tick, len(o.sent))
+	}
+}
+
+// TestOnlineChat hears what another player says, and stops showing it once they are
+// ignored or the chat is muted
+func TestOnlineChat(t *testing.T) {
+	g, _, _, addr := newOnlineTestGame(t)
+	c := g.chat
+	if c == nil {
+		t.Fatal("no chat with a server that has one")
+	}
+	other := online.NewClient(addr)
+	if _, err := other.Hello(context.Background(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Say(context.Background(), online.Say{From: "Priya", Emote: online.EmoteAppeal}); err != nil {
+		t.Fatal(err)
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); len(c.lines) == 0 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		c.listen()
+	}
+	if shown, want := c.shown(time.Now()), []string{"Priya appeals: HOWZAT!"}; !reflect.DeepEqual(shown, want) {
+		t.Fatalf("chat shows %q, want %q", shown, want)
+	}
+	if shown := c.shown(time.Now().Add(chatShownFor + time.Second)); len(shown) > 0 {
+		t.Errorf("chat still shows %q after the lines have gone", shown)
+	}
+
+	c.muted = true
+	if shown := c.shown(time.Now()); len(shown) > 0 {
+		t.Errorf("muted chat shows %q", shown)
+	}
+	c.muted = false
+	c.ignoreLast()
+	if shown, want := c.shown(time.Now()), []string{"Ignoring Priya"}; !reflect.DeepEqual(shown, want) {
+		t.Errorf("chat shows %q after ignoring Priya, want %q", shown, want)
+	}
+}
+
+// newOnlineTestGame starts a match on a server of its own, and a game playing it. The
+// server's address is returned too, for other players.
+func newOnlineTestGame(t *testing.T) (*Game, *config.Config, *difficulty.Preset, string) {
+	t.Helper()
+	t.Setenv("DATA_DIR", t.TempDir())
+	t.Setenv("AUDIO_ENABLED", "false")
+	cfg, err := config.LoadFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	preset, err := difficulty.Load(difficulty.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := online.NewServer(cfg, preset, sim.ModeOvers, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+	client := online.NewClient(httpServer.URL)
+	if _, err := client.Hello(context.Background(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	match, err := client.Start(context.Background(), online.Start{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := NewGame(cfg, WithOnlineMatch(client, match))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g, cfg, preset, httpServer.URL
+}
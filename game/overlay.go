@@ -0,0 +1,115 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const overlayTicks = ebiten.DefaultTPS // How often the overlay file is brought up to date
+
+// OverlayState is the live match data written for streaming overlays, such as an OBS text
+// source reading the file
+type OverlayState struct {
+	State     string    `json:"state"` // playing, paused or game_over
+	Mode      string    `json:"mode"`
+	Score     int       `json:"score"`
+	Wickets   int       `json:"wickets"`
+	Overs     string    `json:"overs"`
+	HighScore int       `json:"high_score"`
+	LastEvent string    `json:"last_event"` // The last announcement, empty before there has been one
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// overlay writes the match to a file once a second for streamers to build overlays from.
+// A file ending in .json gets OverlayState as JSON, any other file a few lines of text.
+type overlay struct {
+	path      string
+	lastEvent string
+	written   OverlayState // The last state written, without its time, so an unchanged match isn't rewritten
+	ticks     int
+}
+
+// startOverlay starts writing the overlay file, if one is configured
+func (g *Game) startOverlay() {
+	path := g.cfg.GetOverlayFile()
+	if len(path) == 0 {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		g.logger.Warn("could not create overlay directory", "path", path, "error", err)
+		return
+	}
+
+	g.overlay = &overlay{path: path}
+	g.logger.Info("writing streaming overlay", "path", path)
+}
+
+// updateOverlay rewrites the overlay file once a second if the match has moved on
+func (g *Game) updateOverlay() {
+	o := g.overlay
+	if o == nil {
+		return
+	}
+	if len(g.world.Announcement) > 0 {
+		o.lastEvent = g.world.Announcement
+	}
+
+	o.ticks++
+	if o.ticks < overlayTicks {
+		return
+	}
+	o.ticks = 0
+
+	state := g.overlayState()
+	if state == o.written {
+		return
+	}
+	if err := o.write(state); err != nil {
+		g.logger.Warn("could not write overlay file, turning it off", "path", o.path, "error", err)
+		g.overlay = nil
+		return
+	}
+	o.written = state
+}
+
+// overlayState is the match as an overlay shows it. Its UpdatedAt is left for write to set.
+func (g *Game) overlayState() OverlayState {
+	match := g.world.MatchState()
+	return OverlayState{
+		State:     reportedState(g.state),
+		Mode:      g.world.Mode.Name(),
+		Score:     g.matchScore(),
+		Wickets:   match.Wickets,
+		Overs:     sim.FormatOvers(match.BallsBowled, match.BallsPerOver),
+		HighScore: g.highScoreManager.highScore.Score,
+		LastEvent: g.overlay.lastEvent,
+	}
+}
+
+// write replaces the overlay file in one go, so an overlay never reads it half written
+func (o *overlay) write(state OverlayState) error {
+	state.UpdatedAt = time.Now()
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(o.path), ".json") {
+		var err error
+		if data, err = json.MarshalIndent(state, "", "  "); err != nil {
+			return err
+		}
+	} else {
+		data = fmt.Appendf(nil, "%s %d/%d (%s ov)\nHigh score: %d\n%s\n", state.Mode, state.Score, state.Wickets, state.Overs, state.HighScore, state.LastEvent)
+	}
+
+	tmp := o.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, o.path)
+}
@@ -0,0 +1,151 @@
+package game
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	photoPanSpeed  = 8    // Pixels a tick at no zoom, slower the closer the camera is
+	photoZoomStep  = 1.02 // Per tick a zoom key is held
+	photoWheelStep = 0.1  // Per notch of the mouse wheel
+	photoMaxZoom   = 4
+)
+
+// photoMode is the match frozen for a picture: the HUD is hidden and the camera can be
+// moved anywhere on the field and zoomed in or out
+type photoMode struct {
+	center  geometry.Vector // What the camera is looking at, in field coordinates
+	zoom    float64
+	effects bool // The post-processing effects are laid over the picture
+}
+
+// togglePhotoMode freezes a match in play or paused for a picture, and goes back to the
+// pause screen after
+func (g *Game) togglePhotoMode() {
+	if !g.keyJustPressed(ebiten.KeyF) && !(g.state == GameStatePhoto && g.keyJustPressed(ebiten.KeyEscape)) {
+		return
+	}
+
+	switch g.state {
+	case GameStatePlaying, GameStatePaused:
+		rest := g.camera.rest
+		g.photo = &photoMode{
+			center:  g.camera.position.Add(geometry.Vector{X: rest.Width / 2, Y: rest.Height / 2}),
+			zoom:    1,
+			effects: true,
+		}
+		g.screenshotPath = ""
+		g.state = GameStatePhoto
+	case GameStatePhoto:
+		g.photo = nil
+		g.state = GameStatePaused
+	}
+}
+
+func (g *Game) updatePhotoMode() {
+	p := g.photo
+	if p == nil {
+		return
+	}
+
+	if g.keyJustPressed(ebiten.KeyE) {
+		p.effects = !p.effects
+	}
+
+	switch {
+	case g.keyPressed(ebiten.KeyEqual), g.keyPressed(ebiten.KeyKPAdd):
+		p.zoom *= photoZoomStep
+	case g.keyPressed(ebiten.KeyMinus), g.keyPressed(ebiten.KeyKPSubtract):
+		p.zoom /= photoZoomStep
+	}
+	_, wheel := ebiten.Wheel()
+	p.zoom *= 1 + wheel*photoWheelStep
+	p.zoom = clampValue(p.zoom, g.camera.minZoom(), photoMaxZoom)
+
+	var pan geometry.Vector
+	if g.keyPressed(ebiten.KeyLeft) {
+		pan.X--
+	}
+	if g.keyPressed(ebiten.KeyRight) {
+		pan.X++
+	}
+	if g.keyPressed(ebiten.KeyUp) {
+		pan.Y--
+	}
+	if g.keyPressed(ebiten.KeyDown) {
+		pan.Y++
+	}
+	field := g.camera.field
+	p.center = p.center.Add(pan.Scale(photoPanSpeed / p.zoom))
+	p.center.X = clampValue(p.center.X, field.X, field.MaxX())
+	p.center.Y = clampValue(p.center.Y, field.Y, field.MaxY())
+}
+
+// minZoom is as far out as photo mode goes: the whole field in view, or the usual view if
+// the field is no bigger than it
+func (c *camera) minZoom() float64 {
+	return min(c.rest.Width/c.field.Width, c.rest.Height/c.field.Height, 1)
+}
+
+// drawFree shows the world from anywhere on the field, at any zoom, with the given point in
+// the middle of the window
+func (c *camera) drawFree(screen *ebiten.Image, w *sim.World, cosmetics looks, center geometry.Vector, zoom float64) {
+	if c.canvas == nil {
+		c.canvas = ebiten.NewImageWithOptions(image.Rect(int(c.field.X), int(c.field.Y), int(c.field.MaxX()), int(c.field.MaxY())), nil)
+	}
+	c.canvas.Clear()
+	drawWorld(c.canvas, w, cosmetics, true)
+
+	op := &ebiten.DrawImageOptions{Filter: assets.Filter()}
+	op.GeoM.Translate(c.field.X-center.X, c.field.Y-center.Y)
+	op.GeoM.Scale(zoom, zoom)
+	op.GeoM.Translate(c.rest.Width/2, c.rest.Height/2)
+	screen.DrawImage(c.canvas, op)
+}
+
+// drawPhotoMode draws the field with no HUD, as it will be in the picture
+func (g *Game) drawPhotoMode(screen *ebiten.Image) {
+	p := g.photo
+	if p == nil {
+		return
+	}
+
+	g.camera.drawFree(screen, g.world, g.cosmetics, p.center, p.zoom)
+	if g.night != nil {
+		g.night.draw(screen, g.world)
+	}
+}
+
+// drawPhotoHint says how to work photo mode. It is drawn after any screenshot is taken, so
+// that it stays out of the picture.
+func (g *Game) drawPhotoHint(window *ebiten.Image) {
+	const (
+		hintX float64 = 20
+		hintY float64 = 20
+	)
+
+	if g.state != GameStatePhoto {
+		return
+	}
+
+	hint := "Arrows to pan, +/- or the wheel to zoom, E for effects, F12 to save, F to go back"
+	if len(g.screenshotPath) > 0 {
+		hint = "Saved " + g.screenshotPath
+	}
+	g.drawText(window, hint, hintX, hintY, 0.7, 0.7, color.RGBA{150, 150, 150, 255})
+}
+
+// frameEffects are the post-processing effects for the frame, left off in photo mode if
+// the player has turned them off for the picture
+func (g *Game) frameEffects() *postEffects {
+	if g.state == GameStatePhoto && g.photo != nil && !g.photo.effects {
+		return nil
+	}
+	return g.postEffects
+}
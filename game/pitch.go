@@ -0,0 +1,44 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	pitchStripHeight   = 8
+	crackSegments      = 4 // Zigzags in a drawn crack
+	crackSegmentHeight = pitchStripHeight / crackSegments
+)
+
+var (
+	pitchColor = color.RGBA{190, 170, 120, 255}
+	crackColor = color.RGBA{90, 70, 40, 255}
+)
+
+// drawPitch draws the strip in front of the stumps, with the cracks that have opened
+func drawPitch(screen *ebiten.Image, w *sim.World) {
+	stumps := w.Stumps.GetBounds()
+	top := stumps.Y + stumps.Height - pitchStripHeight
+	vector.DrawFilledRect(screen, float32(stumps.X), float32(top), float32(w.Width*sim.PitchEnd-stumps.X), pitchStripHeight, pitchColor, false)
+
+	wear := w.PitchWear()
+	for _, c := range w.Pitch.Cracks {
+		if c.OpensAt > wear {
+			continue
+		}
+		// A zigzag across the width of the crack, from the top of the strip to the bottom
+		left := c.X - c.Width/2
+		for i := range crackSegments {
+			fromX, toX := left, left+c.Width
+			if i%2 == 1 {
+				fromX, toX = toX, fromX
+			}
+			fromY := top + float64(i)*crackSegmentHeight
+			vector.StrokeLine(screen, float32(fromX), float32(fromY), float32(toX), float32(fromY+crackSegmentHeight), 1, crackColor, true)
+		}
+	}
+}
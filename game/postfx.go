@@ -0,0 +1,147 @@
+package game
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/logger"
+)
+
+//go:embed shaders/postfx.kage
+var postFXShader []byte
+
+const (
+	postFXAuto = "auto"
+	postFXNone = "none"
+
+	postFXScanlines = 0.35 // How much the scanlines darken every other row
+	postFXBloom     = 0.6  // How much of the glow around bright parts is added
+	postFXVignette  = 0.5  // How much the corners are darkened
+
+	postFXMinFPS     = 45.0 // Below this the effects are costing the game its smoothness
+	postFXSlowFrames = 180  // Frames in a row below the minimum before the effects are dropped
+)
+
+// postEffects lays a CRT scanline filter, a mild bloom on the glowing parts and a vignette
+// over each finished frame. The frame is drawn offscreen first, then run through a shader
+// onto the screen.
+type postEffects struct {
+	shader     *ebiten.Shader
+	scene      *ebiten.Image
+	uniforms   map[string]any
+	slowFrames int
+	logger     logger.Logger
+}
+
+// parsePostEffects reads the effects wanted for a theme, returning the strength of each.
+// Auto picks the theme's own: the whole terminal look for vector, a vignette for sprites.
+func parsePostEffects(setting string, theme assets.Theme) (map[string]any, error) {
+	uniforms := map[string]any{"Scanlines": float32(0), "Bloom": float32(0), "Vignette": float32(0)}
+
+	setting = strings.ToLower(strings.TrimSpace(setting))
+	switch setting {
+	case "", postFXAuto:
+		if theme == assets.ThemeVector {
+			setting = "crt,bloom,vignette"
+		} else {
+			setting = "vignette"
+		}
+	case postFXNone:
+		return nil, nil
+	}
+
+	for _, effect := range strings.Split(setting, ",") {
+		switch strings.TrimSpace(effect) {
+		case "crt", "scanlines":
+			uniforms["Scanlines"] = float32(postFXScanlines)
+		case "bloom":
+			uniforms["Bloom"] = float32(postFXBloom)
+		case "vignette":
+			uniforms["Vignette"] = float32(postFXVignette)
+		default:
+			return nil, fmt.Errorf("unknown post-processing effect %q, use crt, bloom or vignette", effect)
+		}
+	}
+
+	return uniforms, nil
+}
+
+// startPostEffects compiles the post-processing shader, if the player wants any effects.
+// Without a shader the frame is drawn straight to the screen, as before.
+func (g *Game) startPostEffects() {
+	uniforms, err := parsePostEffects(g.cfg.GetPostProcessing(), assets.CurrentTheme())
+	if err != nil {
+		g.logger.Warn("could not read post-processing effects, leaving them off", "error", err)
+		return
+	}
+	if uniforms == nil {
+		return
+	}
+
+	shader, err := ebiten.NewShader(postFXShader)
+	if err != nil {
+		g.logger.Warn("could not compile post-processing shader, leaving effects off", "error", err)
+		return
+	}
+
+	g.postEffects = &postEffects{shader: shader, uniforms: uniforms, logger: logger.New()}
+	g.logger.Info("post-processing", "effects", g.cfg.GetPostProcessing())
+}
+
+// target is what the frame should be drawn on: an offscreen image the size of the screen
+// when there are effects to apply, otherwise the screen itself
+func (p *postEffects) target(screen *ebiten.Image) *ebiten.Image {
+	if p == nil {
+		return screen
+	}
+
+	if p.scene == nil || p.scene.Bounds().Size() != screen.Bounds().Size() {
+		if p.scene != nil {
+			p.scene.Deallocate()
+		}
+		p.scene = ebiten.NewImage(screen.Bounds().Dx(), screen.Bounds().Dy())
+	}
+	return p.scene
+}
+
+// apply draws the finished frame onto the screen through the shader
+func (p *postEffects) apply(screen *ebiten.Image) {
+	if p == nil {
+		return
+	}
+
+	size := screen.Bounds().Size()
+	op := &ebiten.DrawRectShaderOptions{Uniforms: p.uniforms}
+	op.Images[0] = p.scene
+	screen.DrawRectShader(size.X, size.Y, p.shader, op)
+}
+
+// keepingUp turns the effects off for good if the game has run below the minimum frame
+// rate for a while, as the shader is the easiest thing to give up on a slow machine. A
+// slowed game isn't judged, as its frame rate is meant to be low.
+func (g *Game) keepingUp() {
+	p := g.postEffects
+	if p == nil {
+		return
+	}
+	// The frame rate reads 0 until the game has run for a second
+	if fps := ebiten.ActualFPS(); g.throttled || fps == 0 || fps >= postFXMinFPS {
+		p.slowFrames = 0
+		return
+	}
+
+	p.slowFrames++
+	if p.slowFrames < postFXSlowFrames {
+		return
+	}
+
+	p.logger.Warn("frame rate too low, turning post-processing off", "fps", ebiten.ActualFPS(), "minimum", postFXMinFPS)
+	if p.scene != nil {
+		p.scene.Deallocate()
+	}
+	p.shader.Deallocate()
+	g.postEffects = nil
+}
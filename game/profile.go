@@ -0,0 +1,317 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/persist"
+	"github.com/meghashyamc/cricket2d/rating"
+	"github.com/meghashyamc/cricket2d/season"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	defaultProfileName = "default"
+	maxSavedDismissals = 200 // Older dismissals are forgotten so the heatmap reflects recent form
+)
+
+// profileSchema is the layout of a profile file. A migration is added to it whenever the
+// layout changes.
+var profileSchema = persist.Schema{Name: "profile"}
+
+// Profile holds a player's preferences that last between games
+type Profile struct {
+	Name string `json:"name"`
+	// Where the player last left the bat, as fractions of the screen size so that it
+	// survives a change of window size. Nil until the bat has been dragged.
+	BatPosition *geometry.Vector `json:"bat_position,omitempty"`
+	// Names of the players last picked for the team, in batting order
+	XI []string `json:"xi,omitempty"`
+	// The season in progress, or the last one played. Nil until a season has been started.
+	Season *season.Season `json:"season,omitempty"`
+	// The deliveries the player has got out to, oldest first
+	Dismissals []stats.BallEvent `json:"dismissals,omitempty"`
+	// The furthest the player has hit a six, in metres
+	LongestSix float64 `json:"longest_six,omitempty"`
+	// The most stars earned on each challenge, by scenario name. Missing until completed.
+	Challenges map[string]int `json:"challenges,omitempty"`
+	// The achievements earned, in the order they were earned
+	Achievements []string `json:"achievements,omitempty"`
+	// How the player's timing measured up on the calibration balls. Nil until calibrated.
+	Calibration *Calibration `json:"calibration,omitempty"`
+	// Whether the player plays in kid mode
+	KidMode bool `json:"kid_mode,omitempty"`
+	// The player's rating from matches against the computer. Nil until a rated match is played.
+	Rating *rating.Ladder `json:"rating,omitempty"`
+	// Runs scored in every match outside practice, and how many of them have been spent in
+	// the shop
+	RunsEarned int `json:"runs_earned,omitempty"`
+	RunsSpent  int `json:"runs_spent,omitempty"`
+	// The cosmetics bought in the shop, and which is equipped in each slot
+	Cosmetics []string          `json:"cosmetics,omitempty"`
+	Equipped  map[string]string `json:"equipped,omitempty"`
+	// The rules last played by. Nil until the player changes them.
+	HouseRules *sim.HouseRules `json:"house_rules,omitempty"`
+}
+
+type ProfileManager struct {
+	filePath string
+	profile  Profile
+	logger   logger.Logger
+}
+
+// NewProfileManager loads the configured profile, starting a fresh one if it isn't saved yet
+func NewProfileManager(cfg *config.Config) (*ProfileManager, error) {
+	logger := logger.New()
+	if err := os.MkdirAll(cfg.GetDataDir(), 0755); err != nil {
+		logger.Error("could not create data directory", "error", err)
+		return nil, err
+	}
+
+	name := cfg.GetProfile()
+	if len(name) == 0 {
+		name = defaultProfileName
+	}
+
+	pm := &ProfileManager{
+		filePath: filepath.Join(cfg.GetDataDir(), fmt.Sprintf("profile_%s.json", name)),
+		profile:  Profile{Name: name},
+		logger:   logger,
+	}
+
+	pm.logger.Debug("profile manager created", "profile_path", pm.filePath)
+	pm.Load()
+	return pm, nil
+}
+
+func (pm *ProfileManager) Load() {
+	var loadedProfile Profile
+	if err := profileSchema.Load(pm.filePath, &loadedProfile); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			pm.logger.Debug("profile file not found, using defaults")
+		} else {
+			pm.logger.Warn("could not load profile, using defaults", "error", err)
+		}
+		return
+	}
+
+	// The file name decides which profile this is
+	loadedProfile.Name = pm.profile.Name
+	pm.profile = loadedProfile
+	pm.logger.Debug("profile loaded successfully", "name", pm.profile.Name)
+}
+
+func (pm *ProfileManager) Save() error {
+	if err := profileSchema.Save(pm.filePath, pm.profile); err != nil {
+		pm.logger.Debug("failed to write profile file", "error", err)
+		return err
+	}
+
+	pm.logger.Debug("profile saved successfully", "file_path", pm.filePath)
+	return nil
+}
+
+func (pm *ProfileManager) Name() string {
+	return pm.profile.Name
+}
+
+// BatPosition returns where the player likes the bat on a screen of the given size
+func (pm *ProfileManager) BatPosition(screenWidth, screenHeight float64) (geometry.Vector, bool) {
+	if pm.profile.BatPosition == nil {
+		return geometry.Vector{}, false
+	}
+
+	return geometry.Vector{
+		X: pm.profile.BatPosition.X * screenWidth,
+		Y: pm.profile.BatPosition.Y * screenHeight,
+	}, true
+}
+
+// XI returns the players last picked for the team, if any
+func (pm *ProfileManager) XI() []string {
+	return pm.profile.XI
+}
+
+func (pm *ProfileManager) SetXI(names []string) error {
+	pm.profile.XI = names
+	return pm.Save()
+}
+
+// Season returns the player's current season, if they have started one
+func (pm *ProfileManager) Season() *season.Season {
+	return pm.profile.Season
+}
+
+func (pm *ProfileManager) SetSeason(s *season.Season) error {
+	pm.profile.Season = s
+	return pm.Save()
+}
+
+// Dismissals returns the deliveries the player has got out to, oldest first
+func (pm *ProfileManager) Dismissals() []stats.BallEvent {
+	return pm.profile.Dismissals
+}
+
+// AddDismissals remembers more deliveries the player got out to
+func (pm *ProfileManager) AddDismissals(events []stats.BallEvent) error {
+	dismissals := append(pm.profile.Dismissals, events...)
+	if len(dismissals) > maxSavedDismissals {
+		dismissals = dismissals[len(dismissals)-maxSavedDismissals:]
+	}
+	pm.profile.Dismissals = dismissals
+	return pm.Save()
+}
+
+func (pm *ProfileManager) LongestSix() float64 {
+	return pm.profile.LongestSix
+}
+
+// UpdateLongestSix keeps the given carry if it beats the player's longest six, reporting
+// whether it did
+func (pm *ProfileManager) UpdateLongestSix(metres float64) (bool, error) {
+	if metres <= pm.profile.LongestSix {
+		return false, nil
+	}
+	pm.profile.LongestSix = metres
+	return true, pm.Save()
+}
+
+// ChallengeStars returns the most stars earned on each challenge completed
+func (pm *ProfileManager) ChallengeStars() map[string]int {
+	return pm.profile.Challenges
+}
+
+// RecordChallenge keeps the stars earned on a challenge if they beat the player's best,
+// reporting whether they did
+func (pm *ProfileManager) RecordChallenge(name string, stars int) (bool, error) {
+	if stars <= pm.profile.Challenges[name] {
+		return false, nil
+	}
+	if pm.profile.Challenges == nil {
+		pm.profile.Challenges = make(map[string]int)
+	}
+	pm.profile.Challenges[name] = stars
+	return true, pm.Save()
+}
+
+// Achievements returns the achievements earned, in the order they were earned
+func (pm *ProfileManager) Achievements() []string {
+	return pm.profile.Achievements
+}
+
+// AddAchievement records an achievement, reporting whether it hadn't already been earned
+func (pm *ProfileManager) AddAchievement(id string) (bool, error) {
+	if slices.Contains(pm.profile.Achievements, id) {
+		return false, nil
+	}
+	pm.profile.Achievements = append(pm.profile.Achievements, id)
+	return true, pm.Save()
+}
+
+// Calibration returns the last measurement of the player's timing, if they have been calibrated
+func (pm *ProfileManager) Calibration() *Calibration {
+	return pm.profile.Calibration
+}
+
+func (pm *ProfileManager) SetCalibration(c Calibration) error {
+	pm.profile.Calibration = &c
+	return pm.Save()
+}
+
+func (pm *ProfileManager) KidMode() bool {
+	return pm.profile.KidMode
+}
+
+func (pm *ProfileManager) SetKidMode(on bool) error {
+	pm.profile.KidMode = on
+	return pm.Save()
+}
+
+// HouseRules returns the rules the player last played by, or the standard ones if those
+// can't be played by
+func (pm *ProfileManager) HouseRules() sim.HouseRules {
+	if pm.profile.HouseRules == nil || !pm.profile.HouseRules.Valid() {
+		return sim.StandardRules
+	}
+	return *pm.profile.HouseRules
+}
+
+func (pm *ProfileManager) SetHouseRules(rules sim.HouseRules) error {
+	pm.profile.HouseRules = &rules
+	return pm.Save()
+}
+
+// Rating returns the player's rating and the rated matches behind it
+func (pm *ProfileManager) Rating() rating.Ladder {
+	if pm.profile.Rating == nil {
+		return *rating.New()
+	}
+	return *pm.profile.Rating
+}
+
+// RecordRatedMatch moves the player's rating after a match against an opponent of the
+// given rating
+func (pm *ProfileManager) RecordRatedMatch(opponent string, opponentRating, result float64, playedAt time.Time) (rating.Match, error) {
+	if pm.profile.Rating == nil {
+		pm.profile.Rating = rating.New()
+	}
+	match := pm.profile.Rating.Record(opponent, opponentRating, result, playedAt)
+	return match, pm.Save()
+}
+
+// Runs is how many runs the player has left to spend in the shop
+func (pm *ProfileManager) Runs() int {
+	return pm.profile.RunsEarned - pm.profile.RunsSpent
+}
+
+// EarnRuns adds the runs from a match to what the player can spend
+func (pm *ProfileManager) EarnRuns(runs int) error {
+	pm.profile.RunsEarned += runs
+	return pm.Save()
+}
+
+// Owns is true if the player has bought the cosmetic
+func (pm *ProfileManager) Owns(id string) bool {
+	return slices.Contains(pm.profile.Cosmetics, id)
+}
+
+// Buy spends runs on a cosmetic
+func (pm *ProfileManager) Buy(id string, price int) error {
+	if price > pm.Runs() {
+		return errNotEnoughRuns
+	}
+	pm.profile.RunsSpent += price
+	pm.profile.Cosmetics = append(pm.profile.Cosmetics, id)
+	return pm.Save()
+}
+
+// Equipped is the cosmetic equipped in a slot, empty if the player hasn't picked one
+func (pm *ProfileManager) Equipped(slot string) string {
+	return pm.profile.Equipped[slot]
+}
+
+func (pm *ProfileManager) Equip(slot, id string) error {
+	if pm.profile.Equipped == nil {
+		pm.profile.Equipped = make(map[string]string)
+	}
+	pm.profile.Equipped[slot] = id
+	return pm.Save()
+}
+
+// SetBatPosition remembers where the player left the bat on a screen of the given size
+func (pm *ProfileManager) SetBatPosition(position geometry.Vector, screenWidth, screenHeight float64) error {
+	pm.profile.BatPosition = &geometry.Vector{
+		X: position.X / screenWidth,
+		Y: position.Y / screenHeight,
+	}
+	return pm.Save()
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/config"
+)
+
+// profileBundleVersion is bumped whenever the bundle's layout changes, so that an older game
+// can refuse a bundle it would misread
+const profileBundleVersion = 1
+
+// ProfileBundle is everything the game keeps about a player, in one file that can be carried
+// to another machine
+type ProfileBundle struct {
+	Version    int                  `json:"version"`
+	ExportedAt time.Time            `json:"exported_at"`
+	Profile    Profile              `json:"profile"`     // Preferences, season, dismissals and records
+	HighScores map[string]HighScore `json:"high_scores"` // By high score key, "" being the endless mode's
+	Rivalries  []Rivalry            `json:"rivalries"`   // Every rivalry the player is part of
+}
+
+// ExportProfile writes the configured profile, the high scores and the player's rivalries
+// to a bundle at the given path
+func ExportProfile(cfg *config.Config, path string) error {
+	pm, err := NewProfileManager(cfg)
+	if err != nil {
+		return err
+	}
+
+	bundle := ProfileBundle{
+		Version:    profileBundleVersion,
+		ExportedAt: time.Now(),
+		Profile:    pm.profile,
+		HighScores: make(map[string]HighScore),
+		Rivalries:  make([]Rivalry, 0),
+	}
+
+	keys, err := highScoreKeys(cfg)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		hsm, err := NewHighScoreManager(cfg, key)
+		if err != nil {
+			return err
+		}
+		bundle.HighScores[key] = hsm.highScore
+	}
+
+	rivalryPaths, err := filepath.Glob(filepath.Join(cfg.GetDataDir(), "rivalry_*.json"))
+	if err != nil {
+		return err
+	}
+	for _, rivalryPath := range rivalryPaths {
+		var rivalry Rivalry
+		if err := rivalrySchema.Load(rivalryPath, &rivalry); err != nil {
+			pm.logger.Warn("skipping unreadable rivalry", "file_path", rivalryPath, "error", err)
+			continue
+		}
+		if slices.Contains(rivalry.Players[:], pm.Name()) {
+			bundle.Rivalries = append(bundle.Rivalries, rivalry)
+		}
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+
+	pm.logger.Info("profile exported", "name", pm.Name(), "path", path, "high_scores", len(bundle.HighScores), "rivalries", len(bundle.Rivalries))
+	return nil
+}
+
+// ImportProfile loads a bundle into the configured profile. The profile is replaced, while
+// high scores and rivalries already on this machine are only replaced by better or longer
+// running ones from the bundle.
+func ImportProfile(cfg *config.Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var bundle ProfileBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return fmt.Errorf("invalid profile bundle: %w", err)
+	}
+	if bundle.Version < 1 || bundle.Version > profileBundleVersion {
+		return fmt.Errorf("profile bundle version %d is not supported, this game reads up to version %d", bundle.Version, profileBundleVersion)
+	}
+
+	pm, err := NewProfileManager(cfg)
+	if err != nil {
+		return err
+	}
+	name := pm.Name()
+	pm.profile = bundle.Profile
+	pm.profile.Name = name
+	if err := pm.Save(); err != nil {
+		return err
+	}
+
+	for key, highScore := range bundle.HighScores {
+		hsm, err := NewHighScoreManager(cfg, key)
+		if err != nil {
+			return err
+		}
+		if hsm.IsNewHighScore(highScore.Score) {
+			if err := hsm.SetHighScore(highScore.Score, highScore.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, rivalry := range bundle.Rivalries {
+		rm, err := NewRivalryManager(cfg, rivalry.Players[0], rivalry.Players[1])
+		if err != nil {
+			return err
+		}
+		if rivalry.Played() <= rm.rivalry.Played() {
+			continue
+		}
+		rivalry.Players = rm.rivalry.Players
+		rm.rivalry = rivalry
+		if err := rm.Save(); err != nil {
+			return err
+		}
+	}
+
+	pm.logger.Info("profile imported", "name", name, "path", path, "exported_at", bundle.ExportedAt)
+	return nil
+}
+
+// highScoreKeys finds the keys of the high scores saved in the data directory
+func highScoreKeys(cfg *config.Config) ([]string, error) {
+	scoreFilename := cfg.GetScoreFilename()
+	ext := filepath.Ext(scoreFilename)
+	base := strings.TrimSuffix(scoreFilename, ext)
+
+	paths, err := filepath.Glob(filepath.Join(cfg.GetDataDir(), base+"*"+ext))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(paths))
+	for _, path := range paths {
+		key := strings.TrimSuffix(filepath.Base(path), ext)
+		key = strings.TrimPrefix(strings.TrimPrefix(key, base), "_")
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
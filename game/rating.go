@@ -0,0 +1,79 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/rating"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const ratingHistoryShown = 8
+
+// difficultyRatings is what the computer is rated at each built-in difficulty, for rating
+// the player's matches against it
+var difficultyRatings = map[string]float64{
+	"kid":    800,
+	"easy":   1000,
+	"normal": 1200,
+	"hard":   1400,
+}
+
+// recordRating moves the player's rating after a match against the computer that could set
+// a high score, at a built-in difficulty
+func (g *Game) recordRating() {
+	g.ratedMatch = nil
+	if !g.ranked() || !rated(g.mode) {
+		return
+	}
+	opponent := strings.ToLower(g.world.Preset.Name)
+	opponentRating, ok := difficultyRatings[opponent]
+	if !ok {
+		return
+	}
+
+	// A tie has gone to a super over by now, so the match is either won or lost
+	result := float64(rating.Loss)
+	if w, ok := g.world.Mode.(winner); ok && w.Won(g.world.MatchState()) {
+		result = rating.Win
+	}
+
+	match, err := g.profileManager.RecordRatedMatch(opponent, opponentRating, result, time.Now())
+	if err != nil {
+		g.logger.Warn("could not save rating", "error", err)
+	}
+	g.ratedMatch = &match
+	g.logger.Info("rating changed", "opponent", opponent, "result", result, "before", match.Before, "after", match.After)
+}
+
+// rated is true for modes with a result to rate against the computer: ones the batsman can
+// win or lose, but not against a second player
+func rated(mode sim.Mode) bool {
+	if _, ok := mode.(winner); !ok {
+		return false
+	}
+	_, versus := mode.(headToHead)
+	return !versus
+}
+
+// ratingNotices show how the match just played moved the player's rating, and where it
+// has been lately
+func (g *Game) ratingNotices() []string {
+	if g.ratedMatch == nil {
+		return nil
+	}
+
+	m := g.ratedMatch
+	notices := []string{fmt.Sprintf("Rating: %.0f (%+.0f against the computer on %s)", m.After, m.Change(), m.Opponent)}
+	ladder := g.profileManager.Rating()
+	if recent := ladder.Recent(ratingHistoryShown); len(recent) > 1 {
+		ratings := make([]string, 0, len(recent))
+		for _, r := range recent {
+			ratings = append(ratings, fmt.Sprintf("%.0f", r))
+		}
+		notices = append(notices, "Recent ratings: "+strings.Join(ratings, " > "))
+	}
+
+	return notices
+}
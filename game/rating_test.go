@@ -0,0 +1,36 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// TestRatedModes checks that the modes the computer can be beaten or lost to in are rated,
+// whatever they are called, and that the others aren't
+func TestRatedModes(t *testing.T) {
+	cfg, err := config.LoadFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{
+		sim.ModeChase:    true,
+		sim.ModeSeason:   true,
+		"bowling":        true,
+		sim.ModeEndless:  false,
+		sim.ModeOvers:    false,
+		sim.ModeBlitz:    false,
+		sim.ModeSurvival: false,
+		"versus":         false,
+	}
+	for name, rate := range want {
+		mode, err := sim.NewMode(name, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := rated(mode); got != rate {
+			t.Errorf("%s rated: %v, want %v", name, got, rate)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	resultCardDir    = "result_cards" // Under the data directory
+	resultCardWidth  = 600
+	resultCardHeight = 315
+	resultCardMargin = 24
+
+	wagonWheelRadius = 110
+	wagonWheelRunLen = 16 // Length of a shot's line for every run it scored
+)
+
+var (
+	resultCardBackground = color.RGBA{10, 20, 10, 255}
+	resultCardTitle      = color.RGBA{255, 255, 0, 255}
+	resultCardFaint      = color.RGBA{150, 150, 150, 255}
+	wagonWheelField      = color.RGBA{30, 90, 40, 255}
+	wagonWheelSingles    = color.RGBA{255, 255, 255, 200}
+	wagonWheelFours      = color.RGBA{100, 200, 255, 255}
+	wagonWheelSixes      = color.RGBA{255, 80, 80, 255}
+)
+
+// saveResultCard draws a card of the match for sharing, with the score, a wagon wheel of
+// the shots, the best of them and the date, and saves it as a PNG in the data directory
+func (g *Game) saveResultCard() {
+	g.resultCardPath = ""
+	if !g.cfg.GetResultCard() {
+		return
+	}
+
+	card := ebiten.NewImage(resultCardWidth, resultCardHeight)
+	defer card.Deallocate()
+	g.drawResultCard(card, time.Now())
+
+	path, err := g.savePNG(card, resultCardDir, fmt.Sprintf("%s_%s.png", time.Now().Format("20060102_150405"), g.mode.Name()))
+	if err != nil {
+		g.logger.Warn("could not save result card", "error", err)
+		return
+	}
+	g.resultCardPath = path
+	g.logger.Info("result card saved", "path", path)
+
+	if g.cfg.GetResultCardClipboard() {
+		go func() {
+			if err := copyImageToClipboard(path); err != nil {
+				g.logger.Warn("could not copy result card to the clipboard", "error", err)
+			}
+		}()
+	}
+}
+
+func (g *Game) drawResultCard(card *ebiten.Image, playedAt time.Time) {
+	const (
+		lineSpacing float64 = 34
+		textX       float64 = resultCardMargin
+	)
+
+	card.Fill(resultCardBackground)
+	match := g.world.MatchState()
+	y := float64(resultCardMargin)
+
+	g.drawText(card, "CRICKET 2D", textX, y, 1, 1, resultCardTitle)
+	y += lineSpacing
+	g.drawText(card, fmt.Sprintf("%s, %s", g.battingSide(), g.mode.Name()), textX, y, 0.8, 0.8, color.White)
+	y += lineSpacing
+	g.drawText(card, fmt.Sprintf("%d/%d", g.matchScore(), match.Wickets), textX, y, 2, 2, color.White)
+	y += 2 * lineSpacing
+	g.drawText(card, fmt.Sprintf("%s overs", sim.FormatOvers(match.BallsBowled, match.BallsPerOver)), textX, y, 0.8, 0.8, color.White)
+	y += lineSpacing
+	if best, ok := g.world.Innings.BestShot(); ok {
+		g.drawText(card, "Best shot: "+bestShotText(best), textX, y, 0.7, 0.7, color.White)
+	}
+
+	g.drawText(card, playedAt.Format("2 Jan 2006"), textX, resultCardHeight-resultCardMargin-20, 0.7, 0.7, resultCardFaint)
+	drawWagonWheel(card, g.world.Innings.Events(), resultCardWidth-resultCardMargin-wagonWheelRadius, resultCardHeight/2)
+}
+
+// bestShotText describes a shot for the card, such as "6, pull, 78m"
+func bestShotText(event stats.BallEvent) string {
+	parts := []string{fmt.Sprint(event.Runs)}
+	if len(event.Shot) > 0 {
+		parts = append(parts, string(event.Shot))
+	}
+	if event.Lofted && event.Carry > 0 {
+		parts = append(parts, sim.FormatCarry(event.Carry))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// drawWagonWheel draws every scoring shot as a line out from the bat at the centre, in the
+// direction it went and as long as the runs it scored, with boundaries picked out
+func drawWagonWheel(dst *ebiten.Image, events []stats.BallEvent, centerX, centerY float32) {
+	vector.DrawFilledCircle(dst, centerX, centerY, wagonWheelRadius, wagonWheelField, true)
+
+	for _, event := range events {
+		if event.Outcome != stats.OutcomeHit || event.Runs == 0 {
+			continue
+		}
+
+		lineColor := wagonWheelSingles
+		switch {
+		case event.Runs >= 6:
+			lineColor = wagonWheelSixes
+		case event.Runs >= 4:
+			lineColor = wagonWheelFours
+		}
+		length := min(float32(event.Runs*wagonWheelRunLen), wagonWheelRadius)
+		// Shots go off to the left, back past the bowler, with positive angles going up
+		endX := centerX - length*float32(math.Cos(event.Angle))
+		endY := centerY - length*float32(math.Sin(event.Angle))
+		vector.StrokeLine(dst, centerX, centerY, endX, endY, 2, lineColor, true)
+	}
+}
+
+// copyImageToClipboard puts a PNG on the clipboard with the tools the platform comes with
+func copyImageToClipboard(path string) error {
+	var command *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		command = exec.Command("osascript", "-e", fmt.Sprintf("set the clipboard to (read (POSIX file %q) as «class PNGf»)", path))
+	case "windows":
+		command = exec.Command("powershell", "-NoProfile", "-Command",
+			fmt.Sprintf("Add-Type -AssemblyName System.Windows.Forms; [System.Windows.Forms.Clipboard]::SetImage([System.Drawing.Image]::FromFile('%s'))", path))
+	default:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			command = exec.Command("wl-copy", "--type", "image/png")
+		} else {
+			command = exec.Command("xclip", "-selection", "clipboard", "-t", "image/png")
+		}
+		command.Stdin = bytes.NewReader(data)
+	}
+	return command.Run()
+}
@@ -0,0 +1,17 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+var ringColor = color.RGBA{255, 200, 0, 220}
+
+func drawRings(screen *ebiten.Image, w *sim.World) {
+	for _, r := range w.Rings {
+		vector.StrokeCircle(screen, float32(r.Center.X), float32(r.Center.Y), sim.RingRadius, 4, ringColor, true)
+	}
+}
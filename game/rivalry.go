@@ -0,0 +1,138 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/persist"
+)
+
+// rivalrySchema is the layout of a rivalry file. A migration is added to it whenever the
+// layout changes.
+var rivalrySchema = persist.Schema{Name: "rivalry"}
+
+// Rivalry is the head-to-head record between two players
+type Rivalry struct {
+	Players [2]string      `json:"players"`
+	Wins    map[string]int `json:"wins"`
+	Ties    int            `json:"ties"`
+
+	HighestChase  int    `json:"highest_chase"` // Largest target chased down
+	HighestChaser string `json:"highest_chaser,omitempty"`
+	BiggestMargin int    `json:"biggest_margin"` // Most runs a first innings total was defended by
+	BiggestWinner string `json:"biggest_winner,omitempty"`
+}
+
+// Played is how many matches the two players have had
+func (r Rivalry) Played() int {
+	return r.Wins[r.Players[0]] + r.Wins[r.Players[1]] + r.Ties
+}
+
+// Lines describes the rivalry for the screen
+func (r Rivalry) Lines() []string {
+	if r.Played() == 0 {
+		return []string{fmt.Sprintf("First meeting of %s and %s", r.Players[0], r.Players[1])}
+	}
+
+	lines := []string{
+		fmt.Sprintf("Head to head: %s %d, %s %d, tied %d",
+			r.Players[0], r.Wins[r.Players[0]], r.Players[1], r.Wins[r.Players[1]], r.Ties),
+	}
+	if len(r.HighestChaser) > 0 {
+		lines = append(lines, fmt.Sprintf("Highest chase: %d by %s", r.HighestChase, r.HighestChaser))
+	}
+	if len(r.BiggestWinner) > 0 {
+		lines = append(lines, fmt.Sprintf("Biggest win: %s by %d runs", r.BiggestWinner, r.BiggestMargin))
+	}
+
+	return lines
+}
+
+type RivalryManager struct {
+	filePath string
+	rivalry  Rivalry
+	logger   logger.Logger
+}
+
+// NewRivalryManager loads the record between two players. The same file is used whichever
+// of them bats first.
+func NewRivalryManager(cfg *config.Config, player, opponent string) (*RivalryManager, error) {
+	logger := logger.New()
+	if err := os.MkdirAll(cfg.GetDataDir(), 0755); err != nil {
+		logger.Error("could not create data directory", "error", err)
+		return nil, err
+	}
+
+	players := []string{player, opponent}
+	slices.Sort(players)
+
+	rm := &RivalryManager{
+		filePath: filepath.Join(cfg.GetDataDir(), fmt.Sprintf("rivalry_%s_%s.json", players[0], players[1])),
+		rivalry:  Rivalry{Players: [2]string{players[0], players[1]}, Wins: make(map[string]int)},
+		logger:   logger,
+	}
+
+	rm.logger.Debug("rivalry manager created", "rivalry_path", rm.filePath)
+	rm.Load()
+	return rm, nil
+}
+
+func (rm *RivalryManager) Load() {
+	var loadedRivalry Rivalry
+	if err := rivalrySchema.Load(rm.filePath, &loadedRivalry); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			rm.logger.Debug("rivalry file not found, starting afresh")
+		} else {
+			rm.logger.Warn("could not load rivalry, starting afresh", "error", err)
+		}
+		return
+	}
+	if loadedRivalry.Wins == nil {
+		loadedRivalry.Wins = make(map[string]int)
+	}
+
+	loadedRivalry.Players = rm.rivalry.Players
+	rm.rivalry = loadedRivalry
+	rm.logger.Debug("rivalry loaded successfully", "played", rm.rivalry.Played())
+}
+
+func (rm *RivalryManager) Save() error {
+	if err := rivalrySchema.Save(rm.filePath, rm.rivalry); err != nil {
+		rm.logger.Debug("failed to write rivalry file", "error", err)
+		return err
+	}
+
+	rm.logger.Debug("rivalry saved successfully", "file_path", rm.filePath)
+	return nil
+}
+
+func (rm *RivalryManager) Rivalry() Rivalry {
+	return rm.rivalry
+}
+
+// Record adds a finished match to the rivalry, given who batted first and what each scored
+func (rm *RivalryManager) Record(first string, firstScore int, second string, secondScore int) error {
+	r := &rm.rivalry
+	switch {
+	case secondScore > firstScore:
+		r.Wins[second]++
+		if target := firstScore + 1; target > r.HighestChase {
+			r.HighestChase, r.HighestChaser = target, second
+		}
+	case firstScore > secondScore:
+		r.Wins[first]++
+		if margin := firstScore - secondScore; margin > r.BiggestMargin {
+			r.BiggestMargin, r.BiggestWinner = margin, first
+		}
+	default:
+		r.Ties++
+	}
+
+	return rm.Save()
+}
@@ -0,0 +1,36 @@
+package game
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	hitRumbleDuration    = 120 * time.Millisecond
+	fullRumbleHitSpeed   = 40.0 // A ball leaving the bat this fast, in pixels per tick, gives the strongest rumble
+	minHitRumble         = 0.2  // Even a gentle touch is felt
+	wicketRumbleDuration = 600 * time.Millisecond
+)
+
+// rumble vibrates every connected gamepad that can, if the player wants it to
+func (g *Game) rumble(duration time.Duration, strength float64) {
+	if !g.cfg.GetRumble() {
+		return
+	}
+
+	options := &ebiten.VibrateGamepadOptions{
+		Duration:        duration,
+		StrongMagnitude: strength,
+		WeakMagnitude:   strength,
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		ebiten.VibrateGamepad(id, options)
+	}
+}
+
+// rumbleForHit gives a short kick on bat contact, harder the harder the ball was hit
+func (g *Game) rumbleForHit() {
+	strength := max(min(g.world.LastHitSpeed/fullRumbleHitSpeed, 1), minHitRumble)
+	g.rumble(hitRumbleDuration, strength)
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const screenshotDir = "screenshots" // Under the data directory
+
+// savePNG writes an image as a PNG in a directory under the data directory, returning
+// where it went
+func (g *Game) savePNG(img *ebiten.Image, dir, name string) (string, error) {
+	size := img.Bounds().Size()
+	pixels := make([]byte, 4*size.X*size.Y)
+	img.ReadPixels(pixels)
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, &image.RGBA{Pix: pixels, Stride: 4 * size.X, Rect: image.Rect(0, 0, size.X, size.Y)}); err != nil {
+		return "", fmt.Errorf("could not encode image: %w", err)
+	}
+
+	dir = filepath.Join(g.cfg.GetDataDir(), dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, encoded.Bytes(), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// checkScreenshotKey notes that F12 was pressed, so that the next frame is saved once it
+// is drawn
+func (g *Game) checkScreenshotKey() {
+	if g.keyJustPressed(ebiten.KeyF12) {
+		g.screenshotDue = true
+	}
+}
+
+// takeScreenshot saves the finished frame in the data directory, if F12 asked for it
+func (g *Game) takeScreenshot(window *ebiten.Image) {
+	if !g.screenshotDue {
+		return
+	}
+	g.screenshotDue = false
+
+	path, err := g.savePNG(window, screenshotDir, fmt.Sprintf("%s_%s.png", time.Now().Format("20060102_150405"), g.mode.Name()))
+	if err != nil {
+		g.logger.Warn("could not save screenshot", "error", err)
+		g.screenshotPath = ""
+		return
+	}
+	g.screenshotPath = path
+	g.logger.Info("screenshot saved", "path", path)
+}
@@ -0,0 +1,161 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/season"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// seasonTeamName is what the player's side is called in the season
+const seasonTeamName = "Your XI"
+
+// seasonal is implemented by modes played as the fixtures of a season, each a chase of the
+// opposition's score
+type seasonal interface {
+	// SeasonOverCount is how many overs each match of a new season lasts
+	SeasonOverCount() int
+	// Fixture is the mode set up to chase the target in a season's fixture
+	Fixture(number, round int, opponent string, target int) sim.Mode
+}
+
+// seasonMatch is the season fixture being played
+type seasonMatch struct {
+	season         *season.Season
+	fixture        int // Index into the season's fixtures
+	oppositionRuns int
+	sortKey        season.SortKey // How the points table is sorted on the game over screen
+}
+
+// startSeasonMatch sets up the player's next season fixture, if the mode is a season. A new
+// season starts once the last one is over.
+func (g *Game) startSeasonMatch() {
+	g.seasonMatch = nil
+	mode, ok := g.mode.(seasonal)
+	if !ok {
+		return
+	}
+
+	s := g.profileManager.Season()
+	if s == nil || s.Complete() {
+		number := 1
+		if s != nil {
+			number = s.Number + 1
+		}
+		s = season.New(number, seasonTeamName, season.Opponents(), mode.SeasonOverCount())
+		g.logger.Info("new season started", "season", number, "fixtures", len(s.Fixtures))
+	}
+
+	i, ok := s.NextFixture()
+	if !ok {
+		// Only the AI sides had matches left, and they have just been played
+		g.saveSeason(s)
+		s = season.New(s.Number+1, seasonTeamName, season.Opponents(), mode.SeasonOverCount())
+		i, _ = s.NextFixture()
+	}
+
+	fixture := s.Fixtures[i]
+	opponent := fixture.Opposition(s.Team)
+	g.saveSeason(s)
+
+	// The opposition's score is drawn from the match's randomness, once the match has started
+	// with it, so that a seeded match is set the same target
+	g.world.StartInnings(mode.Fixture(s.Number, fixture.Round, opponent, 0))
+	g.seasonMatch = &seasonMatch{season: s, fixture: i, oppositionRuns: season.Score(opponent, g.world.RNG.Extras)}
+	target := g.seasonMatch.oppositionRuns + 1
+	g.world.Mode = mode.Fixture(s.Number, fixture.Round, opponent, target)
+
+	g.logger.Info("season match started", "season", s.Number, "round", fixture.Round, "opponent", opponent, "target", target)
+}
+
+// recordSeasonMatch puts the result of the season fixture just played in the table
+func (g *Game) recordSeasonMatch() {
+	sm := g.seasonMatch
+	if sm == nil {
+		return
+	}
+
+	s := sm.season
+	fixture := s.Fixtures[sm.fixture]
+	opponent := fixture.Opposition(s.Team)
+	runs := g.matchScore()
+
+	winner := opponent
+	switch {
+	case runs > sm.oppositionRuns:
+		winner = s.Team
+	case runs == sm.oppositionRuns && g.superOver != nil && g.superOver.Mode.WinsWith(g.world.Score):
+		winner = s.Team
+	}
+
+	// The opposition batted first and used all their overs
+	home := season.Innings{Runs: runs, Balls: g.matchBalls()}
+	away := season.Innings{Runs: sm.oppositionRuns, Balls: s.Overs * g.world.Rules.BallsPerOver}
+	if fixture.Home != s.Team {
+		home, away = away, home
+	}
+	s.Record(sm.fixture, home, away, winner)
+
+	// Play out the AI fixtures in the rest of the round, so the table is up to date
+	s.NextFixture()
+	g.saveSeason(s)
+
+	g.logger.Info("season match recorded", "season", s.Number, "opponent", opponent, "winner", winner, "complete", s.Complete())
+}
+
+// seasonChampion is true if the match just played finished a season the player won
+func (g *Game) seasonChampion() bool {
+	if g.seasonMatch == nil {
+		return false
+	}
+	s := g.seasonMatch.season
+	return s.Complete() && s.Standings()[0].Team == s.Team
+}
+
+func (g *Game) saveSeason(s *season.Season) {
+	if err := g.profileManager.SetSeason(s); err != nil {
+		g.logger.Warn("could not save season", "error", err)
+	}
+}
+
+// updateStandingsSort sorts the points table by the next column when the player presses Tab
+func (g *Game) updateStandingsSort() {
+	if g.seasonMatch != nil && g.keyJustPressed(ebiten.KeyTab) {
+		g.seasonMatch.sortKey = g.seasonMatch.sortKey.Next()
+	}
+}
+
+// drawSeason shows the points table, and the awards once the season is over
+func (g *Game) drawSeason(screen *ebiten.Image) {
+	if g.seasonMatch == nil {
+		return
+	}
+
+	const (
+		tableX       float64 = 20
+		tableY       float64 = 30
+		tableSpacing float64 = 30
+	)
+
+	s := g.seasonMatch.season
+	lines := []string{
+		fmt.Sprintf("SEASON %d (by %s, Tab to sort)", s.Number, g.seasonMatch.sortKey),
+		fmt.Sprintf("%-18s %2s %2s %2s %2s %3s %7s", "Team", "P", "W", "L", "T", "Pts", "NRR"),
+	}
+	for _, st := range s.StandingsBy(g.seasonMatch.sortKey) {
+		lines = append(lines, fmt.Sprintf("%-18s %2d %2d %2d %2d %3d %+7.3f", st.Team, st.Played, st.Won, st.Lost, st.Tied, st.Points, st.NetRunRate()))
+	}
+
+	if awards := s.Awards(); len(awards) > 0 {
+		lines = append(lines, "", "SEASON AWARDS")
+		for _, award := range awards {
+			lines = append(lines, fmt.Sprintf("%s: %s", award.Title, award.Winner))
+		}
+	}
+
+	for i, line := range lines {
+		g.drawText(screen, line, tableX, tableY+float64(i)*tableSpacing, 1, 1, color.White)
+	}
+}
@@ -0,0 +1,208 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const (
+	slotBat         = "bat"
+	slotTrail       = "trail"
+	slotCelebration = "celebration"
+
+	trailPositions = 12 // Of the ball's recent positions, drawn as its trail
+	trailRadius    = 4
+	shopRowSpace   = 40
+	shopRowsShown  = 12
+)
+
+var errNotEnoughRuns = errors.New("not enough runs")
+
+// cosmetic changes how something looks, and nothing about how it plays. Runs scored are
+// spent on them in the shop. Those with no price are everyone's from the start.
+type cosmetic struct {
+	id      string
+	slot    string
+	name    string
+	price   int          // In runs
+	color   color.RGBA   // The bat's tint or the trail's colour
+	palette []color.RGBA // The celebration's fireworks
+}
+
+// cosmetics lists everything in the shop, in the order it shows them. The first in each
+// slot is equipped until the player picks another.
+var cosmetics = []cosmetic{
+	{id: "bat_willow", slot: slotBat, name: "Willow bat"},
+	{id: "bat_gold", slot: slotBat, name: "Gold bat", price: 200, color: color.RGBA{255, 215, 80, 255}},
+	{id: "bat_ice", slot: slotBat, name: "Ice bat", price: 500, color: color.RGBA{150, 220, 255, 255}},
+	{id: "bat_ember", slot: slotBat, name: "Ember bat", price: 1000, color: color.RGBA{255, 120, 80, 255}},
+	{id: "trail_none", slot: slotTrail, name: "No ball trail"},
+	{id: "trail_comet", slot: slotTrail, name: "Comet trail", price: 150, color: color.RGBA{255, 200, 80, 255}},
+	{id: "trail_neon", slot: slotTrail, name: "Neon trail", price: 400, color: color.RGBA{80, 255, 200, 255}},
+	{id: "celebration_fireworks", slot: slotCelebration, name: "Fireworks", palette: fireworkColors},
+	{id: "celebration_gold", slot: slotCelebration, name: "Golden shower", price: 300, palette: []color.RGBA{
+		{255, 215, 80, 255}, {255, 180, 40, 255}, {255, 240, 160, 255},
+	}},
+	{id: "celebration_ice", slot: slotCelebration, name: "Ice storm", price: 600, palette: []color.RGBA{
+		{150, 220, 255, 255}, {200, 240, 255, 255}, {80, 160, 255, 255},
+	}},
+}
+
+// looks are what the equipped cosmetics change on the field
+type looks struct {
+	batTint     color.RGBA // Transparent for the bat as it is
+	trail       color.RGBA // Transparent for no trail
+	celebration []color.RGBA
+}
+
+// equipped is the cosmetic equipped in a slot
+func (g *Game) equipped(slot string) cosmetic {
+	id := g.profileManager.Equipped(slot)
+	var first cosmetic
+	for _, c := range cosmetics {
+		if c.slot != slot {
+			continue
+		}
+		if c.id == id {
+			return c
+		}
+		if len(first.id) == 0 {
+			first = c
+		}
+	}
+	return first
+}
+
+// owns is true if the cosmetic is free or the player has bought it
+func (g *Game) owns(c cosmetic) bool {
+	return c.price == 0 || g.profileManager.Owns(c.id)
+}
+
+// applyCosmetics puts the equipped cosmetics on the field
+func (g *Game) applyCosmetics() {
+	g.cosmetics = looks{
+		batTint:     g.equipped(slotBat).color,
+		trail:       g.equipped(slotTrail).color,
+		celebration: g.equipped(slotCelebration).palette,
+	}
+}
+
+// earnRuns adds the match's runs to what the player can spend in the shop. Practice doesn't
+// earn anything.
+func (g *Game) earnRuns() {
+	runs := g.matchScore()
+	if g.practiceScript != nil || runs <= 0 {
+		return
+	}
+	if err := g.profileManager.EarnRuns(runs); err != nil {
+		g.logger.Warn("could not save runs earned", "error", err)
+	}
+}
+
+// toggleShop opens the shop from the pause screen, and goes back to it
+func (g *Game) toggleShop() {
+	if !g.keyJustPressed(ebiten.KeyS) && !(g.state == GameStateShop && g.keyJustPressed(ebiten.KeyEscape)) {
+		return
+	}
+
+	switch g.state {
+	case GameStatePaused:
+		g.shopCursor = 0
+		g.shopMessage = ""
+		g.state = GameStateShop
+	case GameStateShop:
+		g.state = GameStatePaused
+	}
+}
+
+func (g *Game) updateShop() {
+	switch {
+	case g.keyJustPressed(ebiten.KeyUp):
+		g.shopCursor = (g.shopCursor + len(cosmetics) - 1) % len(cosmetics)
+		g.shopMessage = ""
+	case g.keyJustPressed(ebiten.KeyDown):
+		g.shopCursor = (g.shopCursor + 1) % len(cosmetics)
+		g.shopMessage = ""
+	case g.keyJustPressed(ebiten.KeyEnter):
+		g.shopMessage = g.buyOrEquip(cosmetics[g.shopCursor])
+	}
+}
+
+// buyOrEquip equips a cosmetic the player owns, and buys it first if they don't. It
+// returns what happened, to show in the shop.
+func (g *Game) buyOrEquip(c cosmetic) string {
+	if !g.owns(c) {
+		if err := g.profileManager.Buy(c.id, c.price); err != nil {
+			if errors.Is(err, errNotEnoughRuns) {
+				return fmt.Sprintf("%s costs %d runs, score %d more to buy it", c.name, c.price, c.price-g.profileManager.Runs())
+			}
+			g.logger.Warn("could not save cosmetic bought", "id", c.id, "error", err)
+			return "Could not save what you bought"
+		}
+		g.logger.Info("cosmetic bought", "id", c.id, "price", c.price)
+	}
+
+	if err := g.profileManager.Equip(c.slot, c.id); err != nil {
+		g.logger.Warn("could not save cosmetic equipped", "id", c.id, "error", err)
+	}
+	g.applyCosmetics()
+	return c.name + " equipped"
+}
+
+// drawTrail draws where the ball has just been, fading out behind it, unless the colour is
+// transparent
+func drawTrail(screen *ebiten.Image, b *sim.Ball, trail color.RGBA) {
+	if trail.A == 0 || !b.Active {
+		return
+	}
+
+	path := b.Path[max(len(b.Path)-trailPositions, 0):]
+	for i, position := range path {
+		fade := float32(i+1) / float32(len(path)+1)
+		dot := trail
+		dot.A = uint8(float32(trail.A) * fade)
+		vector.DrawFilledCircle(screen, float32(position.X), float32(position.Y), trailRadius*fade, dot, true)
+	}
+}
+
+func (g *Game) drawShop(screen *ebiten.Image) {
+	const (
+		titleX       float64 = 20
+		titleY       float64 = 30
+		instructionX float64 = 20
+		instructionY float64 = 70
+		runsY        float64 = 100
+		rowsX        float64 = 20
+		rowsY        float64 = 150
+	)
+
+	g.drawText(screen, "SHOP", titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+	g.drawText(screen, "Up/Down to move, Enter to buy or equip, S to go back", instructionX, instructionY, 1, 1, color.White)
+	g.drawText(screen, fmt.Sprintf("Runs to spend: %d", g.profileManager.Runs()), instructionX, runsY, 1, 1, color.RGBA{150, 150, 150, 255})
+
+	// Scroll so that the picked row is always on screen
+	first := max(g.shopCursor-shopRowsShown+1, 0)
+	for i, c := range cosmetics[first:min(first+shopRowsShown, len(cosmetics))] {
+		row := "  "
+		if first+i == g.shopCursor {
+			row = "> "
+		}
+		switch {
+		case g.equipped(c.slot).id == c.id:
+			row += fmt.Sprintf("%s (%s, equipped)", c.name, c.slot)
+		case g.owns(c):
+			row += fmt.Sprintf("%s (%s)", c.name, c.slot)
+		default:
+			row += fmt.Sprintf("%s (%s) - %d runs", c.name, c.slot, c.price)
+		}
+		g.drawText(screen, row, rowsX, rowsY+float64(i)*shopRowSpace, 1, 1, color.White)
+	}
+
+	messageY := rowsY + shopRowsShown*shopRowSpace
+	g.drawText(screen, g.shopMessage, rowsX, messageY, 1, 1, color.RGBA{255, 255, 0, 255})
+}
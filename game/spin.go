@@ -0,0 +1,57 @@
+package game
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+//go:embed shaders/spin.kage
+var spinShaderSource []byte
+
+var spinShader struct {
+	once   sync.Once
+	shader *ebiten.Shader // nil if it wouldn't compile, leaving balls drawn without their spin
+}
+
+// loadSpinShader compiles the shader that turns the ball's seam, the first time it is needed
+func loadSpinShader() *ebiten.Shader {
+	spinShader.once.Do(func() {
+		shader, err := ebiten.NewShader(spinShaderSource)
+		if err != nil {
+			logger.New().Warn("could not compile spin shader, balls will be drawn without spin", "error", err)
+			return
+		}
+		spinShader.shader = shader
+	})
+	return spinShader.shader
+}
+
+// drawSpinning draws a ball turned to its angle, with the seam smeared by however far it
+// turns in a tick. It returns false if there's no spin to show, or no shader to show it.
+func drawSpinning(screen *ebiten.Image, b *sim.Ball, op *ebiten.DrawImageOptions) bool {
+	if b.Delivery.Spin == 0 || b.IsHit {
+		return false
+	}
+	shader := loadSpinShader()
+	if shader == nil {
+		return false
+	}
+
+	sprite := ballSprite(b.Scale)
+	bounds := sprite.Bounds()
+	shaderOp := &ebiten.DrawRectShaderOptions{
+		GeoM:       op.GeoM,
+		ColorScale: op.ColorScale,
+		Uniforms: map[string]any{
+			"Angle": float32(b.Angle),
+			"Blur":  float32(sim.SpinStep(b.Delivery.Spin)),
+		},
+	}
+	shaderOp.Images[0] = sprite
+	screen.DrawRectShader(bounds.Dx(), bounds.Dy(), shader, shaderOp)
+	return true
+}
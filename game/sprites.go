@@ -0,0 +1,84 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// Bat sprites for each bat size in use, so they are only scaled once
+var batSprites = make(map[float64]*ebiten.Image)
+
+// scaledSprites are the other sprites drawn at sizes other than their usual one, so that
+// they are only scaled once
+var scaledSprites = make(map[scaledSpriteKey]*ebiten.Image)
+
+type scaledSpriteKey struct {
+	source *ebiten.Image
+	scale  float64
+}
+
+// spriteSizes are the sizes of the sprites, for the field to work out its collisions from
+func spriteSizes() sim.Sizes {
+	return sim.Sizes{
+		Ball:   imageSize(assets.BallSprite),
+		Bat:    imageSize(assets.BatSprite),
+		Stumps: imageSize(assets.StumpsSprite),
+	}
+}
+
+func imageSize(img *ebiten.Image) geometry.Vector {
+	bounds := img.Bounds()
+	return geometry.Vector{X: float64(bounds.Dx()), Y: float64(bounds.Dy())}
+}
+
+// batSprite returns the bat sprite scaled to the given size, 1 being the usual size
+func batSprite(size float64) *ebiten.Image {
+	if size <= 0 || size == 1 {
+		return assets.BatSprite
+	}
+	if sprite, ok := batSprites[size]; ok {
+		return sprite
+	}
+
+	bounds := assets.BatSprite.Bounds()
+	sprite := ebiten.NewImage(int(float64(bounds.Dx())*size), int(float64(bounds.Dy())*size))
+	options := &ebiten.DrawImageOptions{Filter: assets.Filter()}
+	options.GeoM.Scale(size, size)
+	sprite.DrawImage(assets.BatSprite, options)
+	batSprites[size] = sprite
+
+	return sprite
+}
+
+// ballSprite is the ball sprite at the given scale
+func ballSprite(scale float64) *ebiten.Image {
+	return scaledSprite(assets.BallSprite, scale)
+}
+
+// scaledSprite is a sprite drawn at the given scale, 1 being its usual size
+func scaledSprite(source *ebiten.Image, scale float64) *ebiten.Image {
+	if scale == 1 {
+		return source
+	}
+	key := scaledSpriteKey{source: source, scale: scale}
+	if sprite, ok := scaledSprites[key]; ok {
+		return sprite
+	}
+
+	sprite := scaledImage(source, scale)
+	scaledSprites[key] = sprite
+	return sprite
+}
+
+// scaledImage draws an image at a different size
+func scaledImage(source *ebiten.Image, scale float64) *ebiten.Image {
+	scale = assets.Scale(scale)
+	bounds := source.Bounds()
+	scaled := ebiten.NewImage(max(int(float64(bounds.Dx())*scale), 1), max(int(float64(bounds.Dy())*scale), 1))
+	options := &ebiten.DrawImageOptions{Filter: assets.Filter()}
+	options.GeoM.Scale(scale, scale)
+	scaled.DrawImage(source, options)
+	return scaled
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// settleTie starts a super over, or sudden death after a tied super over, if the match has
+// ended level. It returns false if the match wasn't tied.
+func (g *Game) settleTie(state sim.MatchState) bool {
+	tb, ok := g.world.Mode.(sim.TieBreaker)
+	if !ok || !tb.Tied(state) {
+		return false
+	}
+
+	if g.superOver == nil {
+		g.superOver = &sim.SuperOver{MainScore: g.world.Score, MainBalls: g.world.BallsFaced(), Mode: sim.NewSuperOverMode(g.world.RNG.Extras)}
+		g.world.Announce("SCORES LEVEL! SUPER OVER")
+	} else {
+		g.superOver.Mode.SuddenDeath = true
+		g.world.Announce("SUPER OVER TIED! SUDDEN DEATH")
+	}
+
+	g.logger.Info("match tied", "main_score", g.superOver.MainScore, "mode", g.superOver.Mode.Description())
+	announcement := g.world.Announcement
+	g.logInnings()
+	g.world.NextInnings(g.superOver.Mode)
+	g.world.Announce(announcement)
+
+	return true
+}
+
+// matchBalls is how many balls the batsman faced in the match, leaving out any super over
+func (g *Game) matchBalls() int {
+	if g.superOver != nil {
+		return g.superOver.MainBalls
+	}
+	return g.world.BallsFaced()
+}
+
+// matchScore is the score that counts for the match, which a super over doesn't change
+func (g *Game) matchScore() int {
+	if g.superOver != nil {
+		return g.superOver.MainScore
+	}
+	if s, ok := g.world.Mode.(sim.Scorer); ok {
+		return s.Points(g.world.MatchState())
+	}
+	return g.world.Score
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/team"
+)
+
+// teamPlayer is implemented by modes played with an XI, which is picked before the match
+type teamPlayer interface {
+	PlaysWithTeam() bool
+}
+
+// teamSelection is the state of the screen for picking an XI before a match
+type teamSelection struct {
+	squad  []team.Batsman
+	picked []string // Names in batting order
+	cursor int
+}
+
+func newTeamSelection(picked []string) *teamSelection {
+	ts := &teamSelection{
+		squad:  team.Squad(),
+		picked: slices.Clone(picked),
+	}
+
+	// Start from the default XI if the saved one no longer makes a team
+	if _, err := team.Pick(ts.picked); err != nil {
+		ts.picked = team.DefaultXI()
+	}
+
+	return ts
+}
+
+// toggle drops the player under the cursor from the team, or adds them to the end of the
+// batting order if there is room
+func (ts *teamSelection) toggle() {
+	name := ts.squad[ts.cursor].Name
+	if i := slices.Index(ts.picked, name); i >= 0 {
+		ts.picked = slices.Delete(ts.picked, i, i+1)
+		return
+	}
+	if len(ts.picked) < team.XISize {
+		ts.picked = append(ts.picked, name)
+	}
+}
+
+// startTeamSelection shows the team selection screen, if the mode plays with a team. A
+// replay that kept its lineup, or an online match, bats the lineup it has without asking.
+func (g *Game) startTeamSelection() bool {
+	if tp, ok := g.mode.(teamPlayer); !ok || !tp.PlaysWithTeam() {
+		return false
+	}
+	if g.playsBy() != nil {
+		return false
+	}
+
+	g.teamSelection = newTeamSelection(g.profileManager.XI())
+	g.userMessage = ""
+	g.state = GameStateTeamSelection
+	return true
+}
+
+func (g *Game) updateTeamSelection() {
+	ts := g.teamSelection
+
+	switch {
+	case g.keyJustPressed(ebiten.KeyUp):
+		ts.cursor = (ts.cursor + len(ts.squad) - 1) % len(ts.squad)
+	case g.keyJustPressed(ebiten.KeyDown):
+		ts.cursor = (ts.cursor + 1) % len(ts.squad)
+	case g.keyJustPressed(ebiten.KeySpace):
+		ts.toggle()
+	case g.keyJustPressed(ebiten.KeyEnter):
+		lineup, err := team.Pick(ts.picked)
+		if err != nil {
+			g.userMessage = err.Error()
+			return
+		}
+
+		if err := g.profileManager.SetXI(ts.picked); err != nil {
+			g.logger.Warn("could not save team", "error", err)
+		}
+		g.world.SetLineup(lineup)
+		g.userMessage = ""
+		g.state = GameStatePlaying
+		g.logger.Info("team picked", "xi", ts.picked)
+	}
+}
+
+func (g *Game) drawTeamSelection(screen *ebiten.Image) {
+	const (
+		titleX       float64 = 20
+		titleY       float64 = 30
+		instructionX float64 = 20
+		instructionY float64 = 70
+		rowsX        float64 = 20
+		rowsY        float64 = 120
+		rowSpacing   float64 = 30
+	)
+
+	g.drawText(screen, "PICK YOUR XI", titleX, titleY, 1, 1, color.RGBA{255, 255, 0, 255})
+	g.drawText(screen, fmt.Sprintf("Up/Down to move, Space to pick or drop, Enter to start (%d/%d picked)", len(g.teamSelection.picked), team.XISize),
+		instructionX, instructionY, 1, 1, color.White)
+
+	for i, player := range g.teamSelection.squad {
+		cursor := "  "
+		if i == g.teamSelection.cursor {
+			cursor = "> "
+		}
+
+		order := "   "
+		textColor := color.Color(color.RGBA{150, 150, 150, 255})
+		if position := slices.Index(g.teamSelection.picked, player.Name); position >= 0 {
+			order = fmt.Sprintf("%2d.", position+1)
+			textColor = color.White
+		}
+
+		row := fmt.Sprintf("%s%s %-10s power %.2f  timing %.2f  bat %.2f", cursor, order, player.Name, player.Power, player.Timing, player.BatSize)
+		g.drawText(screen, row, rowsX, rowsY+float64(i)*rowSpacing, 1, 1, textColor)
+	}
+
+	var (
+		userMessageX float64 = 20
+		userMessageY float64 = rowsY + float64(len(g.teamSelection.squad))*rowSpacing + 20
+	)
+	g.drawText(screen, g.userMessage, userMessageX, userMessageY, 1, 1, color.RGBA{255, 50, 50, 255})
+}
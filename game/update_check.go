@@ -0,0 +1,66 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"net/http"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/version"
+)
+
+const updateCheckTimeout = 5 * time.Second
+
+// startUpdateCheck looks for a newer release in the background, if the player allows it.
+// Development builds have no version to compare, so they never check.
+func (g *Game) startUpdateCheck() {
+	if !g.cfg.GetUpdateCheck() || !version.IsRelease() {
+		return
+	}
+
+	updates := make(chan version.Release, 1)
+	g.updates = updates
+	go func() {
+		defer close(updates)
+		ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+		defer cancel()
+
+		release, ok, err := version.CheckForUpdate(ctx, http.DefaultClient)
+		if err != nil {
+			g.logger.Debug("could not check for updates", "error", err)
+			return
+		}
+		if ok {
+			updates <- release
+		}
+	}()
+}
+
+// pollUpdateCheck picks up the result of the update check once it is in
+func (g *Game) pollUpdateCheck() {
+	select {
+	case release, ok := <-g.updates:
+		if ok {
+			g.logger.Info("update available", "current", version.Current(), "latest", release.Tag)
+			g.availableUpdate = &release
+		}
+		g.updates = nil
+	default:
+	}
+}
+
+// drawUpdateNotice tells the player about a newer release, with what changed in it
+func (g *Game) drawUpdateNotice(screen *ebiten.Image, x, y float64) {
+	if g.availableUpdate == nil {
+		return
+	}
+
+	const lineSpacing float64 = 25
+	release := g.availableUpdate
+	g.drawText(screen, fmt.Sprintf("Version %s is out (you have %s): %s", release.Tag, version.Current(), release.URL), x, y, 1, 1, color.RGBA{100, 200, 255, 255})
+	for i, line := range release.Changelog {
+		g.drawText(screen, "  "+line, x, y+float64(i+1)*lineSpacing, 1, 1, color.White)
+	}
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+const defaultOpponentProfileName = "player2"
+
+// headToHead is implemented by modes in which two players bat an innings each, the second
+// chasing the first
+type headToHead interface {
+	// Innings is the mode set up for an innings, with no setter or target in the first
+	Innings(batting, setter string, target int) sim.Mode
+	// Batting is the player at the crease
+	Batting() string
+}
+
+// versusMatch is a hot-seat match in progress between two profiles
+type versusMatch struct {
+	players    [2]string // In batting order
+	firstScore int       // -1 until the first innings is over
+	rivalry    *RivalryManager
+}
+
+// startVersusMatch shows the head-to-head record before a versus match, if the mode is one
+func (g *Game) startVersusMatch() bool {
+	g.versusMatch = nil
+	mode, ok := g.mode.(headToHead)
+	if !ok {
+		return false
+	}
+
+	player, opponent := g.profileManager.Name(), g.cfg.GetOpponentProfile()
+	if len(opponent) == 0 {
+		opponent = defaultOpponentProfileName
+	}
+
+	rivalry, err := NewRivalryManager(g.cfg, player, opponent)
+	if err != nil {
+		g.logger.Warn("could not load rivalry", "error", err)
+		return false
+	}
+
+	g.versusMatch = &versusMatch{players: [2]string{player, opponent}, firstScore: -1, rivalry: rivalry}
+	g.world.StartInnings(mode.Innings(player, "", 0))
+
+	g.userMessage = ""
+	g.state = GameStatePreMatch
+	return true
+}
+
+// changeInnings hands the bat to the second player once the first innings is over, after
+// a round of catching practice for bonus runs. It returns false if there is no innings to
+// change to.
+func (g *Game) changeInnings() bool {
+	vm := g.versusMatch
+	if vm == nil || vm.firstScore >= 0 {
+		return false
+	}
+
+	vm.firstScore = g.world.Score
+	target := vm.firstScore + 1
+	mode := g.mode.(headToHead).Innings(vm.players[1], vm.players[0], target)
+	g.logInnings()
+	g.startInningsBreak(fmt.Sprintf("INNINGS BREAK: %s NEEDS %d", strings.ToUpper(vm.players[1]), target), true, func() {
+		g.world.NextInnings(mode)
+		g.bestShots.reset()
+		g.startCatching(vm.players[1])
+	})
+
+	g.logger.Info("innings changed", "first_score", vm.firstScore, "batting", vm.players[1])
+	return true
+}
+
+// recordVersusMatch adds the result of a finished versus match to the rivalry
+func (g *Game) recordVersusMatch() {
+	vm := g.versusMatch
+	if vm == nil || vm.firstScore < 0 {
+		return
+	}
+
+	if err := vm.rivalry.Record(vm.players[0], vm.firstScore, vm.players[1], g.world.Score); err != nil {
+		g.logger.Warn("could not save rivalry", "error", err)
+	}
+	g.logger.Info("versus match recorded", "first", vm.players[0], "first_score", vm.firstScore, "second", vm.players[1], "second_score", g.world.Score)
+}
+
+func (g *Game) updatePreMatch() {
+	if g.keyJustPressed(ebiten.KeyEnter) {
+		g.state = GameStatePlaying
+	}
+}
+
+func (g *Game) drawPreMatch(screen *ebiten.Image) {
+	const (
+		titleX      float64 = 20
+		titleY      float64 = 30
+		linesX      float64 = 20
+		linesY      float64 = 90
+		lineSpacing float64 = 30
+	)
+
+	vm := g.versusMatch
+	g.drawText(screen, vm.players[0]+" v "+vm.players[1], titleX, titleY, 2, 2, color.RGBA{255, 255, 0, 255})
+
+	lines := append(vm.rivalry.Rivalry().Lines(), "", vm.players[0]+" bats first. Press Enter to start")
+	for i, line := range lines {
+		g.drawText(screen, line, linesX, linesY+float64(i)*lineSpacing, 1, 1, color.White)
+	}
+}
+
+// drawRivalry shows the updated head-to-head record after a versus match
+func (g *Game) drawRivalry(screen *ebiten.Image) {
+	if g.versusMatch == nil {
+		return
+	}
+
+	const (
+		linesX      float64 = 20
+		linesY      float64 = 30
+		lineSpacing float64 = 30
+	)
+
+	for i, line := range g.versusMatch.rivalry.Rivalry().Lines() {
+		g.drawText(screen, line, linesX, linesY+float64(i)*lineSpacing, 1, 1, color.White)
+	}
+}
@@ -0,0 +1,136 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/assets"
+	"github.com/meghashyamc/cricket2d/config"
+)
+
+const attentionTitlePrefix = "(!) "
+
+// checkFocus pauses a match when the window loses focus, and badges the window's icon and
+// title so the player can see from the taskbar that a match is waiting for them
+func (g *Game) checkFocus() {
+	focused := ebiten.IsFocused()
+	switch {
+	case !focused && g.state == GameStatePlaying && !g.needsAttention:
+		g.state = GameStatePaused
+		g.needsAttention = true
+		g.throttle()
+		ebiten.SetWindowIcon(assets.WindowIcons(true))
+		ebiten.SetWindowTitle(attentionTitlePrefix + g.cfg.GetWindowTitle())
+		g.logger.Debug("match paused while the window is in the background")
+	case focused && g.needsAttention:
+		g.needsAttention = false
+		g.unthrottle()
+		ebiten.SetWindowIcon(assets.WindowIcons(false))
+		ebiten.SetWindowTitle(g.cfg.GetWindowTitle())
+	}
+}
+
+const backgroundTPS = 5 // Ticks, and so frames, a second while a paused match waits in the background
+
+// throttle slows the game to a trickle of ticks and frames, and silences it, while a paused
+// match waits in the background, to save the battery
+func (g *Game) throttle() {
+	g.throttled = true
+	ebiten.SetTPS(backgroundTPS)
+	// Frames that aren't drawn leave the last one on screen
+	ebiten.SetScreenClearedEveryFrame(false)
+	g.sound.Suspend()
+	g.logger.Debug("throttled in the background", "tps", backgroundTPS)
+}
+
+// unthrottle brings the game back to full speed once the player returns
+func (g *Game) unthrottle() {
+	g.throttled = false
+	ebiten.SetTPS(ebiten.DefaultTPS)
+	ebiten.SetScreenClearedEveryFrame(true)
+	g.sound.Resume()
+	g.logger.Debug("back to full speed")
+}
+
+// frameDue is false for frames that can be skipped while throttled, so that a frame is only
+// drawn after a tick
+func (g *Game) frameDue() bool {
+	if !g.throttled {
+		return true
+	}
+	due := g.ticked
+	g.ticked = false
+	return due
+}
+
+const windowTrackTicks = ebiten.DefaultTPS // How often the window's placement is noted
+
+// restoreWindow puts the window back where it was when the game was last closed. If that
+// monitor has gone, the window opens centred on the primary monitor instead. Either way
+// the window is kept small enough to fit on its monitor.
+func (g *Game) restoreWindow() {
+	if !g.cfg.GetRememberWindow() {
+		return
+	}
+	placement, ok := g.cfg.LoadWindowPlacement()
+	if !ok {
+		return
+	}
+
+	monitors := ebiten.AppendMonitors(nil)
+	if len(monitors) == 0 {
+		return
+	}
+	monitor := monitors[0]
+	onSavedMonitor := false
+	for _, m := range monitors {
+		if m.Name() == placement.Monitor {
+			monitor, onSavedMonitor = m, true
+			break
+		}
+	}
+
+	monitorWidth, monitorHeight := monitor.Size()
+	width, height := placement.Width, placement.Height
+	if monitorWidth > 0 && monitorHeight > 0 {
+		width, height = min(width, monitorWidth), min(height, monitorHeight)
+	}
+	ebiten.SetMonitor(monitor)
+	ebiten.SetWindowSize(width, height)
+
+	if !onSavedMonitor {
+		g.logger.Info("saved monitor not found, opening on the primary monitor", "monitor", placement.Monitor)
+		return
+	}
+	x, y := placement.X, placement.Y
+	if monitorWidth > 0 && monitorHeight > 0 {
+		x, y = max(min(x, monitorWidth-width), 0), max(min(y, monitorHeight-height), 0)
+	}
+	ebiten.SetWindowPosition(x, y)
+	g.logger.Debug("window placement restored", "monitor", placement.Monitor, "x", x, "y", y, "width", width, "height", height)
+}
+
+// trackWindow notes where the window is, so it can be saved when the game closes
+func (g *Game) trackWindow() {
+	g.windowTicks++
+	if g.windowTicks < windowTrackTicks {
+		return
+	}
+	g.windowTicks = 0
+
+	placement := config.WindowPlacement{}
+	if monitor := ebiten.Monitor(); monitor != nil {
+		placement.Monitor = monitor.Name()
+	}
+	placement.X, placement.Y = ebiten.WindowPosition()
+	placement.Width, placement.Height = ebiten.WindowSize()
+	g.windowPlacement = &placement
+}
+
+// saveWindow remembers the window's last noted placement for the next time the game starts
+func (g *Game) saveWindow() {
+	if !g.cfg.GetRememberWindow() || g.windowPlacement == nil {
+		return
+	}
+	if err := g.cfg.SaveWindowPlacement(*g.windowPlacement); err != nil {
+		g.logger.Warn("could not save window placement", "error", err)
+	}
+}
@@ -0,0 +1,26 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// drawWorld draws the field, and the balls in play too unless withBalls is false
+func drawWorld(screen *ebiten.Image, w *sim.World, cosmetics looks, withBalls bool) {
+	drawBoundary(screen, w)
+	drawPitch(screen, w)
+	drawStumps(screen, w.Stumps)
+	drawFielders(screen, w)
+	drawRings(screen, w)
+	drawBat(screen, w.Bat, cosmetics.batTint)
+
+	if !withBalls {
+		return
+	}
+
+	for _, ball := range w.Balls {
+		drawTrail(screen, ball, cosmetics.trail)
+		drawBall(screen, ball)
+		drawFreeHitRing(screen, ball)
+	}
+}
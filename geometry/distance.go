@@ -12,3 +12,16 @@ func DistanceFromPointToLine(point, lineStart, lineEnd Vector) float64 {
 	pointVec := Vector{X: point.X - lineStart.X, Y: point.Y - lineStart.Y}
 	return pointVec.Magnitude() * math.Sin(pointVec.AngleTo(lineVec))
 }
+
+// DistanceFromPointToSegment calculates the distance from a point to the nearest point on
+// a line segment, which may be one of its ends
+func DistanceFromPointToSegment(point, segmentStart, segmentEnd Vector) float64 {
+	segment := segmentEnd.Subtract(segmentStart)
+	lengthSquared := segment.DotProduct(segment)
+	if lengthSquared == 0 {
+		return point.Subtract(segmentStart).Magnitude()
+	}
+
+	t := math.Max(0, math.Min(1, point.Subtract(segmentStart).DotProduct(segment)/lengthSquared))
+	return point.Subtract(segmentStart.Add(segment.Scale(t))).Magnitude()
+}
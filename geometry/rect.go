@@ -37,3 +37,21 @@ func (r Rect) Intersects(other Rect) bool {
 		r.Y <= other.MaxY() &&
 		other.Y <= r.MaxY()
 }
+
+// Contains is true if the point is inside the rectangle or on its edge
+func (r Rect) Contains(p Vector) bool {
+	return p.X >= r.X && p.X <= r.MaxX() && p.Y >= r.Y && p.Y <= r.MaxY()
+}
+
+// Intersection returns the overlap of two rectangles, which is empty if they don't overlap
+func (r Rect) Intersection(other Rect) Rect {
+	x := max(r.X, other.X)
+	y := max(r.Y, other.Y)
+	maxX := min(r.MaxX(), other.MaxX())
+	maxY := min(r.MaxY(), other.MaxY())
+
+	if maxX < x || maxY < y {
+		return Rect{}
+	}
+	return NewRect(x, y, maxX-x, maxY-y)
+}
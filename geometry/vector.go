@@ -67,6 +67,10 @@ func (v Vector) Add(other Vector) Vector {
 	return Vector{v.X + other.X, v.Y + other.Y}
 }
 
+func (v Vector) Subtract(other Vector) Vector {
+	return Vector{v.X - other.X, v.Y - other.Y}
+}
+
 func (v Vector) Scale(factor float64) Vector {
 	return Vector{v.X * factor, v.Y * factor}
 }
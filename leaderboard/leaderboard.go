@@ -0,0 +1,189 @@
+package leaderboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/persist"
+)
+
+const (
+	firstRetryDelay = time.Minute    // Wait after the first failed attempt, doubled after every failure since
+	maxRetryDelay   = 24 * time.Hour // Longest wait between attempts
+)
+
+// queueSchema is the layout of the queue file. A migration is added to it whenever the
+// layout changes.
+var queueSchema = persist.Schema{Name: "leaderboard queue"}
+
+// Entry is a score sent to the online leaderboard
+type Entry struct {
+	Name     string    `json:"name"`
+	Score    int       `json:"score"`
+	Mode     string    `json:"mode"`
+	Week     string    `json:"week,omitempty"` // The ISO week of a weekly board, such as 2026-W42, empty for the all-time one
+	Version  string    `json:"version"`
+	PlayedAt time.Time `json:"played_at"`
+}
+
+// pendingEntry is a score yet to reach the leaderboard, with when to try it next
+type pendingEntry struct {
+	Entry       Entry     `json:"entry"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+}
+
+// Queue holds scores until the leaderboard takes them, on disk so that a score made while
+// the leaderboard can't be reached is sent on a later launch. Scores that fail are tried
+// again less and less often.
+type Queue struct {
+	url      string
+	path     string
+	client   *http.Client
+	mu       sync.Mutex
+	pending  []pendingEntry
+	flushing sync.Mutex // Held while scores are being sent, so that only one flush runs at a time
+}
+
+// NewQueue loads the scores waiting at path to be sent to the leaderboard at url
+func NewQueue(url, path string, client *http.Client) (*Queue, error) {
+	q := &Queue{url: url, path: path, client: client}
+
+	var file struct {
+		Pending []pendingEntry `json:"pending"`
+	}
+	if err := queueSchema.Load(path, &file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return q, err
+	}
+	q.pending = file.Pending
+
+	return q, nil
+}
+
+// Add queues a score to be sent on the next flush
+func (q *Queue) Add(entry Entry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pending = append(q.pending, pendingEntry{Entry: entry})
+	return q.save()
+}
+
+// Pending is how many scores are waiting to be sent, and when the soonest of them will be
+// tried again
+func (q *Queue) Pending() (int, time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var next time.Time
+	for _, p := range q.pending {
+		if next.IsZero() || p.NextAttempt.Before(next) {
+			next = p.NextAttempt
+		}
+	}
+	return len(q.pending), next
+}
+
+// Flush sends every score that is due, dropping the ones the leaderboard takes and putting
+// off the rest. It returns how many were sent, and does nothing if a flush is already
+// running.
+func (q *Queue) Flush(ctx context.Context, now time.Time) (int, error) {
+	if !q.flushing.TryLock() {
+		return 0, nil
+	}
+	defer q.flushing.Unlock()
+
+	q.mu.Lock()
+	due := make([]pendingEntry, 0, len(q.pending))
+	for _, p := range q.pending {
+		if !p.NextAttempt.After(now) {
+			due = append(due, p)
+		}
+	}
+	q.mu.Unlock()
+
+	var (
+		sent   = make(map[Entry]bool, len(due))
+		failed = make(map[Entry]bool, len(due))
+		errs   []error
+	)
+	for _, p := range due {
+		if err := q.submit(ctx, p.Entry); err != nil {
+			failed[p.Entry] = true
+			errs = append(errs, err)
+			continue
+		}
+		sent[p.Entry] = true
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	kept := q.pending[:0]
+	for _, p := range q.pending {
+		if sent[p.Entry] {
+			continue
+		}
+		if failed[p.Entry] {
+			p.Attempts++
+			p.NextAttempt = now.Add(retryDelay(p.Attempts))
+		}
+		kept = append(kept, p)
+	}
+	q.pending = kept
+
+	if len(due) > 0 {
+		if err := q.save(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(sent), errors.Join(errs...)
+}
+
+// submit posts a score to the leaderboard
+func (q *Queue) submit(ctx context.Context, entry Entry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, q.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := q.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status submitting score: %s", response.Status)
+	}
+	return nil
+}
+
+func (q *Queue) save() error {
+	return queueSchema.Save(q.path, struct {
+		Pending []pendingEntry `json:"pending"`
+	}{q.pending})
+}
+
+// retryDelay is how long to wait before trying a score again after the given number of
+// failed attempts
+func retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for range attempts - 1 {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
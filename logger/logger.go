@@ -1,8 +1,10 @@
 package logger
 
 import (
+	"context"
 	"log/slog"
-	"os"
+	"runtime"
+	"time"
 )
 
 type Logger interface {
@@ -15,11 +17,67 @@ type Logger interface {
 	Debug(msg string, keyvals ...interface{})
 }
 
+// level is shared by every logger so that the minimum level can be changed at runtime
+var level = new(slog.LevelVar)
+
+func init() {
+	level.Set(slog.LevelDebug) // minimum log level - set to debug to enable debug logs
+}
+
+// logger writes entries as JSON to stderr, except for debug entries while a ring buffer is
+// in use, which wait in the buffer
+type logger struct {
+	handler slog.Handler
+}
+
 func New() Logger {
-	opts := &slog.HandlerOptions{
-		Level:     slog.LevelDebug, // minimum log level - set to debug to enable debug logs
-		AddSource: true,            // include file + line number
+	return &logger{handler: newHandler()}
+}
+
+func (l *logger) Info(msg string, keyvals ...interface{}) {
+	l.log(slog.LevelInfo, msg, keyvals)
+}
+
+func (l *logger) Warn(msg string, keyvals ...interface{}) {
+	l.log(slog.LevelWarn, msg, keyvals)
+}
+
+func (l *logger) Error(msg string, keyvals ...interface{}) {
+	l.log(slog.LevelError, msg, keyvals)
+}
+
+func (l *logger) Debug(msg string, keyvals ...interface{}) {
+	if !l.handler.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	if r := currentRing(); r != nil {
+		r.add(msg, keyvals)
+		return
+	}
+	l.log(slog.LevelDebug, msg, keyvals)
+}
+
+// log writes an entry, giving the caller of the method that called it as its source
+func (l *logger) log(lvl slog.Level, msg string, keyvals []interface{}) {
+	ctx := context.Background()
+	if !l.handler.Enabled(ctx, lvl) {
+		return
 	}
-	handler := slog.NewJSONHandler(os.Stderr, opts)
-	return slog.New(handler)
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // Skip Callers, log and the method calling it
+	record := slog.NewRecord(time.Now(), lvl, msg, pcs[0])
+	record.Add(keyvals...)
+	_ = l.handler.Handle(ctx, record)
+}
+
+// SetLevel changes the minimum level for all loggers, including ones already created
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// DebugEnabled is true if debug entries are being logged. Hot paths check it before calling
+// Debug, as passing the key values allocates even when the entry is then dropped.
+func DebugEnabled() bool {
+	return level.Level() <= slog.LevelDebug
 }
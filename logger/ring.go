@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const maxRingAttrs = 16 // Key value pairs kept for each entry, the rest being dropped
+
+// ringEntry is a debug entry held back in the ring buffer
+type ringEntry struct {
+	at      time.Time
+	pc      uintptr // Where the entry was logged from
+	msg     string
+	keyvals [maxRingAttrs]interface{}
+	n       int // Key value items in keyvals
+}
+
+// ring holds the most recent debug entries in memory, in space set aside up front, until
+// they are flushed. Writing to it costs no encoding, no system call and no allocation of
+// its own, which keeps debug logging from every ball update off stderr during play.
+type ring struct {
+	mu      sync.Mutex
+	entries []ringEntry
+	next    int // Where the next entry goes
+	count   int // Entries held, up to len(entries)
+	handler slog.Handler
+}
+
+var activeRing struct {
+	sync.RWMutex
+	ring *ring
+}
+
+// UseRing sends debug entries from every logger to a ring buffer of the given size, where
+// they wait to be flushed instead of being written out as they happen. Info, warnings and
+// errors are still written straight away. A size of 0 goes back to writing debug entries
+// straight away, flushing anything held first.
+func UseRing(size int) {
+	activeRing.Lock()
+	old := activeRing.ring
+	activeRing.ring = nil
+	if size > 0 {
+		activeRing.ring = &ring{entries: make([]ringEntry, size), handler: newHandler()}
+	}
+	activeRing.Unlock()
+
+	if old != nil {
+		old.flush()
+	}
+}
+
+// FlushRing writes out the debug entries held in the ring buffer, oldest first, and empties
+// it. It does nothing unless UseRing has been called.
+func FlushRing() {
+	activeRing.RLock()
+	r := activeRing.ring
+	activeRing.RUnlock()
+
+	if r != nil {
+		r.flush()
+	}
+}
+
+// currentRing is the ring buffer debug entries go to, if there is one
+func currentRing() *ring {
+	activeRing.RLock()
+	defer activeRing.RUnlock()
+	return activeRing.ring
+}
+
+// add copies an entry into the oldest slot, overwriting whatever was there
+func (r *ring) add(msg string, keyvals []interface{}) {
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // Skip Callers, add and the logger's Debug
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := &r.entries[r.next]
+	e.at = time.Now()
+	e.pc = pcs[0]
+	e.msg = msg
+	e.n = copy(e.keyvals[:], keyvals)
+	clear(e.keyvals[e.n:])
+
+	r.next = (r.next + 1) % len(r.entries)
+	r.count = min(r.count+1, len(r.entries))
+}
+
+func (r *ring) flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	start := (r.next - r.count + len(r.entries)) % len(r.entries)
+	for i := range r.count {
+		e := &r.entries[(start+i)%len(r.entries)]
+		record := slog.NewRecord(e.at, slog.LevelDebug, e.msg, e.pc)
+		record.Add(e.keyvals[:e.n]...)
+		if err := r.handler.Handle(context.Background(), record); err != nil {
+			break
+		}
+		clear(e.keyvals[:e.n])
+	}
+	r.count = 0
+}
+
+func newHandler() slog.Handler {
+	opts := &slog.HandlerOptions{
+		Level:     level,
+		AddSource: true, // include file + line number
+	}
+	return slog.NewJSONHandler(os.Stderr, opts)
+}
@@ -1,26 +1,191 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
 
 	"github.com/meghashyamc/cricket2d/config"
 	"github.com/meghashyamc/cricket2d/game"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/online"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/version"
 )
 
+var errNoLobby = errors.New("no lobby is configured; set online.lobby")
+
 func main() {
-	cfg, err := config.Load("")
+	showVersion := flag.Bool("version", false, "print the version and build details and exit")
+	configPath := flag.String("config", "", "path to a config file, instead of looking for one")
+	exportPath := flag.String("export-profile", "", "write the profile, high scores and rivalries to a bundle at this path and exit")
+	importPath := flag.String("import-profile", "", "load a bundle made with -export-profile into the profile and exit")
+	scenarioName := flag.String("scenario", "", "play a built-in scenario, such as last-over, or a scenario file")
+	challenges := flag.Bool("challenges", false, "start on the challenge menu")
+	mutators := flag.Bool("mutators", false, "start on the mutators screen")
+	featured := flag.Bool("featured", false, "play the week's featured rule set")
+	loadout := flag.String("loadout", "", "start with a loadout saved from the pause screen")
+	code := flag.String("code", "", "play the match in a challenge code shared by another player")
+	calibrate := flag.Bool("calibrate", false, "bowl the calibration balls again to recommend a difficulty")
+	apiAddr := flag.String("api", "", "serve the local control API at this address, such as 127.0.0.1:7777")
+	replayPath := flag.String("replay", "", "play back a replay file, such as one saved under replays in the data directory")
+	serverAddr := flag.String("server", "", "play an online match against the dedicated server at this address, such as example.com:7778")
+	listMatches := flag.Bool("matches", false, "list the open online matches in the configured lobby and exit")
+	joinName := flag.String("join", "", "play the open online match with this name in the configured lobby")
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Println(version.String())
+		return
+	}
+
+	cfg, err := config.LoadFile("", *configPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to load config: %s\n", err)
 		os.Exit(1)
 	}
-	g, err := game.NewGame(cfg)
+
+	logger.UseRing(cfg.GetLogRing())
+
+	if len(*scenarioName) > 0 {
+		cfg.SetScenario(*scenarioName)
+	}
+	if *challenges {
+		cfg.SetChallenges(true)
+	}
+	if *mutators {
+		cfg.SetMutators(true)
+	}
+	if *featured {
+		cfg.SetMode(sim.ModeFeatured)
+	}
+	if len(*loadout) > 0 {
+		cfg.SetLoadout(*loadout)
+	}
+	if len(*code) > 0 {
+		cfg.SetChallengeCode(*code)
+	}
+	if *calibrate {
+		cfg.SetCalibrate(true)
+	}
+
+	if moved, err := cfg.MigrateLegacyData(); err != nil {
+		slog.Warn("could not move saves to the data directory", "data_dir", cfg.GetDataDir(), "err", err)
+	} else if moved > 0 {
+		slog.Info("moved saves to the data directory", "data_dir", cfg.GetDataDir(), "files", moved)
+	}
+
+	switch {
+	case *listMatches:
+		if err := printMatches(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to list online matches: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	case len(*exportPath) > 0:
+		if err := game.ExportProfile(cfg, *exportPath); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to export profile: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	case len(*importPath) > 0:
+		if err := game.ImportProfile(cfg, *importPath); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to import profile: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(*joinName) > 0 {
+		addr, err := joinMatch(cfg, *joinName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to join %q: %s\n", *joinName, err)
+			os.Exit(1)
+		}
+		*serverAddr = addr
+	}
+
+	var opts []game.Option
+	if len(*replayPath) > 0 {
+		r, err := replay.Load(*replayPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load replay: %s\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, game.WithReplay(r))
+	} else if len(*serverAddr) > 0 {
+		client := online.NewClient(*serverAddr)
+		if _, err := client.Hello(context.Background(), version.Current()); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to join the server: %s\n", err)
+			os.Exit(1)
+		}
+		match, err := client.Start(context.Background(), online.Start{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to start an online match: %s\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, game.WithOnlineMatch(client, match))
+	}
+
+	g, err := game.NewGame(cfg, opts...)
 	if err != nil {
 		os.Exit(1)
 	}
+	if len(*apiAddr) > 0 {
+		if err := g.StartControlAPI(*apiAddr); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to start control API: %s\n", err)
+			os.Exit(1)
+		}
+	}
 	if err := g.Run(); err != nil {
+		logger.FlushRing()
 		slog.Error("error running game", "err", err)
 		os.Exit(1)
 	}
 }
+
+// printMatches lists the open matches in the lobby, marking the ones on servers this build
+// can't play against
+func printMatches(cfg *config.Config) error {
+	if len(cfg.GetLobbyURL()) == 0 {
+		return errNoLobby
+	}
+	listings, err := online.NewLobbyClient(cfg.GetLobbyURL()).Matches(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if len(listings) == 0 {
+		fmt.Println("No open matches")
+	}
+	for _, l := range listings {
+		line := fmt.Sprintf("%-40s %s", l.Name, l.Mode)
+		if !l.Playable() {
+			line += " (on a server this build can't play against)"
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// joinMatch takes the open match with the given name in the lobby, returning the address of
+// the server it is played on
+func joinMatch(cfg *config.Config, name string) (string, error) {
+	if len(cfg.GetLobbyURL()) == 0 {
+		return "", errNoLobby
+	}
+	lobby := online.NewLobbyClient(cfg.GetLobbyURL())
+	listing, err := lobby.Find(context.Background(), name)
+	if err != nil {
+		return "", err
+	}
+	connection, err := lobby.Join(context.Background(), listing.ID, version.Current())
+	if err != nil {
+		return "", err
+	}
+	return connection.Addr, nil
+}
@@ -0,0 +1,194 @@
+// Package metrics keeps counters, gauges and histograms and serves them in the Prometheus
+// text format, so that a long running game can be monitored
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// Registry holds metrics and writes them out in the order they were registered
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+}
+
+type metric interface {
+	write(w io.Writer)
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metrics = append(r.metrics, m)
+}
+
+// ServeHTTP writes every metric in the Prometheus text format
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.metrics {
+		m.write(w)
+	}
+}
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// Counter is a count that only goes up
+type Counter struct {
+	name, help string
+	mu         sync.Mutex
+	value      float64
+}
+
+func (r *Registry) Counter(name, help string) *Counter {
+	c := &Counter{name: name, help: help}
+	r.register(c)
+	return c
+}
+
+func (c *Counter) Add(delta float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.value += delta
+}
+
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+func (c *Counter) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	fmt.Fprintf(w, "%s %s\n", c.name, formatFloat(c.value))
+}
+
+// CounterVec is a set of counters told apart by the value of one label
+type CounterVec struct {
+	name, help, label string
+	mu                sync.Mutex
+	values            map[string]float64
+}
+
+func (r *Registry) CounterVec(name, help, label string) *CounterVec {
+	c := &CounterVec{name: name, help: help, label: label, values: make(map[string]float64)}
+	r.register(c)
+	return c
+}
+
+// Inc adds one to the counter with the given label value
+func (c *CounterVec) Inc(labelValue string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[labelValue]++
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	labelValues := make([]string, 0, len(c.values))
+	for labelValue := range c.values {
+		labelValues = append(labelValues, labelValue)
+	}
+	slices.Sort(labelValues)
+	for _, labelValue := range labelValues {
+		fmt.Fprintf(w, "%s{%s=%q} %s\n", c.name, c.label, labelValue, formatFloat(c.values[labelValue]))
+	}
+}
+
+// Gauge is a value that can go up and down
+type Gauge struct {
+	name, help string
+	mu         sync.Mutex
+	value      float64
+}
+
+func (r *Registry) Gauge(name, help string) *Gauge {
+	g := &Gauge{name: name, help: help}
+	r.register(g)
+	return g
+}
+
+func (g *Gauge) Set(value float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.value = value
+}
+
+func (g *Gauge) write(w io.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	writeHeader(w, g.name, g.help, "gauge")
+	fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(g.value))
+}
+
+// Histogram counts observations into buckets by their upper bounds
+type Histogram struct {
+	name, help string
+	mu         sync.Mutex
+	bounds     []float64 // Ascending upper bounds, the last being +Inf
+	counts     []uint64  // Observations no greater than each bound, not yet accumulated
+	sum        float64
+	count      uint64
+}
+
+// Histogram registers a histogram with the given bucket upper bounds, in ascending order.
+// A +Inf bucket is added at the end.
+func (r *Registry) Histogram(name, help string, bounds []float64) *Histogram {
+	bounds = append(slices.Clone(bounds), math.Inf(1))
+	h := &Histogram{name: name, help: help, bounds: bounds, counts: make([]uint64, len(bounds))}
+	r.register(h)
+	return h
+}
+
+func (h *Histogram) Observe(value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	i, _ := slices.BinarySearch(h.bounds, value)
+	h.counts[i]++
+	h.sum += value
+	h.count++
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	writeHeader(w, h.name, h.help, "histogram")
+	cumulative := uint64(0)
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		fmt.Fprintf(w, "%s_bucket{le=%q} %d\n", h.name, formatFloat(bound), cumulative)
+	}
+	fmt.Fprintf(w, "%s_sum %s\n%s_count %d\n", h.name, formatFloat(h.sum), h.name, h.count)
+}
@@ -0,0 +1,259 @@
+package mods
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expressions are kept short so that a mod can't slow the game down
+const maxExpressionLength = 200
+
+// expr is a compiled integer expression. Comparisons and logical operators give 1 for true
+// and 0 for false, and dividing by zero gives 0, so evaluating an expression can never fail.
+type expr interface {
+	eval(vars map[string]int) int
+}
+
+type literal int
+
+func (l literal) eval(map[string]int) int { return int(l) }
+
+type variable string
+
+func (v variable) eval(vars map[string]int) int { return vars[string(v)] }
+
+type unary struct {
+	op      string
+	operand expr
+}
+
+func (u unary) eval(vars map[string]int) int {
+	value := u.operand.eval(vars)
+	if u.op == "-" {
+		return -value
+	}
+	return boolToInt(value == 0)
+}
+
+type binary struct {
+	op          string
+	left, right expr
+}
+
+func (b binary) eval(vars map[string]int) int {
+	left := b.left.eval(vars)
+
+	// Short circuit the logical operators
+	switch b.op {
+	case "&&":
+		return boolToInt(left != 0 && b.right.eval(vars) != 0)
+	case "||":
+		return boolToInt(left != 0 || b.right.eval(vars) != 0)
+	}
+
+	right := b.right.eval(vars)
+	switch b.op {
+	case "+":
+		return left + right
+	case "-":
+		return left - right
+	case "*":
+		return left * right
+	case "/":
+		if right == 0 {
+			return 0
+		}
+		return left / right
+	case "%":
+		if right == 0 {
+			return 0
+		}
+		return left % right
+	case "==":
+		return boolToInt(left == right)
+	case "!=":
+		return boolToInt(left != right)
+	case "<":
+		return boolToInt(left < right)
+	case "<=":
+		return boolToInt(left <= right)
+	case ">":
+		return boolToInt(left > right)
+	default: // ">="
+		return boolToInt(left >= right)
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Binary operators from the loosest binding to the tightest
+var precedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+	known  map[string]bool
+}
+
+// compile parses an expression, allowing only the given variable names
+func compile(source string, known map[string]bool) (expr, error) {
+	if len(source) > maxExpressionLength {
+		return nil, fmt.Errorf("expression is longer than %d characters", maxExpressionLength)
+	}
+
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, known: known}
+	e, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+
+	return e, nil
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) parseBinary(level int) (expr, error) {
+	if level == len(precedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		if !slices.Contains(precedence[level], op) {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	token := p.peek()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+
+	case token == "-" || token == "!":
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unary{op: token, operand: operand}, nil
+
+	case token == "(":
+		p.pos++
+		e, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return e, nil
+
+	case unicode.IsDigit(rune(token[0])):
+		p.pos++
+		value, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", token)
+		}
+		return literal(value), nil
+
+	case isIdentifierStart(rune(token[0])):
+		p.pos++
+		if !p.known[token] {
+			return nil, fmt.Errorf("unknown variable %q", token)
+		}
+		return variable(token), nil
+
+	default:
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+}
+
+func tokenize(source string) ([]string, error) {
+	tokens := make([]string, 0)
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+
+		case isIdentifierStart(r):
+			start := i
+			for i < len(runes) && (isIdentifierStart(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+
+		default:
+			if i+1 < len(runes) {
+				if two := string(runes[i : i+2]); slices.Contains([]string{"&&", "||", "==", "!=", "<=", ">="}, two) {
+					tokens = append(tokens, two)
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("+-*/%<>!()", r) {
+				return nil, fmt.Errorf("unexpected character %q", r)
+			}
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+func isIdentifierStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
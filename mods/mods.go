@@ -0,0 +1,204 @@
+package mods
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Event is a moment in the game that mods can react to
+type Event string
+
+const (
+	EventSpawn     Event = "spawn"
+	EventHit       Event = "hit"
+	EventDismissal Event = "dismissal"
+)
+
+// Limits that stop a mod from taking over the game
+const (
+	maxModFileBytes       = 64 * 1024
+	maxRulesPerMod        = 32
+	maxSpawnBallsPerRule  = 3
+	maxSpawnBallsPerEvent = 4 // Across every rule of every mod, so that mods together can't flood the field
+	maxScorePerRule       = 10
+	maxMessageLength      = 60
+)
+
+// State is what a mod's conditions can look at
+type State struct {
+	Score       int
+	Hits        int
+	BallsBowled int
+	BallsInPlay int
+}
+
+// vars names the state for use in expressions
+func (s State) vars() map[string]int {
+	return map[string]int{
+		"score":         s.Score,
+		"hits":          s.Hits,
+		"balls_bowled":  s.BallsBowled,
+		"balls_in_play": s.BallsInPlay,
+	}
+}
+
+var knownVars = func() map[string]bool {
+	known := make(map[string]bool)
+	for name := range (State{}).vars() {
+		known[name] = true
+	}
+	return known
+}()
+
+// Action is what the game should do in response to an event
+type Action struct {
+	SpawnBalls int    `yaml:"spawn_balls"`
+	AddScore   int    `yaml:"add_score"`
+	Message    string `yaml:"message"`
+}
+
+// Mod is a named set of rules loaded from a mod file
+type Mod struct {
+	Name  string
+	rules []rule
+}
+
+type rule struct {
+	on     Event
+	when   expr // nil if the rule always applies
+	action Action
+}
+
+type modFile struct {
+	Name  string     `yaml:"name"`
+	Rules []ruleFile `yaml:"rules"`
+}
+
+type ruleFile struct {
+	On   Event  `yaml:"on"`
+	When string `yaml:"when"`
+	Do   Action `yaml:"do"`
+}
+
+// LoadDir loads every .yaml mod in dir. Mods that fail to load are skipped and reported in
+// the returned error, alongside the mods that did load.
+func LoadDir(dir string) ([]*Mod, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	mods := make([]*Mod, 0, len(paths))
+	errs := make([]error, 0)
+	for _, path := range paths {
+		mod, err := load(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("mod %s: %w", filepath.Base(path), err))
+			continue
+		}
+		mods = append(mods, mod)
+	}
+
+	return mods, errors.Join(errs...)
+}
+
+func load(path string) (*Mod, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxModFileBytes {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxModFileBytes)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file modFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	if len(file.Rules) > maxRulesPerMod {
+		return nil, fmt.Errorf("more than %d rules", maxRulesPerMod)
+	}
+
+	mod := &Mod{
+		Name:  file.Name,
+		rules: make([]rule, 0, len(file.Rules)),
+	}
+	if len(mod.Name) == 0 {
+		mod.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	for i, rf := range file.Rules {
+		r, err := rf.compile()
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		mod.rules = append(mod.rules, r)
+	}
+
+	return mod, nil
+}
+
+func (rf ruleFile) compile() (rule, error) {
+	switch rf.On {
+	case EventSpawn, EventHit, EventDismissal:
+	default:
+		return rule{}, fmt.Errorf("unknown event %q", rf.On)
+	}
+
+	r := rule{
+		on:     rf.On,
+		action: rf.Do,
+	}
+
+	if len(strings.TrimSpace(rf.When)) > 0 {
+		when, err := compile(rf.When, knownVars)
+		if err != nil {
+			return rule{}, fmt.Errorf("invalid condition %q: %w", rf.When, err)
+		}
+		r.when = when
+	}
+
+	// Keep actions within bounds rather than trusting the mod
+	r.action.SpawnBalls = min(max(r.action.SpawnBalls, 0), maxSpawnBallsPerRule)
+	r.action.AddScore = min(max(r.action.AddScore, -maxScorePerRule), maxScorePerRule)
+	if message := []rune(r.action.Message); len(message) > maxMessageLength {
+		r.action.Message = string(message[:maxMessageLength])
+	}
+
+	return r, nil
+}
+
+// Fire runs the rules of all the given mods for an event, combining the actions of every
+// rule whose condition holds. The balls spawned are capped for the event as a whole.
+func Fire(mods []*Mod, event Event, state State) Action {
+	var combined Action
+	vars := state.vars()
+
+	for _, mod := range mods {
+		for _, r := range mod.rules {
+			if r.on != event || (r.when != nil && r.when.eval(vars) == 0) {
+				continue
+			}
+
+			combined.SpawnBalls += r.action.SpawnBalls
+			combined.AddScore += r.action.AddScore
+			if len(r.action.Message) > 0 {
+				combined.Message = r.action.Message
+			}
+		}
+	}
+	combined.SpawnBalls = min(combined.SpawnBalls, maxSpawnBallsPerEvent)
+
+	return combined
+}
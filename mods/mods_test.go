@@ -0,0 +1,101 @@
+package mods
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// loadMods writes each mod file to a fresh directory and loads them all
+func loadMods(t *testing.T, files ...string) []*Mod {
+	t.Helper()
+	dir := t.TempDir()
+	for i, file := range files {
+		path := filepath.Join(dir, string(rune('a'+i))+".yaml")
+		if err := os.WriteFile(path, []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mods, err := LoadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mods
+}
+
+func TestFire(t *testing.T) {
+	const spawnThree = `
+rules:
+  - on: spawn
+    do: {spawn_balls: 3}
+  - on: spawn
+    do: {spawn_balls: 3}
+`
+	tests := []struct {
+		name  string
+		files []string
+		event Event
+		state State
+		want  Action
+	}{
+		{
+			name:  "condition holds",
+			files: []string{"rules:\n  - on: hit\n    when: hits % 5 == 0\n    do: {add_score: 4, message: five}\n"},
+			event: EventHit,
+			state: State{Hits: 10},
+			want:  Action{AddScore: 4, Message: "five"},
+		},
+		{
+			name:  "condition fails",
+			files: []string{"rules:\n  - on: hit\n    when: hits % 5 == 0\n    do: {add_score: 4}\n"},
+			event: EventHit,
+			state: State{Hits: 7},
+		},
+		{
+			name:  "other event",
+			files: []string{"rules:\n  - on: dismissal\n    do: {add_score: -2}\n"},
+			event: EventHit,
+		},
+		{
+			name:  "rule limits",
+			files: []string{"rules:\n  - on: spawn\n    do: {spawn_balls: 50, add_score: 500}\n"},
+			event: EventSpawn,
+			want:  Action{SpawnBalls: maxSpawnBallsPerRule, AddScore: maxScorePerRule},
+		},
+		{
+			name:  "spawns capped across mods",
+			files: []string{spawnThree, spawnThree, spawnThree},
+			event: EventSpawn,
+			want:  Action{SpawnBalls: maxSpawnBallsPerEvent},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fire(loadMods(t, tt.files...), tt.event, tt.state); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDirSkipsBrokenMods(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"good.yaml":      "name: Good\nrules:\n  - on: hit\n    do: {add_score: 1}\n",
+		"event.yaml":     "rules:\n  - on: lunch\n    do: {add_score: 1}\n",
+		"condition.yaml": "rules:\n  - on: hit\n    when: runs > 3\n    do: {add_score: 1}\n",
+	}
+	for name, file := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mods, err := LoadDir(dir)
+	if err == nil {
+		t.Error("broken mods were not reported")
+	}
+	if len(mods) != 1 || mods[0].Name != "Good" {
+		t.Errorf("got %d mods, want only Good", len(mods))
+	}
+}
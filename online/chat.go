@@ -0,0 +1,159 @@
+package online
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FeatureChat is the match's chat, at /chat: lines of text and emotes from the players
+// connected to the server
+const FeatureChat Feature = "chat"
+
+// MaxChatText is the most characters of a line that are kept
+const MaxChatText = 120
+
+const (
+	maxChatLines = 50          // Lines the server keeps, for players who have just connected
+	maxChatName  = 20          // Characters of a player's name that are kept
+	chatCooldown = time.Second // Least time between one player's lines, so nobody floods the chat
+)
+
+// Emote is a quick message, sent with a key of its own rather than typed
+type Emote string
+
+const (
+	EmoteClap   Emote = "clap"
+	EmoteAppeal Emote = "appeal"
+	EmoteShot   Emote = "shot"
+)
+
+// Emotes are the emotes there are, in the order of their keys
+var Emotes = []Emote{EmoteClap, EmoteAppeal, EmoteShot}
+
+// Say is a player saying something in the chat: a line of text, or an emote
+type Say struct {
+	From  string `json:"from"`
+	Text  string `json:"text,omitempty"`
+	Emote Emote  `json:"emote,omitempty"`
+}
+
+// Line is something said in the chat, numbered in the order the server heard it
+type Line struct {
+	ID    int    `json:"id"`
+	From  string `json:"from"`
+	Text  string `json:"text,omitempty"`
+	Emote Emote  `json:"emote,omitempty"`
+}
+
+// Chat is the lines said since the ones a player has
+type Chat struct {
+	Lines []Line `json:"lines"`
+}
+
+// handleSay adds a line to the chat, unless its player said one too recently
+func (s *Server) handleSay(w http.ResponseWriter, r *http.Request) {
+	var say Say
+	if err := json.NewDecoder(r.Body).Decode(&say); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	line := Line{From: printable(say.From, maxChatName), Text: printable(say.Text, MaxChatText), Emote: say.Emote}
+	switch {
+	case len(line.From) == 0:
+		http.Error(w, "a line needs a name to say it", http.StatusBadRequest)
+		return
+	case len(line.Text) == 0 && len(line.Emote) == 0, len(line.Text) > 0 && len(line.Emote) > 0:
+		http.Error(w, "a line is either text or an emote", http.StatusBadRequest)
+		return
+	case len(line.Emote) > 0 && !slices.Contains(Emotes, line.Emote):
+		http.Error(w, fmt.Sprintf("there is no %q emote", line.Emote), http.StatusBadRequest)
+		return
+	}
+
+	// Players are told apart by where they connect from, so changing name doesn't get round
+	// the cooldown
+	player, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		player = r.RemoteAddr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if last, ok := s.lastSaid[player]; ok && now.Sub(last) < chatCooldown {
+		http.Error(w, "slow down, one line a second", http.StatusTooManyRequests)
+		return
+	}
+	s.lastSaid[player] = now
+
+	s.lineID++
+	line.ID = s.lineID
+	s.chat = append(s.chat, line)
+	if len(s.chat) > maxChatLines {
+		s.chat = slices.Delete(s.chat, 0, len(s.chat)-maxChatLines)
+	}
+	writeJSON(w, line)
+}
+
+// handleChat replies with the lines after the one given as after, or all of those kept
+func (s *Server) handleChat(w http.ResponseWriter, r *http.Request) {
+	after := 0
+	if param := r.URL.Query().Get("after"); len(param) > 0 {
+		var err error
+		if after, err = strconv.Atoi(param); err != nil {
+			http.Error(w, "after is the ID of a line", http.StatusBadRequest)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	chat := Chat{Lines: []Line{}}
+	for _, line := range s.chat {
+		if line.ID > after {
+			chat.Lines = append(chat.Lines, line)
+		}
+	}
+	writeJSON(w, chat)
+}
+
+// printable is the text without what can't be drawn, trimmed to at most the given length
+func printable(text string, length int) string {
+	text = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsPrint(r) {
+			return r
+		}
+		return -1
+	}, text))
+	if runes := []rune(text); len(runes) > length {
+		text = strings.TrimSpace(string(runes[:length]))
+	}
+	return text
+}
+
+// Say adds a line to the match's chat. Only servers with FeatureChat have one.
+func (c *Client) Say(ctx context.Context, say Say) (Line, error) {
+	var line Line
+	if err := c.post(ctx, "/chat", say, &line); err != nil {
+		return Line{}, err
+	}
+	return line, nil
+}
+
+// Chat is the lines said after the one with the given ID, or all of those kept for 0
+func (c *Client) Chat(ctx context.Context, after int) (Chat, error) {
+	var chat Chat
+	if err := c.get(ctx, "/chat?after="+strconv.Itoa(after), &chat); err != nil {
+		return Chat{}, err
+	}
+	return chat, nil
+}
@@ -0,0 +1,91 @@
+package online
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+func TestChat(t *testing.T) {
+	cfg, err := config.LoadFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	preset, err := difficulty.Load(difficulty.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewServer(cfg, preset, sim.ModeEndless, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+	httpServer := httptest.NewServer(s.Handler())
+	defer httpServer.Close()
+	client := NewClient(httpServer.URL)
+	ctx := context.Background()
+
+	if _, err := client.Hello(ctx, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if !client.Has(FeatureChat) {
+		t.Fatal("chat wasn't agreed on")
+	}
+
+	first, err := client.Say(ctx, Say{From: " Priya\n", Text: "well bowled"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.From != "Priya" || first.Text != "well bowled" {
+		t.Errorf("said %+v", first)
+	}
+	// Under a new name too, a second line straight away is too soon
+	if _, err := client.Say(ctx, Say{From: "Someone else", Emote: EmoteClap}); err == nil || !strings.Contains(err.Error(), "slow down") {
+		t.Errorf("a line too soon after the last was said: %v", err)
+	}
+
+	now = now.Add(chatCooldown)
+	for _, say := range []Say{
+		{From: "Priya", Emote: "wave"},
+		{From: "Priya"},
+		{From: "Priya", Text: "shot!", Emote: EmoteShot},
+		{Text: "who said this?"},
+	} {
+		if _, err := client.Say(ctx, say); err == nil {
+			t.Errorf("%+v was said", say)
+		}
+	}
+	second, err := client.Say(ctx, Say{From: "Priya", Emote: EmoteAppeal, Text: " "})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chat, err := client.Chat(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chat.Lines) != 2 || chat.Lines[0] != first || chat.Lines[1] != second {
+		t.Errorf("chat is %+v, want %+v and %+v", chat.Lines, first, second)
+	}
+	if chat, err = client.Chat(ctx, first.ID); err != nil || len(chat.Lines) != 1 || chat.Lines[0] != second {
+		t.Errorf("chat after the first line is %+v (%v), want %+v", chat.Lines, err, second)
+	}
+
+	// Only the latest lines are kept
+	for range maxChatLines {
+		now = now.Add(chatCooldown)
+		if _, err := client.Say(ctx, Say{From: "Priya", Emote: EmoteClap}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if chat, err = client.Chat(ctx, 0); err != nil || len(chat.Lines) != maxChatLines || chat.Lines[0].ID != second.ID+1 {
+		t.Errorf("kept %d lines from %+v (%v), want the latest %d", len(chat.Lines), chat.Lines[0], err, maxChatLines)
+	}
+}
@@ -0,0 +1,138 @@
+package online
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	requestTimeout = 5 * time.Second
+	maxReasonBytes = 1024 // Of an error the server sends back
+)
+
+// Client talks to a dedicated server
+type Client struct {
+	base     string
+	http     *http.Client
+	version  int       // Agreed on in the hello, 0 before it
+	features []Feature // Both ends have, from the hello
+}
+
+// NewClient talks to the server at the given address, such as example.com:7778
+func NewClient(addr string) *Client {
+	return &Client{base: baseURL(addr), http: &http.Client{Timeout: requestTimeout}}
+}
+
+// baseURL is the address as a URL, over plain HTTP if it doesn't say
+func baseURL(addr string) string {
+	base := strings.TrimSuffix(addr, "/")
+	if !strings.Contains(base, "://") {
+		base = "http://" + base
+	}
+	return base
+}
+
+// Hello agrees on a version of the protocol and the features to use with the server. It
+// has to be said before anything else, and fails with a message for the player if the
+// game and the server are too far apart to play.
+func (c *Client) Hello(ctx context.Context, build string) (Welcome, error) {
+	var welcome Welcome
+	if err := c.post(ctx, "/hello", NewHello(build), &welcome); err != nil {
+		return Welcome{}, err
+	}
+	if !Spoken(welcome.Version) {
+		return Welcome{}, fmt.Errorf("the server (%s) answered in online protocol %d, which the game (%s) doesn't speak", welcome.Build, welcome.Version, build)
+	}
+
+	c.version, c.features = welcome.Version, welcome.Features
+	return welcome, nil
+}
+
+// Has is true if both ends have the feature
+func (c *Client) Has(feature Feature) bool {
+	return slices.Contains(c.features, feature)
+}
+
+// Start begins a new match on the server
+func (c *Client) Start(ctx context.Context, start Start) (Match, error) {
+	var match Match
+	if err := c.post(ctx, "/start", start, &match); err != nil {
+		return Match{}, err
+	}
+	return match, nil
+}
+
+// Send has the server play the inputs, returning where it has got to
+func (c *Client) Send(ctx context.Context, inputs Inputs) (Played, error) {
+	var played Played
+	if err := c.post(ctx, "/inputs", inputs, &played); err != nil {
+		return Played{}, err
+	}
+	return played, nil
+}
+
+func (c *Client) post(ctx context.Context, path string, body, reply any) error {
+	return send(ctx, c.http, http.MethodPost, c.base+path, c.header(), body, reply)
+}
+
+func (c *Client) get(ctx context.Context, path string, reply any) error {
+	return send(ctx, c.http, http.MethodGet, c.base+path, c.header(), nil, reply)
+}
+
+// header carries the version agreed on in the hello, once there has been one
+func (c *Client) header() http.Header {
+	header := make(http.Header)
+	if c.version > 0 {
+		header.Set(VersionHeader, strconv.Itoa(c.version))
+	}
+	return header
+}
+
+// send makes a request with the body as JSON, if there is one, and reads the JSON reply
+func send(ctx context.Context, client *http.Client, method, url string, header http.Header, body, reply any) error {
+	var encoded io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		encoded = bytes.NewReader(b)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, url, encoded)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		// The server says what went wrong in the body, such as which end is out of date
+		reason, _ := io.ReadAll(io.LimitReader(response.Body, maxReasonBytes))
+		if message := strings.TrimSpace(string(reason)); len(message) > 0 {
+			return fmt.Errorf("%s: %s", response.Status, message)
+		}
+		return fmt.Errorf("unexpected status from %s: %s", request.URL.Path, response.Status)
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(reply); err != nil {
+		return fmt.Errorf("could not read the reply to %s: %w", request.URL.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package online
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LobbyVersion is the version of the lobby's endpoints, which are served under /v1/ and so
+// on. Bump it whenever a message changes, and keep serving the old paths while builds that
+// use them are about.
+//
+// The endpoints are:
+//
+//	POST /v1/hosts             a Host, to list a server's match or keep it listed; replied
+//	                           to with a Hosted
+//	GET  /v1/matches           the open matches, as Listings
+//	POST /v1/matches/{id}/join a Join, to take a match; replied to with a Connection
+const LobbyVersion = 1
+
+const (
+	hostRefresh = 10 * time.Second // How often a host says it is still there
+	hostExpiry  = 30 * time.Second // A host not heard from for this long is taken off the list
+	joinHold    = 30 * time.Second // A match someone has joined is kept off the list this long
+	maxHostName = 40               // Characters of a host's name that are kept
+)
+
+// Host is a dedicated server listing its match. It says so again every little while, with
+// the ID it was given, to stay on the list.
+type Host struct {
+	ID         string `json:"id,omitempty"`
+	Name       string `json:"name"`
+	Addr       string `json:"addr"` // Where the game connects to play
+	Mode       string `json:"mode"`
+	Version    int    `json:"version"` // The online protocols the server speaks
+	MinVersion int    `json:"min_version"`
+	Open       bool   `json:"open"` // Nobody is playing it
+}
+
+// Hosted is the ID the lobby knows a host by
+type Hosted struct {
+	ID string `json:"id"`
+}
+
+// Listing is an open match, as the lobby lists it
+type Listing struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Mode       string `json:"mode"`
+	Version    int    `json:"version"`
+	MinVersion int    `json:"min_version"`
+}
+
+// Playable is true if this build speaks a version of the protocol the match's server does
+func (l Listing) Playable() bool {
+	return l.Version >= MinVersion && l.MinVersion <= Version
+}
+
+// Join is a player taking a match
+type Join struct {
+	Build string `json:"build"`
+}
+
+// Connection is what the game needs to play a match it has joined
+type Connection struct {
+	Addr string `json:"addr"`
+}
+
+// Lobby lists the matches dedicated servers have open, for players to pick one and join it
+type Lobby struct {
+	mu    sync.Mutex
+	hosts map[string]*lobbyHost
+	now   func() time.Time
+}
+
+type lobbyHost struct {
+	Host
+	seen       time.Time
+	joinedTill time.Time // Kept off the list until then, so two players don't take it
+}
+
+func NewLobby() *Lobby {
+	return &Lobby{hosts: make(map[string]*lobbyHost), now: time.Now}
+}
+
+// Handler serves the endpoints
+func (l *Lobby) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("POST /v%d/hosts", LobbyVersion), l.handleHost)
+	mux.HandleFunc(fmt.Sprintf("GET /v%d/matches", LobbyVersion), l.handleMatches)
+	mux.HandleFunc(fmt.Sprintf("POST /v%d/matches/{id}/join", LobbyVersion), l.handleJoin)
+	return mux
+}
+
+func (l *Lobby) handleHost(w http.ResponseWriter, r *http.Request) {
+	var host Host
+	if err := json.NewDecoder(r.Body).Decode(&host); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	host.Name = strings.TrimSpace(host.Name)
+	if len(host.Name) == 0 || len(host.Addr) == 0 {
+		http.Error(w, "a host needs a name and an address", http.StatusBadRequest)
+		return
+	}
+	if runes := []rune(host.Name); len(runes) > maxHostName {
+		host.Name = string(runes[:maxHostName])
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	known, ok := l.hosts[host.ID]
+	if !ok {
+		host.ID = newHostID()
+		known = &lobbyHost{}
+		l.hosts[host.ID] = known
+	}
+	known.Host = host
+	known.seen = l.now()
+
+	writeJSON(w, Hosted{ID: host.ID})
+}
+
+func (l *Lobby) handleMatches(w http.ResponseWriter, r *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+
+	listings := make([]Listing, 0, len(l.hosts))
+	for _, h := range l.hosts {
+		if h.listed(l.now()) {
+			listings = append(listings, h.listing())
+		}
+	}
+	slices.SortFunc(listings, func(a, b Listing) int { return strings.Compare(a.Name, b.Name) })
+	writeJSON(w, listings)
+}
+
+func (l *Lobby) handleJoin(w http.ResponseWriter, r *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+
+	h, ok := l.hosts[r.PathValue("id")]
+	if !ok {
+		http.Error(w, "that match is no longer listed", http.StatusNotFound)
+		return
+	}
+	if !h.listed(l.now()) {
+		http.Error(w, "someone else is playing that match", http.StatusConflict)
+		return
+	}
+	h.joinedTill = l.now().Add(joinHold)
+
+	writeJSON(w, Connection{Addr: h.Addr})
+}
+
+// expire takes hosts that have stopped saying they are there off the list
+func (l *Lobby) expire() {
+	for id, h := range l.hosts {
+		if l.now().Sub(h.seen) > hostExpiry {
+			delete(l.hosts, id)
+		}
+	}
+}
+
+func (h *lobbyHost) listed(now time.Time) bool {
+	return h.Open && now.After(h.joinedTill)
+}
+
+func (h *lobbyHost) listing() Listing {
+	return Listing{ID: h.ID, Name: h.Name, Mode: h.Mode, Version: h.Version, MinVersion: h.MinVersion}
+}
+
+func newHostID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// LobbyClient talks to a lobby, for a player to find a match or a server to list one
+type LobbyClient struct {
+	base string
+	http *http.Client
+}
+
+// NewLobbyClient talks to the lobby at the given address
+func NewLobbyClient(addr string) *LobbyClient {
+	return &LobbyClient{base: fmt.Sprintf("%s/v%d", baseURL(addr), LobbyVersion), http: &http.Client{Timeout: requestTimeout}}
+}
+
+// Host lists the server's match, or keeps it listed
+func (c *LobbyClient) Host(ctx context.Context, host Host) (Hosted, error) {
+	var hosted Hosted
+	err := send(ctx, c.http, http.MethodPost, c.base+"/hosts", nil, host, &hosted)
+	return hosted, err
+}
+
+// Matches are the open matches
+func (c *LobbyClient) Matches(ctx context.Context) ([]Listing, error) {
+	var listings []Listing
+	err := send(ctx, c.http, http.MethodGet, c.base+"/matches", nil, nil, &listings)
+	return listings, err
+}
+
+// Join takes a match, for the game to go and play it
+func (c *LobbyClient) Join(ctx context.Context, id, build string) (Connection, error) {
+	var connection Connection
+	err := send(ctx, c.http, http.MethodPost, c.base+"/matches/"+url.PathEscape(id)+"/join", nil, Join{Build: build}, &connection)
+	return connection, err
+}
+
+// Find picks the open match with the given name that this build can play
+func (c *LobbyClient) Find(ctx context.Context, name string) (Listing, error) {
+	listings, err := c.Matches(ctx)
+	if err != nil {
+		return Listing{}, err
+	}
+
+	found := false
+	for _, l := range listings {
+		if !strings.EqualFold(l.Name, name) {
+			continue
+		}
+		if l.Playable() {
+			return l, nil
+		}
+		found = true
+	}
+	if found {
+		return Listing{}, fmt.Errorf("the match %q is on a server this build can't play against: update the game", name)
+	}
+	return Listing{}, fmt.Errorf("no open match is called %q", name)
+}
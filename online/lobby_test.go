@@ -0,0 +1,72 @@
+package online
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLobby(t *testing.T) {
+	lobby := NewLobby()
+	now := time.Unix(1000, 0)
+	lobby.now = func() time.Time { return now }
+	httpServer := httptest.NewServer(lobby.Handler())
+	defer httpServer.Close()
+	client := NewLobbyClient(httpServer.URL)
+	ctx := context.Background()
+
+	nets := Host{Name: "Sunday nets", Addr: "nets.example.com:7778", Mode: "overs", Version: Version, MinVersion: MinVersion, Open: true}
+	hosted, err := client.Host(ctx, nets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nets.ID = hosted.ID
+	if _, err := client.Host(ctx, Host{Name: "Busy", Addr: "busy.example.com:7778", Version: Version, MinVersion: MinVersion}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Host(ctx, Host{Name: "Future", Addr: "future.example.com:7778", Version: Version + 2, MinVersion: Version + 1, Open: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	listings, err := client.Matches(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listings) != 2 || listings[0].Name != "Future" || listings[1].Name != "Sunday nets" {
+		t.Fatalf("listed %+v, want the two open matches", listings)
+	}
+	if _, err := client.Find(ctx, "future"); err == nil || !strings.Contains(err.Error(), "update the game") {
+		t.Errorf("found a match on a newer server: %v", err)
+	}
+
+	listing, err := client.Find(ctx, "sunday NETS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	connection, err := client.Join(ctx, listing.ID, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if connection.Addr != nets.Addr {
+		t.Errorf("joined at %s, want %s", connection.Addr, nets.Addr)
+	}
+	if _, err := client.Join(ctx, listing.ID, "test"); err == nil {
+		t.Error("a match was joined twice")
+	}
+
+	// The host saying it is still open once the player has had time to get there lists the
+	// match again, and a host that stops saying anything is taken off
+	now = now.Add(joinHold + time.Second)
+	if _, err := client.Host(ctx, nets); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Find(ctx, "Sunday nets"); err != nil {
+		t.Errorf("match wasn't listed again: %v", err)
+	}
+	now = now.Add(hostExpiry + time.Second)
+	if listings, err := client.Matches(ctx); err != nil || len(listings) != 0 {
+		t.Errorf("listed %+v after the hosts went quiet: %v", listings, err)
+	}
+}
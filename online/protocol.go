@@ -0,0 +1,125 @@
+// Package online is what the game and the dedicated server say to each other in an online
+// match. The server plays the match as the one true copy of it, and the game plays its own
+// copy alongside, sending the server how the bat was held on each tick.
+//
+// The endpoints are:
+//
+//	POST /hello   a Hello, to agree on a version of the protocol; replied to with a Welcome
+//	POST /start   a Start, to begin a new match; replied to with the match's Match
+//	POST /inputs  an Inputs, played on from Tick; replied to with a Played
+//	GET  /state   the match as it stands, as a Played
+//	POST /chat    a Say, with FeatureChat; replied to with the Line said
+//	GET  /chat    the Chat since the line given as ?after=
+//
+// Every request after the hello carries the version agreed on in a VersionHeader. Builds
+// speak a range of versions, and two builds can play if their ranges meet. Features added
+// without changing what a message means are agreed on by name in the hello instead, and
+// only used if both ends have them.
+//
+// Servers can list their match in a Lobby, where players find one and join it to be given
+// its address. The lobby's endpoints are under a version of their own, LobbyVersion.
+package online
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+// Version is the newest version of the protocol this build speaks, and MinVersion the
+// oldest. Bump Version whenever a message changes in a way an older build would misread,
+// and MinVersion when this build can no longer speak an old one.
+const (
+	Version    = 1
+	MinVersion = 1
+)
+
+// VersionHeader carries the version agreed on in the hello
+const VersionHeader = "Cricket2d-Protocol"
+
+// Feature is something a build can do on top of the version it speaks
+type Feature string
+
+// Features are what this build can do
+var Features = []Feature{FeatureChat}
+
+// Hello is a client saying which versions and features it has, and which build it is
+type Hello struct {
+	Version    int       `json:"version"`
+	MinVersion int       `json:"min_version"`
+	Features   []Feature `json:"features"`
+	Build      string    `json:"build"`
+}
+
+// Welcome is the server's answer to a Hello: the version to speak and the features both
+// ends have
+type Welcome struct {
+	Version  int       `json:"version"`
+	Features []Feature `json:"features"`
+	Build    string    `json:"build"`
+}
+
+// NewHello is this build's hello
+func NewHello(build string) Hello {
+	return Hello{Version: Version, MinVersion: MinVersion, Features: Features, Build: build}
+}
+
+// Negotiate picks the newest version both ends speak, and the features both have. Builds
+// too far apart to play get an error saying which one is out of date.
+func Negotiate(hello Hello, features []Feature, build string) (Welcome, error) {
+	switch {
+	case hello.Version < MinVersion:
+		return Welcome{}, fmt.Errorf("the game (%s) speaks online protocol %d and the server (%s) needs %d or newer: update the game",
+			hello.Build, hello.Version, build, MinVersion)
+	case hello.MinVersion > Version:
+		return Welcome{}, fmt.Errorf("the game (%s) needs online protocol %d or newer and the server (%s) speaks %d: update the server",
+			hello.Build, hello.MinVersion, build, Version)
+	}
+
+	welcome := Welcome{Version: min(hello.Version, Version), Build: build}
+	for _, feature := range features {
+		if slices.Contains(hello.Features, feature) {
+			welcome.Features = append(welcome.Features, feature)
+		}
+	}
+	return welcome, nil
+}
+
+// Spoken is true if this build can speak the given version
+func Spoken(version int) bool {
+	return version >= MinVersion && version <= Version
+}
+
+// Start asks the server for a new match, over whatever it was playing
+type Start struct {
+	Seed   uint32 `json:"seed"`
+	Seeded bool   `json:"seeded"` // Play Seed, as for a restart, instead of the server's own
+}
+
+// Match is how the server is playing the match: its mode, difficulty, seed and rules, for
+// the game to set its own field up the same. Score is left out.
+type Match struct {
+	Header replay.Header `json:"header"`
+}
+
+// Inputs are how the bat was held on each tick from Tick on, the first being 0
+type Inputs struct {
+	Tick   int            `json:"tick"`
+	Inputs []replay.Input `json:"inputs"`
+}
+
+// Played is where the server has got to. Inputs for ticks before Tick have been played,
+// or were too late and were played with the bat held as it was.
+type Played struct {
+	Tick  int              `json:"tick"`
+	State sim.ControlState `json:"state"`
+	Held  []Span           `json:"held,omitempty"` // Ticks played without the client's inputs in reply to them
+}
+
+// Span is the ticks from From up to To
+type Span struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
@@ -0,0 +1,45 @@
+package online
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	const (
+		featureA Feature = "a"
+		featureB Feature = "b"
+	)
+	tests := []struct {
+		name     string
+		hello    Hello
+		features []Feature
+		version  int
+		agreed   []Feature
+		refused  string // In the error, empty if the builds can play
+	}{
+		{"same build", Hello{Version: Version, MinVersion: MinVersion}, nil, Version, nil, ""},
+		{"newer game", Hello{Version: Version + 1, MinVersion: MinVersion}, nil, Version, nil, ""},
+		{"game too old", Hello{Version: MinVersion - 1, MinVersion: MinVersion - 1}, nil, 0, nil, "update the game"},
+		{"server too old", Hello{Version: Version + 2, MinVersion: Version + 1}, nil, 0, nil, "update the server"},
+		{"shared features", Hello{Version: Version, MinVersion: MinVersion, Features: []Feature{featureA}}, []Feature{featureA, featureB}, Version, []Feature{featureA}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			welcome, err := Negotiate(tt.hello, tt.features, "server")
+			if len(tt.refused) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.refused) {
+					t.Fatalf("got %v, want an error saying %q", err, tt.refused)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if welcome.Version != tt.version || !slices.Equal(welcome.Features, tt.agreed) {
+				t.Errorf("agreed on %+v, want version %d with %v", welcome, tt.version, tt.agreed)
+			}
+		})
+	}
+}
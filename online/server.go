@@ -0,0 +1,248 @@
+package online
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/sim"
+	"github.com/meghashyamc/cricket2d/version"
+)
+
+const (
+	maxLagTicks  = 3 * sim.TPS      // How far a client can fall behind before the match goes on without it
+	maxLeadTicks = sim.TPS / 2      // How far a client can get ahead of the clock
+	idleAfter    = 10 * time.Second // A match nobody has sent anything for is open for another player
+)
+
+// Server plays matches as the one true copy of them, for the endpoints in the package doc.
+// There is one match at a time, and a client starting one ends whatever was being played.
+type Server struct {
+	cfg      *config.Config
+	preset   *difficulty.Preset
+	modeName string
+	seed     uint32 // 0 for a new seed each match
+	features []Feature
+	build    string
+	now      func() time.Time
+
+	mu      sync.Mutex
+	sim     *sim.Simulation // nil until a client starts a match
+	tick    int             // Ticks played so far
+	held    replay.Input    // How the bat was held on the last tick played
+	started time.Time       // When the first inputs came in, the zero time until they have
+	active  time.Time       // When a client last started a match or sent inputs
+
+	chat     []Line               // The latest lines said, kept across matches
+	lineID   int                  // Of the last line said
+	lastSaid map[string]time.Time // When each player last said something, by their host
+}
+
+// NewServer plays matches of the given mode, bowled according to the preset, from the
+// given seed or a new one each match if it is 0
+func NewServer(cfg *config.Config, preset *difficulty.Preset, modeName string, seed uint32) (*Server, error) {
+	if len(modeName) == 0 {
+		modeName = sim.ModeEndless
+	}
+	// Set one up now, so a bad mode is found before anyone joins
+	if _, err := sim.NewSimulation(cfg, preset, modeName, seed); err != nil {
+		return nil, err
+	}
+	return &Server{cfg: cfg, preset: preset, modeName: modeName, seed: seed, features: Features, build: version.Current(), now: time.Now,
+		lastSaid: make(map[string]time.Time)}, nil
+}
+
+// Handler serves the endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /hello", s.handleHello)
+	mux.HandleFunc("POST /start", speaking(s.handleStart))
+	mux.HandleFunc("POST /inputs", speaking(s.handleInputs))
+	mux.HandleFunc("GET /state", speaking(s.handleState))
+	mux.HandleFunc("POST /chat", speaking(s.handleSay))
+	mux.HandleFunc("GET /chat", speaking(s.handleChat))
+	return mux
+}
+
+func (s *Server) handleHello(w http.ResponseWriter, r *http.Request) {
+	var hello Hello
+	if err := json.NewDecoder(r.Body).Decode(&hello); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	welcome, err := Negotiate(hello, s.features, s.build)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUpgradeRequired)
+		return
+	}
+	slog.Warn("client said hello", "build", hello.Build, "version", welcome.Version, "features", welcome.Features, "remote", r.RemoteAddr)
+	writeJSON(w, welcome)
+}
+
+// speaking refuses requests that aren't in a version of the protocol the server speaks
+func speaking(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(VersionHeader)
+		if len(header) == 0 {
+			http.Error(w, "say hello first, to agree on a version of the protocol", http.StatusUpgradeRequired)
+			return
+		}
+		if v, err := strconv.Atoi(header); err != nil || !Spoken(v) {
+			http.Error(w, fmt.Sprintf("online protocol %s isn't spoken here", header), http.StatusUpgradeRequired)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// handleStart begins a new match, over whatever was being played. Its clock starts with
+// the first inputs, so a client setting its field up doesn't fall behind.
+func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
+	var start Start
+	if err := json.NewDecoder(r.Body).Decode(&start); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	seed := s.seed
+	if start.Seeded {
+		seed = start.Seed
+	} else if seed == 0 {
+		seed = rand.Uint32()
+	}
+	simulation, err := sim.NewSimulation(s.cfg, s.preset, s.modeName, seed)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rules := simulation.Rules()
+
+	s.mu.Lock()
+	s.sim = simulation
+	s.tick, s.held, s.started = 0, replay.Input{}, time.Time{}
+	s.active = s.now()
+	s.mu.Unlock()
+
+	slog.Warn("match started", "seed", seed, "remote", r.RemoteAddr)
+	writeJSON(w, Match{Header: replay.Header{Version: replay.Version, Mode: s.modeName, Difficulty: s.preset.Name, Seed: seed, Rules: &rules}})
+}
+
+// handleInputs plays the client's inputs on from the last tick played. Inputs for ticks
+// already played are too late and are dropped, and inputs too far ahead of the clock are
+// left for the client to send again.
+func (s *Server) handleInputs(w http.ResponseWriter, r *http.Request) {
+	var inputs Inputs
+	if err := json.NewDecoder(r.Body).Decode(&inputs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sim == nil {
+		http.Error(w, "no match has been started", http.StatusConflict)
+		return
+	}
+	if inputs.Tick > s.tick {
+		http.Error(w, fmt.Sprintf("inputs start at tick %d, after the %d played", inputs.Tick, s.tick), http.StatusConflict)
+		return
+	}
+
+	if s.started.IsZero() {
+		s.started = s.now()
+	}
+	s.active = s.now()
+	due := int(s.now().Sub(s.started) * sim.TPS / time.Second)
+	// A client that has stopped sending doesn't hold the match up
+	var held []Span
+	if s.tick < due-maxLagTicks {
+		held = append(held, Span{From: s.tick, To: due - maxLagTicks})
+	}
+	for s.tick < due-maxLagTicks {
+		s.step(s.held)
+	}
+	for i, input := range inputs.Inputs {
+		if inputs.Tick+i < s.tick {
+			continue
+		}
+		if s.tick >= due+maxLeadTicks {
+			break
+		}
+		s.step(input)
+	}
+
+	played := s.played()
+	played.Held = held
+	writeJSON(w, played)
+}
+
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sim == nil {
+		http.Error(w, "no match has been started", http.StatusConflict)
+		return
+	}
+
+	writeJSON(w, s.played())
+}
+
+// Open is true if nobody is playing: no match has been started, the last one is over, or
+// its player has gone quiet
+func (s *Server) Open() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sim == nil || s.sim.Over() || s.now().Sub(s.active) > idleAfter
+}
+
+// Advertise lists the server's match in the lobby under the given name, for players to
+// join at the given address, until the context is done
+func (s *Server) Advertise(ctx context.Context, lobby *LobbyClient, name, addr string) {
+	host := Host{Name: name, Addr: addr, Mode: s.modeName, Version: Version, MinVersion: MinVersion}
+	ticker := time.NewTicker(hostRefresh)
+	defer ticker.Stop()
+	for {
+		host.Open = s.Open()
+		if hosted, err := lobby.Host(ctx, host); err != nil {
+			slog.Warn("could not list the match in the lobby", "error", err)
+		} else {
+			host.ID = hosted.ID
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// step plays a tick with the bat held as the input says
+func (s *Server) step(input replay.Input) {
+	s.sim.Step(sim.ReplayControl(input))
+	s.held = input
+	s.tick++
+}
+
+func (s *Server) played() Played {
+	return Played{Tick: s.tick, State: s.sim.State()}
+}
+
+func writeJSON(w http.ResponseWriter, reply any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(reply); err != nil {
+		slog.Warn("could not send the reply", "error", err)
+	}
+}
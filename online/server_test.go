@@ -0,0 +1,114 @@
+package online
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/sim"
+)
+
+func TestServerPlaysInputs(t *testing.T) {
+	cfg, err := config.LoadFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	preset, err := difficulty.Load(difficulty.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewServer(cfg, preset, sim.ModeEndless, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+	httpServer := httptest.NewServer(s.Handler())
+	defer httpServer.Close()
+	client := NewClient(httpServer.URL)
+	ctx := context.Background()
+
+	inputs := make([]replay.Input, 2*sim.TPS)
+	for i := range inputs {
+		inputs[i] = replay.Input{X: 700, Y: 400 + float64(i), Drag: i < sim.TPS}
+	}
+
+	if _, err := client.Start(ctx, Start{}); err == nil {
+		t.Fatal("a match was started before saying hello")
+	}
+	if _, err := client.Hello(ctx, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Send(ctx, Inputs{Inputs: inputs}); err == nil {
+		t.Fatal("inputs were played before a match was started")
+	}
+	match, err := client.Start(ctx, Start{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if match.Header.Seed != 42 || match.Header.Rules == nil {
+		t.Fatalf("started %+v", match.Header)
+	}
+
+	// The clock starts with the first inputs, and a client can't get far ahead of it
+	played, err := client.Send(ctx, Inputs{Inputs: inputs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if played.Tick != maxLeadTicks {
+		t.Fatalf("played %d ticks ahead of the clock, want %d", played.Tick, maxLeadTicks)
+	}
+	now = now.Add(2 * time.Second)
+	played, err = client.Send(ctx, Inputs{Tick: played.Tick, Inputs: inputs[played.Tick:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if played.Tick != len(inputs) {
+		t.Fatalf("played %d ticks, want %d", played.Tick, len(inputs))
+	}
+
+	// The server plays the same match the inputs play anywhere else
+	local, err := sim.NewSimulation(cfg, preset, sim.ModeEndless, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, input := range inputs {
+		local.Step(sim.ReplayControl(input))
+	}
+	if !reflect.DeepEqual(played.State, local.State()) {
+		t.Errorf("server has %+v, want %+v", played.State, local.State())
+	}
+
+	// A client gone quiet is left behind, and its inputs are too late when they come
+	now = now.Add(10 * time.Second)
+	played, err = client.Send(ctx, Inputs{Tick: len(inputs), Inputs: inputs[:1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 12*sim.TPS - maxLagTicks; played.Tick != want {
+		t.Errorf("played %d ticks after the client went quiet, want %d", played.Tick, want)
+	}
+	if want := []Span{{From: len(inputs), To: played.Tick}}; !reflect.DeepEqual(played.Held, want) {
+		t.Errorf("held the bat for %v, want %v", played.Held, want)
+	}
+	if _, err := client.Send(ctx, Inputs{Tick: played.Tick + 1, Inputs: inputs[:1]}); err == nil {
+		t.Error("inputs after a gap were played")
+	}
+
+	// Starting again plays the same match from the top
+	if _, err := client.Start(ctx, Start{Seed: 42, Seeded: true}); err != nil {
+		t.Fatal(err)
+	}
+	played, err = client.Send(ctx, Inputs{Inputs: inputs[:1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if played.Tick != 1 {
+		t.Errorf("played %d ticks of the new match, want 1", played.Tick)
+	}
+}
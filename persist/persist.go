@@ -0,0 +1,161 @@
+package persist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// VersionKey is the field every saved document keeps its schema version in. Files saved
+// before versioning don't have it, and are version 0.
+const VersionKey = "schema_version"
+
+var (
+	// ErrCorrupt is returned for a file that couldn't be read as its schema. The file has
+	// been moved aside, so that it isn't overwritten by the next save.
+	ErrCorrupt = errors.New("corrupt file")
+	// ErrTooNew is returned for a file saved by a newer game than this one. It has been
+	// moved aside too, for the newer game to find again.
+	ErrTooNew = errors.New("file saved by a newer version of the game")
+)
+
+// Migration moves a document on by one version, changing it in place
+type Migration func(doc map[string]any) error
+
+// Schema describes a kind of saved file and how to bring old copies of it up to date.
+// Migrations[i] moves a document from version i to version i+1, so the current version is
+// the number of migrations. Migrations are only ever added to the end.
+type Schema struct {
+	Name       string // What the file holds, for errors
+	Migrations []Migration
+}
+
+// Version is the schema version files are saved with
+func (s Schema) Version() int {
+	return len(s.Migrations)
+}
+
+// Load reads the file at path into v, migrating it first if it was saved under an older
+// version. A migrated file keeps a backup of the original alongside it. A file that can't
+// be read as the schema, or is newer than it, is moved aside and an error wrapping
+// ErrCorrupt or ErrTooNew returned. A file that doesn't exist returns an error satisfying
+// errors.Is(err, fs.ErrNotExist).
+func (s Schema) Load(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return s.quarantine(path, err)
+	}
+
+	version, err := documentVersion(doc)
+	if err != nil {
+		return s.quarantine(path, err)
+	}
+	if version > s.Version() {
+		aside := fmt.Sprintf("%s.v%d", path, version)
+		if err := os.Rename(path, aside); err != nil {
+			return fmt.Errorf("%s is version %d, newer than %d, and could not be moved aside: %w", s.Name, version, s.Version(), err)
+		}
+		return fmt.Errorf("%s %w (version %d, this game reads up to %d), moved to %s", s.Name, ErrTooNew, version, s.Version(), aside)
+	}
+
+	from := version
+	for ; version < s.Version(); version++ {
+		if err := s.Migrations[version](doc); err != nil {
+			return s.quarantine(path, fmt.Errorf("migrating from version %d: %w", version, err))
+		}
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return s.quarantine(path, err)
+	}
+	if err := json.Unmarshal(migrated, v); err != nil {
+		return s.quarantine(path, err)
+	}
+
+	if from < s.Version() {
+		// The original is kept until the migrated file has been saved over it
+		if err := writeFile(fmt.Sprintf("%s.v%d.bak", path, from), data); err != nil {
+			return fmt.Errorf("could not back up %s before migrating it: %w", s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Save writes v to the file at path with the current schema version. The file is replaced
+// in one go, so that a crash part way through never leaves it half written.
+func (s Schema) Save(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return fmt.Errorf("%s must save as a JSON object: %w", s.Name, err)
+	}
+	doc[VersionKey] = s.Version()
+
+	if data, err = json.Marshal(doc); err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+// quarantine moves a file that couldn't be read aside, with the time in its name, so that
+// it can be looked at or recovered instead of being overwritten
+func (s Schema) quarantine(path string, cause error) error {
+	aside := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+	if err := os.Rename(path, aside); err != nil {
+		return fmt.Errorf("%s %w (%v), and it could not be moved aside: %v", s.Name, ErrCorrupt, cause, err)
+	}
+	return fmt.Errorf("%s is a %w (%v), moved to %s", s.Name, ErrCorrupt, cause, aside)
+}
+
+// decodeDocument reads a JSON object, keeping numbers as written so that large ones survive
+// a migration unchanged
+func decodeDocument(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc map[string]any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errors.New("not a JSON object")
+	}
+	return doc, nil
+}
+
+func documentVersion(doc map[string]any) (int, error) {
+	raw, ok := doc[VersionKey]
+	if !ok {
+		return 0, nil
+	}
+	number, ok := raw.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("%s is not a number", VersionKey)
+	}
+	version, err := number.Int64()
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("%s %q is not a version", VersionKey, number)
+	}
+	return int(version), nil
+}
+
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
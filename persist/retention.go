@@ -0,0 +1,131 @@
+package persist
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Retention limits how much a folder of kept files, such as match logs, may grow. A zero
+// limit is no limit.
+type Retention struct {
+	MaxFiles int
+	MaxBytes int64
+	MaxAge   time.Duration
+}
+
+// Usage is how many files match a pattern and how much space they take
+type Usage struct {
+	Files int
+	Bytes int64
+}
+
+type keptFile struct {
+	path     string
+	size     int64
+	modified time.Time
+}
+
+// Prune deletes the oldest files in dir matching pattern until what is left is within the
+// limits, returning what was deleted. A missing dir has nothing to prune.
+func Prune(dir, pattern string, r Retention, now time.Time) (Usage, error) {
+	files, err := keptFiles(dir, pattern)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	// Newest first, so that whatever is past a limit is the oldest
+	slices.SortFunc(files, func(a, b keptFile) int {
+		return b.modified.Compare(a.modified)
+	})
+
+	var (
+		kept   Usage
+		excess []keptFile
+	)
+	for _, file := range files {
+		tooMany := r.MaxFiles > 0 && kept.Files >= r.MaxFiles
+		tooBig := r.MaxBytes > 0 && kept.Bytes+file.size > r.MaxBytes
+		tooOld := r.MaxAge > 0 && now.Sub(file.modified) > r.MaxAge
+		if tooMany || tooBig || tooOld {
+			excess = append(excess, file)
+			continue
+		}
+		kept.Files++
+		kept.Bytes += file.size
+	}
+
+	return removeFiles(excess)
+}
+
+// DirUsage measures the files in dir matching pattern
+func DirUsage(dir, pattern string) (Usage, error) {
+	files, err := keptFiles(dir, pattern)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	var usage Usage
+	for _, file := range files {
+		usage.Files++
+		usage.Bytes += file.size
+	}
+	return usage, nil
+}
+
+// Clear deletes every file in dir matching pattern, returning what was deleted
+func Clear(dir, pattern string) (Usage, error) {
+	files, err := keptFiles(dir, pattern)
+	if err != nil {
+		return Usage{}, err
+	}
+	return removeFiles(files)
+}
+
+func removeFiles(files []keptFile) (Usage, error) {
+	var (
+		deleted Usage
+		errs    []error
+	)
+	for _, file := range files {
+		if err := os.Remove(file.path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleted.Files++
+		deleted.Bytes += file.size
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// keptFiles lists the regular files directly in dir matching pattern
+func keptFiles(dir, pattern string) ([]keptFile, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]keptFile, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if ok, err := filepath.Match(pattern, entry.Name()); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, keptFile{path: filepath.Join(dir, entry.Name()), size: info.Size(), modified: info.ModTime()})
+	}
+	return files, nil
+}
@@ -0,0 +1,87 @@
+package rating
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// Initial is the rating of a player yet to play a rated match
+	Initial = 1200
+	// kFactor is the most a rating can move in one match
+	kFactor = 32
+	// Ratings this far apart make the higher rated player ten times as likely to win
+	spread = 400
+	// Older matches are dropped from the history
+	maxHistory = 50
+)
+
+// Results of a rated match, from the player's side
+const (
+	Loss = 0
+	Tie  = 0.5
+	Win  = 1
+)
+
+// Match is a rated match the player has played
+type Match struct {
+	Opponent       string    `json:"opponent"`
+	OpponentRating float64   `json:"opponent_rating"`
+	Result         float64   `json:"result"` // Win, Tie or Loss
+	Before         float64   `json:"before"`
+	After          float64   `json:"after"`
+	PlayedAt       time.Time `json:"played_at"`
+}
+
+// Change is how far the match moved the player's rating
+func (m Match) Change() float64 {
+	return m.After - m.Before
+}
+
+// Ladder is a player's rating, Elo style, and the rated matches that led to it, oldest first
+type Ladder struct {
+	Rating  float64 `json:"rating"`
+	History []Match `json:"history,omitempty"`
+}
+
+// New is the ladder of a player yet to play a rated match
+func New() *Ladder {
+	return &Ladder{Rating: Initial}
+}
+
+// Expected is the share of matches a player of the given rating should take from an
+// opponent, counting ties as half
+func Expected(rating, opponent float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponent-rating)/spread))
+}
+
+// Record moves the rating after a match against an opponent of the given rating, by how
+// much better or worse the result was than expected
+func (l *Ladder) Record(opponent string, opponentRating, result float64, playedAt time.Time) Match {
+	match := Match{
+		Opponent:       opponent,
+		OpponentRating: opponentRating,
+		Result:         result,
+		Before:         l.Rating,
+		After:          l.Rating + kFactor*(result-Expected(l.Rating, opponentRating)),
+		PlayedAt:       playedAt,
+	}
+
+	l.Rating = match.After
+	l.History = append(l.History, match)
+	if len(l.History) > maxHistory {
+		l.History = l.History[len(l.History)-maxHistory:]
+	}
+
+	return match
+}
+
+// Recent is the ratings after each of the last n matches, oldest first
+func (l *Ladder) Recent(n int) []float64 {
+	history := l.History[max(len(l.History)-n, 0):]
+	ratings := make([]float64, 0, len(history))
+	for _, match := range history {
+		ratings = append(ratings, match.After)
+	}
+	return ratings
+}
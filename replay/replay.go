@@ -0,0 +1,495 @@
+// Package replay is the one file format for replays of a match: what is needed to set the
+// match up again, and the bat input for every tick of play. The world only moves on from its
+// input, so playing the inputs back against the same seed and rules plays the same match.
+//
+// A replay is written in a compact binary layout, or as JSON for reading and editing by
+// hand. The binary layout, all little endian, is:
+//
+//	magic        4 bytes, "C2DR"
+//	version      uint16
+//	mode         uint16 length, then that many bytes
+//	difficulty   uint16 length, then that many bytes
+//	seed         uint32
+//	score        int32
+//	rules        from version 2 on, a byte that is 1 if they were kept, then:
+//	  numbers    the sizes, drag area and bat home as float64 x, y pairs or float64s, then
+//	             the overs, chase target, over time, old ball overs, spawn time, house
+//	             rules and assist, in the order of rulesRecord, then its switches a byte each
+//	  strings    bowling, scenario, practice script, arena and loadout, each a uint16
+//	             length then that many bytes
+//	  mutators   uint16 count, then each as a string
+//	  lineup     uint16 count, then for each batsman its name as a string and its power,
+//	             timing and bat size as float64s
+//	inputs       uint32 count, then for each input:
+//	  x, y       float64 each
+//	  flags      1 byte: 1 if dragging, 2 if blocking
+//
+// Both layouts carry the version, and files from a newer version are refused rather than
+// misread. So are files without a version or a mode, which can't be played back, and ones
+// with rules no match could be played by.
+//
+// Highlights and the instant replay don't use the format. They show the last few seconds
+// of the field as it was drawn, and inputs can only be played back from the first tick of
+// a match.
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/meghashyamc/cricket2d/geometry"
+)
+
+// Version is the version of the format written. Bump it whenever the layout changes, and
+// teach Decode to read the old one.
+const Version = 2
+
+// rulesVersion is the first version with the rules in the header
+const rulesVersion = 2
+
+// The house rules the game can be played by
+const (
+	minBallsPerOver = 4
+	maxBallsPerOver = 8
+	maxShotRuns     = 6
+)
+
+const (
+	flagDrag  = 1 << 0
+	flagBlock = 1 << 1
+)
+
+var magic = [4]byte{'C', '2', 'D', 'R'}
+
+var (
+	// ErrNotReplay is returned for files that are in neither layout
+	ErrNotReplay = errors.New("not a replay")
+	// ErrTooNew is returned for replays written by a newer version of the game
+	ErrTooNew = errors.New("replay saved by a newer version of the game")
+	// ErrBadRules is returned for replays with rules no match could be played by
+	ErrBadRules = errors.New("replay rules can't be played by")
+)
+
+// Header is what is needed to set the match up again
+type Header struct {
+	Version    int    `json:"version"`
+	Mode       string `json:"mode"`
+	Difficulty string `json:"difficulty"`
+	Seed       uint32 `json:"seed"`            // The deliveries are drawn from it
+	Score      int    `json:"score"`           // As the match finished, to check a playback against
+	Rules      *Rules `json:"rules,omitempty"` // nil in replays from before they were kept
+}
+
+// Rules are everything besides the seed that decides how a match plays out: how it was set
+// up, the house rules and mutators it was played by, what the loadout changed and the bats
+// the batsmen carried
+type Rules struct {
+	View   geometry.Vector `json:"view"`  // Size of the window, which the inputs are positions in
+	Field  geometry.Vector `json:"field"` // Size of the playing field
+	Ball   geometry.Vector `json:"ball"`  // Sizes collisions are worked out from
+	Bat    geometry.Vector `json:"bat"`
+	Stumps geometry.Vector `json:"stumps"`
+
+	DragRight float64         `json:"drag_right"` // How far the bat can be dragged, in pixels
+	DragUp    float64         `json:"drag_up"`
+	DragDown  float64         `json:"drag_down"`
+	BatHome   geometry.Vector `json:"bat_home"` // Where each new bat starts
+
+	Bowling        string  `json:"bowling"`
+	Overs          int     `json:"overs"`
+	ChaseTarget    int     `json:"chase_target"`
+	MaxOverSeconds float64 `json:"max_over_seconds"`
+	OldBallOvers   int     `json:"old_ball_overs"`
+	SpawnSeconds   float64 `json:"spawn_seconds"` // Between deliveries, with any override
+	Scenario       string  `json:"scenario,omitempty"`
+	PracticeScript string  `json:"practice_script,omitempty"`
+
+	BallsPerOver int  `json:"balls_per_over"`
+	Wides        bool `json:"wides"`
+	NoBalls      bool `json:"no_balls"`
+	GroundRuns   int  `json:"ground_runs"`
+	LoftedRuns   int  `json:"lofted_runs"`
+
+	Mutators      []string  `json:"mutators,omitempty"`
+	Kid           bool      `json:"kid"`
+	Arena         string    `json:"arena,omitempty"`
+	ChaosOvers    bool      `json:"chaos_overs"`
+	FreeHits      bool      `json:"free_hits"`
+	TargetRings   bool      `json:"target_rings"`
+	DynamicAssist bool      `json:"dynamic_assist"`
+	Assist        Assist    `json:"assist"`            // Where the dynamic assist had got to before the match
+	Loadout       string    `json:"loadout,omitempty"` // Its settings are kept in the rest of the rules
+	Lineup        []Batsman `json:"lineup,omitempty"`  // Empty for the standard batsman every time
+}
+
+// Assist is how far the dynamic assist had got, which carries on from match to match
+type Assist struct {
+	Level           float64 `json:"level"`
+	QuickDismissals int     `json:"quick_dismissals"`
+	Faced           int     `json:"faced"`
+}
+
+// Batsman is one of the lineup, as the bat they carry and how it plays
+type Batsman struct {
+	Name    string  `json:"name"`
+	Power   float64 `json:"power"`
+	Timing  float64 `json:"timing"`
+	BatSize float64 `json:"bat_size"`
+}
+
+// rulesRecord is the part of the rules of a fixed size, in the order of the binary layout
+type rulesRecord struct {
+	View, Field, Ball, Bat, Stumps  geometry.Vector
+	DragRight, DragUp, DragDown     float64
+	BatHome                         geometry.Vector
+	Overs, ChaseTarget              int32
+	MaxOverSeconds                  float64
+	OldBallOvers                    int32
+	SpawnSeconds                    float64
+	BallsPerOver                    int32
+	GroundRuns, LoftedRuns          int32
+	AssistLevel                     float64
+	AssistDismissals, AssistFaced   int32
+	Wides, NoBalls, Kid, ChaosOvers bool
+	FreeHits, TargetRings           bool
+	DynamicAssist                   bool
+}
+
+// Input is how the bat was held on a tick
+type Input struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Drag  bool    `json:"drag,omitempty"`
+	Block bool    `json:"block,omitempty"` // Playing a defensive shot
+}
+
+// Replay is a match's header and its inputs, a tick each
+type Replay struct {
+	Header Header  `json:"header"`
+	Inputs []Input `json:"inputs"`
+}
+
+// New starts a replay of the current version
+func New(mode, difficulty string, seed uint32, rules Rules) *Replay {
+	return &Replay{Header: Header{Version: Version, Mode: mode, Difficulty: difficulty, Seed: seed, Rules: &rules}}
+}
+
+// Record adds the input of the next tick
+func (r *Replay) Record(input Input) {
+	r.Inputs = append(r.Inputs, input)
+}
+
+// Encode writes the replay in the binary layout
+func Encode(w io.Writer, r *Replay) error {
+	bw := bufio.NewWriter(w)
+	bw.Write(magic[:])
+	binary.Write(bw, binary.LittleEndian, uint16(Version))
+	if err := writeString(bw, r.Header.Mode); err != nil {
+		return err
+	}
+	if err := writeString(bw, r.Header.Difficulty); err != nil {
+		return err
+	}
+	binary.Write(bw, binary.LittleEndian, r.Header.Seed)
+	binary.Write(bw, binary.LittleEndian, int32(r.Header.Score))
+	if err := writeRules(bw, r.Header.Rules); err != nil {
+		return err
+	}
+
+	binary.Write(bw, binary.LittleEndian, uint32(len(r.Inputs)))
+	var record [17]byte
+	for _, input := range r.Inputs {
+		binary.LittleEndian.PutUint64(record[0:], math.Float64bits(input.X))
+		binary.LittleEndian.PutUint64(record[8:], math.Float64bits(input.Y))
+		record[16] = 0
+		if input.Drag {
+			record[16] |= flagDrag
+		}
+		if input.Block {
+			record[16] |= flagBlock
+		}
+		bw.Write(record[:])
+	}
+	return bw.Flush()
+}
+
+// Decode reads a replay written by Encode
+func Decode(r io.Reader) (*Replay, error) {
+	br := bufio.NewReader(r)
+	var head [4]byte
+	if _, err := io.ReadFull(br, head[:]); err != nil || head != magic {
+		return nil, ErrNotReplay
+	}
+
+	var version uint16
+	if err := binary.Read(br, binary.LittleEndian, &version); err != nil {
+		return nil, fmt.Errorf("reading version: %w", err)
+	}
+	if err := checkVersion(int(version)); err != nil {
+		return nil, err
+	}
+
+	replay := &Replay{Header: Header{Version: int(version)}}
+	var err error
+	if replay.Header.Mode, err = readString(br); err != nil {
+		return nil, fmt.Errorf("reading mode: %w", err)
+	}
+	if err := replay.Header.check(); err != nil {
+		return nil, err
+	}
+	if replay.Header.Difficulty, err = readString(br); err != nil {
+		return nil, fmt.Errorf("reading difficulty: %w", err)
+	}
+	var score int32
+	if err := binary.Read(br, binary.LittleEndian, &replay.Header.Seed); err != nil {
+		return nil, fmt.Errorf("reading seed: %w", err)
+	}
+	if err := binary.Read(br, binary.LittleEndian, &score); err != nil {
+		return nil, fmt.Errorf("reading score: %w", err)
+	}
+	replay.Header.Score = int(score)
+	if replay.Header.Version >= rulesVersion {
+		if replay.Header.Rules, err = readRules(br); err != nil {
+			return nil, fmt.Errorf("reading rules: %w", err)
+		}
+		if err := replay.Header.Rules.check(); err != nil {
+			return nil, err
+		}
+	}
+
+	var count uint32
+	if err := binary.Read(br, binary.LittleEndian, &count); err != nil {
+		return nil, fmt.Errorf("reading input count: %w", err)
+	}
+	// The count isn't trusted to size the slice, as a damaged file could claim anything
+	var record [17]byte
+	for i := uint32(0); i < count; i++ {
+		if _, err := io.ReadFull(br, record[:]); err != nil {
+			return nil, fmt.Errorf("reading input %d of %d: %w", i+1, count, err)
+		}
+		replay.Inputs = append(replay.Inputs, Input{
+			X:     math.Float64frombits(binary.LittleEndian.Uint64(record[0:])),
+			Y:     math.Float64frombits(binary.LittleEndian.Uint64(record[8:])),
+			Drag:  record[16]&flagDrag != 0,
+			Block: record[16]&flagBlock != 0,
+		})
+	}
+	return replay, nil
+}
+
+// EncodeJSON writes the replay as JSON
+func EncodeJSON(w io.Writer, r *Replay) error {
+	copied := *r
+	copied.Header.Version = Version
+	return json.NewEncoder(w).Encode(&copied)
+}
+
+// DecodeJSON reads a replay written by EncodeJSON
+func DecodeJSON(r io.Reader) (*Replay, error) {
+	var replay Replay
+	if err := json.NewDecoder(r).Decode(&replay); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotReplay, err)
+	}
+	if err := replay.Header.check(); err != nil {
+		return nil, err
+	}
+	if replay.Header.Version < rulesVersion {
+		replay.Header.Rules = nil
+	}
+	if err := replay.Header.Rules.check(); err != nil {
+		return nil, err
+	}
+	return &replay, nil
+}
+
+// Load reads a replay file in either layout
+func Load(path string) (*Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, magic[:]) {
+		return Decode(bytes.NewReader(data))
+	}
+	return DecodeJSON(bytes.NewReader(data))
+}
+
+// Save writes a replay file in the binary layout
+func Save(path string, r *Replay) error {
+	var buf bytes.Buffer
+	if err := Encode(&buf, r); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// check refuses a header that can't be played back, or that is from a newer version
+func (h Header) check() error {
+	if err := checkVersion(h.Version); err != nil {
+		return err
+	}
+	if len(h.Mode) == 0 {
+		return fmt.Errorf("%w: no mode", ErrNotReplay)
+	}
+	return nil
+}
+
+// check refuses rules the match couldn't have been played by, as from a file edited by
+// hand. A replay without rules has nothing to check.
+func (r *Rules) check() error {
+	switch {
+	case r == nil:
+		return nil
+	case r.View.X <= 0 || r.View.Y <= 0:
+		return fmt.Errorf("%w: view of %gx%g", ErrBadRules, r.View.X, r.View.Y)
+	case r.BallsPerOver < minBallsPerOver || r.BallsPerOver > maxBallsPerOver:
+		return fmt.Errorf("%w: %d balls an over", ErrBadRules, r.BallsPerOver)
+	case r.GroundRuns < 1 || r.GroundRuns > maxShotRuns || r.LoftedRuns < 1 || r.LoftedRuns > maxShotRuns:
+		return fmt.Errorf("%w: %d runs along the ground and %d lofted", ErrBadRules, r.GroundRuns, r.LoftedRuns)
+	}
+	return nil
+}
+
+// checkVersion refuses a replay without a version, or from a newer version
+func checkVersion(version int) error {
+	if version <= 0 {
+		return fmt.Errorf("%w: no version", ErrNotReplay)
+	}
+	if version > Version {
+		return fmt.Errorf("%w (version %d, this game reads up to %d)", ErrTooNew, version, Version)
+	}
+	return nil
+}
+
+// writeRules writes the rules, or that there are none
+func writeRules(w io.Writer, r *Rules) error {
+	if r == nil {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+	w.Write([]byte{1})
+
+	record := rulesRecord{
+		View: r.View, Field: r.Field, Ball: r.Ball, Bat: r.Bat, Stumps: r.Stumps,
+		DragRight: r.DragRight, DragUp: r.DragUp, DragDown: r.DragDown, BatHome: r.BatHome,
+		Overs: int32(r.Overs), ChaseTarget: int32(r.ChaseTarget), MaxOverSeconds: r.MaxOverSeconds,
+		OldBallOvers: int32(r.OldBallOvers), SpawnSeconds: r.SpawnSeconds,
+		BallsPerOver: int32(r.BallsPerOver), GroundRuns: int32(r.GroundRuns), LoftedRuns: int32(r.LoftedRuns),
+		AssistLevel: r.Assist.Level, AssistDismissals: int32(r.Assist.QuickDismissals), AssistFaced: int32(r.Assist.Faced),
+		Wides: r.Wides, NoBalls: r.NoBalls, Kid: r.Kid, ChaosOvers: r.ChaosOvers,
+		FreeHits: r.FreeHits, TargetRings: r.TargetRings, DynamicAssist: r.DynamicAssist,
+	}
+	if err := binary.Write(w, binary.LittleEndian, &record); err != nil {
+		return err
+	}
+	for _, s := range []string{r.Bowling, r.Scenario, r.PracticeScript, r.Arena, r.Loadout} {
+		if err := writeString(w, s); err != nil {
+			return err
+		}
+	}
+
+	if len(r.Mutators) > math.MaxUint16 || len(r.Lineup) > math.MaxUint16 {
+		return errors.New("too many mutators or batsmen for a replay")
+	}
+	binary.Write(w, binary.LittleEndian, uint16(len(r.Mutators)))
+	for _, m := range r.Mutators {
+		if err := writeString(w, m); err != nil {
+			return err
+		}
+	}
+	binary.Write(w, binary.LittleEndian, uint16(len(r.Lineup)))
+	for _, b := range r.Lineup {
+		if err := writeString(w, b.Name); err != nil {
+			return err
+		}
+		binary.Write(w, binary.LittleEndian, [3]float64{b.Power, b.Timing, b.BatSize})
+	}
+	return nil
+}
+
+// readRules reads rules written by writeRules, nil if there were none
+func readRules(r io.Reader) (*Rules, error) {
+	var kept [1]byte
+	if _, err := io.ReadFull(r, kept[:]); err != nil {
+		return nil, err
+	}
+	if kept[0] == 0 {
+		return nil, nil
+	}
+
+	var record rulesRecord
+	if err := binary.Read(r, binary.LittleEndian, &record); err != nil {
+		return nil, err
+	}
+	rules := &Rules{
+		View: record.View, Field: record.Field, Ball: record.Ball, Bat: record.Bat, Stumps: record.Stumps,
+		DragRight: record.DragRight, DragUp: record.DragUp, DragDown: record.DragDown, BatHome: record.BatHome,
+		Overs: int(record.Overs), ChaseTarget: int(record.ChaseTarget), MaxOverSeconds: record.MaxOverSeconds,
+		OldBallOvers: int(record.OldBallOvers), SpawnSeconds: record.SpawnSeconds,
+		BallsPerOver: int(record.BallsPerOver), GroundRuns: int(record.GroundRuns), LoftedRuns: int(record.LoftedRuns),
+		Assist: Assist{Level: record.AssistLevel, QuickDismissals: int(record.AssistDismissals), Faced: int(record.AssistFaced)},
+		Wides:  record.Wides, NoBalls: record.NoBalls, Kid: record.Kid, ChaosOvers: record.ChaosOvers,
+		FreeHits: record.FreeHits, TargetRings: record.TargetRings, DynamicAssist: record.DynamicAssist,
+	}
+	for _, s := range []*string{&rules.Bowling, &rules.Scenario, &rules.PracticeScript, &rules.Arena, &rules.Loadout} {
+		var err error
+		if *s, err = readString(r); err != nil {
+			return nil, err
+		}
+	}
+
+	var count uint16
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, err
+	}
+	for range count {
+		m, err := readString(r)
+		if err != nil {
+			return nil, err
+		}
+		rules.Mutators = append(rules.Mutators, m)
+	}
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, err
+	}
+	for range count {
+		name, err := readString(r)
+		if err != nil {
+			return nil, err
+		}
+		var skills [3]float64
+		if err := binary.Read(r, binary.LittleEndian, &skills); err != nil {
+			return nil, err
+		}
+		rules.Lineup = append(rules.Lineup, Batsman{Name: name, Power: skills[0], Timing: skills[1], BatSize: skills[2]})
+	}
+	return rules, nil
+}
+
+func writeString(w io.Writer, s string) error {
+	if len(s) > math.MaxUint16 {
+		return fmt.Errorf("%q is too long for a replay", s)
+	}
+	binary.Write(w, binary.LittleEndian, uint16(len(s)))
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+func readString(r io.Reader) (string, error) {
+	var length uint16
+	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+		return "", err
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
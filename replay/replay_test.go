@@ -0,0 +1,225 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/meghashyamc/cricket2d/geometry"
+)
+
+func sampleRules() Rules {
+	return Rules{
+		View:           geometry.Vector{X: 1200, Y: 800},
+		Field:          geometry.Vector{X: 2400, Y: 1600},
+		Ball:           geometry.Vector{X: 93, Y: 77},
+		Bat:            geometry.Vector{X: 58, Y: 387},
+		Stumps:         geometry.Vector{X: 77, Y: 347},
+		DragRight:      400,
+		DragUp:         200,
+		DragDown:       100,
+		BatHome:        geometry.Vector{X: 210.5, Y: 340},
+		Bowling:        "adaptive",
+		Overs:          3,
+		ChaseTarget:    24,
+		MaxOverSeconds: 45.5,
+		OldBallOvers:   2,
+		SpawnSeconds:   1.5,
+		BallsPerOver:   8,
+		Wides:          true,
+		NoBalls:        true,
+		GroundRuns:     2,
+		LoftedRuns:     6,
+		Mutators:       []string{"low gravity", "tiny stumps"},
+		Kid:            true,
+		Arena:          "bouncy",
+		ChaosOvers:     true,
+		TargetRings:    true,
+		DynamicAssist:  true,
+		Assist:         Assist{Level: 0.2, QuickDismissals: 1, Faced: 4},
+		Loadout:        "Sam",
+		Lineup:         []Batsman{{Name: "Opener", Power: 0.9, Timing: 1.1, BatSize: 1.2}, {Name: "Tail", Power: 0.6, Timing: 0.7, BatSize: 0.9}},
+	}
+}
+
+func sampleReplay() *Replay {
+	r := New("chase", "normal", 0xC0FFEE, sampleRules())
+	r.Header.Score = 27
+	r.Record(Input{X: 900, Y: 600})
+	r.Record(Input{X: 900.25, Y: 601.5, Drag: true})
+	r.Record(Input{X: -1e-9, Y: 0, Block: true})
+	r.Record(Input{X: 1, Y: 2, Drag: true, Block: true})
+	return r
+}
+
+func TestRoundTrip(t *testing.T) {
+	codecs := []struct {
+		name   string
+		encode func(*bytes.Buffer, *Replay) error
+		decode func(*bytes.Buffer) (*Replay, error)
+	}{
+		{"binary", func(b *bytes.Buffer, r *Replay) error { return Encode(b, r) }, func(b *bytes.Buffer) (*Replay, error) { return Decode(b) }},
+		{"json", func(b *bytes.Buffer, r *Replay) error { return EncodeJSON(b, r) }, func(b *bytes.Buffer) (*Replay, error) { return DecodeJSON(b) }},
+	}
+	for _, codec := range codecs {
+		t.Run(codec.name, func(t *testing.T) {
+			want := sampleReplay()
+			var buf bytes.Buffer
+			if err := codec.encode(&buf, want); err != nil {
+				t.Fatal(err)
+			}
+			got, err := codec.decode(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestNewerVersionRefused(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, sampleReplay()); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	binary.LittleEndian.PutUint16(data[len(magic):], Version+1)
+	if _, err := Decode(bytes.NewReader(data)); !errors.Is(err, ErrTooNew) {
+		t.Errorf("binary: got %v, want %v", err, ErrTooNew)
+	}
+
+	newer := fmt.Sprintf(`{"header": {"version": %d, "mode": "chase"}}`, Version+1)
+	if _, err := DecodeJSON(strings.NewReader(newer)); !errors.Is(err, ErrTooNew) {
+		t.Errorf("json: got %v, want %v", err, ErrTooNew)
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, sampleReplay()); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if _, err := Decode(bytes.NewReader(data[:len(data)-1])); err == nil {
+		t.Error("decoded a truncated replay")
+	}
+	if _, err := Decode(strings.NewReader("not a replay")); !errors.Is(err, ErrNotReplay) {
+		t.Errorf("got %v, want %v", err, ErrNotReplay)
+	}
+}
+
+func TestIncompleteRefused(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"empty", `{}`},
+		{"no version", `{"header": {"mode": "chase", "seed": 7}}`},
+		{"no mode", `{"header": {"version": 2, "seed": 7}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeJSON(strings.NewReader(tt.json)); !errors.Is(err, ErrNotReplay) {
+				t.Errorf("got %v, want %v", err, ErrNotReplay)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	r := sampleReplay()
+	r.Header.Mode = ""
+	if err := Encode(&buf, r); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decode(&buf); !errors.Is(err, ErrNotReplay) {
+		t.Errorf("binary without a mode: got %v, want %v", err, ErrNotReplay)
+	}
+}
+
+// TestBadRulesRefused checks that a replay edited by hand to rules no match could be played
+// by is refused when it is loaded, rather than played
+func TestBadRulesRefused(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeJSON(&buf, sampleReplay()); err != nil {
+		t.Fatal(err)
+	}
+	saved := buf.String()
+
+	tests := []struct {
+		name     string
+		old, new string
+	}{
+		{"no balls in an over", `"balls_per_over":8`, `"balls_per_over":0`},
+		{"over left out", `"balls_per_over":8,`, ``},
+		{"long over", `"balls_per_over":8`, `"balls_per_over":12`},
+		{"shots worth nothing", `"ground_runs":2`, `"ground_runs":0`},
+		{"no view", `"view":{"X":1200,"Y":800}`, `"view":{"X":0,"Y":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(saved, tt.old) {
+				t.Fatalf("%s isn't in the saved replay", tt.old)
+			}
+			path := filepath.Join(t.TempDir(), "edited.json")
+			if err := os.WriteFile(path, []byte(strings.Replace(saved, tt.old, tt.new, 1)), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); !errors.Is(err, ErrBadRules) {
+				t.Errorf("got %v, want %v", err, ErrBadRules)
+			}
+		})
+	}
+
+	r := sampleReplay()
+	r.Header.Rules.BallsPerOver = 0
+	buf.Reset()
+	if err := Encode(&buf, r); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decode(&buf); !errors.Is(err, ErrBadRules) {
+		t.Errorf("binary with no balls in an over: got %v, want %v", err, ErrBadRules)
+	}
+}
+
+// TestVersion1 checks that replays from before the rules were kept are still read, without
+// any rules
+func TestVersion1(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write(magic[:])
+	binary.Write(&buf, binary.LittleEndian, uint16(1))
+	writeString(&buf, "overs")
+	writeString(&buf, "easy")
+	binary.Write(&buf, binary.LittleEndian, uint32(42))
+	binary.Write(&buf, binary.LittleEndian, int32(12))
+	binary.Write(&buf, binary.LittleEndian, uint32(1))
+	binary.Write(&buf, binary.LittleEndian, [2]float64{900, 600})
+	buf.WriteByte(flagDrag)
+
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Replay{
+		Header: Header{Version: 1, Mode: "overs", Difficulty: "easy", Seed: 42, Score: 12},
+		Inputs: []Input{{X: 900, Y: 600, Drag: true}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	got, err = DecodeJSON(strings.NewReader(`{"header": {"version": 1, "mode": "overs", "rules": {"overs": 3}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Header.Rules != nil {
+		t.Errorf("version 1 replay read with rules %+v", got.Header.Rules)
+	}
+}
@@ -0,0 +1,45 @@
+package scenario
+
+import (
+	_ "embed"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Challenge is one of the game's built-in scenarios, played in order from the challenge menu
+type Challenge struct {
+	Scenario      string `yaml:"scenario"`       // Name of the built-in scenario
+	RequiresStars int    `yaml:"requires_stars"` // Stars needed across all challenges to unlock it
+}
+
+//go:embed challenges.yaml
+var challengesFile []byte
+
+// Challenges lists the challenges in the order they unlock
+func Challenges() ([]Challenge, error) {
+	var file struct {
+		Challenges []Challenge `yaml:"challenges"`
+	}
+	if err := yaml.Unmarshal(challengesFile, &file); err != nil {
+		return nil, fmt.Errorf("could not parse challenges: %w", err)
+	}
+
+	return file.Challenges, nil
+}
+
+// Unlocked reports which challenges can be played, given the best stars earned on each
+// so far. The first is always open, and each one after needs the one before it completed
+// and enough stars in total.
+func Unlocked(challenges []Challenge, stars map[string]int) []bool {
+	total := 0
+	for _, challenge := range challenges {
+		total += stars[challenge.Scenario]
+	}
+
+	unlocked := make([]bool, len(challenges))
+	for i, challenge := range challenges {
+		unlocked[i] = i == 0 || (stars[challenges[i-1].Scenario] > 0 && total >= challenge.RequiresStars)
+	}
+	return unlocked
+}
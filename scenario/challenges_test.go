@@ -0,0 +1,87 @@
+package scenario
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestChallenges checks that every challenge is a built-in scenario, and that the first
+// needs no stars to open
+func TestChallenges(t *testing.T) {
+	challenges, err := Challenges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(challenges) == 0 {
+		t.Fatal("no challenges")
+	}
+	if challenges[0].RequiresStars > 0 {
+		t.Errorf("the first challenge needs %d stars", challenges[0].RequiresStars)
+	}
+	for _, challenge := range challenges {
+		if _, err := Load(challenge.Scenario); err != nil {
+			t.Errorf("challenge %s: %v", challenge.Scenario, err)
+		}
+	}
+}
+
+func TestUnlocked(t *testing.T) {
+	challenges := []Challenge{
+		{Scenario: "a"},
+		{Scenario: "b"},
+		{Scenario: "c", RequiresStars: 3},
+		{Scenario: "d"},
+	}
+	tests := []struct {
+		name  string
+		stars map[string]int
+		want  []bool
+	}{
+		{name: "none played", want: []bool{true, false, false, false}},
+		{name: "first completed", stars: map[string]int{"a": 1}, want: []bool{true, true, false, false}},
+		{name: "short of stars", stars: map[string]int{"a": 1, "b": 1}, want: []bool{true, true, false, false}},
+		{name: "enough stars", stars: map[string]int{"a": 2, "b": 1}, want: []bool{true, true, true, false}},
+		{name: "stars from later ones count", stars: map[string]int{"a": 1, "b": 1, "d": 1}, want: []bool{true, true, true, false}},
+		{name: "all completed", stars: map[string]int{"a": 1, "b": 1, "c": 1}, want: []bool{true, true, true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unlocked(challenges, tt.stars); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStars(t *testing.T) {
+	s := &Scenario{
+		Balls:   6,
+		Wickets: 2,
+		Goal:    Goal{Runs: 5},
+		Rules:   Rules{NoLofting: true},
+		Bonuses: []Bonus{{BallsLeft: 2}, {WicketsLeft: 2}},
+	}
+	tests := []struct {
+		name   string
+		p      Progress
+		result Result
+		stars  int
+	}{
+		{name: "in progress", p: Progress{Runs: 3, Balls: 3}, result: InProgress},
+		{name: "passed", p: Progress{Runs: 5, Balls: 6, Wickets: 1}, result: Passed, stars: 1},
+		{name: "passed with both bonuses", p: Progress{Runs: 5, Balls: 4}, result: Passed, stars: 3},
+		{name: "lofted", p: Progress{Runs: 6, Lofted: 1, Balls: 2}, result: Failed},
+		{name: "out of wickets", p: Progress{Runs: 2, Wickets: 2, Balls: 3}, result: Failed},
+		{name: "out of balls", p: Progress{Runs: 4, Balls: 6, BowlingDone: true}, result: Failed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result, _ := s.Check(tt.p); result != tt.result {
+				t.Errorf("got result %d, want %d", result, tt.result)
+			}
+			if stars := s.Stars(tt.p); stars != tt.stars {
+				t.Errorf("got %d stars, want %d", stars, tt.stars)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package scenario
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario sets up a moment in a match, such as the last over of a tight chase, and says
+// what the batsman has to do to come out of it on top. Scenarios are YAML or JSON files, so
+// they can be shared:
+//
+//	name: Last over
+//	description: Need 5 off the last over with 1 wicket left.
+//	balls: 6
+//	wickets: 1
+//	goal:
+//	  runs: 5
+//	script: yorkers-then-bouncers
+type Scenario struct {
+	Name        string
+	Description string
+	Balls       int // Legal balls the batsman has to face, 0 for no limit
+	Wickets     int // Wickets in hand
+	Goal        Goal
+	Rules       Rules
+	Bonuses     []Bonus            // Extras that earn a star each, on top of the one for passing
+	Deliveries  *deliveries.Script // What is bowled, nil for the usual bowling
+}
+
+// Goal is what the batsman has to do. Every part of it that is set has to be done.
+type Goal struct {
+	Runs    int  `yaml:"runs" json:"runs"`       // Runs to score
+	Lofted  int  `yaml:"lofted" json:"lofted"`   // Lofted shots to play
+	Survive bool `yaml:"survive" json:"survive"` // Still be in when the balls run out
+}
+
+// Bonus is something extra to have done by the time a scenario is passed. Every part of it
+// that is set has to be done.
+type Bonus struct {
+	Runs        int `yaml:"runs" json:"runs"`
+	Lofted      int `yaml:"lofted" json:"lofted"`
+	BallsLeft   int `yaml:"balls_left" json:"balls_left"`     // Balls to spare
+	WicketsLeft int `yaml:"wickets_left" json:"wickets_left"` // Wickets in hand
+}
+
+// Rules are restrictions on how the batsman gets there
+type Rules struct {
+	NoLofting bool `yaml:"no_lofting" json:"no_lofting"` // Playing a lofted shot fails the scenario
+}
+
+type scenarioFile struct {
+	Name        string           `yaml:"name" json:"name"`
+	Description string           `yaml:"description" json:"description"`
+	Balls       int              `yaml:"balls" json:"balls"`
+	Wickets     int              `yaml:"wickets" json:"wickets"`
+	Goal        Goal             `yaml:"goal" json:"goal"`
+	Rules       Rules            `yaml:"rules" json:"rules"`
+	Bonuses     []Bonus          `yaml:"bonuses" json:"bonuses"`
+	Script      string           `yaml:"script" json:"script"`         // A delivery script, built in or a path
+	Deliveries  []deliveries.Row `yaml:"deliveries" json:"deliveries"` // Deliveries written into the scenario instead
+}
+
+//go:embed scenarios/*.yaml
+var builtinScenarios embed.FS
+
+// Names lists the scenarios built into the game in alphabetical order
+func Names() []string {
+	entries, _ := builtinScenarios.ReadDir("scenarios")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())))
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// Load reads a scenario from a YAML or JSON file. A name without a file extension refers to
+// one of the scenarios built into the game.
+func Load(name string) (*Scenario, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext == "" {
+		ext = ".yaml"
+		data, err = builtinScenarios.ReadFile("scenarios/" + name + ext)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read scenario %s: %w", name, err)
+	}
+
+	var file scenarioFile
+	switch ext {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		err = fmt.Errorf("unsupported file type %s", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse scenario %s: %w", name, err)
+	}
+
+	s, err := file.toScenario()
+	if err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", name, err)
+	}
+	return s, nil
+}
+
+func (f scenarioFile) toScenario() (*Scenario, error) {
+	if len(f.Name) == 0 {
+		return nil, fmt.Errorf("scenario needs a name")
+	}
+	if f.Balls < 0 || f.Wickets < 0 || f.Goal.Runs < 0 || f.Goal.Lofted < 0 {
+		return nil, fmt.Errorf("balls, wickets and goals can't be negative")
+	}
+	if f.Goal.Runs == 0 && f.Goal.Lofted == 0 && !f.Goal.Survive {
+		return nil, fmt.Errorf("scenario needs a goal")
+	}
+	if f.Goal.Survive && f.Balls == 0 && f.Deliveries == nil && len(f.Script) == 0 {
+		return nil, fmt.Errorf("surviving needs a number of balls or deliveries to survive")
+	}
+	if f.Rules.NoLofting && f.Goal.Lofted > 0 {
+		return nil, fmt.Errorf("scenario can't ask for lofted shots and forbid them")
+	}
+	for _, bonus := range f.Bonuses {
+		if bonus.BallsLeft > 0 && f.Balls == 0 {
+			return nil, fmt.Errorf("a bonus for balls to spare needs a number of balls")
+		}
+	}
+
+	s := &Scenario{
+		Name:        f.Name,
+		Description: f.Description,
+		Balls:       f.Balls,
+		Wickets:     max(f.Wickets, 1),
+		Goal:        f.Goal,
+		Rules:       f.Rules,
+		Bonuses:     f.Bonuses,
+	}
+
+	var err error
+	switch {
+	case len(f.Script) > 0 && len(f.Deliveries) > 0:
+		return nil, fmt.Errorf("scenario can have a script or deliveries, not both")
+	case len(f.Script) > 0:
+		s.Deliveries, err = deliveries.Load(f.Script)
+	case len(f.Deliveries) > 0:
+		s.Deliveries, err = deliveries.NewScript(f.Name, f.Deliveries)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Progress is how the batsman is getting on in a scenario
+type Progress struct {
+	Runs        int
+	Lofted      int
+	Wickets     int  // Wickets lost
+	Balls       int  // Legal balls faced
+	BowlingDone bool // Every ball has been bowled and dealt with
+}
+
+// Result is where a scenario stands
+type Result int
+
+const (
+	InProgress Result = iota
+	Passed
+	Failed
+)
+
+// Check decides whether the scenario has been passed or failed, and if failed, why
+func (s *Scenario) Check(p Progress) (Result, string) {
+	if s.Rules.NoLofting && p.Lofted > 0 {
+		return Failed, "Lofted a shot"
+	}
+
+	scored := p.Runs >= s.Goal.Runs && p.Lofted >= s.Goal.Lofted
+	if scored && !s.Goal.Survive {
+		return Passed, ""
+	}
+	if p.Wickets >= s.Wickets {
+		return Failed, "Out of wickets"
+	}
+	if p.BowlingDone {
+		if scored {
+			return Passed, ""
+		}
+		return Failed, "Out of balls"
+	}
+
+	return InProgress, ""
+}
+
+// MaxStars is the most stars the scenario can earn
+func (s *Scenario) MaxStars() int {
+	return 1 + len(s.Bonuses)
+}
+
+// Stars is how many stars a finished scenario earned: none if it was failed, and otherwise
+// one for passing and one for each bonus
+func (s *Scenario) Stars(p Progress) int {
+	if result, _ := s.Check(p); result != Passed {
+		return 0
+	}
+
+	stars := 1
+	for _, bonus := range s.Bonuses {
+		if p.Runs >= bonus.Runs && p.Lofted >= bonus.Lofted &&
+			(bonus.BallsLeft == 0 || s.Balls-p.Balls >= bonus.BallsLeft) &&
+			s.Wickets-p.Wickets >= bonus.WicketsLeft {
+			stars++
+		}
+	}
+	return stars
+}
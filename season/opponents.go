@@ -0,0 +1,33 @@
+package season
+
+// Opponent is an AI side that plays in the season
+type Opponent struct {
+	Name     string
+	Strength float64 // Multiplier on the runs the side scores, with 1 being an ordinary side
+}
+
+var opponents = []Opponent{
+	{Name: "Harbour Hawks", Strength: 0.8},
+	{Name: "Valley Vipers", Strength: 0.9},
+	{Name: "Capital Kings", Strength: 1},
+	{Name: "Coastal Chargers", Strength: 1.15},
+	{Name: "Highland Thunder", Strength: 1.3},
+}
+
+// Opponents returns the AI sides in the league, weakest first
+func Opponents() []Opponent {
+	sides := make([]Opponent, len(opponents))
+	copy(sides, opponents)
+
+	return sides
+}
+
+func findOpponent(name string) (Opponent, bool) {
+	for _, opponent := range opponents {
+		if opponent.Name == name {
+			return opponent, true
+		}
+	}
+
+	return Opponent{}, false
+}
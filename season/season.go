@@ -0,0 +1,201 @@
+package season
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+const (
+	pointsForWin = 2
+	pointsForTie = 1
+	// An ordinary side scores around this many runs in a match
+	parScore = 20
+	// Balls in an over, for working out run rates
+	ballsPerOver = 6
+	// How far either side of their usual score a side can end up, as a fraction
+	scoreSpread = 0.3
+)
+
+// Fixture is a match in the season, and its result once played
+type Fixture struct {
+	Round    int    `json:"round"`
+	Home     string `json:"home"`
+	Away     string `json:"away"`
+	Played   bool   `json:"played"`
+	HomeRuns int    `json:"home_runs,omitempty"`
+	AwayRuns int    `json:"away_runs,omitempty"`
+	// Legal balls each side faced, counting the full quota for a side that was all out
+	HomeBalls int    `json:"home_balls,omitempty"`
+	AwayBalls int    `json:"away_balls,omitempty"`
+	Winner    string `json:"winner,omitempty"` // Empty for a tie
+}
+
+// Innings is what a side made batting in a fixture
+type Innings struct {
+	Runs  int
+	Balls int // Legal balls faced, or the full quota if the side was all out
+}
+
+// Involves is true if the given side plays in the fixture
+func (f Fixture) Involves(side string) bool {
+	return f.Home == side || f.Away == side
+}
+
+// Opposition is the side the given one is up against in the fixture
+func (f Fixture) Opposition(side string) string {
+	if f.Home == side {
+		return f.Away
+	}
+	return f.Home
+}
+
+// Season is a league where every side plays every other side once
+type Season struct {
+	Number   int       `json:"number"`
+	Team     string    `json:"team"`  // The player's side
+	Overs    int       `json:"overs"` // Per side in each match
+	Fixtures []Fixture `json:"fixtures"`
+}
+
+// New draws up the fixture list for a season between the player's side and the opponents,
+// with matches of the given number of overs
+func New(number int, team string, sides []Opponent, overs int) *Season {
+	names := []string{team}
+	for _, side := range sides {
+		names = append(names, side.Name)
+	}
+	// The round robin needs an even number of sides, so one sits out each round
+	if len(names)%2 == 1 {
+		names = append(names, "")
+	}
+
+	s := &Season{Number: number, Team: team, Overs: overs}
+	rounds := len(names) - 1
+	for round := range rounds {
+		for i := range len(names) / 2 {
+			home, away := names[i], names[len(names)-1-i]
+			if home == "" || away == "" {
+				continue
+			}
+			if round%2 == 1 {
+				home, away = away, home
+			}
+			s.Fixtures = append(s.Fixtures, Fixture{Round: round + 1, Home: home, Away: away})
+		}
+		// Keep the first side where it is and rotate the rest
+		last := names[len(names)-1]
+		copy(names[2:], names[1:len(names)-1])
+		names[1] = last
+	}
+
+	return s
+}
+
+// NextFixture is the index of the player's next match, playing out any AI fixtures due
+// before it. It returns false once the player's matches are all done.
+func (s *Season) NextFixture() (int, bool) {
+	for i, fixture := range s.Fixtures {
+		if fixture.Played {
+			continue
+		}
+		if fixture.Involves(s.Team) {
+			return i, true
+		}
+		s.simulate(i)
+	}
+
+	return 0, false
+}
+
+// Record stores the result of a fixture
+func (s *Season) Record(i int, home, away Innings, winner string) {
+	fixture := &s.Fixtures[i]
+	fixture.Played = true
+	fixture.HomeRuns, fixture.HomeBalls = home.Runs, home.Balls
+	fixture.AwayRuns, fixture.AwayBalls = away.Runs, away.Balls
+	fixture.Winner = winner
+}
+
+// Complete is true once every fixture has been played
+func (s *Season) Complete() bool {
+	for _, fixture := range s.Fixtures {
+		if !fixture.Played {
+			return false
+		}
+	}
+	return true
+}
+
+// simulate plays out a fixture between two AI sides. The result follows from the season and
+// the fixture, so it comes out the same however many times the season is loaded.
+func (s *Season) simulate(i int) {
+	fixture := s.Fixtures[i]
+	r := rand.New(rand.NewPCG(uint64(s.Number), uint64(i)))
+	homeRuns, awayRuns := Score(fixture.Home, r), Score(fixture.Away, r)
+
+	winner := ""
+	switch {
+	case homeRuns > awayRuns:
+		winner = fixture.Home
+	case awayRuns > homeRuns:
+		winner = fixture.Away
+	}
+	// AI sides bat out their overs
+	balls := s.Overs * ballsPerOver
+	s.Record(i, Innings{Runs: homeRuns, Balls: balls}, Innings{Runs: awayRuns, Balls: balls}, winner)
+}
+
+// Score is how many runs an AI side makes in a match, depending on how strong they are and
+// drawing the rest from r
+func Score(side string, r *rand.Rand) int {
+	strength := 1.0
+	if opponent, ok := findOpponent(side); ok {
+		strength = opponent.Strength
+	}
+
+	spread := 1 + scoreSpread*(2*r.Float64()-1)
+	return int(math.Round(parScore * strength * spread))
+}
+
+// Award is an end of season honour
+type Award struct {
+	Title  string
+	Winner string
+}
+
+// Awards are handed out once the season is complete
+func (s *Season) Awards() []Award {
+	if !s.Complete() {
+		return nil
+	}
+
+	awards := []Award{{Title: "Champions", Winner: s.Standings()[0].Team}}
+
+	var biggestWin, highestTotal *Fixture
+	highestTotalRuns := -1
+	for i, fixture := range s.Fixtures {
+		if fixture.Winner != "" && (biggestWin == nil || margin(fixture) > margin(*biggestWin)) {
+			biggestWin = &s.Fixtures[i]
+		}
+		if runs := max(fixture.HomeRuns, fixture.AwayRuns); runs > highestTotalRuns {
+			highestTotal, highestTotalRuns = &s.Fixtures[i], runs
+		}
+	}
+
+	if biggestWin != nil {
+		awards = append(awards, Award{Title: "Biggest win", Winner: biggestWin.Winner})
+	}
+	if highestTotal != nil {
+		side := highestTotal.Home
+		if highestTotal.AwayRuns > highestTotal.HomeRuns {
+			side = highestTotal.Away
+		}
+		awards = append(awards, Award{Title: "Highest total", Winner: side})
+	}
+
+	return awards
+}
+
+func margin(f Fixture) int {
+	return max(f.HomeRuns-f.AwayRuns, f.AwayRuns-f.HomeRuns)
+}
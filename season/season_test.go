@@ -0,0 +1,43 @@
+package season
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+func TestScoreFollowsFromRand(t *testing.T) {
+	for _, side := range Opponents() {
+		first := Score(side.Name, rand.New(rand.NewPCG(7, 1)))
+		second := Score(side.Name, rand.New(rand.NewPCG(7, 1)))
+		if first != second {
+			t.Errorf("%s scored %d, then %d from the same seed", side.Name, first, second)
+		}
+
+		low := int(math.Round(parScore * side.Strength * (1 - scoreSpread)))
+		high := int(math.Round(parScore * side.Strength * (1 + scoreSpread)))
+		if first < low || first > high {
+			t.Errorf("%s scored %d, want between %d and %d", side.Name, first, low, high)
+		}
+	}
+}
+
+// TestSimulatedResultsRepeat plays the AI fixtures of the same season twice, as happens
+// when a season is set up again, and checks they come out the same
+func TestSimulatedResultsRepeat(t *testing.T) {
+	play := func() []Fixture {
+		s := New(3, "Your XI", Opponents(), 2)
+		for {
+			i, ok := s.NextFixture()
+			if !ok {
+				return s.Fixtures
+			}
+			s.Record(i, Innings{Runs: 20, Balls: 12}, Innings{Runs: 10, Balls: 12}, s.Team)
+		}
+	}
+
+	if first, second := play(), play(); !reflect.DeepEqual(first, second) {
+		t.Error("the same season played out different results")
+	}
+}
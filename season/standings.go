@@ -0,0 +1,157 @@
+package season
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// SortKey is a column the points table can be sorted by
+type SortKey int
+
+const (
+	SortByPoints SortKey = iota // The league's order, with tie-breaks
+	SortByNetRunRate
+	SortByWon
+	SortByTeam
+)
+
+var sortKeyNames = map[SortKey]string{
+	SortByPoints:     "points",
+	SortByNetRunRate: "net run rate",
+	SortByWon:        "won",
+	SortByTeam:       "team",
+}
+
+func (k SortKey) String() string {
+	if name, ok := sortKeyNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("SortKey(%d)", int(k))
+}
+
+// Next is the sort key after this one, wrapping round to the first
+func (k SortKey) Next() SortKey {
+	return (k + 1) % SortKey(len(sortKeyNames))
+}
+
+// Standing is a side's record in the points table
+type Standing struct {
+	Team   string
+	Played int
+	Won    int
+	Lost   int
+	Tied   int
+	Points int
+
+	RunsFor     int
+	BallsFaced  int
+	RunsAgainst int
+	BallsBowled int
+}
+
+// NetRunRate is the side's runs per over scored less the runs per over conceded
+func (st Standing) NetRunRate() float64 {
+	return runRate(st.RunsFor, st.BallsFaced) - runRate(st.RunsAgainst, st.BallsBowled)
+}
+
+func runRate(runs, balls int) float64 {
+	if balls == 0 {
+		return 0
+	}
+	return float64(runs) * ballsPerOver / float64(balls)
+}
+
+// Standings is the points table in the league's order, top of the table first
+func (s *Season) Standings() []Standing {
+	return s.StandingsBy(SortByPoints)
+}
+
+// StandingsBy is the points table sorted by the given column. Sides level on points are
+// split by wins, then net run rate, then the result when they played each other.
+func (s *Season) StandingsBy(key SortKey) []Standing {
+	table := make(map[string]*Standing)
+	standing := func(team string) *Standing {
+		if _, ok := table[team]; !ok {
+			table[team] = &Standing{Team: team}
+		}
+		return table[team]
+	}
+
+	for _, fixture := range s.Fixtures {
+		home, away := standing(fixture.Home), standing(fixture.Away)
+		if !fixture.Played {
+			continue
+		}
+
+		home.Played++
+		away.Played++
+		home.RunsFor += fixture.HomeRuns
+		home.BallsFaced += fixture.HomeBalls
+		home.RunsAgainst += fixture.AwayRuns
+		home.BallsBowled += fixture.AwayBalls
+		away.RunsFor += fixture.AwayRuns
+		away.BallsFaced += fixture.AwayBalls
+		away.RunsAgainst += fixture.HomeRuns
+		away.BallsBowled += fixture.HomeBalls
+
+		switch fixture.Winner {
+		case fixture.Home:
+			home.Won++
+			away.Lost++
+		case fixture.Away:
+			away.Won++
+			home.Lost++
+		default:
+			home.Tied++
+			away.Tied++
+		}
+	}
+
+	standings := make([]Standing, 0, len(table))
+	for _, st := range table {
+		st.Points = st.Won*pointsForWin + st.Tied*pointsForTie
+		standings = append(standings, *st)
+	}
+
+	leagueOrder := func(a, b Standing) int {
+		return cmp.Or(
+			cmp.Compare(b.Points, a.Points),
+			cmp.Compare(b.Won, a.Won),
+			cmp.Compare(b.NetRunRate(), a.NetRunRate()),
+			s.headToHead(a.Team, b.Team),
+			cmp.Compare(a.Team, b.Team),
+		)
+	}
+	slices.SortFunc(standings, func(a, b Standing) int {
+		switch key {
+		case SortByNetRunRate:
+			return cmp.Or(cmp.Compare(b.NetRunRate(), a.NetRunRate()), leagueOrder(a, b))
+		case SortByWon:
+			return cmp.Or(cmp.Compare(b.Won, a.Won), leagueOrder(a, b))
+		case SortByTeam:
+			return cmp.Compare(a.Team, b.Team)
+		default:
+			return leagueOrder(a, b)
+		}
+	})
+
+	return standings
+}
+
+// headToHead is negative if a beat b when they played, positive if b beat a, and 0 if they
+// haven't played or tied
+func (s *Season) headToHead(a, b string) int {
+	for _, fixture := range s.Fixtures {
+		if !fixture.Played || !fixture.Involves(a) || !fixture.Involves(b) {
+			continue
+		}
+		switch fixture.Winner {
+		case a:
+			return -1
+		case b:
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,137 @@
+package sim
+
+import (
+	"math/rand/v2"
+
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	adaptiveBowlerMemory      = 30  // How many recent balls the bowler studies
+	adaptiveBowlerBias        = 4   // How much a fully aggressive bowler favours the batsman's weakest delivery
+	adaptiveBowlerSpeedJitter = 0.1 // Deliveries vary by up to this fraction of their usual speed
+	bowledTroubleWeight       = 3   // Getting out counts as this many plays and misses
+)
+
+// adaptiveBowler picks the type of each delivery at random, but at the start of every over
+// studies the batsman's recent shots and leans towards whatever troubled them most.
+// The preset's aggressiveness controls how strongly it leans.
+type adaptiveBowler struct {
+	preset         *difficulty.Preset
+	types          []string
+	weights        []float64
+	bowledThisOver int
+	overLength     int
+	rng            *rand.Rand
+	logger         logger.Logger
+}
+
+func newAdaptiveBowler(preset *difficulty.Preset, rng *rand.Rand) *adaptiveBowler {
+	ab := &adaptiveBowler{
+		preset:     preset,
+		types:      deliveries.Types(),
+		overLength: ballsPerOver,
+		rng:        rng,
+		logger:     logger.New(),
+	}
+	ab.Reset()
+
+	return ab
+}
+
+func (ab *adaptiveBowler) NextDelivery(history *stats.Innings) (deliveries.Delivery, bool) {
+	if ab.bowledThisOver >= ab.overLength {
+		ab.bowledThisOver = 0
+	}
+	if ab.bowledThisOver == 0 {
+		ab.planOver(history.Recent(adaptiveBowlerMemory))
+	}
+	ab.bowledThisOver++
+
+	delivery, _ := deliveries.OfType(ab.pickType())
+	delivery.Speed *= 1 + (2*ab.rng.Float64()-1)*adaptiveBowlerSpeedJitter
+	delivery.Speed = clampValue(delivery.Speed, ab.preset.Ball.MinSpeed, ab.preset.Ball.MaxSpeed)
+	delivery.IntervalSeconds = ab.preset.SpawnIntervalSeconds
+
+	return delivery, true
+}
+
+func (ab *adaptiveBowler) SetOverLength(balls int) {
+	ab.overLength = balls
+}
+
+func (ab *adaptiveBowler) Reset() {
+	ab.bowledThisOver = 0
+	ab.weights = make([]float64, len(ab.types))
+	for i := range ab.weights {
+		ab.weights[i] = 1
+	}
+}
+
+// planOver works out how likely each type of delivery is for the coming over
+func (ab *adaptiveBowler) planOver(recent []stats.BallEvent) {
+	weaknesses := weaknessByType(recent)
+	aggressiveness := ab.preset.Bowling.Aggressiveness
+
+	for i, deliveryType := range ab.types {
+		ab.weights[i] = 1 + aggressiveness*adaptiveBowlerBias*weaknesses[deliveryType]
+	}
+
+	ab.logger.Debug("bowler planned over", "types", ab.types, "weights", ab.weights)
+}
+
+func (ab *adaptiveBowler) pickType() string {
+	total := 0.0
+	for _, weight := range ab.weights {
+		total += weight
+	}
+
+	pick := ab.rng.Float64() * total
+	for i, weight := range ab.weights {
+		if pick < weight {
+			return ab.types[i]
+		}
+		pick -= weight
+	}
+
+	return ab.types[len(ab.types)-1]
+}
+
+// weaknessByType scores each type of delivery from 0 to 1 by how much trouble the batsman
+// has had with it. Types the batsman hasn't faced score in the middle. A batsman who keeps
+// lofting the ball is also marked as weak against yorkers, which are hard to get under.
+func weaknessByType(recent []stats.BallEvent) map[string]float64 {
+	trouble := make(map[string]float64)
+	faced := make(map[string]float64)
+	lofted, hits := 0.0, 0.0
+
+	for _, event := range recent {
+		faced[event.DeliveryType]++
+		switch event.Outcome {
+		case stats.OutcomeMissed:
+			trouble[event.DeliveryType]++
+		case stats.OutcomeBowled, stats.OutcomeLBW, stats.OutcomeCaught, stats.OutcomeHitWicket:
+			trouble[event.DeliveryType] += bowledTroubleWeight
+		case stats.OutcomeHit:
+			hits++
+			if event.Lofted {
+				lofted++
+			}
+		}
+	}
+
+	weaknesses := make(map[string]float64)
+	for _, deliveryType := range deliveries.Types() {
+		// Start every type off at 0.5, as if it had been faced twice with one miss
+		weaknesses[deliveryType] = min((trouble[deliveryType]+1)/(faced[deliveryType]+2), 1)
+	}
+
+	if hits > 0 {
+		weaknesses["yorker"] = min(weaknesses["yorker"]+lofted/hits, 1)
+	}
+
+	return weaknesses
+}
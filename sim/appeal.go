@@ -0,0 +1,148 @@
+package sim
+
+import (
+	"math/rand/v2"
+
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	umpireMaxErrorChance = 0.3   // Chance of the umpire getting the closest of calls wrong
+	AppealDecisionTicks  = TPS   // How long the umpire thinks before deciding
+	lbwAppealMargin      = 0.15  // Balls clearing the stumps by less than this fraction of their height are appealed for LBW
+	thinEdgeThreshold    = 0.985 // Handle contacts thinner than this are appealed as edges
+)
+
+// Appeal is the fielding side asking the umpire whether the batsman is out
+type Appeal struct {
+	kind      Dismissal
+	ball      stats.BallEvent // The ball appealed for, as first recorded
+	runs      int             // Runs scored off the ball, taken back if the batsman is given out
+	closeness float64         // 0 for a clear cut case, 1 for the closest of calls
+	tracking  *HawkEye        // Ball tracking for LBW appeals
+	trulyOut  bool
+	TicksLeft int
+}
+
+// umpireDecision is how an appeal was decided, and whether the umpire got it right
+type umpireDecision struct {
+	Appeal
+	givenOut bool
+}
+
+func (d umpireDecision) correct() bool {
+	return d.givenOut == d.trulyOut
+}
+
+// appealForEdge appeals if a hit came off the faintest part of the handle. The batsman
+// really did edge it, but the thinner the edge, the harder it is for the umpire to see.
+func (w *World) appealForEdge(b *Ball, runs int, thinness float64) {
+	if thinness < thinEdgeThreshold {
+		return
+	}
+
+	w.startAppeal(Appeal{
+		kind:      CaughtBehind,
+		ball:      w.ballEvent(b, stats.OutcomeHit, runs),
+		runs:      runs,
+		closeness: (thinness - thinEdgeThreshold) / (1 - thinEdgeThreshold),
+		trulyOut:  true,
+	})
+}
+
+// appealForLBW appeals once the ball has gone past the stumps, if it only just cleared
+// them. The batsman is never really out, but a near miss can fool the umpire.
+func (w *World) appealForLBW(b *Ball) {
+	if b.passedStumps || b.IsHit {
+		return
+	}
+
+	ballBounds := b.getBounds()
+	stumpsBounds := w.Stumps.GetBounds()
+	if ballBounds.Center().X > stumpsBounds.Center().X {
+		return
+	}
+	b.passedStumps = true
+
+	gap := stumpsBounds.Y - ballBounds.MaxY()
+	margin := lbwAppealMargin * stumpsBounds.Height
+	if gap < 0 || gap > margin {
+		return
+	}
+
+	w.startAppeal(Appeal{
+		kind:      LBW,
+		ball:      w.ballEvent(b, stats.OutcomeMissed, 0),
+		closeness: 1 - gap/margin,
+		trulyOut:  false,
+		tracking:  newHawkEye(b, stumpsBounds),
+	})
+}
+
+func (w *World) startAppeal(a Appeal) {
+	// The fielders are still waiting on the last one
+	if w.PendingAppeal != nil || a.ball.Number == 0 {
+		return
+	}
+
+	a.TicksLeft = AppealDecisionTicks
+	w.PendingAppeal = &a
+	w.Events = append(w.Events, EventAppeal)
+	if logger.DebugEnabled() {
+		w.logger.Debug("appeal", "kind", a.kind, "closeness", a.closeness, "truly_out", a.trulyOut)
+	}
+}
+
+// updateAppeal has the umpire decide the pending appeal once they have thought it over
+func (w *World) updateAppeal() {
+	if w.PendingAppeal == nil {
+		return
+	}
+
+	w.PendingAppeal.TicksLeft--
+	if w.PendingAppeal.TicksLeft > 0 {
+		return
+	}
+
+	decision := umpireDecision{Appeal: *w.PendingAppeal, givenOut: decide(*w.PendingAppeal, w.RNG.play)}
+	w.PendingAppeal = nil
+	w.lastDecision = &decision
+	if logger.DebugEnabled() {
+		w.logger.Debug("umpire decided", "kind", decision.kind, "given_out", decision.givenOut, "correct", decision.correct())
+	}
+
+	if decision.tracking != nil {
+		decision.tracking.show(decision.givenOut)
+		w.HawkEye = decision.tracking
+	}
+
+	if !decision.givenOut {
+		w.Events = append(w.Events, EventGivenNotOut)
+		w.Announce("NOT OUT")
+		return
+	}
+
+	w.Events = append(w.Events, EventGivenOut)
+	w.Score = max(w.Score-decision.runs, 0)
+
+	outcome := stats.OutcomeLBW
+	if decision.kind == CaughtBehind {
+		outcome = stats.OutcomeCaught
+	}
+	if !w.Innings.SetOutcome(decision.ball.Number, outcome, 0) {
+		event := decision.ball
+		event.Outcome, event.Runs = outcome, 0
+		w.Innings.Record(event)
+	}
+
+	w.dismiss(decision.kind)
+}
+
+// decide is the umpire's call. The closer the call, the more likely they are to get it wrong.
+func decide(a Appeal, rng *rand.Rand) bool {
+	if rng.Float64() < umpireMaxErrorChance*a.closeness {
+		return !a.trulyOut
+	}
+	return a.trulyOut
+}
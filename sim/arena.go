@@ -0,0 +1,80 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+)
+
+const (
+	arenaOpen   = "open"   // Balls go out of play past any edge, as in the original game
+	arenaBouncy = "bouncy" // Balls bounce back off the top and left edges
+
+	wallBounceDamping = 0.7 // Fraction of its speed a ball keeps bouncing off a wall
+	maxWallBounces    = 3   // After this many bounces a ball goes out past the walls as usual
+)
+
+// Edges is a set of the edges of the field
+type Edges uint8
+
+const (
+	edgeTop Edges = 1 << iota
+	edgeLeft
+	edgeRight
+	edgeBottom
+)
+
+func (e Edges) has(edge Edges) bool { return e&edge != 0 }
+
+// ArenaWalls are the edges of the field that balls bounce off in an arena. Unknown arenas
+// are open.
+func ArenaWalls(arena string) Edges {
+	if arena == arenaBouncy {
+		return edgeTop | edgeLeft
+	}
+	return 0
+}
+
+// walls are the edges a ball bounces off rather than going out of play past
+func (w *World) walls(b *Ball) Edges {
+	walls := w.ArenaWalls
+	if b.IsHit && w.Mutators[mutatorBouncyBoundary] {
+		walls |= edgeTop | edgeLeft | edgeRight
+	}
+	return walls
+}
+
+// rebound turns the ball back off any of the walls it has gone past
+func (b *Ball) rebound(bounds geometry.Rect, walls Edges) {
+	size := b.size()
+	bounced := false
+
+	switch {
+	case walls.has(edgeLeft) && b.Position.X < bounds.X && b.Velocity.X < 0:
+		b.Position.X = 2*bounds.X - b.Position.X
+		b.Velocity.X = -b.Velocity.X
+		bounced = true
+	case walls.has(edgeRight) && b.Position.X+size.X > bounds.MaxX() && b.Velocity.X > 0:
+		b.Position.X = 2*(bounds.MaxX()-size.X) - b.Position.X
+		b.Velocity.X = -b.Velocity.X
+		bounced = true
+	}
+	switch {
+	case walls.has(edgeTop) && b.Position.Y < bounds.Y && b.Velocity.Y < 0:
+		b.Position.Y = 2*bounds.Y - b.Position.Y
+		b.Velocity.Y = -b.Velocity.Y
+		bounced = true
+	case walls.has(edgeBottom) && b.Position.Y+size.Y > bounds.MaxY() && b.Velocity.Y > 0:
+		b.Position.Y = 2*(bounds.MaxY()-size.Y) - b.Position.Y
+		b.Velocity.Y = -b.Velocity.Y
+		bounced = true
+	}
+	if !bounced {
+		return
+	}
+
+	b.Velocity = b.Velocity.Scale(wallBounceDamping)
+	b.bounces++
+	if logger.DebugEnabled() {
+		b.logger.Debug("ball bounced off a wall", "position", b.Position, "bounces", b.bounces)
+	}
+}
@@ -0,0 +1,28 @@
+package sim
+
+const (
+	QuickDismissalBalls   = 12   // Getting out within this many balls of the last dismissal is getting out quickly
+	AssistQuickDismissals = 2    // Quick dismissals in a row before the assist steps up
+	AssistSteadyBalls     = 30   // Batting this long without getting out steps the assist back down
+	AssistStep            = 0.25 // How much the assist changes in a step, out of 1
+	assistMaxSlowdown     = 0.2  // Deliveries are this much slower at full assist
+	assistMaxWidening     = 0.5  // The middle of the bat is this much wider at full assist
+	assistMaxOnTimeFrames = 2    // Shots this many frames out count as on time at full assist
+)
+
+// DynamicAssist quietly helps a player who keeps getting out quickly, slowing deliveries and
+// being more forgiving of timing, then backs off as they settle in
+type DynamicAssist struct {
+	Level           float64 // From 0 for no help to 1 for the most
+	QuickDismissals int     // Quick dismissals in a row
+	Faced           int     // Balls faced since the last dismissal
+	LastBalls       int     // Legal balls in the innings when the assist last looked
+}
+
+// assistDelivery slows the next delivery by however much the batsman is being helped
+func (w *World) assistDelivery() {
+	if w.Assist == 0 || !w.HasUpcoming {
+		return
+	}
+	w.Upcoming.Speed *= 1 - assistMaxSlowdown*w.Assist
+}
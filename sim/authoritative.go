@@ -0,0 +1,98 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/team"
+)
+
+// Simulation is an innings played without a window, a tick at a time with the input a
+// player sends, so that a server can play it as the one true copy of an online match.
+// Clients send their input and draw the state it sends back. Everything in it follows from
+// its seed and the inputs, so a match played again with both ends the same.
+type Simulation struct {
+	world *World
+	cfg   *config.Config
+}
+
+// NewSimulation sets up an innings of the given mode, endless if none is given, with
+// deliveries bowled according to the preset and drawn from the seed. The bowlers take
+// their overs as they do in the game, so a game set up by the simulation's rules plays
+// the same match.
+func NewSimulation(cfg *config.Config, preset *difficulty.Preset, modeName string, seed uint32) (*Simulation, error) {
+	if len(modeName) == 0 {
+		modeName = ModeEndless
+	}
+	mode, err := NewMode(modeName, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := NewMatchRand()
+	rng.Fix(seed)
+	bowler, err := NewBowler(cfg, preset, rng)
+	if err != nil {
+		return nil, err
+	}
+	if supplier, ok := mode.(BowlerSupplier); ok {
+		bowler = supplier.Bowler(preset)
+	} else {
+		bowler = NewBowlingAttack(bowler, team.Attack(), preset, mode.Rules().Overs)
+	}
+
+	w := NewWorld(cfg.GetWindowWidth(), cfg.GetWindowHeight(), preset, bowler, mode,
+		NewDragArea(cfg, cfg.GetWindowWidth(), cfg.GetWindowHeight()), rng)
+	w.SetField(cfg.GetFieldWidth(), cfg.GetFieldHeight())
+	return &Simulation{world: w, cfg: cfg}, nil
+}
+
+// Step plays a tick with the bat held as the input says. Ticks is ignored.
+func (s *Simulation) Step(input ControlInput) {
+	if s.Over() {
+		return
+	}
+	s.world.Update(BatInput{Cursor: geometry.Vector{X: input.X, Y: input.Y}, Dragging: input.Drag, Blocking: input.Block})
+}
+
+// Seed is the seed the deliveries are drawn from
+func (s *Simulation) Seed() uint32 {
+	return s.world.RNG.Seed
+}
+
+// Rules are the rules the innings is played by, for a client to set its own field up by
+func (s *Simulation) Rules() replay.Rules {
+	rules := s.world.ReplayRules()
+	rules.Bowling = s.cfg.GetBowling()
+	rules.Overs = s.cfg.GetOvers()
+	rules.ChaseTarget = s.cfg.GetChaseTarget()
+	rules.MaxOverSeconds = s.cfg.GetMaxOverSeconds()
+	rules.OldBallOvers = s.cfg.GetOldBallOvers()
+	rules.SpawnSeconds = s.world.Preset.SpawnIntervalSeconds
+	return rules
+}
+
+// Over is true once the mode says the innings is done
+func (s *Simulation) Over() bool {
+	over, _ := s.world.Mode.End(s.world.MatchState())
+	return over
+}
+
+// State is the innings as it stands, as the control API reports a game
+func (s *Simulation) State() ControlState {
+	return s.world.PlayState()
+}
+
+// PlayState is the innings as it stands, as a simulation reports it: with the mode's points
+// for the score, and over once the mode says so
+func (w *World) PlayState() ControlState {
+	state := w.ControlState()
+	if sc, ok := w.Mode.(Scorer); ok {
+		state.Score = sc.Points(w.MatchState())
+	}
+	if over, _ := w.Mode.End(w.MatchState()); over {
+		state.State = "game_over"
+	}
+	return state
+}
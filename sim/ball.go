@@ -0,0 +1,259 @@
+package sim
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	hitSpeedMultiplier     = 2    // How much the bat speed affects ball speed
+	minDeflectionSpeed     = 1.67 // Minimum speed per tick after being hit (for bat body hits)
+	minUpwardSpeedAfterHit = 0.083
+	loftedSlope            = 0.5 // Climb per unit of horizontal travel above which a hit counts as lofted
+	maxTrackedPositions    = 60  // How much of its path a ball remembers, for ball tracking
+	blockedBallSpeed       = 1.5 // Speed away from the stumps of a ball after a defensive shot
+	blockedBallDrop        = 2   // Downward speed of a ball after a defensive shot
+)
+
+type Ball struct {
+	Position geometry.Vector
+	Velocity geometry.Vector
+	gravity  float64 // Downward distance added to the velocity in a tick
+	Delivery deliveries.Delivery
+	Number   int // Position of the ball in the innings, 0 for balls that don't count
+	// True once the ball has gone past the stumps and had its chance of an LBW appeal
+	passedStumps bool
+	// Where the ball lands on the pitch, and whether it has yet
+	PitchX  float64
+	pitched bool
+	Path    []geometry.Vector // Recent positions of the ball's centre, oldest first
+	// What became of the ball if it passed the bat untouched
+	passedBat   bool
+	passOutcome stats.Outcome
+	passRuns    int
+	// How the ball came off the bat, once it has been hit
+	runs        int
+	lofted      bool
+	throughRing bool    // Whether the shot has gone through a target ring
+	carry       float64 // Metres a lofted shot will travel in the air
+	timing      ShotTiming
+	shot        stats.Shot
+	edged       bool // Off the handle end of the bat
+	FreeHit     bool // Bowled as a free hit
+	// How the ball's age changes it: sideways movement in the air before it is hit, and
+	// how much of a shot's speed it keeps
+	swing      float64
+	liveliness float64
+	bounces    int     // Times the ball has bounced back off a wall
+	Angle      float64 // How far the delivery's spin has turned the ball, in radians
+	Scale      float64 // Of the ball's usual size
+	Active     bool
+	IsHit      bool
+	logger     logger.Logger
+}
+
+func NewBall(screenWidth float64, screenHeight float64, delivery deliveries.Delivery, gravity float64) *Ball {
+	ball := &Ball{
+		Position: geometry.Vector{
+			X: screenWidth + sizes.Ball.X,
+			Y: delivery.Height * screenHeight,
+		},
+		Velocity: geometry.Vector{
+			X: -delivery.Speed,
+			Y: delivery.Dip,
+		},
+		gravity:    gravity,
+		Delivery:   delivery,
+		Path:       make([]geometry.Vector, 0, maxTrackedPositions),
+		Scale:      1,
+		Active:     true,
+		IsHit:      false,
+		liveliness: 1,
+		// Balls that never reach the bat count as played and missed
+		passOutcome: stats.OutcomeMissed,
+		logger:      logger.New(),
+	}
+
+	if logger.DebugEnabled() {
+		ball.logger.Debug("ball created", "type", delivery.Type, "position", ball.Position, "velocity", ball.Velocity)
+	}
+	return ball
+}
+
+// Update moves the ball on a tick, taking it out of play once it leaves the given bounds
+// past any edge but the walls it bounces off
+func (b *Ball) Update(bounds geometry.Rect, walls Edges) {
+	if !b.Active {
+		return
+	}
+
+	b.Velocity.Y += b.gravity
+	if !b.IsHit {
+		b.Velocity.Y += b.swing
+	}
+
+	b.Position = b.Position.Add(b.Velocity)
+	b.turn()
+	if b.bounces >= maxWallBounces {
+		walls = 0
+	}
+	b.rebound(bounds, walls)
+	b.track()
+
+	if b.isOutside(bounds, walls) {
+		if logger.DebugEnabled() {
+			b.logger.Debug("ball went out of play", "position", b.Position)
+		}
+		b.Active = false
+	}
+}
+
+// track remembers where the ball's centre is, forgetting the oldest position when full
+func (b *Ball) track() {
+	// Shifting in place keeps the path's backing array, so tracking never allocates
+	if len(b.Path) == maxTrackedPositions {
+		copy(b.Path, b.Path[1:])
+		b.Path = b.Path[:len(b.Path)-1]
+	}
+	b.Path = append(b.Path, b.getBounds().Center())
+}
+
+// block kills the ball's momentum, so that it drops in front of the batsman without scoring
+func (b *Ball) block() bool {
+	if b.IsHit || !b.Active {
+		return false
+	}
+
+	b.IsHit = true
+	b.Velocity = geometry.Vector{X: blockedBallSpeed, Y: blockedBallDrop}
+
+	return true
+}
+
+func (b *Ball) hit(bat *Bat, zone collisionZone, settings difficulty.HitSettings, rng *rand.Rand) bool {
+	if b.IsHit || !b.Active {
+		return false
+	}
+
+	oldVelocity := b.Velocity
+	b.IsHit = true
+
+	normal := bat.getNormal()
+	// Calculate reflected velocity vector
+	reflected := b.Velocity.Reflect(normal)
+
+	// Calculate deflection angle from reflected vector
+	deflectionAngle := math.Atan2(reflected.Y, reflected.X)
+
+	// Calculate hit speed based on swing velocity and current ball speed
+	currentSpeed := b.Velocity.Magnitude()
+	hitSpeed := currentSpeed + math.Abs(bat.CurrentAngle-bat.PreviousAngle)*hitSpeedMultiplier*60.0
+	hitSpeed *= bat.batsman.Power * b.liveliness
+
+	var (
+		// Apply different physics based on collision zone
+
+		// How randomly the ball gets deflected after a hit
+		randomnessFactor,
+		// Reduced power after hit
+		speedModifier,
+		// Add upward bias to make balls fly more realistically
+		upwardBias float64
+	)
+
+	switch zone {
+	case handleZone:
+
+		randomnessFactor = settings.HandleRandomness
+		speedModifier = 0.7
+		upwardBias = 0.33
+		// Ensure minimum speed is lower for handle hits
+		hitSpeed = clampValue(hitSpeed, minDeflectionSpeed/2, hitSpeed)
+
+	// default is BodyZone
+	default:
+		randomnessFactor = settings.BodyRandomness
+		speedModifier = 1.0
+		upwardBias = 0.5
+
+		hitSpeed = clampValue(hitSpeed, minDeflectionSpeed, hitSpeed)
+	}
+
+	// Apply randomness and speed modifier
+	deflectionAngle += (rng.Float64() - 0.5) * randomnessFactor
+	hitSpeed *= speedModifier
+
+	// Set new ball velocity based on deflection angle and hit speed
+	b.Velocity = geometry.Vector{
+		X: -math.Cos(deflectionAngle) * hitSpeed,
+		Y: -math.Sin(deflectionAngle) * hitSpeed,
+	}
+
+	// If not already going up significantly
+	if b.Velocity.Y > -minUpwardSpeedAfterHit {
+		b.Velocity.Y -= upwardBias
+	}
+
+	if logger.DebugEnabled() {
+		b.logger.Debug("ball hit physics calculated",
+			"collision_zone", zone,
+			"bat_angle", bat.CurrentAngle,
+			"swing_angle", bat.CurrentAngle-bat.PreviousAngle,
+			"deflection_angle", deflectionAngle,
+			"hit_speed", hitSpeed,
+			"speed_modifier", speedModifier,
+			"randomness_factor", randomnessFactor,
+			"old_velocity", oldVelocity,
+			"new_velocity", b.Velocity,
+		)
+	}
+
+	return true
+}
+
+// isLofted is true if the ball is climbing steeply, as it does after an attacking shot
+func (b *Ball) isLofted() bool {
+	return b.Velocity.Y < 0 && -b.Velocity.Y > math.Abs(b.Velocity.X)*loftedSlope
+}
+
+// isOutside is true once the ball is wholly beyond the given bounds, past an edge that
+// isn't a wall
+func (b *Ball) isOutside(bounds geometry.Rect, walls Edges) bool {
+	size := b.size()
+	return (!walls.has(edgeBottom) && b.Position.Y > bounds.MaxY()+size.Y) ||
+		(!walls.has(edgeLeft) && b.Position.X < bounds.X-size.X) ||
+		(!walls.has(edgeRight) && b.Position.X > bounds.MaxX()+size.X) ||
+		(!walls.has(edgeTop) && b.Position.Y < bounds.Y-size.Y)
+}
+
+// size is how big the ball is, at its scale
+func (b *Ball) size() geometry.Vector {
+	return scaledSize(sizes.Ball, b.Scale)
+}
+
+func (b *Ball) getBounds() geometry.Rect {
+	size := b.size()
+	return geometry.NewRect(
+		b.Position.X,
+		b.Position.Y,
+		size.X,
+		size.Y,
+	)
+}
+
+func (b *Ball) CenterAndRadius() (geometry.Vector, float64) {
+	bounds := b.getBounds()
+	return bounds.Center(), math.Min(bounds.Width, bounds.Height) / 2
+}
+
+func (b *Ball) collidesWith(s *Stumps) bool {
+
+	// Check if the ball is within the stumps bounds
+	return b.getBounds().Intersects(s.GetBounds())
+}
@@ -0,0 +1,54 @@
+package sim
+
+import (
+	"fmt"
+)
+
+const (
+	newBallPace     = 0.1  // A brand new ball comes this much faster
+	newBallSwing    = 0.04 // Most a brand new ball moves up or down in the air, in pixels per tick per tick
+	oldBallGrip     = 0.6  // Extra dip an old ball gets off the pitch
+	oldBallSoftness = 0.2  // An old ball comes off the bat this much slower
+)
+
+// ballAge is how worn the ball is, from 0 for a new ball to 1 for an old one. It returns
+// false if the mode's ball doesn't wear.
+func (w *World) ballAge() (float64, bool) {
+	overs := w.Mode.Rules().OldBallOvers
+	if overs <= 0 {
+		return 0, false
+	}
+	return min(float64(w.LegalBalls())/float64(overs*w.Rules.BallsPerOver), 1), true
+}
+
+// ageBall makes a delivery behave like the ball it is bowled with. A new ball comes faster
+// and swings, and an old one grips the pitch but is softer off the bat.
+func (w *World) ageBall(b *Ball) {
+	age, ok := w.ballAge()
+	if !ok {
+		return
+	}
+
+	newness := 1 - age
+	b.Velocity.X *= 1 + newBallPace*newness
+	b.swing = newBallSwing * newness * (2*w.RNG.pitch.Float64() - 1)
+	b.Velocity.Y += oldBallGrip * age
+	b.liveliness = 1 - oldBallSoftness*age
+}
+
+// BallAgeHUD describes the ball's condition for the HUD
+func (w *World) BallAgeHUD() (string, bool) {
+	age, ok := w.ballAge()
+	if !ok {
+		return "", false
+	}
+
+	condition := "new"
+	switch {
+	case age >= 2.0/3:
+		condition = "old"
+	case age >= 1.0/3:
+		condition = "worn"
+	}
+	return fmt.Sprintf("Ball: %s (%s overs)", condition, FormatOvers(w.LegalBalls(), w.Rules.BallsPerOver)), true
+}
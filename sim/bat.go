@@ -0,0 +1,361 @@
+package sim
+
+import (
+	"math"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/team"
+)
+
+// collisionZone represents which part of the bat was hit
+type collisionZone int
+
+const (
+	noCollision collisionZone = iota
+	handleZone
+	bodyZone
+)
+
+const (
+	maxSwingAngle          = math.Pi / 3 // 60 degrees maximum swing
+	initialbatX            = 200
+	initialbatY            = 350
+	batMouseHistoryLimit   = 10  // Mouse history for calculating velocity
+	batSpeedLimitingFactor = 0.3 // How fast the bat follows the mouse
+
+	// Default draggable area, relative to the stumps position, as fractions of the screen size
+	defaultDragAreaRight = 1.0 / 3 // How far right from stumps the bat can be dragged
+	defaultDragAreaUp    = 0.25    // How far up from stumps the bat can be dragged
+	defaultDragAreaDown  = 0.125   // How far down from stumps the bat can be dragged
+
+	// Collision zone boundaries (as percentage of bat length)
+	handleZoneStart = 0.05 // Handle starts at the top 5%
+	handleZoneEnd   = 0.35 // Handle covers 35% of bat length
+	bodyZoneStart   = 0.35 // Body starts at 35%
+	bodyZoneEnd     = 0.95 // Body ends at 95%
+)
+
+type Bat struct {
+	Position      geometry.Vector // Position of bat handle (pivot point)
+	Scale         float64         // Of the usual bat size
+	CurrentAngle  float64         // Current rotation angle (0 = vertical)
+	PreviousAngle float64         // Previous frame angle for velocity calculation
+	lastMousePos  geometry.Vector // Last mouse position
+	mouseHistory  []geometry.Vector
+
+	// Drag functionality fields
+	IsDragging     bool            // True when mouse button is held down for dragging
+	dragOffset     geometry.Vector // Offset from bat position to mouse when drag starts
+	dragStartAngle float64         // Angle when drag started (preserved during drag)
+	DragArea       DragArea
+	isBlocking     bool // True while the player holds the bat still in a defensive shot
+
+	// The batsman holding the bat
+	batsman    team.Batsman
+	DragBounds geometry.Rect // Where the bat can currently be dragged, for drawing the guide
+
+	logger logger.Logger
+}
+
+// DragArea is how far the bat can be dragged from the stumps, in pixels
+type DragArea struct {
+	Right float64
+	Up    float64
+	Down  float64
+}
+
+// NewDragArea sizes the draggable area for the screen, using the configured fractions of
+// the screen size where they are set
+func NewDragArea(cfg *config.Config, screenWidth, screenHeight float64) DragArea {
+	right, up, down := cfg.GetDragAreaRight(), cfg.GetDragAreaUp(), cfg.GetDragAreaDown()
+	if right <= 0 {
+		right = defaultDragAreaRight
+	}
+	if up <= 0 {
+		up = defaultDragAreaUp
+	}
+	if down <= 0 {
+		down = defaultDragAreaDown
+	}
+
+	return DragArea{
+		Right: right * screenWidth,
+		Up:    up * screenHeight,
+		Down:  down * screenHeight,
+	}
+}
+
+func (a DragArea) bounds(stumpsPos geometry.Vector) geometry.Rect {
+	return geometry.NewRect(stumpsPos.X, stumpsPos.Y-a.Up, a.Right, a.Up+a.Down)
+}
+
+func newBat(area DragArea, batsman team.Batsman) *Bat {
+	scale := batsman.BatSize
+	if scale <= 0 {
+		scale = 1
+	}
+
+	position := geometry.Vector{
+		X: initialbatX,
+		Y: initialbatY,
+	}
+
+	bat := &Bat{
+		Position:       position,
+		Scale:          scale,
+		CurrentAngle:   -math.Pi / 3,
+		PreviousAngle:  0,
+		lastMousePos:   geometry.Vector{X: 0, Y: 0},
+		mouseHistory:   make([]geometry.Vector, 0, batMouseHistoryLimit), // Keep last 10 positions for velocity calc
+		IsDragging:     false,
+		dragOffset:     geometry.Vector{X: 0, Y: 0},
+		dragStartAngle: 0,
+		DragArea:       area,
+		batsman:        batsman,
+		logger:         logger.New(),
+	}
+
+	bat.logger.Debug("bat created", "position", bat.Position, "max_swing_angle", maxSwingAngle)
+	return bat
+}
+
+// constrainToDraggableArea ensures the bat position stays within the allowed draggable area
+func (b *Bat) constrainToDraggableArea(position geometry.Vector, stumpsPos geometry.Vector) geometry.Vector {
+	// Define boundaries relative to stumps position
+	b.DragBounds = b.DragArea.bounds(stumpsPos)
+
+	// Clamp the position within boundaries
+	constrainedX := clampValue(position.X, b.DragBounds.X, b.DragBounds.MaxX())
+	constrainedY := clampValue(position.Y, b.DragBounds.Y, b.DragBounds.MaxY())
+
+	return geometry.Vector{X: constrainedX, Y: constrainedY}
+}
+
+// startDrag initializes drag mode when mouse button is first pressed
+func (b *Bat) startDrag(mousePos geometry.Vector) {
+	b.IsDragging = true
+	b.dragOffset = geometry.Vector{
+		X: b.Position.X - mousePos.X,
+		Y: b.Position.Y - mousePos.Y,
+	}
+	b.dragStartAngle = b.CurrentAngle
+}
+
+// updateDragPosition moves the bat during drag mode while preserving angle
+func (b *Bat) updateDragPosition(mousePos geometry.Vector, stumpsPos geometry.Vector) {
+	newPosition := geometry.Vector{
+		X: mousePos.X + b.dragOffset.X,
+		Y: mousePos.Y + b.dragOffset.Y,
+	}
+
+	b.Position = b.constrainToDraggableArea(newPosition, stumpsPos)
+
+	// Keep the angle constant during drag
+	b.CurrentAngle = b.dragStartAngle
+}
+
+func (b *Bat) update(input BatInput, stumpsPos geometry.Vector) {
+
+	currentMousePosition := input.Cursor
+	// Update mouse history
+	b.mouseHistory = append(b.mouseHistory, currentMousePosition)
+	if len(b.mouseHistory) > batMouseHistoryLimit {
+		b.mouseHistory = b.mouseHistory[1:]
+	}
+
+	if input.Dragging && !b.IsDragging {
+		// Start dragging
+		b.startDrag(currentMousePosition)
+	}
+
+	if !input.Dragging && b.IsDragging {
+		// Stop dragging
+		b.IsDragging = false
+	}
+
+	// Store previous angle for swing velocity calculation (needed when bat hits ball)
+	b.PreviousAngle = b.CurrentAngle
+	b.lastMousePos = currentMousePosition
+	b.isBlocking = input.Blocking && !b.IsDragging
+	if b.IsDragging {
+		// In drag mode, move the bat while preserving angle
+		b.updateDragPosition(currentMousePosition, stumpsPos)
+		return
+	}
+
+	// A defensive shot holds the bat where it is, with no swing
+	if b.isBlocking {
+		return
+	}
+
+	// In normal mode: adjust bat angle based on mouse position
+	targetAngle := b.getNewTargetAngle(&currentMousePosition)
+	targetAngle = clampValue(targetAngle, -maxSwingAngle, maxSwingAngle)
+	b.CurrentAngle += (targetAngle - b.CurrentAngle) * batSpeedLimitingFactor
+
+}
+
+// reach is how far from the handle the bat can touch the ball
+func (b *Bat) reach() float64 {
+	return b.size().Y
+}
+
+// size is how big the bat is, at its scale
+func (b *Bat) size() geometry.Vector {
+	return scaledSize(sizes.Bat, b.Scale)
+}
+
+// swingSpeed is how fast the tip of the bat is moving, in pixels per tick
+func (b *Bat) swingSpeed() float64 {
+	return math.Abs(b.CurrentAngle-b.PreviousAngle) * b.size().Y
+}
+
+func (b *Bat) collidesWith(s *Stumps) bool {
+
+	return b.getBounds().Intersects(s.GetBounds())
+}
+
+func (b *Bat) getBounds() geometry.Rect {
+	// Create a more accurate collision rectangle that represents the rotated bat
+	size := b.size()
+	batWidth := size.X
+	batHeight := size.Y
+
+	// Calculate the four corners of the rotated bat rectangle
+	// Start with corners relative to the bat center
+	halfWidth := batWidth / 2
+
+	// Original corners (before rotation)
+	corners := []geometry.Vector{
+		{-halfWidth, 0},         // Top-left
+		{halfWidth, 0},          // Top-right
+		{halfWidth, batHeight},  // Bottom-right
+		{-halfWidth, batHeight}, // Bottom-left
+	}
+
+	// Rotate each corner and translate to bat position
+	rotatedCorners := make([]geometry.Vector, 4)
+	for i, corner := range corners {
+		// Rotate the corner
+		rotatedX := corner.X*math.Cos(b.CurrentAngle) - corner.Y*math.Sin(b.CurrentAngle)
+		rotatedY := corner.X*math.Sin(b.CurrentAngle) + corner.Y*math.Cos(b.CurrentAngle)
+
+		// Translate to bat position
+		rotatedCorners[i] = geometry.Vector{
+			X: b.Position.X + rotatedX,
+			Y: b.Position.Y + rotatedY,
+		}
+	}
+
+	// Find the bounding box of the rotated bat
+	minX := rotatedCorners[0].X
+	maxX := rotatedCorners[0].X
+	minY := rotatedCorners[0].Y
+	maxY := rotatedCorners[0].Y
+
+	for _, corner := range rotatedCorners[1:] {
+		if corner.X < minX {
+			minX = corner.X
+		}
+		if corner.X > maxX {
+			maxX = corner.X
+		}
+		if corner.Y < minY {
+			minY = corner.Y
+		}
+		if corner.Y > maxY {
+			maxY = corner.Y
+		}
+	}
+
+	return geometry.NewRect(minX, minY, maxX-minX, maxY-minY)
+}
+
+func (b *Bat) getNormal() geometry.Vector {
+	normalAngle := b.CurrentAngle + math.Pi/2
+	normalX := math.Cos(normalAngle)
+	normalY := math.Sin(normalAngle)
+	return geometry.Vector{X: normalX, Y: normalY}
+}
+
+// Performs precise collision detection between bat and ball, returning collision zone
+func (b *Bat) checkCollision(ball *Ball) collisionZone {
+	ballCenter, ballRadius := ball.CenterAndRadius()
+
+	// Get bat dimensions
+	size := b.size()
+	batHeight := size.Y
+	batWidth := size.X
+	handleWidth := 2 * batWidth / 3
+
+	// Check handle zone first
+	if b.checkCollisionWithPortionOfBat(handleZoneStart, handleZoneEnd, batHeight, handleWidth, ballRadius, ballCenter) {
+		return handleZone
+	}
+
+	// Check body zone
+	if b.checkCollisionWithPortionOfBat(bodyZoneStart, bodyZoneEnd, batHeight, batWidth, ballRadius, ballCenter) {
+		return bodyZone
+	}
+
+	return noCollision
+}
+
+// edgeThinness tells how thinly the ball is touching the handle, from 0 for dead centre to
+// 1 for the faintest of edges. It is 0 if the ball isn't touching the handle.
+func (b *Bat) edgeThinness(ball *Ball) float64 {
+	ballCenter, ballRadius := ball.CenterAndRadius()
+
+	size := b.size()
+	batHeight := size.Y
+	handleWidth := 2 * size.X / 3
+
+	ratio, ok := b.contactRatio(handleZoneStart, handleZoneEnd, batHeight, handleWidth, ballRadius, ballCenter)
+	if !ok {
+		return 0
+	}
+	return ratio
+}
+
+func (b *Bat) checkCollisionWithPortionOfBat(startPercent, endPercent, batHeight, batWidth, ballRadius float64, ballCenter geometry.Vector) bool {
+	_, ok := b.contactRatio(startPercent, endPercent, batHeight, batWidth, ballRadius, ballCenter)
+	return ok
+}
+
+// contactRatio returns how far the ball's centre is from a portion of the bat, relative to
+// the furthest it can be while still touching it. It returns false if there is no contact.
+func (b *Bat) contactRatio(startPercent, endPercent, batHeight, batWidth, ballRadius float64, ballCenter geometry.Vector) (float64, bool) {
+	startOffset := batHeight * startPercent
+	endOffset := batHeight * endPercent
+
+	batStart := geometry.Vector{
+		X: b.Position.X + math.Sin(-b.CurrentAngle)*startOffset,
+		Y: b.Position.Y + math.Cos(-b.CurrentAngle)*startOffset,
+	}
+	batEnd := geometry.Vector{
+		X: b.Position.X + math.Sin(-b.CurrentAngle)*endOffset,
+		Y: b.Position.Y + math.Cos(-b.CurrentAngle)*endOffset,
+	}
+
+	if batStart.Y > (ballCenter.Y+ballRadius) || batEnd.Y < (ballCenter.Y-ballRadius) {
+		return 0, false
+	}
+
+	distance := geometry.DistanceFromPointToLine(ballCenter, batStart, batEnd)
+	maxDistance := ballRadius + b.batsman.Timing*batWidth/3
+
+	if distance < 0 || distance > maxDistance {
+		return 0, false
+	}
+	return distance / maxDistance, true
+}
+
+func (b *Bat) getNewTargetAngle(currentMousePosition *geometry.Vector) float64 {
+	deltaX := currentMousePosition.X - b.Position.X
+	deltaY := currentMousePosition.Y - b.Position.Y
+
+	// Calculate angle from vertical (0 = vertical, positive = clockwise)
+	return math.Atan2(-deltaX, math.Abs(deltaY))
+}
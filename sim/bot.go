@@ -0,0 +1,118 @@
+package sim
+
+import (
+	"math"
+
+	"github.com/meghashyamc/cricket2d/geometry"
+)
+
+const (
+	botMaxAimErrorPixels = 120 // Aim error of a bot with no skill at all
+	botMaxReactionTicks  = 30  // Ticks a bot with no skill takes to notice a new ball
+	botLookAheadPixels   = 40  // How far in front of the bat the bot tries to meet the ball
+	botPivotAbovePixels  = 120 // How far above the ball the bot likes the bat handle to be
+	botDragThreshold     = 40  // How far the bat handle must be from where the bot wants it before it drags
+)
+
+// BotBatsman moves the bat on behalf of a computer player. Its skill is between 0 and 1,
+// a higher skill meaning a more accurate aim and a quicker reaction to new balls.
+type BotBatsman struct {
+	skill          float64
+	target         *Ball
+	aimError       float64
+	reactionTicks  int
+	restingPointer geometry.Vector
+}
+
+func NewBotBatsman(skill float64) *BotBatsman {
+	return &BotBatsman{
+		skill: clampValue(skill, 0, 1),
+	}
+}
+
+// Input works out where the bot would put the mouse pointer on this tick
+func (bb *BotBatsman) Input(w *World) BatInput {
+	bat := w.Bat
+	bb.restingPointer = geometry.Vector{X: bat.Position.X + 100, Y: bat.Position.Y + 200}
+
+	home := geometry.Vector{X: initialbatX, Y: initialbatY}
+
+	target := nextIncomingBall(w)
+	if target == nil {
+		bb.target = nil
+		if bat.Position.Subtract(home).Magnitude() > botDragThreshold {
+			return bb.drag(bat, home)
+		}
+		return BatInput{Cursor: bb.restingPointer}
+	}
+
+	if target != bb.target {
+		bb.target = target
+		bb.aimError = w.RNG.bot.NormFloat64() * botMaxAimErrorPixels * (1 - bb.skill)
+		bb.reactionTicks = int(botMaxReactionTicks * (1 - bb.skill) * w.RNG.bot.Float64())
+	}
+
+	if bb.reactionTicks > 0 {
+		bb.reactionTicks--
+		return BatInput{Cursor: bb.restingPointer}
+	}
+
+	meetX := bat.Position.X + botLookAheadPixels
+	meetPoint := predictBallCenter(target, meetX)
+	meetPoint.Y += bb.aimError
+
+	// High balls can only be reached by dragging the bat up first
+	handle := geometry.Vector{X: home.X, Y: math.Min(meetPoint.Y-botPivotAbovePixels, home.Y)}
+	if math.Abs(handle.Y-bat.Position.Y) > botDragThreshold {
+		return bb.drag(bat, handle)
+	}
+
+	// Never aim behind the bat, where the stumps are
+	meetPoint.X = math.Max(meetPoint.X, bat.Position.X)
+
+	return BatInput{Cursor: meetPoint}
+}
+
+// drag moves the bat handle to the given position. The first tick only grabs the bat at its
+// handle, so that the handle follows the pointer exactly from the next tick on.
+func (bb *BotBatsman) drag(bat *Bat, handle geometry.Vector) BatInput {
+	if !bat.IsDragging {
+		return BatInput{Cursor: bat.Position, Dragging: true}
+	}
+
+	return BatInput{Cursor: handle, Dragging: true}
+}
+
+// nextIncomingBall is the incoming ball that will reach the bat first, nil if none is coming
+func nextIncomingBall(w *World) *Ball {
+	var target *Ball
+	for _, ball := range w.Balls {
+		if ball.IsHit || !ball.Active || ball.Velocity.X >= 0 || ball.Position.X < w.Bat.Position.X {
+			continue
+		}
+
+		if target == nil || ball.Position.X < target.Position.X {
+			target = ball
+		}
+	}
+
+	return target
+}
+
+// predictBallCenter estimates where the centre of the ball will be when it reaches x
+func predictBallCenter(b *Ball, x float64) geometry.Vector {
+	center := b.getBounds().Center()
+	if b.Velocity.X == 0 {
+		return center
+	}
+
+	ticks := (x - center.X) / b.Velocity.X
+	if ticks < 0 {
+		return center
+	}
+
+	return geometry.Vector{
+		X: x,
+		Y: center.Y + b.Velocity.Y*ticks + b.gravity*ticks*(ticks+1)/2,
+	}
+}
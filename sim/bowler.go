@@ -0,0 +1,96 @@
+package sim
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	BowlingRandom   = "random"
+	bowlingAdaptive = "adaptive"
+)
+
+// Bowler decides what the next delivery is, knowing what has happened in the innings so far.
+// It returns false once it has nothing more to bowl.
+type Bowler interface {
+	NextDelivery(history *stats.Innings) (deliveries.Delivery, bool)
+	Reset()
+}
+
+// OverPlanner is a bowler that goes about its bowling an over at a time, and so needs to be
+// told how long an over is when house rules change it
+type OverPlanner interface {
+	SetOverLength(balls int)
+}
+
+// BowlerSupplier is implemented by modes that bowl with a bowler of their own, such as the
+// player, in place of the configured attack
+type BowlerSupplier interface {
+	Bowler(preset *difficulty.Preset) Bowler
+}
+
+// NewBowler creates the kind of bowler picked in the config, choosing deliveries with the
+// match's randomness
+func NewBowler(cfg *config.Config, preset *difficulty.Preset, rng *MatchRand) (Bowler, error) {
+	switch cfg.GetBowling() {
+	case "", BowlingRandom:
+		return NewRandomBowler(preset, rng.Deliveries), nil
+	case bowlingAdaptive:
+		return newAdaptiveBowler(preset, rng.Deliveries), nil
+	case bowlingChat:
+		return newChatBowler(cfg, preset)
+	default:
+		return nil, fmt.Errorf("unknown bowling %q, expected %s, %s or %s", cfg.GetBowling(), BowlingRandom, bowlingAdaptive, bowlingChat)
+	}
+}
+
+// RandomBowler bowls an endless stream of deliveries at random heights and speeds, within
+// the limits of a difficulty preset
+type RandomBowler struct {
+	preset *difficulty.Preset
+	rng    *rand.Rand
+}
+
+func NewRandomBowler(preset *difficulty.Preset, rng *rand.Rand) *RandomBowler {
+	return &RandomBowler{preset: preset, rng: rng}
+}
+
+func (rb *RandomBowler) NextDelivery(*stats.Innings) (deliveries.Delivery, bool) {
+	ballSettings := rb.preset.Ball
+	return deliveries.Delivery{
+		Speed:           rb.rng.Float64()*(ballSettings.MaxSpeed-ballSettings.MinSpeed) + ballSettings.MinSpeed,
+		Height:          rb.rng.Float64() * ballSettings.MaxReleaseHeight,
+		IntervalSeconds: rb.preset.SpawnIntervalSeconds,
+	}, true
+}
+
+func (rb *RandomBowler) Reset() {}
+
+// ScriptedBowler bowls the deliveries of a script in order, once
+type ScriptedBowler struct {
+	script *deliveries.Script
+	next   int
+}
+
+func NewScriptedBowler(script *deliveries.Script) *ScriptedBowler {
+	return &ScriptedBowler{script: script}
+}
+
+func (sb *ScriptedBowler) NextDelivery(*stats.Innings) (deliveries.Delivery, bool) {
+	if sb.next >= len(sb.script.Deliveries) {
+		return deliveries.Delivery{}, false
+	}
+
+	delivery := sb.script.Deliveries[sb.next]
+	sb.next++
+	return delivery, true
+}
+
+func (sb *ScriptedBowler) Reset() {
+	sb.next = 0
+}
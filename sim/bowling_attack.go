@@ -0,0 +1,101 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/stats"
+	"github.com/meghashyamc/cricket2d/team"
+)
+
+const (
+	paceSpeedBoost  = 0.15 // A pace bowler at full skill bowls this much faster than usual
+	spinSpeedFactor = 0.7  // Spinners bowl this fraction of the usual speed
+	spinDip         = 0.8  // Extra dip a spinner at full skill puts on the ball
+)
+
+// BowlingAttack hands each over to a named bowler, who puts their own style on the deliveries
+// of the bowler underneath. Nobody bowls two overs in a row, and in a limited overs innings
+// nobody bowls more than their share of the overs.
+type BowlingAttack struct {
+	inner          Bowler
+	bowlers        []team.Bowler
+	preset         *difficulty.Preset
+	maxOvers       int // Per bowler, 0 for no limit
+	oversBowled    []int
+	current        int // Index of the bowler with the ball, -1 before the first over
+	bowledThisOver int
+	overLength     int
+}
+
+func NewBowlingAttack(inner Bowler, bowlers []team.Bowler, preset *difficulty.Preset, inningsOvers int) *BowlingAttack {
+	ba := &BowlingAttack{
+		inner:      inner,
+		bowlers:    bowlers,
+		preset:     preset,
+		overLength: ballsPerOver,
+	}
+	if inningsOvers > 0 {
+		ba.maxOvers = (inningsOvers + len(bowlers) - 1) / len(bowlers)
+	}
+	ba.Reset()
+
+	return ba
+}
+
+func (ba *BowlingAttack) NextDelivery(history *stats.Innings) (deliveries.Delivery, bool) {
+	delivery, ok := ba.inner.NextDelivery(history)
+	if !ok {
+		return delivery, false
+	}
+
+	if ba.current < 0 || ba.bowledThisOver >= ba.overLength {
+		ba.changeBowler()
+	}
+	ba.bowledThisOver++
+
+	bowler := ba.bowlers[ba.current]
+	switch bowler.Style {
+	case team.StylePace:
+		delivery.Speed *= 1 + paceSpeedBoost*bowler.Skill
+		delivery.Spin = paceSeamTurns
+	case team.StyleSpin:
+		delivery.Speed *= spinSpeedFactor
+		delivery.Dip += spinDip * bowler.Skill
+		delivery.Spin = spinTurns * bowler.Skill
+	}
+	delivery.Speed = clampValue(delivery.Speed, ba.preset.Ball.MinSpeed, ba.preset.Ball.MaxSpeed)
+	delivery.Bowler = bowler.Name
+
+	return delivery, true
+}
+
+// changeBowler brings on the next bowler in the rotation who has overs left
+func (ba *BowlingAttack) changeBowler() {
+	next := (ba.current + 1) % len(ba.bowlers)
+	for range ba.bowlers {
+		if next != ba.current && (ba.maxOvers == 0 || ba.oversBowled[next] < ba.maxOvers) {
+			break
+		}
+		next = (next + 1) % len(ba.bowlers)
+	}
+
+	ba.current = next
+	ba.oversBowled[next]++
+	ba.bowledThisOver = 0
+}
+
+// SetOverLength changes bowlers after the given number of balls, passing it on to the bowler
+// underneath in case it plans by the over too
+func (ba *BowlingAttack) SetOverLength(balls int) {
+	ba.overLength = balls
+	if planner, ok := ba.inner.(OverPlanner); ok {
+		planner.SetOverLength(balls)
+	}
+}
+
+func (ba *BowlingAttack) Reset() {
+	ba.inner.Reset()
+	ba.oversBowled = make([]int, len(ba.bowlers))
+	ba.current = -1
+	ba.bowledThisOver = 0
+}
@@ -0,0 +1,183 @@
+package sim
+
+import (
+	"fmt"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	modeBowling = "bowling"
+
+	bowlingModeWickets  = 3
+	bowlingWicketPoints = 20  // Points for each wicket, less a point for every run conceded
+	BowlingBotSkill     = 0.8 // Of the computer batsman
+	playerRunUpSeconds  = 1.0 // Shortest wait between the player's deliveries
+	minGesturePixels    = 40  // Shortest drag towards the batsman that bowls a ball
+	maxGestureDip       = 3.0 // Most a ball can be bowled downwards, in pixels per tick
+)
+
+func init() {
+	RegisterMode(modeBowling, func(cfg *config.Config) Mode { return newBowlingMode(cfg) })
+}
+
+// Scorer is implemented by modes that score the match some other way than by the runs the
+// batsman makes, such as by the bowler's wickets
+type Scorer interface {
+	Points(state MatchState) int
+}
+
+// bowlingMode turns the game around: the player bowls at a computer batsman, trying to
+// take the wickets inside the overs while giving away as few runs as they can
+type bowlingMode struct {
+	overs int
+}
+
+func newBowlingMode(cfg *config.Config) bowlingMode {
+	overs := cfg.GetOvers()
+	if overs <= 0 {
+		overs = defaultOvers
+	}
+	return bowlingMode{overs: overs}
+}
+
+func (m bowlingMode) Name() string { return modeBowling }
+
+func (m bowlingMode) Description() string {
+	return fmt.Sprintf("Bowl at a computer batsman. Take %d wickets in %d overs for as few runs as you can.", bowlingModeWickets, m.overs)
+}
+
+func (m bowlingMode) Rules() ModeRules {
+	return ModeRules{
+		Overs:        m.overs,
+		Wickets:      bowlingModeWickets,
+		HighScoreKey: fmt.Sprintf("%s%d", modeBowling, m.overs),
+		OldBallOvers: m.overs,
+	}
+}
+
+func (m bowlingMode) RunsForHit(MatchState) int { return 1 }
+
+func (m bowlingMode) End(state MatchState) (bool, string) {
+	if state.AllOut {
+		return true, "BOWLED THEM OUT!"
+	}
+	if state.BowlingDone {
+		return true, "OVERS UP!"
+	}
+	return false, ""
+}
+
+// Won is true when the player has taken every wicket
+func (m bowlingMode) Won(state MatchState) bool { return state.AllOut }
+
+// Bowler is the player, bowling with the mouse
+func (m bowlingMode) Bowler(preset *difficulty.Preset) Bowler { return NewPlayerBowler(preset) }
+
+// Points are for wickets taken, less the runs given away
+func (m bowlingMode) Points(state MatchState) int {
+	return max(state.Wickets*bowlingWicketPoints-state.Score, 0)
+}
+
+func (m bowlingMode) HUD(state MatchState) []string {
+	return []string{
+		"Drag from the right towards the batsman to bowl",
+		fmt.Sprintf("Overs: %s/%d", FormatOvers(state.BallsBowled, state.BallsPerOver), m.overs),
+		fmt.Sprintf("Wickets: %d/%d", state.Wickets, bowlingModeWickets),
+		fmt.Sprintf("Points: %d", m.Points(state)),
+	}
+}
+
+// ReleasingBowler is a bowler that may only decide a delivery as it lets go of the ball,
+// such as a player bowling with the mouse. It is given the delivery planned at the start of
+// the run up, and gives back the one bowled, or false while it has yet to let go.
+type ReleasingBowler interface {
+	Release(planned deliveries.Delivery) (deliveries.Delivery, bool)
+}
+
+// PlayerBowler bowls the deliveries the player makes with the mouse. A drag towards the
+// batsman bowls a ball released at the height the drag started, as fast as the drag and
+// dipping as much as the drag heads down.
+type PlayerBowler struct {
+	preset   *difficulty.Preset
+	held     bool // The player's input was dragging on the last tick
+	Dragging bool
+	Start    geometry.Vector // Where the drag started
+	Cursor   geometry.Vector // Where the drag has got to
+	ticks    int             // That the drag has lasted
+	Pending  *deliveries.Delivery
+}
+
+func NewPlayerBowler(preset *difficulty.Preset) *PlayerBowler {
+	return &PlayerBowler{preset: preset}
+}
+
+// NextDelivery starts the run up. What the player bowls is only known on release.
+func (pb *PlayerBowler) NextDelivery(*stats.Innings) (deliveries.Delivery, bool) {
+	return deliveries.Delivery{IntervalSeconds: playerRunUpSeconds}, true
+}
+
+func (pb *PlayerBowler) Release(planned deliveries.Delivery) (deliveries.Delivery, bool) {
+	if pb.Pending == nil {
+		return deliveries.Delivery{}, false
+	}
+
+	delivery := *pb.Pending
+	delivery.IntervalSeconds = planned.IntervalSeconds
+	pb.Pending = nil
+	return delivery, true
+}
+
+func (pb *PlayerBowler) Reset() {
+	pb.held = false
+	pb.Dragging = false
+	pb.Pending = nil
+}
+
+// Gesture follows a drag of the player's input on the given field, making the delivery it
+// describes once the drag is let go
+func (pb *PlayerBowler) Gesture(input BatInput, width, height float64) {
+	pressed := input.Dragging && !pb.held
+	pb.held = input.Dragging
+	pb.Cursor = input.Cursor
+
+	switch {
+	case pressed && input.Cursor.X > width/2:
+		pb.Dragging, pb.Start, pb.ticks = true, input.Cursor, 0
+	case pb.Dragging && input.Dragging:
+		pb.ticks++
+	case pb.Dragging:
+		pb.Dragging = false
+		drag := input.Cursor.Subtract(pb.Start)
+		if -drag.X < minGesturePixels || pb.Pending != nil {
+			return
+		}
+
+		ballSettings := pb.preset.Ball
+		speed := clampValue(-drag.X/float64(max(pb.ticks, 1)), ballSettings.MinSpeed, ballSettings.MaxSpeed)
+		pb.Pending = &deliveries.Delivery{
+			Speed:  speed,
+			Height: clampValue(pb.Start.Y/height, 0, ballSettings.MaxReleaseHeight),
+			Dip:    clampValue(drag.Y/-drag.X*speed, 0, maxGestureDip),
+		}
+	}
+}
+
+// Released has the bowler let go of the ball if it decides deliveries on release, reporting
+// whether the ball is out of its hand
+func (w *World) Released() bool {
+	rb, ok := w.Bowler.(ReleasingBowler)
+	if !ok {
+		return true
+	}
+
+	delivery, ok := rb.Release(w.Upcoming)
+	if ok {
+		w.Upcoming = delivery
+	}
+	return ok
+}
@@ -0,0 +1,54 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/meghashyamc/cricket2d/geometry"
+)
+
+// bowlGesture drags the player's input from one point to another over the given ticks, and
+// lets go
+func bowlGesture(pb *PlayerBowler, w *World, from, to geometry.Vector, ticks int) {
+	pb.Gesture(BatInput{Cursor: from}, w.Width, w.Height)
+	for i := range ticks {
+		step := to.Subtract(from).Scale(float64(i) / float64(ticks))
+		pb.Gesture(BatInput{Cursor: from.Add(step), Dragging: true}, w.Width, w.Height)
+	}
+	pb.Gesture(BatInput{Cursor: to}, w.Width, w.Height)
+}
+
+func TestBowlingGesture(t *testing.T) {
+	w := newBenchWorld(t)
+	pb := NewPlayerBowler(w.Preset)
+	ballSettings := w.Preset.Ball
+
+	tests := []struct {
+		name     string
+		from, to geometry.Vector
+		ticks    int
+		bowled   bool
+	}{
+		{"towards the batsman", geometry.Vector{X: 1000, Y: 300}, geometry.Vector{X: 800, Y: 320}, 20, true},
+		{"too short", geometry.Vector{X: 1000, Y: 300}, geometry.Vector{X: 980, Y: 300}, 5, false},
+		{"away from the batsman", geometry.Vector{X: 800, Y: 300}, geometry.Vector{X: 1000, Y: 300}, 20, false},
+		{"started on the batsman's half", geometry.Vector{X: 500, Y: 300}, geometry.Vector{X: 200, Y: 300}, 20, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pb.Reset()
+			bowlGesture(pb, w, tt.from, tt.to, tt.ticks)
+			if (pb.Pending != nil) != tt.bowled {
+				t.Fatalf("bowled %v, want %v", pb.Pending != nil, tt.bowled)
+			}
+			if !tt.bowled {
+				return
+			}
+			if pb.Pending.Speed < ballSettings.MinSpeed || pb.Pending.Speed > ballSettings.MaxSpeed {
+				t.Errorf("speed %v outside %v to %v", pb.Pending.Speed, ballSettings.MinSpeed, ballSettings.MaxSpeed)
+			}
+			if want := tt.from.Y / w.Height; pb.Pending.Height != min(want, ballSettings.MaxReleaseHeight) {
+				t.Errorf("released at height %v, want %v", pb.Pending.Height, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/meghashyamc/cricket2d/geometry"
+)
+
+const (
+	// Chosen so that a well struck lofted shot carries about as far as a real six
+	carryMetresPerPixel = 0.01
+	// Air resistance shortens long hits more than short ones, so that nothing carries
+	// further than this
+	maxCarryMetres = 130
+)
+
+// GroundLevel is the height of the ground on the screen, at the foot of the stumps
+func (w *World) GroundLevel() float64 {
+	return w.Stumps.Position.Y + w.Stumps.size().Y
+}
+
+// carry is how far in metres a ball will travel through the air before landing, following
+// its current velocity under gravity
+func (w *World) carry(b *Ball) float64 {
+	if b.gravity <= 0 {
+		return 0
+	}
+
+	center, _ := b.CenterAndRadius()
+	drop := w.GroundLevel() - center.Y
+	if drop < 0 {
+		return 0
+	}
+
+	// Solve y0 + vy*t + g*t*t/2 = ground for the time in ticks until the ball lands
+	ticks := (-b.Velocity.Y + math.Sqrt(b.Velocity.Y*b.Velocity.Y+2*b.gravity*drop)) / b.gravity
+	distance := geometry.Vector{X: b.Velocity.X * ticks}.Magnitude() * carryMetresPerPixel
+
+	return maxCarryMetres * (1 - math.Exp(-distance/maxCarryMetres))
+}
+
+func FormatCarry(metres float64) string {
+	return fmt.Sprintf("%.0fm", metres)
+}
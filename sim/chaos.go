@@ -0,0 +1,70 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/logger"
+)
+
+const (
+	chaosEveryRuns   = 25 // Runs between chaos overs
+	chaosSpreadTicks = 8  // Between the balls of a chaos over, so that they arrive nearly together
+)
+
+// Release heights of the balls of a chaos over, as fractions of the screen height
+var chaosHeights = []float64{0.3, 0.45, 0.6}
+
+// burstBall is a ball waiting to be bowled as part of a burst
+type burstBall struct {
+	delivery deliveries.Delivery
+	ticks    int // Until it is bowled
+}
+
+// checkChaos sets up a chaos over once the score passes the next multiple of
+// chaosEveryRuns, warning the batsman before the balls come
+func (w *World) checkChaos() {
+	if !w.ChaosOvers || w.ChaosDue || w.Score < w.nextChaosAt {
+		return
+	}
+
+	for w.nextChaosAt <= w.Score {
+		w.nextChaosAt += chaosEveryRuns
+	}
+	w.ChaosDue = true
+	w.Announce("CHAOS OVER! Three balls at once")
+	w.Events = append(w.Events, EventChaos)
+	if logger.DebugEnabled() {
+		w.logger.Debug("chaos over due", "score", w.Score, "next_chaos_at", w.nextChaosAt)
+	}
+}
+
+// startBurst turns the upcoming delivery into a chaos over: it comes from the first height
+// and copies of it follow from the others. The copies are extras that don't count towards
+// the overs.
+func (w *World) startBurst() {
+	w.ChaosDue = false
+	w.Upcoming.Height = chaosHeights[0]
+	for i, height := range chaosHeights[1:] {
+		delivery := w.Upcoming
+		delivery.Height = height
+		w.burst = append(w.burst, burstBall{delivery: delivery, ticks: (i + 1) * chaosSpreadTicks})
+	}
+}
+
+// updateBurst bowls the balls of a burst as they come due
+func (w *World) updateBurst() {
+	due := 0
+	for i := range w.burst {
+		w.burst[i].ticks--
+		if w.burst[i].ticks > 0 {
+			w.burst[due] = w.burst[i]
+			due++
+			continue
+		}
+		b := NewBall(w.Width, w.Height, w.burst[i].delivery, w.Gravity())
+		b.Scale = w.ballScale
+		w.ageBall(b)
+		w.pitchBall(b)
+		w.Balls = append(w.Balls, b)
+	}
+	w.burst = w.burst[:due]
+}
@@ -0,0 +1,143 @@
+package sim
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/stats"
+	"github.com/meghashyamc/cricket2d/twitch"
+)
+
+const (
+	bowlingChat = "chat"
+
+	chatVotePrefix      = "!"
+	chatVoterCooldown   = 5 * time.Second // Least time between one viewer's votes
+	chatBallotCooldown  = time.Second     // Votes are ignored for this long after a ballot closes
+	maxChatVotesPerTick = 50              // Messages read in a tick, so a flooded chat can't stall the game
+	chatPickDisplay     = 3 * time.Second // How long the chat's pick stays on the HUD
+)
+
+// ChatBowler lets a Twitch chat play the bowler. Viewers vote for a type of delivery by
+// typing it, such as !yorker, and whichever type has the most votes when the ballot closes
+// is bowled next. Without any votes the type is picked at random.
+type ChatBowler struct {
+	chat   *twitch.Chat
+	preset *difficulty.Preset
+	types  []string
+
+	votes         map[string]int       // Votes for each type in the open ballot
+	voted         map[string]bool      // Viewers who have voted in the open ballot
+	lastVote      map[string]time.Time // When each viewer last had a vote counted
+	cooldownUntil time.Time            // When the next ballot opens
+	picked        string               // The type picked when the last ballot closed
+	pickedAt      time.Time
+	logger        logger.Logger
+}
+
+// newChatBowler joins the configured channel's chat
+func newChatBowler(cfg *config.Config, preset *difficulty.Preset) (*ChatBowler, error) {
+	chat, err := twitch.Connect(cfg.GetTwitchChannel(), cfg.GetTwitchNick(), cfg.GetTwitchToken())
+	if err != nil {
+		return nil, err
+	}
+
+	cb := &ChatBowler{
+		chat:     chat,
+		preset:   preset,
+		types:    deliveries.Types(),
+		lastVote: make(map[string]time.Time),
+		logger:   logger.New(),
+	}
+	cb.Reset()
+
+	return cb, nil
+}
+
+func (cb *ChatBowler) NextDelivery(*stats.Innings) (deliveries.Delivery, bool) {
+	cb.Poll()
+	cb.picked = cb.winner()
+	cb.pickedAt = time.Now()
+	cb.logger.Debug("chat ballot closed", "votes", cb.votes, "picked", cb.picked)
+	cb.Reset()
+	cb.cooldownUntil = time.Now().Add(chatBallotCooldown)
+
+	delivery, _ := deliveries.OfType(cb.picked)
+	delivery.Speed = clampValue(delivery.Speed, cb.preset.Ball.MinSpeed, cb.preset.Ball.MaxSpeed)
+	delivery.IntervalSeconds = cb.preset.SpawnIntervalSeconds
+	return delivery, true
+}
+
+func (cb *ChatBowler) Reset() {
+	cb.votes = make(map[string]int)
+	cb.voted = make(map[string]bool)
+}
+
+// Poll counts the votes that have come in since the last tick
+func (cb *ChatBowler) Poll() {
+	now := time.Now()
+	for range maxChatVotesPerTick {
+		var message twitch.Message
+		select {
+		case message = <-cb.chat.Messages():
+		default:
+			return
+		}
+
+		vote, ok := strings.CutPrefix(strings.ToLower(message.Text), chatVotePrefix)
+		if !ok || !slices.Contains(cb.types, vote) {
+			continue
+		}
+		if now.Before(cb.cooldownUntil) || cb.voted[message.User] || now.Sub(cb.lastVote[message.User]) < chatVoterCooldown {
+			continue
+		}
+		cb.votes[vote]++
+		cb.voted[message.User] = true
+		cb.lastVote[message.User] = now
+	}
+}
+
+// winner is the type with the most votes, ties and an empty ballot going to chance
+func (cb *ChatBowler) winner() string {
+	most := 0
+	leaders := make([]string, 0, len(cb.types))
+	for _, deliveryType := range cb.types {
+		switch votes := cb.votes[deliveryType]; {
+		case votes > most:
+			most = votes
+			leaders = append(leaders[:0], deliveryType)
+		case votes == most:
+			leaders = append(leaders, deliveryType)
+		}
+	}
+
+	return leaders[rand.IntN(len(leaders))]
+}
+
+// HUD shows how the vote is going, and how long until it closes, given the ticks until the
+// next ball is bowled
+func (cb *ChatBowler) HUD(ticksUntilSpawn int) []string {
+	lines := make([]string, 0, 2)
+	if wait := time.Until(cb.cooldownUntil); wait > 0 {
+		lines = append(lines, fmt.Sprintf("Chat voting opens in %.0fs", wait.Seconds()))
+	} else {
+		tally := make([]string, 0, len(cb.types))
+		for _, deliveryType := range cb.types {
+			tally = append(tally, fmt.Sprintf("!%s %d", deliveryType, cb.votes[deliveryType]))
+		}
+		seconds := (ticksUntilSpawn + TPS - 1) / TPS
+		lines = append(lines, fmt.Sprintf("Chat vote: %s (closes in %ds)", strings.Join(tally, "  "), seconds))
+	}
+	if len(cb.picked) > 0 && time.Since(cb.pickedAt) < chatPickDisplay {
+		lines = append(lines, "Chat picked: "+cb.picked)
+	}
+
+	return lines
+}
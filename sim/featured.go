@@ -0,0 +1,77 @@
+package sim
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/config"
+)
+
+// ModeFeatured is the mode with a different rule set every week
+const ModeFeatured = "featured"
+
+func init() {
+	RegisterMode(ModeFeatured, func(cfg *config.Config) Mode { return newFeaturedMode(time.Now()) })
+}
+
+// featuredEpoch is the Monday the rotation of featured rule sets starts from. Weeks are
+// counted in UTC, so every player has the same rule set whatever their time zone.
+var featuredEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// FeaturedRuleSet is one of the rule sets the featured mode rotates through: a mode to play
+// and the mutators to play it with
+type FeaturedRuleSet struct {
+	name     string
+	mode     Mode
+	Mutators []Mutator
+}
+
+// featuredRuleSets are taken in turn, a week each
+var featuredRuleSets = []FeaturedRuleSet{
+	{name: "Moon Cricket", mode: oversMode{overs: 3, oldBallOvers: 3}, Mutators: []Mutator{mutatorLowGravity}},
+	{name: "Giant Blitz", mode: blitzMode{}, Mutators: []Mutator{mutatorGiantBall}},
+	{name: "Tiny Timbers", mode: EndlessMode{}, Mutators: []Mutator{mutatorTinyStumps, mutatorDoubleSpawn}},
+	{name: "Pinball", mode: oversMode{overs: 5, oldBallOvers: 5}, Mutators: []Mutator{mutatorBouncyBoundary}},
+	{name: "Beach Ball", mode: oversMode{overs: 2, oldBallOvers: 2}, Mutators: []Mutator{mutatorGiantBall, mutatorDoubleSpawn}},
+	{name: "Featherweight", mode: blitzMode{}, Mutators: []Mutator{mutatorLowGravity, mutatorBouncyBoundary}},
+}
+
+// FeaturedMode plays the week's featured rule set. It keeps a high score of its own for
+// the week, which starts again with the next rule set.
+type FeaturedMode struct {
+	Mode
+	Set  FeaturedRuleSet
+	Week string // The ISO week, such as 2026-W42
+}
+
+// newFeaturedMode picks the rule set for the week of the given time
+func newFeaturedMode(now time.Time) FeaturedMode {
+	now = now.UTC()
+	weeks := int(now.Sub(featuredEpoch).Hours()) / (24 * 7)
+	set := featuredRuleSets[weeks%len(featuredRuleSets)]
+	year, week := now.ISOWeek()
+
+	return FeaturedMode{Mode: set.mode, Set: set, Week: fmt.Sprintf("%d-W%02d", year, week)}
+}
+
+func (m FeaturedMode) Name() string { return ModeFeatured }
+
+func (m FeaturedMode) Description() string {
+	names := make([]string, 0, len(m.Set.Mutators))
+	for _, mutator := range m.Set.Mutators {
+		names = append(names, string(mutator))
+	}
+	return fmt.Sprintf("This week's rule set, %s: %s With %s. A new one every Monday, with its own high score.",
+		m.Set.name, m.Mode.Description(), strings.Join(names, " and "))
+}
+
+func (m FeaturedMode) Rules() ModeRules {
+	rules := m.Mode.Rules()
+	rules.HighScoreKey = fmt.Sprintf("%s_%s", ModeFeatured, m.Week)
+	return rules
+}
+
+func (m FeaturedMode) HUD(state MatchState) []string {
+	return append([]string{fmt.Sprintf("FEATURED: %s (%s)", strings.ToUpper(m.Set.name), m.Week)}, m.Mode.HUD(state)...)
+}
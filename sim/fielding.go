@@ -0,0 +1,114 @@
+package sim
+
+import (
+	"math"
+
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	CatchRadius          = 30          // Lofted balls passing this close to a fielder are caught
+	fieldMapDisplayTicks = 2 * TPS     // How long the field map shows at the start of an over
+	wagonWheelMemory     = 30          // How many recent balls the captain studies when setting the field
+	wagonWheelPull       = math.Pi / 4 // Hits within this angle of a fielder draw them towards the shots
+)
+
+// Fielder stands somewhere on the field, ready to catch a ball hit in the air
+type Fielder struct {
+	name     string
+	Position geometry.Vector
+	// Where the preset puts the fielder, as an angle and distance from the bat
+	homeAngle    float64
+	homeDistance float64
+}
+
+// setUpField places the preset's fielders, if the mode has any
+func (w *World) setUpField() {
+	w.Fielders = w.Fielders[:0]
+	if !w.Mode.Rules().Fielders {
+		return
+	}
+
+	for _, spot := range w.Preset.Fielders {
+		position := geometry.Vector{X: spot.X * w.Width, Y: spot.Y * w.Height}
+		angle, distance := w.shotAngle(position), position.Subtract(w.Bat.Position).Magnitude()
+		w.Fielders = append(w.Fielders, &Fielder{
+			name:         spot.Name,
+			Position:     position,
+			homeAngle:    angle,
+			homeDistance: distance,
+		})
+	}
+}
+
+// shotAngle is the direction of a point from the bat, 0 being straight back past the
+// bowler and positive angles going up
+func (w *World) shotAngle(point geometry.Vector) float64 {
+	offset := point.Subtract(w.Bat.Position)
+	return math.Atan2(-offset.Y, offset.X)
+}
+
+// planField moves the fielders at the start of an over. Each fielder shifts from their usual
+// spot towards the recent shots near them, the more so the more aggressive the bowling side.
+func (w *World) planField() {
+	if len(w.Fielders) == 0 {
+		return
+	}
+
+	recent := w.Innings.Recent(wagonWheelMemory)
+	aggressiveness := w.Preset.Bowling.Aggressiveness
+
+	for _, f := range w.Fielders {
+		total, count := 0.0, 0
+		for _, event := range recent {
+			if event.Outcome != stats.OutcomeHit {
+				continue
+			}
+			offset := event.Angle - f.homeAngle
+			if math.Abs(offset) <= wagonWheelPull {
+				total += offset
+				count++
+			}
+		}
+
+		angle := f.homeAngle
+		if count > 0 {
+			angle += aggressiveness * total / float64(count)
+		}
+		f.Position = w.Bat.Position.Add(geometry.Vector{
+			X: math.Cos(angle) * f.homeDistance,
+			Y: -math.Sin(angle) * f.homeDistance,
+		})
+	}
+
+	w.FieldMapTicks = fieldMapDisplayTicks
+	w.logger.Debug("field set for the over", "fielders", len(w.Fielders))
+}
+
+// checkCatch is true if a ball hit in the air has gone to a fielder
+func (w *World) checkCatch(b *Ball) bool {
+	if !b.IsHit || !b.lofted {
+		return false
+	}
+
+	center, _ := b.CenterAndRadius()
+	for _, f := range w.Fielders {
+		if center.Subtract(f.Position).Magnitude() <= CatchRadius {
+			if logger.DebugEnabled() {
+				w.logger.Debug("ball caught", "fielder", f.name)
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// catchOut dismisses the batsman caught off the given ball, taking back the runs it scored
+func (w *World) catchOut(b *Ball) {
+	w.Score = max(w.Score-b.runs, 0)
+	w.Innings.SetOutcome(b.Number, stats.OutcomeCaught, 0)
+	w.dismiss(Caught)
+}
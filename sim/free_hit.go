@@ -0,0 +1,53 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	freeHitAfter     = 3   // Balls beaten or edged in a row that earn a free hit
+	freeHitReleaseAt = 0.5 // Release height of a free hit, as a fraction of the preset's lowest
+
+	freeHitDelivery = "free hit" // Delivery type of a free hit
+)
+
+// trackBeaten counts the balls in a row the batsman has been beaten by or edged, and
+// grants a free hit once there are enough of them. An edge counts whatever it ran for, as
+// the batsman was beaten all the same. Wides and no balls don't break the run, and anything
+// else does.
+func (w *World) trackBeaten(b *Ball, outcome stats.Outcome) {
+	if !w.FreeHits {
+		return
+	}
+
+	switch {
+	case !outcome.Legal():
+		return
+	case outcome == stats.OutcomeMissed, outcome == stats.OutcomeHit && b.edged:
+		w.beatenInARow++
+	default:
+		w.beatenInARow = 0
+	}
+	if w.beatenInARow < freeHitAfter || w.FreeHitDue {
+		return
+	}
+
+	w.beatenInARow = 0
+	w.FreeHitDue = true
+	w.Announce("Beaten three times. FREE HIT next ball!")
+	if logger.DebugEnabled() {
+		w.logger.Debug("free hit due", "balls_bowled", w.BallsBowled)
+	}
+}
+
+// easeFreeHit turns the upcoming delivery into a free hit: as slow as the preset bowls,
+// from halfway down its release heights, with no spin
+func (w *World) easeFreeHit() {
+	w.FreeHitDue = false
+	w.Upcoming.Speed = w.Preset.Ball.MinSpeed
+	w.Upcoming.Height = w.Preset.Ball.MaxReleaseHeight * freeHitReleaseAt
+	w.Upcoming.Dip = 0
+	w.Upcoming.Spin = 0
+	w.Upcoming.Type = freeHitDelivery
+}
@@ -0,0 +1,63 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	ghostBatSwingTicks = 12   // How long the ideal swing takes from full backlift to vertical
+	ghostBatMemory     = 12   // How many recent balls decide how faint the ghost is
+	GhostBatMaxAlpha   = 0.45 // How solid the ghost is for a player yet to time anything
+)
+
+// GhostSwing is the ideal swing for the next ball in: the bat held where the ball meets
+// its middle, and turning so that it is vertical as the ball gets there
+type GhostSwing struct {
+	Handle geometry.Vector
+	Angle  float64
+	Meet   geometry.Vector // Where the ball will be when it is met
+}
+
+// IdealSwing works out the ghost's swing for the ball that will reach the bat first, from
+// the ball's predicted flight. It is false if no ball is coming.
+func (w *World) IdealSwing() (GhostSwing, bool) {
+	target := nextIncomingBall(w)
+	if target == nil {
+		return GhostSwing{}, false
+	}
+
+	bat := w.Bat
+	meet := predictBallCenter(target, bat.Position.X)
+	bounds := bat.DragArea.bounds(w.Stumps.Position)
+	handle := geometry.Vector{
+		X: bat.Position.X,
+		Y: clampValue(meet.Y-sweetSpot*bat.reach(), bounds.Y, bounds.MaxY()),
+	}
+
+	center := target.getBounds().Center()
+	ticksLeft := (meet.X - center.X) / target.Velocity.X
+	angle := clampValue(ticksLeft*maxSwingAngle/ghostBatSwingTicks, -maxSwingAngle, maxSwingAngle)
+
+	return GhostSwing{Handle: handle, Angle: angle, Meet: meet}, true
+}
+
+// GhostBatAlpha is how solid the ghost is, fading as the player's recent shots come off
+// the middle on time
+func (w *World) GhostBatAlpha() float32 {
+	recent := w.Innings.Recent(ghostBatMemory)
+	if len(recent) == 0 {
+		return GhostBatMaxAlpha
+	}
+
+	var timed float64
+	for _, event := range recent {
+		switch event.Timing {
+		case stats.TimingPerfect:
+			timed++
+		case stats.TimingOnTime:
+			timed += 0.5
+		}
+	}
+	return float32(GhostBatMaxAlpha * (1 - timed/float64(len(recent))))
+}
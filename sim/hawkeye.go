@@ -0,0 +1,84 @@
+package sim
+
+import (
+	"slices"
+
+	"github.com/meghashyamc/cricket2d/geometry"
+)
+
+const (
+	hawkEyeDisplayTicks    = 3 * TPS // How long ball tracking stays on screen
+	hawkEyeProjectionTicks = 6       // How far past the stumps the path is projected
+)
+
+// HawkEye is TV style ball tracking for an LBW decision: the path the ball took, its
+// projection on past the stumps and where it crossed the line of the stumps
+type HawkEye struct {
+	Tracked    []geometry.Vector
+	Projected  []geometry.Vector
+	Impact     geometry.Vector // Where the ball's centre crossed the middle of the stumps
+	BallRadius float64
+	Hitting    bool // Whether the ball would have hit the stumps
+	givenOut   bool
+	ticksLeft  int
+}
+
+func newHawkEye(b *Ball, stumpsBounds geometry.Rect) *HawkEye {
+	_, radius := b.CenterAndRadius()
+	h := &HawkEye{
+		Tracked:    slices.Clone(b.Path),
+		BallRadius: radius,
+	}
+
+	// Carry on from the ball's last position with the same physics the ball uses
+	position := b.getBounds().Center()
+	velocity := b.Velocity
+	for range hawkEyeProjectionTicks {
+		velocity.Y += b.gravity + b.swing
+		position = position.Add(velocity)
+		h.Projected = append(h.Projected, position)
+	}
+
+	stumpsLine := stumpsBounds.Center().X
+	path := append(slices.Clone(h.Tracked), h.Projected...)
+	for i := 1; i < len(path); i++ {
+		from, to := path[i-1], path[i]
+		if from.X < stumpsLine || to.X > stumpsLine || from.X == to.X {
+			continue
+		}
+		along := (from.X - stumpsLine) / (from.X - to.X)
+		h.Impact = from.Add(to.Subtract(from).Scale(along))
+		break
+	}
+	h.Hitting = h.Impact.Y+h.BallRadius >= stumpsBounds.Y && h.Impact.Y-h.BallRadius <= stumpsBounds.MaxY()
+
+	return h
+}
+
+// show puts the tracking on screen alongside the umpire's decision
+func (h *HawkEye) show(givenOut bool) {
+	h.givenOut = givenOut
+	h.ticksLeft = hawkEyeDisplayTicks
+}
+
+func (h *HawkEye) update() {
+	if h.ticksLeft > 0 {
+		h.ticksLeft--
+	}
+}
+
+func (h *HawkEye) Visible() bool {
+	return h.ticksLeft > 0
+}
+
+// Verdict describes what the tracking shows, for printing under the view
+func (h *HawkEye) Verdict() string {
+	call := "NOT OUT"
+	if h.givenOut {
+		call = "OUT"
+	}
+	if h.Hitting {
+		return "HITTING - given " + call
+	}
+	return "MISSING - given " + call
+}
@@ -0,0 +1,19 @@
+package sim
+
+import (
+	"cmp"
+)
+
+// clampValue clamps the value between min and max if the value is < min or > max
+func clampValue[T cmp.Ordered](value T, min T, max T) T {
+	if value > max {
+		value = max
+		return value
+	}
+
+	if value < min {
+		value = min
+	}
+
+	return value
+}
@@ -0,0 +1,40 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+// TestHitWicketRecorded checks that a hit wicket goes into the ball-by-ball record like any
+// other dismissal, against the ball being faced or, with none on its way, the last one
+func TestHitWicketRecorded(t *testing.T) {
+	t.Run("ball on its way", func(t *testing.T) {
+		w := newBenchWorld(t)
+		w.Innings.Record(stats.BallEvent{Number: 1, Outcome: stats.OutcomeHit, Runs: 1})
+		b := benchBall(w)
+		b.Number = 2
+		w.Balls = append(w.Balls, b)
+
+		w.recordHitWicket()
+		w.dismiss(HitWicket)
+		events := w.Innings.Events()
+		if len(events) != 2 || events[1].Number != 2 || events[1].Outcome != stats.OutcomeHitWicket {
+			t.Fatalf("recorded %+v", events)
+		}
+		if summary := w.Innings.Summary(ballsPerOver); summary.Wickets != w.wickets {
+			t.Errorf("summary has %d wickets, the field %d", summary.Wickets, w.wickets)
+		}
+	})
+
+	t.Run("between balls", func(t *testing.T) {
+		w := newBenchWorld(t)
+		w.Innings.Record(stats.BallEvent{Number: 1, Outcome: stats.OutcomeHit, Runs: 2})
+
+		w.recordHitWicket()
+		events := w.Innings.Events()
+		if len(events) != 1 || events[0].Outcome != stats.OutcomeHitWicket || events[0].Runs != 2 {
+			t.Errorf("recorded %+v, want the last ball out hit wicket for its 2 runs", events)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package sim
+
+const (
+	MinBallsPerOver = 4
+	MaxBallsPerOver = 8
+	MaxShotRuns     = 6 // The most a house rule can make a shot worth
+	noBallRuns      = 1
+)
+
+// HouseRules are the rules of the match the players can change to suit themselves. Anything
+// but the standard rules keeps the match from setting a high score.
+type HouseRules struct {
+	BallsPerOver int  `json:"balls_per_over"`
+	Wides        bool `json:"wides"`    // Balls out of reach are wides
+	NoBalls      bool `json:"no_balls"` // Full tosses above the handle of the bat are no balls
+	GroundRuns   int  `json:"ground_runs"`
+	LoftedRuns   int  `json:"lofted_runs"`
+}
+
+// StandardRules are the rules the game plays by unless the players pick others
+var StandardRules = HouseRules{BallsPerOver: ballsPerOver, Wides: true, GroundRuns: 1, LoftedRuns: 1}
+
+// Standard is true if the rules are the ones the game plays by
+func (r HouseRules) Standard() bool {
+	return r == StandardRules
+}
+
+// Valid is true if the rules can be played by: overs of a length the game allows, and at
+// least a run for every shot
+func (r HouseRules) Valid() bool {
+	return r.BallsPerOver >= MinBallsPerOver && r.BallsPerOver <= MaxBallsPerOver &&
+		r.GroundRuns >= 1 && r.GroundRuns <= MaxShotRuns && r.LoftedRuns >= 1 && r.LoftedRuns <= MaxShotRuns
+}
+
+// runsFor is what a shot scores under the rules, given the runs the mode gives for a hit
+func (r HouseRules) runsFor(b *Ball, runs int) int {
+	if b.lofted {
+		return runs * r.LoftedRuns
+	}
+	return runs * r.GroundRuns
+}
+
+// SetHouseRules makes the world play by the given rules, from the next ball on. Rules that
+// can't be played by, such as from a hand-edited file, give way to the standard ones.
+func (w *World) SetHouseRules(r HouseRules) {
+	if !r.Valid() {
+		r = StandardRules
+	}
+	w.Rules = r
+	w.MaxBalls = w.Mode.Rules().MaxBalls(r.BallsPerOver)
+	if planner, ok := w.Bowler.(OverPlanner); ok {
+		planner.SetOverLength(r.BallsPerOver)
+	}
+}
@@ -0,0 +1,138 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+func TestRunsFor(t *testing.T) {
+	rules := HouseRules{GroundRuns: 2, LoftedRuns: 6}
+	tests := []struct {
+		name   string
+		lofted bool
+		runs   int
+		want   int
+	}{
+		{name: "along the ground", runs: 1, want: 2},
+		{name: "lofted", lofted: true, runs: 1, want: 6},
+		{name: "mode gives more", lofted: true, runs: 2, want: 12},
+		{name: "mode gives nothing", runs: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.runsFor(&Ball{lofted: tt.lofted}, tt.runs); got != tt.want {
+				t.Errorf("got %d runs, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// overCounter is a bowler that keeps the over length it was last told
+type overCounter struct {
+	ScriptedBowler
+	overLength int
+}
+
+func (oc *overCounter) SetOverLength(balls int) { oc.overLength = balls }
+
+func TestSetHouseRules(t *testing.T) {
+	w := newBenchWorld(t)
+	w.Mode = oversMode{overs: 3}
+	bowler := &overCounter{}
+	w.Bowler = bowler
+
+	w.SetHouseRules(HouseRules{BallsPerOver: 5, GroundRuns: 1, LoftedRuns: 1})
+	if w.MaxBalls != 15 {
+		t.Errorf("innings lasts %d balls, want 15", w.MaxBalls)
+	}
+	if bowler.overLength != 5 {
+		t.Errorf("bowler plans overs of %d balls, want 5", bowler.overLength)
+	}
+	if w.Rules.Standard() {
+		t.Error("five ball overs taken for the standard rules")
+	}
+
+	w.SetHouseRules(StandardRules)
+	if w.MaxBalls != 3*ballsPerOver || !w.Rules.Standard() {
+		t.Errorf("back on the standard rules, innings lasts %d balls", w.MaxBalls)
+	}
+}
+
+// TestCallsExtras checks that wides and no balls are only called when the house rules play
+// with them
+func TestCallsExtras(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   HouseRules
+		offset  geometry.Vector // From the bat's handle to where the ball goes past
+		pitched bool
+		want    stats.Outcome
+	}{
+		{name: "no ball", rules: HouseRules{NoBalls: true}, offset: geometry.Vector{X: -5, Y: -10}, want: stats.OutcomeNoBall},
+		{name: "no balls off", offset: geometry.Vector{X: -5, Y: -10}, want: stats.OutcomeLeft},
+		{name: "pitched first", rules: HouseRules{NoBalls: true}, offset: geometry.Vector{X: -5, Y: -10}, pitched: true, want: stats.OutcomeLeft},
+		{name: "wide", rules: HouseRules{Wides: true}, offset: geometry.Vector{X: -5, Y: 2000}, want: stats.OutcomeWide},
+		{name: "wides off", offset: geometry.Vector{X: -5, Y: 2000}, want: stats.OutcomeLeft},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newBenchWorld(t)
+			w.Rules = tt.rules
+			w.HasUpcoming = true
+			// The bat is held still, so a ball that isn't an extra is left alone
+			w.Bat.PreviousAngle = w.Bat.CurrentAngle
+
+			b := NewBall(w.Width, w.Height, deliveries.Delivery{Speed: 10, Height: 0.3}, w.Preset.Ball.Gravity)
+			b.pitched = tt.pitched
+			center, _ := b.CenterAndRadius()
+			b.Position = b.Position.Add(w.Bat.Position.Add(tt.offset).Subtract(center))
+
+			score := w.Score
+			w.checkBallPassedBat(b)
+			if b.passOutcome != tt.want {
+				t.Fatalf("called %v, want %v", b.passOutcome, tt.want)
+			}
+			if runs := w.Score - score; runs != b.passRuns {
+				t.Errorf("scored %d for it, want %d", runs, b.passRuns)
+			}
+		})
+	}
+}
+
+// TestInvalidHouseRules checks that rules no one could play by, such as from a hand-edited
+// profile, give way to the standard ones rather than stopping the match
+func TestInvalidHouseRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules HouseRules
+	}{
+		{name: "zero", rules: HouseRules{}},
+		{name: "no balls in an over", rules: HouseRules{BallsPerOver: 0, GroundRuns: 1, LoftedRuns: 1}},
+		{name: "negative over", rules: HouseRules{BallsPerOver: -6, GroundRuns: 1, LoftedRuns: 1}},
+		{name: "long over", rules: HouseRules{BallsPerOver: MaxBallsPerOver + 1, GroundRuns: 1, LoftedRuns: 1}},
+		{name: "shots worth nothing", rules: HouseRules{BallsPerOver: 6, GroundRuns: 0, LoftedRuns: 1}},
+		{name: "shots worth too much", rules: HouseRules{BallsPerOver: 6, GroundRuns: 1, LoftedRuns: MaxShotRuns + 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.rules.Valid() {
+				t.Fatalf("%+v taken as valid", tt.rules)
+			}
+			w := newBenchWorld(t)
+			w.SetHouseRules(tt.rules)
+			if !w.Rules.Standard() {
+				t.Errorf("playing by %+v, want the standard rules", w.Rules)
+			}
+			for range TPS {
+				w.Update(BatInput{})
+			}
+		})
+	}
+
+	if !StandardRules.Valid() {
+		t.Error("the standard rules aren't valid")
+	}
+}
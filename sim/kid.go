@@ -0,0 +1,16 @@
+package sim
+
+const (
+	KidPreset    = "kid" // The built-in difficulty preset kid mode plays with
+	kidBallScale = 1.6
+	kidTryAgain  = "Oops! Try again!"
+)
+
+// forgive stands in for a dismissal in kid mode: the ball is taken away and the batsman
+// has another go
+func (w *World) forgive() {
+	w.Balls = nil
+	w.burst = w.burst[:0]
+	w.PendingAppeal = nil
+	w.Announce(kidTryAgain)
+}
@@ -0,0 +1,90 @@
+package sim
+
+import (
+	"math/rand/v2"
+)
+
+// Streams of the match's randomness, kept apart so that drawing more from one, such as for
+// the extra balls a mod spawns, leaves the others as they were
+const (
+	streamDeliveries = iota + 1
+	streamPitch
+	streamExtras
+	streamPlay
+	streamLights
+	streamBot
+)
+
+// MatchRand is all the randomness of a match. Everything drawn from it follows from its
+// seed, so two players given the same seed face the same balls, and the same inputs play
+// out the same match.
+type MatchRand struct {
+	Seed    uint32
+	fixed   bool // The seed came from a challenge code, and is kept for every restart
+	innings int  // Of the match, each drawing from a seed of its own that follows from the match's
+
+	Deliveries *rand.Rand // What the bowler bowls
+	pitch      *rand.Rand // The cracks in the pitch, where balls land and how they swing
+	Extras     *rand.Rand // What depends on how the match goes, such as balls mods spawn and super overs
+	play       *rand.Rand // How the ball comes off the bat and the umpire's calls
+	Lights     *rand.Rand // The glare off the floodlights, kept apart as only a night match draws on it
+	bot        *rand.Rand // The computer batsman's aim, kept apart so a player in its place changes nothing else
+
+	deliveriesSource, pitchSource, extrasSource, playSource, lightsSource, botSource *rand.PCG
+}
+
+// NewMatchRand starts the match's randomness from a seed of its own
+func NewMatchRand() *MatchRand {
+	m := &MatchRand{
+		deliveriesSource: rand.NewPCG(0, 0),
+		pitchSource:      rand.NewPCG(0, 0),
+		extrasSource:     rand.NewPCG(0, 0),
+		playSource:       rand.NewPCG(0, 0),
+		lightsSource:     rand.NewPCG(0, 0),
+		botSource:        rand.NewPCG(0, 0),
+	}
+	m.Deliveries = rand.New(m.deliveriesSource)
+	m.pitch = rand.New(m.pitchSource)
+	m.Extras = rand.New(m.extrasSource)
+	m.play = rand.New(m.playSource)
+	m.Lights = rand.New(m.lightsSource)
+	m.bot = rand.New(m.botSource)
+	m.restart()
+
+	return m
+}
+
+// Fix seeds the match from a challenge code, for this match and every restart
+func (m *MatchRand) Fix(seed uint32) {
+	m.Seed = seed
+	m.fixed = true
+	m.restart()
+}
+
+// restart begins the randomness again for a new match: from the same seed if it came from
+// a challenge code, otherwise from a new one
+func (m *MatchRand) restart() {
+	if !m.fixed {
+		m.Seed = rand.Uint32()
+	}
+	m.innings = 0
+	m.reseed()
+}
+
+// nextInnings moves the randomness on to the match's next innings, such as a super over
+func (m *MatchRand) nextInnings() {
+	m.innings++
+	m.reseed()
+}
+
+func (m *MatchRand) reseed() {
+	// The first innings is seeded as it always has been, so challenge codes still bowl the
+	// same balls
+	seed := uint64(m.Seed) | uint64(m.innings)<<32
+	m.deliveriesSource.Seed(seed, streamDeliveries)
+	m.pitchSource.Seed(seed, streamPitch)
+	m.extrasSource.Seed(seed, streamExtras)
+	m.playSource.Seed(seed, streamPlay)
+	m.lightsSource.Seed(seed, streamLights)
+	m.botSource.Seed(seed, streamBot)
+}
@@ -0,0 +1,46 @@
+package sim
+
+import (
+	"reflect"
+	"testing"
+)
+
+const determinismBalls = 30
+
+// playSeeded plays an innings from the seed with a computer batsman, and returns the balls
+// it was made of
+func playSeeded(t *testing.T, seed uint32) *World {
+	t.Helper()
+	w := newBenchWorld(t)
+	w.RNG.Fix(seed)
+	w.Fielders = nil
+	w.MaxBalls = determinismBalls
+	w.Reset()
+	w.RelayPitch()
+	bot := NewBotBatsman(0.6) // Skilled enough to hit, unskilled enough to draw on its aim
+	for range determinismBalls * maxSimulationTicksPerBall {
+		if w.allOut || w.BowlingComplete() {
+			break
+		}
+		w.Update(bot.Input(w))
+	}
+	return w
+}
+
+// TestSameSeedSameMatch plays an innings twice from the same seed, as a server and client
+// would, and checks that every ball went the same way
+func TestSameSeedSameMatch(t *testing.T) {
+	const seed = 0xC0FFEE
+	first, second := playSeeded(t, seed), playSeeded(t, seed)
+
+	if first.Ticks != second.Ticks || first.Score != second.Score || first.wickets != second.wickets {
+		t.Errorf("got %d runs for %d in %d ticks, then %d for %d in %d", first.Score, first.wickets, first.Ticks,
+			second.Score, second.wickets, second.Ticks)
+	}
+	if !reflect.DeepEqual(first.Innings.Events(), second.Innings.Events()) {
+		t.Error("the same seed played out a different innings")
+	}
+	if first.Innings.Events() == nil || first.hits == 0 {
+		t.Fatal("nothing was hit, so the shots weren't checked")
+	}
+}
@@ -0,0 +1,74 @@
+package sim
+
+import (
+	"fmt"
+
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/logger"
+)
+
+const (
+	modeMirror = "mirror"
+
+	mirrorNeutralSwing = 30.0 // Swing speed, in pixels per tick, that leaves deliveries at their usual pace
+	mirrorMinPace      = 0.6  // Slowest a delivery comes, as a multiple of its usual speed
+	mirrorMaxPace      = 1.6  // Fastest, so that wild swinging doesn't make the game unplayable
+	mirrorFollow       = 0.5  // How much of the way to the latest swing the pace moves each ball
+)
+
+func init() {
+	RegisterMode(modeMirror, func(cfg *config.Config) Mode { return mirrorMode{} })
+}
+
+// pacer is implemented by modes that change how fast deliveries come depending on how the
+// batsman swings
+type pacer interface {
+	// Pace is how fast the next delivery comes, as a multiple of its usual speed, given the
+	// pace of the last one and the fastest swing since it was bowled
+	Pace(pace, swing float64) float64
+}
+
+// mirrorMode is the endless mode with deliveries that come as fast as the batsman swings,
+// so that swinging hard only makes batting harder
+type mirrorMode struct{}
+
+func (mirrorMode) Name() string { return modeMirror }
+
+func (mirrorMode) Description() string {
+	return "Bat until you are out. The harder you swing, the faster they bowl."
+}
+
+func (mirrorMode) Rules() ModeRules {
+	return ModeRules{Wickets: 1, HighScoreKey: modeMirror, OldBallOvers: endlessOldBallOvers}
+}
+
+func (mirrorMode) RunsForHit(MatchState) int { return 1 }
+
+func (mirrorMode) End(state MatchState) (bool, string) {
+	return state.AllOut, dismissalMessage(state.LastDismissal)
+}
+
+func (mirrorMode) HUD(state MatchState) []string {
+	return []string{fmt.Sprintf("Pace: %.2fx", state.Pace)}
+}
+
+func (mirrorMode) Pace(pace, swing float64) float64 {
+	target := swing / mirrorNeutralSwing
+	return clampValue(pace+(target-pace)*mirrorFollow, mirrorMinPace, mirrorMaxPace)
+}
+
+// paceDelivery speeds up or slows down the upcoming delivery to suit the way the batsman
+// has been swinging, if the mode asks for it
+func (w *World) paceDelivery() {
+	p, ok := w.Mode.(pacer)
+	if !ok || !w.HasUpcoming {
+		return
+	}
+
+	w.pace = p.Pace(w.pace, w.peakSwing)
+	w.Upcoming.Speed *= w.pace
+	if logger.DebugEnabled() {
+		w.logger.Debug("delivery paced", "peak_swing", w.peakSwing, "pace", w.pace)
+	}
+	w.peakSwing = 0
+}
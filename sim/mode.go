@@ -0,0 +1,111 @@
+package sim
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/meghashyamc/cricket2d/config"
+)
+
+// MatchState is what a mode gets to see of the match in progress
+type MatchState struct {
+	Score          int
+	Hits           int
+	BallsBowled    int
+	Wickets        int     // Wickets lost so far
+	Lofted         int     // Hits that went up in the air
+	Pace           float64 // Speed of the next delivery, as a multiple of its usual speed
+	LastDismissal  string  // How the last batsman got out, empty if nobody has
+	AllOut         bool    // No wickets left, so nobody can bat any more
+	BowlingDone    bool    // The bowler has nothing more to bowl and no balls are in play
+	BallsPerOver   int
+	ElapsedSeconds float64
+}
+
+// ModeRules are the fixed parts of a mode that the world needs to know up front
+type ModeRules struct {
+	Overs            int    // 0 for no limit
+	Balls            int    // Legal balls in the innings, when it isn't a whole number of overs
+	Wickets          int    // 0 for no limit
+	DismissalPenalty int    // Runs taken off the score for every dismissal
+	HighScoreKey     string // Keeps the mode's high score apart from other modes, empty to share the original one
+
+	MaxOverSeconds      float64 // Overs taking longer than this in play, counted in ticks, are penalised, 0 for no limit
+	SlowOverRatePenalty int     // Runs awarded to the batsman for every slow over
+
+	Fielders bool // The difficulty preset's fielders take the field, so lofted shots can be caught
+
+	OldBallOvers int // Overs it takes the new ball to wear out, 0 for a ball that doesn't change
+}
+
+// Mode is a way of playing the game, deciding how runs are scored, when the match ends
+// and anything extra to show on the HUD
+type Mode interface {
+	Name() string
+	Description() string
+	Rules() ModeRules
+
+	// RunsForHit is called every time the bat connects with a ball
+	RunsForHit(state MatchState) int
+
+	// End reports whether the match is over, and if so the message to show
+	End(state MatchState) (bool, string)
+
+	// HUD returns extra lines of text to show while playing
+	HUD(state MatchState) []string
+}
+
+// MaxBalls is how many legal balls the innings lasts with overs of the given length, 0 for
+// no limit
+func (r ModeRules) MaxBalls(overLength int) int {
+	if r.Balls > 0 {
+		return r.Balls
+	}
+	return r.Overs * overLength
+}
+
+// ModeFactory creates a mode, reading any settings it needs from the config
+type ModeFactory func(cfg *config.Config) Mode
+
+var (
+	modeRegistryLock sync.RWMutex
+	modeRegistry     = make(map[string]ModeFactory)
+)
+
+// RegisterMode makes a mode available under the given name, so that it can be picked in the
+// config. Registering a name twice replaces the earlier mode.
+func RegisterMode(name string, factory ModeFactory) {
+	modeRegistryLock.Lock()
+	defer modeRegistryLock.Unlock()
+
+	modeRegistry[strings.ToLower(name)] = factory
+}
+
+// ModeNames lists the names of all registered modes in alphabetical order
+func ModeNames() []string {
+	modeRegistryLock.RLock()
+	defer modeRegistryLock.RUnlock()
+
+	names := make([]string, 0, len(modeRegistry))
+	for name := range modeRegistry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// NewMode creates the registered mode with the given name
+func NewMode(name string, cfg *config.Config) (Mode, error) {
+	modeRegistryLock.RLock()
+	factory, ok := modeRegistry[strings.ToLower(name)]
+	modeRegistryLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown mode %q, expected one of %s", name, strings.Join(ModeNames(), ", "))
+	}
+
+	return factory(cfg), nil
+}
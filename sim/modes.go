@@ -0,0 +1,399 @@
+package sim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/meghashyamc/cricket2d/config"
+)
+
+const (
+	ModeEndless = "endless"
+	ModeOvers   = "overs"
+	ModeBlitz   = "blitz"
+	ModeChase   = "chase"
+	ModeSeason  = "season"
+	modeVersus  = "versus"
+)
+
+const (
+	gameEndMessageHitWicket    = "HIT WICKET!"
+	gameEndMessageBowled       = "BOWLED!"
+	gameEndMessageLBW          = "LBW!"
+	gameEndMessageCaughtBehind = "CAUGHT BEHIND!"
+	gameEndMessageCaught       = "CAUGHT!"
+)
+
+const (
+	defaultOvers       = 5
+	defaultChaseTarget = 20
+	oversModeWickets   = 3
+	chaseModeWickets   = 2
+	blitzSeconds       = 60
+	blitzPenalty       = 5
+	// Penalty runs go to the batsman, as the bowling side is the one being slow
+	slowOverRatePenalty = 5
+	// With no end to the innings, the ball is old after this many overs
+	endlessOldBallOvers = 10
+)
+
+func init() {
+	RegisterMode(ModeEndless, func(cfg *config.Config) Mode { return EndlessMode{} })
+	RegisterMode(ModeOvers, func(cfg *config.Config) Mode { return newOversMode(cfg) })
+	RegisterMode(ModeBlitz, func(cfg *config.Config) Mode { return blitzMode{} })
+	RegisterMode(ModeChase, func(cfg *config.Config) Mode { return newChaseMode(cfg) })
+	RegisterMode(ModeSeason, func(cfg *config.Config) Mode { return newSeasonMode(cfg) })
+	RegisterMode(modeVersus, func(cfg *config.Config) Mode { return newVersusMode(cfg) })
+}
+
+// dismissalMessage is the headline shown when a batsman gets out
+func dismissalMessage(how string) string {
+	switch how {
+	case HitWicket.String():
+		return gameEndMessageHitWicket
+	case LBW.String():
+		return gameEndMessageLBW
+	case CaughtBehind.String():
+		return gameEndMessageCaughtBehind
+	case Caught.String():
+		return gameEndMessageCaught
+	default:
+		return gameEndMessageBowled
+	}
+}
+
+// oldBallOvers is how long the ball takes to wear out in a limited overs innings, which is
+// the whole innings unless the config says otherwise
+func oldBallOvers(cfg *config.Config, inningsOvers int) int {
+	if overs := cfg.GetOldBallOvers(); overs > 0 {
+		return overs
+	}
+	return inningsOvers
+}
+
+// EndlessMode is the original game: bat until you are out
+type EndlessMode struct{}
+
+func (EndlessMode) Name() string { return ModeEndless }
+
+func (EndlessMode) Description() string {
+	return "Bat until you are out. Every hit is a run."
+}
+
+func (EndlessMode) Rules() ModeRules { return ModeRules{Wickets: 1, OldBallOvers: endlessOldBallOvers} }
+
+func (EndlessMode) RunsForHit(MatchState) int { return 1 }
+
+// Calibrates is true, as there is no limit on balls to be used up by the calibration balls
+func (EndlessMode) Calibrates() bool { return true }
+
+// FollowsSeed is true, as every ball of the match follows from its seed
+func (EndlessMode) FollowsSeed() bool { return true }
+
+func (EndlessMode) End(state MatchState) (bool, string) {
+	return state.AllOut, dismissalMessage(state.LastDismissal)
+}
+
+func (EndlessMode) HUD(MatchState) []string { return nil }
+
+// oversMode gives the batsman a fixed number of overs and a few wickets
+type oversMode struct {
+	overs          int
+	maxOverSeconds float64
+	oldBallOvers   int
+}
+
+func newOversMode(cfg *config.Config) oversMode {
+	overs := cfg.GetOvers()
+	if overs <= 0 {
+		overs = defaultOvers
+	}
+	return oversMode{overs: overs, maxOverSeconds: cfg.GetMaxOverSeconds(), oldBallOvers: oldBallOvers(cfg, overs)}
+}
+
+func (m oversMode) Name() string { return ModeOvers }
+
+func (m oversMode) Description() string {
+	return fmt.Sprintf("Score as much as you can in %d overs with %d wickets.", m.overs, oversModeWickets)
+}
+
+func (m oversMode) Rules() ModeRules {
+	return ModeRules{
+		Overs:               m.overs,
+		Wickets:             oversModeWickets,
+		HighScoreKey:        fmt.Sprintf("%s%d", ModeOvers, m.overs),
+		MaxOverSeconds:      m.maxOverSeconds,
+		SlowOverRatePenalty: slowOverRatePenalty,
+		Fielders:            true,
+		OldBallOvers:        m.oldBallOvers,
+	}
+}
+
+func (m oversMode) RunsForHit(MatchState) int { return 1 }
+
+func (m oversMode) PlaysWithTeam() bool { return true }
+
+// FollowsSeed is true, as every ball of the match follows from its seed and overs
+func (m oversMode) FollowsSeed() bool { return true }
+
+func (m oversMode) End(state MatchState) (bool, string) {
+	if state.AllOut {
+		return true, "ALL OUT!"
+	}
+	if state.BowlingDone {
+		return true, "INNINGS OVER!"
+	}
+	return false, ""
+}
+
+func (m oversMode) HUD(state MatchState) []string {
+	return []string{
+		fmt.Sprintf("Overs: %s/%d", FormatOvers(state.BallsBowled, state.BallsPerOver), m.overs),
+		fmt.Sprintf("Wickets: %d/%d", state.Wickets, oversModeWickets),
+	}
+}
+
+// blitzMode is a race against the clock where getting out costs runs instead of the innings
+type blitzMode struct{}
+
+func (blitzMode) Name() string { return ModeBlitz }
+
+func (blitzMode) Description() string {
+	return fmt.Sprintf("Score as much as you can in %d seconds. Getting out costs %d runs.", blitzSeconds, blitzPenalty)
+}
+
+func (blitzMode) Rules() ModeRules {
+	return ModeRules{DismissalPenalty: blitzPenalty, HighScoreKey: ModeBlitz}
+}
+
+func (blitzMode) RunsForHit(MatchState) int { return 1 }
+
+// FollowsSeed is true, as every ball of the match follows from its seed
+func (blitzMode) FollowsSeed() bool { return true }
+
+func (blitzMode) End(state MatchState) (bool, string) {
+	return state.ElapsedSeconds >= blitzSeconds, "TIME UP!"
+}
+
+func (blitzMode) HUD(state MatchState) []string {
+	return []string{
+		fmt.Sprintf("Time left: %ds", max(0, blitzSeconds-int(state.ElapsedSeconds))),
+		fmt.Sprintf("Dismissals: %d", state.Wickets),
+	}
+}
+
+// chaseMode sets a target to reach within a few overs
+type chaseMode struct {
+	target       int
+	overs        int
+	oldBallOvers int
+}
+
+func newChaseMode(cfg *config.Config) chaseMode {
+	target := cfg.GetChaseTarget()
+	if target <= 0 {
+		target = defaultChaseTarget
+	}
+	overs := cfg.GetOvers()
+	if overs <= 0 {
+		overs = defaultOvers
+	}
+	return chaseMode{target: target, overs: overs, oldBallOvers: oldBallOvers(cfg, overs)}
+}
+
+func (m chaseMode) Name() string { return ModeChase }
+
+func (m chaseMode) Description() string {
+	return fmt.Sprintf("Score %d in %d overs with %d wickets.", m.target, m.overs, chaseModeWickets)
+}
+
+func (m chaseMode) Rules() ModeRules {
+	return ModeRules{
+		Overs:        m.overs,
+		Wickets:      chaseModeWickets,
+		HighScoreKey: fmt.Sprintf("%s%d", ModeChase, m.target),
+		Fielders:     true,
+		OldBallOvers: m.oldBallOvers,
+	}
+}
+
+func (m chaseMode) RunsForHit(MatchState) int { return 1 }
+
+func (m chaseMode) End(state MatchState) (bool, string) {
+	if state.Score >= m.target {
+		return true, "TARGET CHASED!"
+	}
+	if state.AllOut || state.BowlingDone {
+		return true, "CHASE FAILED!"
+	}
+	return false, ""
+}
+
+func (m chaseMode) Won(state MatchState) bool { return state.Score >= m.target }
+
+func (m chaseMode) Target() int { return m.target }
+
+// Tied is true when the chase ends one run short, level with the opposition's score
+func (m chaseMode) Tied(state MatchState) bool {
+	return (state.AllOut || state.BowlingDone) && state.Score == m.target-1
+}
+
+func (m chaseMode) HUD(state MatchState) []string {
+	ballsLeft := m.overs*state.BallsPerOver - state.BallsBowled
+	return []string{
+		fmt.Sprintf("Target: %d", m.target),
+		fmt.Sprintf("Need %d off %d balls", max(0, m.target-state.Score), max(0, ballsLeft)),
+		fmt.Sprintf("Wickets: %d/%d", state.Wickets, chaseModeWickets),
+	}
+}
+
+// seasonMode is a chase against the next side on the season's fixture list. The opposition
+// always bats first, so the game sets up each match once it knows who they are.
+type seasonMode struct {
+	overs        int
+	oldBallOvers int
+	number       int // Of the season
+	round        int
+	opponent     string // Empty until a fixture has been set up
+	target       int
+}
+
+func newSeasonMode(cfg *config.Config) seasonMode {
+	overs := cfg.GetOvers()
+	if overs <= 0 {
+		overs = defaultOvers
+	}
+	return seasonMode{overs: overs, oldBallOvers: oldBallOvers(cfg, overs)}
+}
+
+func (m seasonMode) Name() string { return ModeSeason }
+
+func (m seasonMode) Description() string {
+	if len(m.opponent) == 0 {
+		return "Play a season of chases against the league's AI sides."
+	}
+	return fmt.Sprintf("Chase %s's %d in %d overs with %d wickets.", m.opponent, m.target-1, m.overs, chaseModeWickets)
+}
+
+func (m seasonMode) Rules() ModeRules {
+	return ModeRules{Overs: m.overs, Wickets: chaseModeWickets, HighScoreKey: ModeSeason, Fielders: true, OldBallOvers: m.oldBallOvers}
+}
+
+func (m seasonMode) RunsForHit(MatchState) int { return 1 }
+
+func (m seasonMode) PlaysWithTeam() bool { return true }
+
+func (m seasonMode) SeasonOverCount() int { return m.overs }
+
+func (m seasonMode) Fixture(number, round int, opponent string, target int) Mode {
+	m.number, m.round, m.opponent, m.target = number, round, opponent, target
+	return m
+}
+
+func (m seasonMode) End(state MatchState) (bool, string) {
+	if state.Score >= m.target {
+		return true, "WON!"
+	}
+	if state.AllOut || state.BowlingDone {
+		return true, "LOST!"
+	}
+	return false, ""
+}
+
+func (m seasonMode) Won(state MatchState) bool { return state.Score >= m.target }
+
+func (m seasonMode) Target() int { return m.target }
+
+// Tied is true when the chase ends level with the opposition's score
+func (m seasonMode) Tied(state MatchState) bool {
+	return (state.AllOut || state.BowlingDone) && state.Score == m.target-1
+}
+
+func (m seasonMode) HUD(state MatchState) []string {
+	ballsLeft := m.overs*state.BallsPerOver - state.BallsBowled
+	return []string{
+		fmt.Sprintf("Season %d, round %d v %s", m.number, m.round, m.opponent),
+		fmt.Sprintf("Target: %d", m.target),
+		fmt.Sprintf("Need %d off %d balls", max(0, m.target-state.Score), max(0, ballsLeft)),
+		fmt.Sprintf("Wickets: %d/%d", state.Wickets, chaseModeWickets),
+	}
+}
+
+// versusMode is a hot-seat match between two players. The first sets a score in their overs
+// and the second then chases it.
+type versusMode struct {
+	overs        int
+	oldBallOvers int
+	batting      string // The player at the crease
+	setter       string // The player who batted first, once the second innings is under way
+	target       int    // 0 in the first innings
+}
+
+func newVersusMode(cfg *config.Config) versusMode {
+	overs := cfg.GetOvers()
+	if overs <= 0 {
+		overs = defaultOvers
+	}
+	return versusMode{overs: overs, oldBallOvers: oldBallOvers(cfg, overs)}
+}
+
+func (m versusMode) Name() string { return modeVersus }
+
+func (m versusMode) Description() string {
+	return fmt.Sprintf("Two players take turns to bat for %d overs. The higher score wins.", m.overs)
+}
+
+func (m versusMode) Rules() ModeRules {
+	return ModeRules{Overs: m.overs, Wickets: oversModeWickets, HighScoreKey: modeVersus, Fielders: true, OldBallOvers: m.oldBallOvers}
+}
+
+func (m versusMode) RunsForHit(MatchState) int { return 1 }
+
+func (m versusMode) End(state MatchState) (bool, string) {
+	if m.target > 0 && state.Score >= m.target {
+		return true, strings.ToUpper(m.batting) + " WINS!"
+	}
+	if state.AllOut || state.BowlingDone {
+		switch {
+		case m.target == 0:
+			return true, "INNINGS OVER!"
+		case state.Score == m.target-1:
+			return true, "MATCH TIED!"
+		default:
+			return true, strings.ToUpper(m.setter) + " WINS!"
+		}
+	}
+	return false, ""
+}
+
+// Won is true when the second player has chased down the first player's score
+func (m versusMode) Won(state MatchState) bool { return m.target > 0 && state.Score >= m.target }
+
+// Target is the first player's score and one more in the second innings, and 0 in the first
+func (m versusMode) Target() int { return m.target }
+
+func (m versusMode) Innings(batting, setter string, target int) Mode {
+	m.batting, m.setter, m.target = batting, setter, target
+	return m
+}
+
+func (m versusMode) Batting() string { return m.batting }
+
+func (m versusMode) HUD(state MatchState) []string {
+	lines := []string{fmt.Sprintf("Batting: %s", m.batting)}
+	if m.target > 0 {
+		ballsLeft := m.overs*state.BallsPerOver - state.BallsBowled
+		lines = append(lines,
+			fmt.Sprintf("Target: %d", m.target),
+			fmt.Sprintf("Need %d off %d balls", max(0, m.target-state.Score), max(0, ballsLeft)))
+	} else {
+		lines = append(lines, fmt.Sprintf("Overs: %s/%d", FormatOvers(state.BallsBowled, state.BallsPerOver), m.overs))
+	}
+	return append(lines, fmt.Sprintf("Wickets: %d/%d", state.Wickets, oversModeWickets))
+}
+
+// FormatOvers writes a number of balls the way cricket scoreboards do, e.g. 2.3 for
+// two overs and three balls
+func FormatOvers(balls, overLength int) string {
+	return fmt.Sprintf("%d.%d", balls/overLength, balls%overLength)
+}
@@ -0,0 +1,38 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/mods"
+)
+
+// fireModEvent lets the loaded mods react to something that just happened on the field
+func (w *World) fireModEvent(event mods.Event) {
+	if len(w.ActiveMods) == 0 {
+		return
+	}
+
+	action := mods.Fire(w.ActiveMods, event, mods.State{
+		Score:       w.Score,
+		Hits:        w.hits,
+		BallsBowled: w.LegalBalls(),
+		BallsInPlay: len(w.Balls),
+	})
+
+	w.Score = max(w.Score+action.AddScore, 0)
+
+	// Balls spawned by mods don't fire spawn events themselves, so mods can't loop forever
+	if event != mods.EventDismissal {
+		for range action.SpawnBalls {
+			delivery, _ := NewRandomBowler(w.Preset, w.RNG.Extras).NextDelivery(w.Innings)
+			w.Balls = append(w.Balls, NewBall(w.Width, w.Height, delivery, w.Gravity()))
+		}
+	}
+
+	if len(action.Message) > 0 {
+		w.Announce(action.Message)
+	}
+
+	if logger.DebugEnabled() {
+		w.logger.Debug("mods fired", "event", event, "spawn_balls", action.SpawnBalls, "add_score", action.AddScore, "message", action.Message)
+	}
+}
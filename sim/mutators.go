@@ -0,0 +1,82 @@
+package sim
+
+// Mutator is a just-for-fun change to the physics, picked before the match
+type Mutator string
+
+const (
+	mutatorLowGravity     Mutator = "low gravity"
+	mutatorGiantBall      Mutator = "giant ball"
+	mutatorTinyStumps     Mutator = "tiny stumps"
+	mutatorDoubleSpawn    Mutator = "double spawn rate"
+	mutatorBouncyBoundary Mutator = "bouncy boundaries"
+)
+
+const (
+	lowGravityFactor = 0.4 // Of the preset's gravity
+	giantBallScale   = 2.5
+	tinyStumpsScale  = 0.5
+)
+
+// AllMutators lists the mutators in the order the mutators screen shows them
+var AllMutators = []struct {
+	Mutator     Mutator
+	Description string
+}{
+	{mutatorLowGravity, "Balls hang in the air"},
+	{mutatorGiantBall, "A ball you can't miss, or can you?"},
+	{mutatorTinyStumps, "Half as much to defend"},
+	{mutatorDoubleSpawn, "Deliveries come twice as often"},
+	{mutatorBouncyBoundary, "Shots bounce back off the boundary"},
+}
+
+// Mutators are the mutators in play
+type Mutators map[Mutator]bool
+
+// Names lists the mutators in play, in the order the mutators screen shows them
+func (m Mutators) Names() []string {
+	names := make([]string, 0, len(m))
+	for _, entry := range AllMutators {
+		if m[entry.Mutator] {
+			names = append(names, string(entry.Mutator))
+		}
+	}
+	return names
+}
+
+// Any is true if at least one mutator is in play
+func (m Mutators) Any() bool {
+	return len(m.Names()) > 0
+}
+
+// SetMutators changes the physics of the field to suit the mutators picked for the match
+func (w *World) SetMutators(m Mutators) {
+	w.Mutators = m
+
+	w.Stumps = newStumps(w.Height)
+	if m[mutatorTinyStumps] {
+		w.Stumps.resize(tinyStumpsScale)
+	}
+
+	w.SizeBall()
+}
+
+// SizeBall picks the size of new balls: giant for the mutator, bigger in kid mode and
+// otherwise the usual size
+func (w *World) SizeBall() {
+	switch {
+	case w.Mutators[mutatorGiantBall]:
+		w.ballScale = giantBallScale
+	case w.Kid:
+		w.ballScale = kidBallScale
+	default:
+		w.ballScale = 1
+	}
+}
+
+// Gravity is what pulls the ball down on every tick
+func (w *World) Gravity() float64 {
+	if w.Mutators[mutatorLowGravity] {
+		return w.Preset.Ball.Gravity * lowGravityFactor
+	}
+	return w.Preset.Ball.Gravity
+}
@@ -0,0 +1,101 @@
+package sim
+
+import (
+	"math/rand/v2"
+
+	"github.com/meghashyamc/cricket2d/logger"
+)
+
+const (
+	pitchCracks    = 6    // Cracks that open up over the life of a pitch
+	pitchLifeOvers = 10   // Overs a pitch lasts before it is fully worn, in modes without an overs limit
+	PitchStart     = 0.3  // Nearest a delivery pitches to the stumps, as a fraction of the view width
+	PitchEnd       = 0.8  // Furthest a delivery pitches from the stumps
+	minCrackWidth  = 10.0 // Pixels of pitch a crack covers
+	maxCrackWidth  = 30.0
+	crackDeviation = 1.2 // Most a crack changes the ball's climb, in pixels per tick, on an ordinary pitch
+)
+
+// Crack is a break in the pitch that sends a ball landing on it off unpredictably
+type Crack struct {
+	X       float64 // Centre of the crack
+	Width   float64
+	OpensAt float64 // How worn the pitch has to be before the crack opens, from 0 to 1
+}
+
+// Pitch is the strip the ball is bowled on. It wears over the match, opening cracks.
+type Pitch struct {
+	Cracks []Crack
+	balls  int // Deliveries bowled on the pitch so far
+}
+
+// newPitch lays a fresh pitch across a view of the given width, with cracks that open at
+// evenly spread points in its life
+func newPitch(width float64, rng *rand.Rand) *Pitch {
+	p := &Pitch{Cracks: make([]Crack, 0, pitchCracks)}
+	for i := range pitchCracks {
+		p.Cracks = append(p.Cracks, Crack{
+			X:       width * (PitchStart + (PitchEnd-PitchStart)*rng.Float64()),
+			Width:   minCrackWidth + (maxCrackWidth-minCrackWidth)*rng.Float64(),
+			OpensAt: (float64(i) + rng.Float64()) / pitchCracks,
+		})
+	}
+
+	return p
+}
+
+// RelayPitch puts down a fresh pitch for a new match
+func (w *World) RelayPitch() {
+	w.Pitch = newPitch(w.Width, w.RNG.pitch)
+}
+
+// PitchWear is how far the pitch has deteriorated, from 0 for a fresh pitch to 1 for one
+// with every crack open. A pitch lasts the mode's overs, or pitchLifeOvers if it has no limit.
+func (w *World) PitchWear() float64 {
+	life := w.MaxBalls
+	if life <= 0 {
+		life = pitchLifeOvers * w.Rules.BallsPerOver
+	}
+	return min(float64(w.Pitch.balls)*w.Preset.Pitch.Deterioration/float64(life), 1)
+}
+
+// openCrackAt returns the open crack under the given point on the pitch, if there is one
+func (w *World) openCrackAt(x float64) (Crack, bool) {
+	wear := w.PitchWear()
+	for _, c := range w.Pitch.Cracks {
+		if c.OpensAt <= wear && x >= c.X-c.Width/2 && x <= c.X+c.Width/2 {
+			return c, true
+		}
+	}
+
+	return Crack{}, false
+}
+
+// pitchBall bowls a new delivery onto the pitch, choosing where it will land
+func (w *World) pitchBall(b *Ball) {
+	w.Pitch.balls++
+	b.PitchX = w.Width * (PitchStart + (PitchEnd-PitchStart)*w.RNG.pitch.Float64())
+}
+
+// checkPitching sends a ball off line if it lands on an open crack. Cracks can move the ball
+// either way, so the batsman can't simply allow for them.
+func (w *World) checkPitching(b *Ball) {
+	if b.pitched || b.IsHit {
+		return
+	}
+
+	center, _ := b.CenterAndRadius()
+	if center.X > b.PitchX {
+		return
+	}
+	b.pitched = true
+
+	if _, ok := w.openCrackAt(b.PitchX); !ok {
+		return
+	}
+	deviation := crackDeviation * w.Preset.Pitch.Deterioration * (2*w.RNG.pitch.Float64() - 1)
+	b.Velocity.Y += deviation
+	if logger.DebugEnabled() {
+		w.logger.Debug("ball hit a crack", "pitch_x", b.PitchX, "deviation", deviation)
+	}
+}
@@ -0,0 +1,98 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/team"
+)
+
+// ReplayInput is a tick's bat input as replays keep it
+func ReplayInput(input BatInput) replay.Input {
+	return replay.Input{X: input.Cursor.X, Y: input.Cursor.Y, Drag: input.Dragging, Block: input.Blocking}
+}
+
+// RecordedInput is the bat input kept for a tick of a replay
+func RecordedInput(input replay.Input) BatInput {
+	return BatInput{Cursor: geometry.Vector{X: input.X, Y: input.Y}, Dragging: input.Drag, Blocking: input.Block}
+}
+
+// ReplayControl is the bat input kept for a tick of a replay, as a simulation is stepped
+// with it
+func ReplayControl(input replay.Input) ControlInput {
+	return ControlInput{X: input.X, Y: input.Y, Drag: input.Drag, Block: input.Block}
+}
+
+// ReplayRules are the rules the field is being played by, for a replay of the match. The
+// settings the field was made with, such as the overs and the bowling, are left for the
+// caller to fill in.
+func (w *World) ReplayRules() replay.Rules {
+	rules := replay.Rules{
+		View:         geometry.Vector{X: w.Width, Y: w.Height},
+		Field:        geometry.Vector{X: w.Field.Width, Y: w.Field.Height},
+		Ball:         sizes.Ball,
+		Bat:          sizes.Bat,
+		Stumps:       sizes.Stumps,
+		DragRight:    w.DragArea.Right,
+		DragUp:       w.DragArea.Up,
+		DragDown:     w.DragArea.Down,
+		BallsPerOver: w.Rules.BallsPerOver,
+		Wides:        w.Rules.Wides,
+		NoBalls:      w.Rules.NoBalls,
+		GroundRuns:   w.Rules.GroundRuns,
+		LoftedRuns:   w.Rules.LoftedRuns,
+		Mutators:     w.Mutators.Names(),
+		Kid:          w.Kid,
+		ChaosOvers:   w.ChaosOvers,
+		FreeHits:     w.FreeHits,
+		TargetRings:  w.TargetRings,
+	}
+	if w.hasBatHome {
+		rules.BatHome = w.batHome
+	}
+	if w.ArenaWalls != 0 {
+		rules.Arena = arenaBouncy
+	}
+	for _, b := range w.Lineup {
+		rules.Lineup = append(rules.Lineup, replay.Batsman{Name: b.Name, Power: b.Power, Timing: b.Timing, BatSize: b.BatSize})
+	}
+	return rules
+}
+
+// PlayBy sets the field up by the rules a replay was saved with. The field has to have
+// been made at the size of the replay's view, with its sizes in place. Kid mode is left to
+// the caller, as it plays with a preset of its own.
+func (w *World) PlayBy(r *replay.Rules) {
+	w.SetField(r.Field.X, r.Field.Y)
+	w.DragArea = DragArea{Right: r.DragRight, Up: r.DragUp, Down: r.DragDown}
+	w.ArenaWalls = ArenaWalls(r.Arena)
+	w.ChaosOvers = r.ChaosOvers
+	w.FreeHits = r.FreeHits
+	w.TargetRings = r.TargetRings
+
+	mutators := make(Mutators)
+	for _, name := range r.Mutators {
+		mutators[Mutator(name)] = true
+	}
+	w.SetMutators(mutators)
+	w.SetHouseRules(HouseRules{
+		BallsPerOver: r.BallsPerOver,
+		Wides:        r.Wides,
+		NoBalls:      r.NoBalls,
+		GroundRuns:   r.GroundRuns,
+		LoftedRuns:   r.LoftedRuns,
+	})
+
+	lineup := make([]team.Batsman, 0, len(r.Lineup))
+	for _, b := range r.Lineup {
+		lineup = append(lineup, team.Batsman{Name: b.Name, Power: b.Power, Timing: b.Timing, BatSize: b.BatSize})
+	}
+	w.SetLineup(lineup)
+	if r.BatHome != (geometry.Vector{}) {
+		w.SetBatHome(r.BatHome)
+	} else {
+		// The match started with the bat where it is first put, not where this player
+		// last left one
+		w.hasBatHome = false
+		w.Bat = w.newBatAtHome()
+	}
+}
@@ -0,0 +1,88 @@
+package sim
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/replay"
+	"github.com/meghashyamc/cricket2d/team"
+)
+
+const replayBalls = 30
+
+// playBack sets a field up by a replay and plays its inputs back, as the game does when a
+// replay is watched
+func playBack(t *testing.T, r *replay.Replay) *World {
+	t.Helper()
+	preset, err := difficulty.Load(r.Header.Difficulty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := r.Header.Rules
+	rng := NewMatchRand()
+	rng.Fix(r.Header.Seed)
+	w := NewWorld(rules.View.X, rules.View.Y, preset, NewRandomBowler(preset, rng.Deliveries), EndlessMode{}, DragArea{}, rng)
+	w.PlayBy(rules)
+	w.Reset()
+	w.RelayPitch()
+	for _, input := range r.Inputs {
+		w.Update(RecordedInput(input))
+	}
+	return w
+}
+
+// TestReplayPlaysBack records an innings played by rules other than the usual ones, saves
+// it, and checks that playing the saved replay back finishes on the same score
+func TestReplayPlaysBack(t *testing.T) {
+	w := newBenchWorld(t)
+	w.RNG.Fix(0xBA7)
+	w.SetField(benchWidth*2, benchHeight*2)
+	w.DragArea = DragArea{Right: 300, Up: 150, Down: 150}
+	w.FreeHits = true
+	w.ArenaWalls = ArenaWalls(arenaBouncy)
+	w.SetMutators(Mutators{mutatorLowGravity: true, mutatorTinyStumps: true})
+	w.SetHouseRules(HouseRules{BallsPerOver: 5, Wides: true, NoBalls: true, GroundRuns: 2, LoftedRuns: 4})
+	w.SetLineup([]team.Batsman{
+		{Name: "Slugger", Power: 1.3, Timing: 0.8, BatSize: 1.4},
+		{Name: "Tailender", Power: 0.7, Timing: 0.6, BatSize: 0.8},
+	})
+	w.SetBatHome(geometry.Vector{X: 220, Y: 330})
+	w.Reset()
+	w.RelayPitch()
+
+	recorded := replay.New(ModeEndless, w.Preset.Name, w.RNG.Seed, w.ReplayRules())
+	bot := NewBotBatsman(0.6)
+	for range replayBalls * maxSimulationTicksPerBall {
+		if w.allOut || w.LegalBalls() >= replayBalls {
+			break
+		}
+		input := bot.Input(w)
+		recorded.Record(ReplayInput(input))
+		w.Update(input)
+	}
+	recorded.Header.Score = w.Score
+	if w.hits == 0 {
+		t.Fatal("nothing was hit, so the shots weren't checked")
+	}
+
+	var buf bytes.Buffer
+	if err := replay.Encode(&buf, recorded); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := replay.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	played := playBack(t, saved)
+	if played.Score != saved.Header.Score || played.wickets != w.wickets || played.LegalBalls() != w.LegalBalls() {
+		t.Errorf("played back %d for %d off %d balls, recorded %d for %d off %d", played.Score, played.wickets,
+			played.LegalBalls(), saved.Header.Score, w.wickets, w.LegalBalls())
+	}
+	if !reflect.DeepEqual(played.Innings.Events(), w.Innings.Events()) {
+		t.Error("the replay played out a different innings")
+	}
+}
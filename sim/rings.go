@@ -0,0 +1,98 @@
+package sim
+
+import (
+	"fmt"
+
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+)
+
+const (
+	ringChance     = 0.2 // That a ring goes up with each delivery
+	maxRings       = 2
+	RingRadius     = 40
+	ringTicks      = 8 * TPS // How long a ring stays up
+	ringDriftSpeed = 1.0     // Pixels a ring drifts up or down in a tick
+	ringTop        = 0.1     // Highest a ring goes, as a fraction of the view height
+	ringBottom     = 0.5     // Lowest
+	ringLeft       = 0.4     // Nearest a ring is to the batsman, as a fraction of the view width
+	ringRight      = 0.9
+)
+
+// Ring is a target floating in the air. A lofted shot through it scores double.
+type Ring struct {
+	Center    geometry.Vector
+	velocity  geometry.Vector
+	ticksLeft int
+}
+
+// raiseRing sometimes puts up a ring as a ball is bowled, if target rings are on
+func (w *World) raiseRing() {
+	if !w.TargetRings || len(w.Rings) >= maxRings || w.RNG.Extras.Float64() >= ringChance {
+		return
+	}
+
+	drift := ringDriftSpeed
+	if w.RNG.Extras.IntN(2) == 0 {
+		drift = -drift
+	}
+	w.Rings = append(w.Rings, &Ring{
+		Center: geometry.Vector{
+			X: w.Width * (ringLeft + (ringRight-ringLeft)*w.RNG.Extras.Float64()),
+			Y: w.Height * (ringTop + (ringBottom-ringTop)*w.RNG.Extras.Float64()),
+		},
+		velocity:  geometry.Vector{Y: drift},
+		ticksLeft: ringTicks,
+	})
+}
+
+// updateRings drifts the rings up and down, taking them down once their time is up
+func (w *World) updateRings() {
+	up := w.Rings[:0]
+	for _, r := range w.Rings {
+		r.ticksLeft--
+		if r.ticksLeft <= 0 {
+			continue
+		}
+		r.Center = r.Center.Add(r.velocity)
+		if r.Center.Y < w.Height*ringTop || r.Center.Y > w.Height*ringBottom {
+			r.velocity.Y = -r.velocity.Y
+		}
+		up = append(up, r)
+	}
+	w.Rings = up
+}
+
+// checkRings doubles the runs of a lofted shot that has gone through a ring since the last
+// tick
+func (w *World) checkRings(b *Ball) {
+	if !b.lofted || b.throughRing || len(b.Path) < 2 {
+		return
+	}
+
+	from, to := b.Path[len(b.Path)-2], b.Path[len(b.Path)-1]
+	for i, r := range w.Rings {
+		if geometry.DistanceFromPointToSegment(r.Center, from, to) > RingRadius {
+			continue
+		}
+
+		b.throughRing = true
+		w.Score += b.runs
+		w.Announce(fmt.Sprintf("Through the ring! Runs doubled, +%d", b.runs))
+		w.Events = append(w.Events, EventRingBonus)
+		if logger.DebugEnabled() {
+			w.logger.Debug("shot went through a ring", "ring", r.Center, "bonus", b.runs)
+		}
+		b.runs *= 2
+		w.Rings = append(w.Rings[:i], w.Rings[i+1:]...)
+		return
+	}
+}
+
+// RingsHUD tells the batsman there is a ring up to aim for
+func (w *World) RingsHUD() (string, bool) {
+	if len(w.Rings) == 0 {
+		return "", false
+	}
+	return "Ring up! Loft a shot through it for double runs", true
+}
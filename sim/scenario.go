@@ -0,0 +1,77 @@
+package sim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/meghashyamc/cricket2d/scenario"
+)
+
+const (
+	modeScenario               = "scenario"
+	gameEndMessageScenarioDone = "SCENARIO COMPLETE!"
+)
+
+// ScenarioMode plays out a scenario, ending as soon as it is passed or failed
+type ScenarioMode struct {
+	Scenario *scenario.Scenario
+}
+
+func (m ScenarioMode) Name() string { return modeScenario }
+
+func (m ScenarioMode) Description() string { return m.Scenario.Description }
+
+func (m ScenarioMode) Rules() ModeRules {
+	return ModeRules{Balls: m.Scenario.Balls, Wickets: m.Scenario.Wickets, HighScoreKey: modeScenario, Fielders: true}
+}
+
+func (m ScenarioMode) RunsForHit(MatchState) int { return 1 }
+
+func (m ScenarioMode) End(state MatchState) (bool, string) {
+	switch result, reason := m.Scenario.Check(ScenarioProgress(state)); result {
+	case scenario.Passed:
+		return true, gameEndMessageScenarioDone
+	case scenario.Failed:
+		return true, strings.ToUpper(reason) + "!"
+	default:
+		return false, ""
+	}
+}
+
+func (m ScenarioMode) Won(state MatchState) bool {
+	result, _ := m.Scenario.Check(ScenarioProgress(state))
+	return result == scenario.Passed
+}
+
+func (m ScenarioMode) HUD(state MatchState) []string {
+	s := m.Scenario
+	lines := []string{"Scenario: " + s.Name}
+	ballsLeft := s.Balls - state.BallsBowled
+	switch {
+	case s.Goal.Runs > 0 && s.Balls > 0:
+		lines = append(lines, fmt.Sprintf("Need %d off %d balls", max(0, s.Goal.Runs-state.Score), max(0, ballsLeft)))
+	case s.Goal.Runs > 0:
+		lines = append(lines, fmt.Sprintf("Need %d", max(0, s.Goal.Runs-state.Score)))
+	case s.Goal.Survive:
+		lines = append(lines, fmt.Sprintf("Survive %d more balls", max(0, ballsLeft)))
+	}
+	if s.Goal.Lofted > 0 {
+		lines = append(lines, fmt.Sprintf("Lofted shots: %d/%d", state.Lofted, s.Goal.Lofted))
+	}
+	if s.Rules.NoLofting {
+		lines = append(lines, "No lofted shots!")
+	}
+	lines = append(lines, fmt.Sprintf("Wickets left: %d", max(0, s.Wickets-state.Wickets)))
+
+	return lines
+}
+
+func ScenarioProgress(state MatchState) scenario.Progress {
+	return scenario.Progress{
+		Runs:        state.Score,
+		Lofted:      state.Lofted,
+		Wickets:     state.Wickets,
+		Balls:       state.BallsBowled,
+		BowlingDone: state.BowlingDone,
+	}
+}
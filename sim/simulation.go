@@ -0,0 +1,79 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/config"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	ballsPerOver = 6
+
+	// An innings that is still going after this many ticks per ball allowed is stopped
+	maxSimulationTicksPerBall = 20 * TPS
+)
+
+// InningsResult summarises an innings that was played headlessly
+type InningsResult struct {
+	Score       int
+	BallsBowled int
+	Dismissal   string
+	RunsPerOver []int
+	Timing      map[stats.Timing]int // How the shots were timed
+}
+
+// SimulateInnings plays a single innings without a window, with a bot batsman of the given
+// skill (0 to 1) holding the bat against balls bowled according to the preset. The innings
+// ends on a dismissal or once maxBalls have been bowled and dealt with.
+func SimulateInnings(cfg *config.Config, preset *difficulty.Preset, skill float64, maxBalls int) (InningsResult, error) {
+	rng := NewMatchRand()
+	bowler, err := NewBowler(cfg, preset, rng)
+	if err != nil {
+		return InningsResult{}, err
+	}
+
+	w := NewWorld(cfg.GetWindowWidth(), cfg.GetWindowHeight(), preset, bowler, EndlessMode{},
+		NewDragArea(cfg, cfg.GetWindowWidth(), cfg.GetWindowHeight()), rng)
+	w.MaxBalls = maxBalls
+	bot := NewBotBatsman(skill)
+
+	result := InningsResult{
+		RunsPerOver: make([]int, 0, maxBalls/ballsPerOver+1),
+	}
+
+	for tick := 0; tick < maxBalls*maxSimulationTicksPerBall; tick++ {
+		if w.BowlingComplete() {
+			break
+		}
+
+		scoreBefore := w.Score
+		w.Update(bot.Input(w))
+
+		if runs := w.Score - scoreBefore; runs > 0 && w.LegalBalls() > 0 {
+			over := (w.LegalBalls() - 1) / ballsPerOver
+			for len(result.RunsPerOver) <= over {
+				result.RunsPerOver = append(result.RunsPerOver, 0)
+			}
+			result.RunsPerOver[over] += runs
+		}
+
+		if w.allOut {
+			break
+		}
+	}
+
+	// Overs in which nothing was scored still count towards the run rate
+	for len(result.RunsPerOver)*ballsPerOver < w.LegalBalls() {
+		result.RunsPerOver = append(result.RunsPerOver, 0)
+	}
+
+	result.Score = w.Score
+	result.BallsBowled = w.LegalBalls()
+	result.Timing = w.Innings.TimingDistribution()
+	result.Dismissal = notOut.String()
+	if w.allOut {
+		result.Dismissal = w.Dismissal.String()
+	}
+
+	return result, nil
+}
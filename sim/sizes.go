@@ -0,0 +1,36 @@
+package sim
+
+import (
+	"math"
+
+	"github.com/meghashyamc/cricket2d/geometry"
+)
+
+// Sizes are how big the ball, bat and stumps are at their usual scale, in pixels, which is
+// what collisions are worked out from. Every copy of a match has to be played at the same
+// sizes to play out the same.
+type Sizes struct {
+	Ball   geometry.Vector
+	Bat    geometry.Vector
+	Stumps geometry.Vector
+}
+
+// DefaultSizes are the sizes of the built-in sprites
+var DefaultSizes = Sizes{
+	Ball:   geometry.Vector{X: 93, Y: 77},
+	Bat:    geometry.Vector{X: 58, Y: 387},
+	Stumps: geometry.Vector{X: 77, Y: 347},
+}
+
+var sizes = DefaultSizes
+
+// SetSizes changes how big the ball, bat and stumps are, such as to the size of the sprites
+// they are drawn with
+func SetSizes(s Sizes) {
+	sizes = s
+}
+
+// scaledSize is a size scaled, in whole pixels as a sprite drawn at it would be
+func scaledSize(size geometry.Vector, scale float64) geometry.Vector {
+	return geometry.Vector{X: max(math.Floor(size.X*scale), 1), Y: max(math.Floor(size.Y*scale), 1)}
+}
@@ -0,0 +1,24 @@
+package sim
+
+import (
+	"math"
+)
+
+const (
+	spinTurns     = 3.0  // Turns a second a spinner at full skill puts on the ball
+	paceSeamTurns = -0.6 // Turns a second of the seam of a pace bowler's ball, spinning back towards them
+)
+
+// SpinStep is how far, in radians, a delivery's spin turns the ball in a tick
+func SpinStep(spin float64) float64 {
+	return 2 * math.Pi * spin / float64(TPS)
+}
+
+// turn spins the ball on a tick, until it is hit, so that the seam shows how hard it is
+// turning before it pitches
+func (b *Ball) turn() {
+	if b.IsHit || b.Delivery.Spin == 0 {
+		return
+	}
+	b.Angle = math.Mod(b.Angle+SpinStep(b.Delivery.Spin), 2*math.Pi)
+}
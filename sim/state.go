@@ -0,0 +1,54 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/geometry"
+)
+
+// ControlState is the game as the control API reports it
+type ControlState struct {
+	State       string          `json:"state"` // playing, paused or game_over
+	Mode        string          `json:"mode"`
+	Tick        int             `json:"tick"`
+	Score       int             `json:"score"`
+	Wickets     int             `json:"wickets"`
+	BallsBowled int             `json:"balls_bowled"`
+	Bat         geometry.Vector `json:"bat"`
+	Stumps      geometry.Vector `json:"stumps"`
+	Balls       []ControlBall   `json:"balls"`
+}
+
+// ControlBall is a ball in play
+type ControlBall struct {
+	Position geometry.Vector `json:"position"`
+	Velocity geometry.Vector `json:"velocity"`
+	Hit      bool            `json:"hit"`
+}
+
+// ControlInput moves the bat as the mouse would, for the given number of ticks
+type ControlInput struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Drag  bool    `json:"drag"`  // As if the left button were held
+	Block bool    `json:"block"` // Play a defensive shot
+	Ticks int     `json:"ticks"` // 1 if left out
+}
+
+// ControlState is the field as the control API reports it, while it is being played
+func (w *World) ControlState() ControlState {
+	match := w.MatchState()
+	state := ControlState{
+		State:       "playing",
+		Mode:        w.Mode.Name(),
+		Tick:        w.Ticks,
+		Score:       w.Score,
+		Wickets:     match.Wickets,
+		BallsBowled: match.BallsBowled,
+		Bat:         w.Bat.Position,
+		Stumps:      w.Stumps.Position,
+		Balls:       make([]ControlBall, 0, len(w.Balls)),
+	}
+	for _, b := range w.Balls {
+		state.Balls = append(state.Balls, ControlBall{Position: b.Position, Velocity: b.Velocity, Hit: b.IsHit})
+	}
+	return state
+}
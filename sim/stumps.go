@@ -0,0 +1,89 @@
+package sim
+
+import (
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+)
+
+const (
+	initialstumpsX        = 30
+	initialstumpsYPercent = 0.9 // Percentage of screen height (starting from top) where stumps are placed
+)
+
+type Stumps struct {
+	Position geometry.Vector
+	Scale    float64 // Of the usual size of the stumps
+	IsFallen bool
+	logger   logger.Logger
+}
+
+func newStumps(screenHeight float64) *Stumps {
+	// Position stumps on the left side of screen, closer to the bottom
+	pos := geometry.Vector{
+		X: initialstumpsX,
+		Y: initialstumpsYPercent * (screenHeight - sizes.Stumps.Y),
+	}
+
+	stumps := &Stumps{
+		Position: pos,
+		Scale:    1,
+		IsFallen: false,
+		logger:   logger.New(),
+	}
+
+	stumps.logger.Debug("stumps created", "position", pos, "size", sizes.Stumps)
+	return stumps
+}
+
+func (s *Stumps) CheckCollision(ball *Ball, bat *Bat) bool {
+	if s.IsFallen {
+		return false
+	}
+
+	var ballCollided, batCollided bool
+	if ball != nil && ball.Active {
+		ballCollided = ball.collidesWith(s)
+	}
+
+	if bat != nil {
+		batCollided = bat.collidesWith(s)
+	}
+
+	return ballCollided || batCollided
+}
+
+func (s *Stumps) fall() {
+	if logger.DebugEnabled() {
+		s.logger.Debug("stumps falling")
+	}
+	s.IsFallen = true
+}
+
+func (s *Stumps) reset() {
+	if logger.DebugEnabled() {
+		s.logger.Debug("stumps reset")
+	}
+	s.IsFallen = false
+}
+
+// resize scales the stumps, keeping them planted where they stand
+func (s *Stumps) resize(scale float64) {
+	bottom := s.Position.Y + s.size().Y
+	s.Scale *= scale
+	s.Position.Y = bottom - s.size().Y
+}
+
+// size is how big the stumps are, at their scale
+func (s *Stumps) size() geometry.Vector {
+	return scaledSize(sizes.Stumps, s.Scale)
+}
+
+func (s *Stumps) GetBounds() geometry.Rect {
+	size := s.size()
+	return geometry.NewRect(
+		s.Position.X,
+		s.Position.Y,
+		size.X,
+		size.Y,
+	)
+}
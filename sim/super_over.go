@@ -0,0 +1,102 @@
+package sim
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+const (
+	modeSuperOver      = "super over"
+	superOverWickets   = 2
+	superOverMinRuns   = 3 // Fewest runs the opposition can set in their super over
+	superOverMaxRuns   = 8 // Most runs the opposition can set in their super over
+	suddenDeathWickets = 1
+)
+
+// TieBreaker is implemented by modes whose matches can end level and need settling
+type TieBreaker interface {
+	Tied(state MatchState) bool
+}
+
+// SuperOverMode is a one over shootout to settle a tied match. The opposition bats first,
+// so the batsman knows what to beat. If the super over is tied too, it goes to sudden death:
+// the next run wins and the next wicket loses.
+type SuperOverMode struct {
+	OppositionRuns int
+	SuddenDeath    bool
+}
+
+// NewSuperOverMode draws what the opposition scored in their over from the match's randomness
+func NewSuperOverMode(rng *rand.Rand) SuperOverMode {
+	return SuperOverMode{OppositionRuns: superOverMinRuns + rng.IntN(superOverMaxRuns-superOverMinRuns+1)}
+}
+
+func (m SuperOverMode) Name() string { return modeSuperOver }
+
+func (m SuperOverMode) Description() string {
+	if m.SuddenDeath {
+		return "Sudden death: the next run wins, the next wicket loses."
+	}
+	return fmt.Sprintf("Beat %d in one over with %d wickets.", m.OppositionRuns, superOverWickets)
+}
+
+func (m SuperOverMode) Rules() ModeRules {
+	if m.SuddenDeath {
+		return ModeRules{Wickets: suddenDeathWickets, Fielders: true}
+	}
+	return ModeRules{Overs: 1, Wickets: superOverWickets, Fielders: true}
+}
+
+func (m SuperOverMode) RunsForHit(MatchState) int { return 1 }
+
+// won is true once the batsman has done enough to win the super over
+func (m SuperOverMode) WinsWith(score int) bool {
+	if m.SuddenDeath {
+		return score > 0
+	}
+	return score > m.OppositionRuns
+}
+
+func (m SuperOverMode) Won(state MatchState) bool { return m.WinsWith(state.Score) }
+
+func (m SuperOverMode) Target() int {
+	if m.SuddenDeath {
+		return 1
+	}
+	return m.OppositionRuns + 1
+}
+
+func (m SuperOverMode) End(state MatchState) (bool, string) {
+	if m.WinsWith(state.Score) {
+		return true, "SUPER OVER WON!"
+	}
+	if state.AllOut || state.BowlingDone {
+		return true, "SUPER OVER LOST!"
+	}
+	return false, ""
+}
+
+func (m SuperOverMode) Tied(state MatchState) bool {
+	return !m.SuddenDeath && state.BowlingDone && !state.AllOut && state.Score == m.OppositionRuns
+}
+
+func (m SuperOverMode) HUD(state MatchState) []string {
+	if m.SuddenDeath {
+		return []string{"SUDDEN DEATH", "Next run wins, next wicket loses"}
+	}
+
+	ballsLeft := state.BallsPerOver - state.BallsBowled
+	return []string{
+		"SUPER OVER",
+		fmt.Sprintf("Opposition: %d", m.OppositionRuns),
+		fmt.Sprintf("Need %d off %d balls", max(0, m.OppositionRuns+1-state.Score), max(0, ballsLeft)),
+		fmt.Sprintf("Wickets: %d/%d", state.Wickets, superOverWickets),
+	}
+}
+
+// SuperOver remembers the match a super over is settling
+type SuperOver struct {
+	MainScore int // What the batsman scored in the match itself
+	MainBalls int // Balls faced in the match itself, for the run rate
+	Mode      SuperOverMode
+}
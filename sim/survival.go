@@ -0,0 +1,81 @@
+package sim
+
+import (
+	"fmt"
+
+	"github.com/meghashyamc/cricket2d/config"
+)
+
+const (
+	ModeSurvival = "survival"
+
+	survivalShrinkRuns   = 10  // Runs between each shrink of the bat
+	survivalShrinkPixels = 6   // Length the bat loses each time
+	survivalMinBatScale  = 0.4 // Smallest the bat gets, as a fraction of its usual length
+)
+
+func init() {
+	RegisterMode(ModeSurvival, func(cfg *config.Config) Mode { return survivalMode{} })
+}
+
+// batSizer is implemented by modes that change the size of the bat as the innings goes on
+type batSizer interface {
+	// BatScale is the bat's size for the state of the match, 1 being its usual size, given
+	// its usual length in pixels
+	BatScale(state MatchState, length float64) float64
+}
+
+// survivalMode is the endless mode with a bat that gets shorter every few runs
+type survivalMode struct{}
+
+func (survivalMode) Name() string { return ModeSurvival }
+
+func (survivalMode) Description() string {
+	return fmt.Sprintf("Bat until you are out, with a bat %d pixels shorter every %d runs.", survivalShrinkPixels, survivalShrinkRuns)
+}
+
+func (survivalMode) Rules() ModeRules {
+	return ModeRules{Wickets: 1, HighScoreKey: ModeSurvival, OldBallOvers: endlessOldBallOvers}
+}
+
+func (survivalMode) RunsForHit(MatchState) int { return 1 }
+
+func (survivalMode) End(state MatchState) (bool, string) {
+	return state.AllOut, dismissalMessage(state.LastDismissal)
+}
+
+func (survivalMode) HUD(state MatchState) []string {
+	next := survivalShrinkRuns - state.Score%survivalShrinkRuns
+	if next == 1 {
+		return []string{"Bat shrinks next run"}
+	}
+	return []string{fmt.Sprintf("Bat shrinks in %d runs", next)}
+}
+
+func (survivalMode) BatScale(state MatchState, length float64) float64 {
+	shrinks := state.Score / survivalShrinkRuns
+	return max(1-float64(shrinks*survivalShrinkPixels)/length, survivalMinBatScale)
+}
+
+// sizeBat gives the bat the size the mode wants it, if the mode changes it
+func (w *World) sizeBat() {
+	sizer, ok := w.Mode.(batSizer)
+	if !ok {
+		return
+	}
+
+	usual := w.Bat.batsman.BatSize
+	if usual <= 0 {
+		usual = 1
+	}
+	length := scaledSize(sizes.Bat, usual).Y
+	scale := usual * sizer.BatScale(w.MatchState(), length)
+	if scale == w.Bat.Scale {
+		return
+	}
+	if scaledSize(sizes.Bat, scale).Y < w.Bat.size().Y {
+		w.Announce("The bat shrinks!")
+	}
+	w.Bat.Scale = scale
+	w.logger.Debug("bat resized", "length", w.Bat.size().Y)
+}
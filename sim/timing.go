@@ -0,0 +1,103 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/stats"
+)
+
+const (
+	sweetSpot          = 0.7  // Where the middle of the bat is, as a fraction of its length from the handle
+	sweetSpotHalfWidth = 0.1  // How far either side of the sweet spot still counts as the middle
+	minTimingSwing     = 0.01 // Swings slower than this many radians a tick are taken to be on time
+	maxTimingFrames    = 30   // Timing further out than this is shown as this
+	timingDisplayTicks = TPS
+)
+
+// ShotTiming is how well a shot was timed. The bat is on time when it reaches the vertical
+// as it meets the ball: a bat already past it got there early, and one still coming down
+// got there late.
+type ShotTiming struct {
+	frames  int // Negative when early, positive when late
+	middled bool
+}
+
+// timing measures a shot as the bat meets the ball, more forgivingly the more the batsman
+// is being assisted
+func (b *Bat) timing(ball *Ball, assist float64) ShotTiming {
+	center, _ := ball.CenterAndRadius()
+
+	// How far down the bat the ball is, along the blade
+	along := geometry.Vector{X: math.Sin(-b.CurrentAngle), Y: math.Cos(-b.CurrentAngle)}
+	offset := center.Subtract(b.Position)
+	contact := offset.DotProduct(along) / b.reach()
+
+	t := ShotTiming{middled: math.Abs(contact-sweetSpot) <= sweetSpotHalfWidth*(1+assistMaxWidening*assist)}
+
+	// The forward swing turns the bat anticlockwise, from positive angles to negative ones,
+	// so the bat reached the vertical currentAngle/swing ticks ago
+	swing := b.CurrentAngle - b.PreviousAngle
+	if math.Abs(swing) < minTimingSwing {
+		return t
+	}
+	frames := int(math.Round(b.CurrentAngle / swing))
+	if math.Abs(float64(frames)) <= math.Round(assistMaxOnTimeFrames*assist) {
+		return t
+	}
+	t.frames = -clampValue(frames, -maxTimingFrames, maxTimingFrames)
+
+	return t
+}
+
+func (t ShotTiming) Kind() stats.Timing {
+	switch {
+	case t.frames < 0:
+		return stats.TimingEarly
+	case t.frames > 0:
+		return stats.TimingLate
+	case t.middled:
+		return stats.TimingPerfect
+	default:
+		return stats.TimingOnTime
+	}
+}
+
+func (t ShotTiming) String() string {
+	switch t.Kind() {
+	case stats.TimingEarly:
+		return fmt.Sprintf("Early %s", pluralFrames(-t.frames))
+	case stats.TimingLate:
+		return fmt.Sprintf("Late %s", pluralFrames(t.frames))
+	case stats.TimingPerfect:
+		return "Perfect"
+	default:
+		return "On time, off the edge"
+	}
+}
+
+func pluralFrames(n int) string {
+	if n == 1 {
+		return "1 frame"
+	}
+	return fmt.Sprintf("%d frames", n)
+}
+
+// ShotSummary writes how many of each kind of shot the innings had, for the scorecard
+func ShotSummary(tally map[stats.Shot]int) string {
+	summary := "Shots:"
+	for _, shot := range stats.Shots() {
+		summary += fmt.Sprintf(" %s %d", shot, tally[shot])
+	}
+	return summary
+}
+
+// TimingSummary writes the innings' timing distribution for the scorecard
+func TimingSummary(distribution map[stats.Timing]int) string {
+	summary := "Timing:"
+	for _, kind := range stats.Timings() {
+		summary += fmt.Sprintf(" %s %d", kind, distribution[kind])
+	}
+	return summary
+}
@@ -0,0 +1,800 @@
+// Package sim plays the field: the ball, bat and stumps, the bowlers, the modes and what
+// happens on every tick. It draws nothing and has no Ebiten import, so that a match can be
+// played headlessly, such as by the match server or the simulator, the same as in the game.
+package sim
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+	"github.com/meghashyamc/cricket2d/mods"
+	"github.com/meghashyamc/cricket2d/stats"
+	"github.com/meghashyamc/cricket2d/team"
+)
+
+// Dismissal represents how (or whether) the batsman got out
+type Dismissal int
+
+const (
+	notOut Dismissal = iota
+	Bowled
+	HitWicket
+	LBW
+	CaughtBehind
+	Caught
+)
+
+func (d Dismissal) String() string {
+	switch d {
+	case Bowled:
+		return "bowled"
+	case HitWicket:
+		return "hit wicket"
+	case LBW:
+		return "lbw"
+	case CaughtBehind:
+		return "caught behind"
+	case Caught:
+		return "caught"
+	default:
+		return "not out"
+	}
+}
+
+// BatInput is what the bat responds to on a tick, whether it comes from the mouse or a bot
+type BatInput struct {
+	Cursor   geometry.Vector
+	Dragging bool
+	Blocking bool // Playing a defensive shot, which deadens the ball instead of hitting it
+}
+
+// FieldEvent is something on the field that the game may want to react to, such as with a sound
+type FieldEvent int
+
+const (
+	EventAppeal FieldEvent = iota
+	EventGivenOut
+	EventGivenNotOut
+	EventBatPlaced // The player has finished dragging the bat somewhere new
+	EventHit
+	EventLoftedHit // Comes with an eventHit when the shot goes in the air
+	EventLeft
+	EventWide
+	EventWicket
+	EventChaos     // A chaos over is on its way
+	EventRingBonus // A shot went through a target ring
+)
+
+const (
+	wideReachFactor = 1.2 // Balls further than this many bat lengths from the handle are wides
+	wideRuns        = 1
+	leaveSwingSpeed = 2 // Balls passing a bat slower than this, in pixels per tick, were left alone
+)
+
+// TPS is how many ticks the field is played in a second, which is Ebiten's usual rate
+const TPS = 60
+
+const (
+	announcementDisplayTicks = 2 * TPS     // How long an announcement stays on screen
+	walkInTicks              = 3 * TPS / 2 // How long a new batsman takes to walk in
+)
+
+// Phase is a part of a tick that a PhaseTimer is told the length of
+type Phase int
+
+const (
+	PhaseSpawn     Phase = iota // Bowling new deliveries
+	PhaseCollision              // Moving the balls and checking what they hit
+)
+
+// PhaseTimer is told how long the phases of a tick take, to track down hitching
+type PhaseTimer interface {
+	TimePhase(phase Phase, took time.Duration)
+}
+
+// HitContact is where the bat met the ball, and what came of it
+type HitContact struct {
+	At    geometry.Vector
+	Runs  int
+	Edged bool
+}
+
+// World holds everything on the field that moves on a tick. It knows nothing about
+// menus, high scores or where its input comes from, so it can also be run headlessly.
+type World struct {
+	Width             float64 // Of the window's view of the field
+	Height            float64
+	Field             geometry.Rect // The playing area, out to the boundary
+	Bat               *Bat
+	Lineup            []team.Batsman // Batting order, empty for the standard batsman every time
+	DragArea          DragArea
+	batHome           geometry.Vector // Where the player last left the bat, for new bats
+	hasBatHome        bool
+	walkInTicksLeft   int             // Ticks until the new batsman has walked in, 0 when play is on
+	walkInFrom        geometry.Vector // Where the new batsman's bat starts its walk from
+	walkInTo          geometry.Vector // Where the new batsman's bat ends up
+	Balls             []*Ball         // In the order they were bowled, so they are always dealt with in that order
+	finishedBalls     []*Ball         // Reused on every tick for the balls going out of play
+	Stumps            *Stumps
+	Pitch             *Pitch
+	Mode              Mode
+	Preset            *difficulty.Preset
+	Innings           *stats.Innings
+	Score             int
+	hits              int
+	wickets           int
+	loftedHits        int
+	BallsBowled       int // Every delivery, wides and no balls included
+	Wides             int
+	noBalls           int
+	MaxBalls          int // No limit if 0
+	Ticks             int
+	shotGraceTicks    int           // Ticks left in which following through into the stumps is forgiven
+	overStartedAt     time.Duration // Time in play when the current over's first ball was bowled
+	Bowler            Bowler
+	currentBowler     string // Who bowled the last ball, if anyone in particular
+	Upcoming          deliveries.Delivery
+	HasUpcoming       bool
+	TicksUntilSpawn   int
+	Dismissal         Dismissal // How the last batsman got out
+	allOut            bool
+	ActiveMods        []*mods.Mod
+	PendingAppeal     *Appeal
+	lastDecision      *umpireDecision
+	HawkEye           *HawkEye // Ball tracking shown after an LBW decision, if any
+	Fielders          []*Fielder
+	FieldMapTicks     int          // Ticks left showing the field map
+	Events            []FieldEvent // What happened on the last tick, for the game to react to
+	Announcement      string
+	AnnouncementTicks int
+	LastTiming        ShotTiming // Timing of the last shot, shown for a moment after it
+	LastShot          stats.Shot
+	LastHitSpeed      float64    // How fast the last shot left the bat, in pixels per tick
+	LastContact       HitContact // Where and how the bat met the ball for the last shot
+	peakSwing         float64    // Fastest the bat has swung since the last delivery was prepared
+	Assist            float64    // How much the dynamic assist is helping the batsman, from 0 to 1
+	Kid               bool       // Kid mode, where nobody gets out
+	Timer             PhaseTimer // Told how long the phases of the tick take, if not nil
+	Mutators          Mutators
+	ArenaWalls        Edges       // Edges of the field that every ball bounces off
+	ChaosOvers        bool        // Whether every chaosEveryRuns runs bring a chaos over
+	ChaosDue          bool        // The next delivery starts a chaos over
+	nextChaosAt       int         // Score that brings the next chaos over
+	burst             []burstBall // Balls of a chaos over still to be bowled
+	Rules             HouseRules  // The rules the match is played by
+	FreeHits          bool        // Whether being beaten freeHitAfter times in a row earns a free hit
+	FreeHitDue        bool        // The next delivery is a free hit
+	beatenInARow      int         // Balls in a row the batsman has been beaten by or edged
+	BreakTaken        bool        // The innings has stopped for its halfway break
+	TargetRings       bool        // Whether rings go up now and then for lofted shots to go through
+	Rings             []*Ring
+	ballScale         float64 // Of new balls, as a multiple of the usual size
+	pace              float64 // Speed of the last delivery prepared, as a multiple of its usual speed
+	TimingTicks       int
+	RNG               *MatchRand // Decides the deliveries, from a seed a challenge code can share
+	logger            logger.Logger
+}
+
+func NewWorld(width float64, height float64, preset *difficulty.Preset, bowler Bowler, mode Mode, area DragArea, rng *MatchRand) *World {
+	w := &World{
+		Width:       width,
+		Height:      height,
+		Field:       geometry.NewRect(0, 0, width, height),
+		Bat:         newBat(area, team.Standard()),
+		DragArea:    area,
+		Stumps:      newStumps(height),
+		Mode:        mode,
+		Preset:      preset,
+		Innings:     stats.NewInnings(),
+		MaxBalls:    mode.Rules().MaxBalls(ballsPerOver),
+		Rules:       StandardRules,
+		Bowler:      bowler,
+		Dismissal:   notOut,
+		pace:        1,
+		ballScale:   1,
+		nextChaosAt: chaosEveryRuns,
+		RNG:         rng,
+		logger:      logger.New(),
+	}
+	w.setUpField()
+	w.RelayPitch()
+	w.PrepareNextDelivery()
+
+	return w
+}
+
+// View is the part of the field the window shows when the camera is at rest
+func (w *World) View() geometry.Rect {
+	return geometry.NewRect(0, 0, w.Width, w.Height)
+}
+
+// SetField changes the size of the playing field, which is centred on the view
+func (w *World) SetField(width, height float64) {
+	width, height = max(width, w.Width), max(height, w.Height)
+	w.Field = geometry.NewRect((w.Width-width)/2, (w.Height-height)/2, width, height)
+}
+
+// SetBatHome makes new bats start where the player last left one, and moves the bat there
+func (w *World) SetBatHome(position geometry.Vector) {
+	w.batHome = position
+	w.hasBatHome = true
+	w.Bat.Position = w.Bat.constrainToDraggableArea(position, w.Stumps.Position)
+}
+
+// SetLineup sets the batting order, handing the bat to whoever is due in
+func (w *World) SetLineup(lineup []team.Batsman) {
+	w.Lineup = lineup
+	w.Bat = w.newBatAtHome()
+}
+
+// CurrentBatsman is the batsman due in after the wickets fallen so far
+func (w *World) CurrentBatsman() team.Batsman {
+	if len(w.Lineup) == 0 {
+		return team.Standard()
+	}
+	return w.Lineup[w.wickets%len(w.Lineup)]
+}
+
+// newBatAtHome brings in a new bat for the current batsman, where the player last left the
+// old one if they moved it
+func (w *World) newBatAtHome() *Bat {
+	b := newBat(w.DragArea, w.CurrentBatsman())
+	if w.hasBatHome {
+		b.Position = b.constrainToDraggableArea(w.batHome, w.Stumps.Position)
+	}
+
+	return b
+}
+
+// Update advances the world by a single tick
+func (w *World) Update(input BatInput) {
+	if w.allOut {
+		return
+	}
+
+	w.Events = w.Events[:0]
+	w.Ticks++
+
+	w.sizeBat()
+	wasDragging := w.Bat.IsDragging
+	w.Bat.update(input, w.Stumps.Position)
+	w.peakSwing = max(w.peakSwing, w.Bat.swingSpeed())
+	if wasDragging && !w.Bat.IsDragging {
+		w.batHome = w.Bat.Position
+		w.hasBatHome = true
+		w.Events = append(w.Events, EventBatPlaced)
+	}
+
+	if w.AnnouncementTicks > 0 {
+		w.AnnouncementTicks--
+	}
+	if w.HawkEye != nil {
+		w.HawkEye.update()
+	}
+	if w.FieldMapTicks > 0 {
+		w.FieldMapTicks--
+	}
+	if w.TimingTicks > 0 {
+		w.TimingTicks--
+	}
+
+	// Play stops while a new batsman walks in
+	if w.walkInTicksLeft > 0 {
+		w.walkIn()
+		return
+	}
+
+	// New balls come in when the bowler is ready with the next delivery
+	started := w.startPhase()
+	if w.HasUpcoming {
+		w.TicksUntilSpawn--
+		if w.TicksUntilSpawn <= 0 && w.Released() {
+			if w.ChaosDue {
+				w.startBurst()
+			}
+			if w.FreeHitDue {
+				w.easeFreeHit()
+			}
+			w.spawnBall()
+			w.PrepareNextDelivery()
+		}
+	}
+	w.updateBurst()
+	w.endPhase(PhaseSpawn, started)
+	w.updateRings()
+
+	if w.shotGraceTicks > 0 {
+		w.shotGraceTicks--
+	}
+
+	// On every tick, check if the wicket has been hit by the bat
+	if !w.Kid && w.checkHitWicket() {
+		if logger.DebugEnabled() {
+			w.logger.Debug("bat collided with stumps", "score", w.Score)
+		}
+		w.recordHitWicket()
+		w.dismiss(HitWicket)
+		return
+	}
+
+	started = w.startPhase()
+	w.UpdateBalls()
+	w.endPhase(PhaseCollision, started)
+	w.checkChaos()
+	w.updateAppeal()
+}
+
+// startPhase is when a phase of the tick starts, for endPhase. The clock is only read while
+// a timer is listening, so that nothing on the field depends on it otherwise.
+func (w *World) startPhase() time.Time {
+	if w.Timer == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// endPhase tells the timer how long a phase of the tick took
+func (w *World) endPhase(phase Phase, started time.Time) {
+	if w.Timer != nil {
+		w.Timer.TimePhase(phase, time.Since(started))
+	}
+}
+
+// checkHitWicket is true if the bat has gone into the stumps deep enough, or hard enough, to
+// dislodge the bails. Brushing them, or following through just after a shot, doesn't count.
+func (w *World) checkHitWicket() bool {
+	if !w.Stumps.CheckCollision(nil, w.Bat) || w.shotGraceTicks > 0 {
+		return false
+	}
+
+	rules := w.Preset.HitWicket
+	overlap := w.Bat.getBounds().Intersection(w.Stumps.GetBounds())
+	depth := min(overlap.Width, overlap.Height)
+
+	return depth >= rules.MinOverlap || w.Bat.swingSpeed() >= rules.MinSwingSpeed
+}
+
+func (w *World) spawnBall() {
+	newball := NewBall(w.Width, w.Height, w.Upcoming, w.Gravity())
+	newball.Scale = w.ballScale
+	w.ageBall(newball)
+	w.pitchBall(newball)
+	w.raiseRing()
+	newball.FreeHit = w.Upcoming.Type == freeHitDelivery
+	w.Balls = append(w.Balls, newball)
+	w.BallsBowled++
+	newball.Number = w.BallsBowled
+	w.currentBowler = newball.Delivery.Bowler
+	switch w.BallsBowled % w.Rules.BallsPerOver {
+	case 1:
+		w.overStartedAt = w.Elapsed()
+		w.planField()
+	case 0:
+		w.checkOverRate()
+	}
+	if logger.DebugEnabled() {
+		w.logger.Debug("new ball spawned", "ballCount", len(w.Balls), "ballPosition", newball.Position)
+	}
+	w.fireModEvent(mods.EventSpawn)
+}
+
+// PrepareNextDelivery asks the bowler for the next delivery and starts its run up
+func (w *World) PrepareNextDelivery() {
+	if w.MaxBalls > 0 && w.LegalBalls() >= w.MaxBalls {
+		w.HasUpcoming = false
+		return
+	}
+
+	w.Upcoming, w.HasUpcoming = w.Bowler.NextDelivery(w.Innings)
+	w.paceDelivery()
+	w.assistDelivery()
+	w.TicksUntilSpawn = int(w.Upcoming.IntervalSeconds * TPS)
+	if w.Mutators[mutatorDoubleSpawn] {
+		w.TicksUntilSpawn /= 2
+	}
+}
+
+// CurrentBowlerFigures returns the figures of whoever bowled the last ball, if it was
+// bowled by anyone in particular
+func (w *World) CurrentBowlerFigures() (stats.Figures, bool) {
+	if len(w.currentBowler) == 0 {
+		return stats.Figures{}, false
+	}
+
+	for _, figures := range w.Innings.BowlerFigures() {
+		if figures.Bowler == w.currentBowler {
+			return figures, true
+		}
+	}
+	return stats.Figures{Bowler: w.currentBowler}, true
+}
+
+// LegalBalls is how many deliveries count towards the overs, which wides and no balls don't
+func (w *World) LegalBalls() int {
+	return w.BallsBowled - w.Wides - w.noBalls
+}
+
+// BallsFaced is how many balls count towards the batting side's run rate. A side that is all
+// out is taken to have faced its full quota of overs.
+func (w *World) BallsFaced() int {
+	if w.allOut && w.MaxBalls > 0 {
+		return w.MaxBalls
+	}
+	return w.LegalBalls()
+}
+
+// BowlingComplete is true when no more balls will be bowled and none are left in play
+func (w *World) BowlingComplete() bool {
+	return !w.HasUpcoming && len(w.Balls) == 0 && len(w.burst) == 0
+}
+
+func (w *World) UpdateBalls() {
+	ballsToDeactivate := w.finishedBalls[:0]
+
+	for _, ball := range w.Balls {
+		// The keeper takes balls the batsman doesn't hit, but hit balls run on to the boundary
+		bounds := w.View()
+		if ball.IsHit {
+			bounds = w.Field
+		}
+		ball.Update(bounds, w.walls(ball))
+		w.checkRings(ball)
+		w.checkPitching(ball)
+
+		if !ball.Active {
+			if !ball.IsHit {
+				w.recordBall(ball, ball.passOutcome, ball.passRuns)
+			}
+			// Remove inactive balls
+			ballsToDeactivate = append(ballsToDeactivate, ball)
+			continue
+		}
+
+		if w.checkCatch(ball) {
+			w.catchOut(ball)
+			break
+		}
+
+		collisionZone := w.Bat.checkCollision(ball)
+		if collisionZone != noCollision && w.Bat.isBlocking {
+			if ball.block() {
+				w.recordBall(ball, stats.OutcomeBlocked, 0)
+				if logger.DebugEnabled() {
+					w.logger.Debug("ball blocked", "collision_zone", collisionZone)
+				}
+			}
+			continue
+		}
+		if collisionZone != noCollision {
+			// Measured before the hit moves the ball away from the bat
+			edgeThinness := w.Bat.edgeThinness(ball)
+			timing := w.Bat.timing(ball, w.Assist)
+			contact, _ := ball.CenterAndRadius()
+			if ball.hit(w.Bat, collisionZone, w.Preset.Hit, w.RNG.play) {
+				w.hits++
+				w.shotGraceTicks = int(w.Preset.HitWicket.GraceSeconds * TPS)
+				ball.lofted = ball.isLofted()
+				runs := w.Rules.runsFor(ball, w.Mode.RunsForHit(w.MatchState()))
+				w.Score += runs
+				ball.runs = runs
+				w.LastHitSpeed = ball.Velocity.Magnitude()
+				if ball.lofted {
+					w.loftedHits++
+					w.Events = append(w.Events, EventLoftedHit)
+					ball.carry = w.carry(ball)
+					w.Announce(fmt.Sprintf("That went %s!", FormatCarry(ball.carry)))
+				}
+				ball.timing = timing
+				ball.edged = collisionZone == handleZone
+				ball.shot = stats.ClassifyShot(w.Bat.CurrentAngle, math.Atan2(-ball.Velocity.Y, ball.Velocity.X))
+				w.LastTiming, w.LastShot, w.TimingTicks = timing, ball.shot, timingDisplayTicks
+				w.LastContact = HitContact{At: contact, Runs: runs, Edged: ball.edged}
+				w.recordBall(ball, stats.OutcomeHit, runs)
+				w.Events = append(w.Events, EventHit)
+				if logger.DebugEnabled() {
+					w.logger.Debug("ball hit successfully", "new_score", w.Score, "collision_zone", collisionZone, "ball_velocityy", ball.Velocity)
+				}
+				w.fireModEvent(mods.EventHit)
+				if collisionZone == handleZone {
+					w.appealForEdge(ball, runs, edgeThinness)
+				}
+			}
+			continue
+		}
+
+		// Check ball's collision with stumps. A ball coming back off a wall can't bowl the batsman.
+		if ball.bounces == 0 && w.Stumps.CheckCollision(ball, nil) {
+			if logger.DebugEnabled() {
+				w.logger.Debug("ball collided with stumps", "ballPosition", ball.Position, "score", w.Score)
+			}
+			w.recordBall(ball, stats.OutcomeBowled, 0)
+			w.dismiss(Bowled)
+			break
+		}
+
+		w.checkBallPassedBat(ball)
+		w.appealForLBW(ball)
+	}
+
+	if len(ballsToDeactivate) > 0 {
+		kept := w.Balls[:0]
+		for _, ball := range w.Balls {
+			if !slices.Contains(ballsToDeactivate, ball) {
+				kept = append(kept, ball)
+			}
+		}
+		clear(w.Balls[len(kept):])
+		w.Balls = kept
+	}
+	w.finishedBalls = ballsToDeactivate
+}
+
+func (w *World) dismiss(how Dismissal) {
+	if w.Kid {
+		w.forgive()
+		return
+	}
+	rules := w.Mode.Rules()
+
+	w.Stumps.fall()
+	w.Events = append(w.Events, EventWicket)
+	w.Dismissal = how
+	w.wickets++
+	w.Score = max(w.Score-rules.DismissalPenalty, 0)
+
+	if rules.Wickets > 0 && w.wickets >= rules.Wickets {
+		w.allOut = true
+	} else {
+		w.Announce(dismissalMessage(how.String()) + " Next batsman in")
+		w.nextBatsman()
+	}
+
+	w.fireModEvent(mods.EventDismissal)
+}
+
+// checkBallPassedBat works out what the batsman did with a ball once it gets past the bat
+// untouched. Balls out of the batsman's reach are wides, and with no balls in the rules so
+// are full tosses above the handle of the bat. Both cost a run and have to be bowled again.
+// Anything else is a dot ball, either left alone or played at and missed.
+func (w *World) checkBallPassedBat(b *Ball) {
+	if b.passedBat || b.IsHit {
+		return
+	}
+
+	center, _ := b.CenterAndRadius()
+	if center.X > w.Bat.Position.X {
+		return
+	}
+	b.passedBat = true
+
+	switch {
+	case w.Rules.NoBalls && !b.pitched && center.Y < w.Bat.Position.Y:
+		w.noBalls++
+		w.Score += noBallRuns
+		b.passOutcome, b.passRuns = stats.OutcomeNoBall, noBallRuns
+		w.Announce(fmt.Sprintf("No ball! +%d", noBallRuns))
+		if !w.HasUpcoming {
+			w.PrepareNextDelivery()
+		}
+	case w.Rules.Wides && center.Subtract(w.Bat.Position).Magnitude() > w.Bat.reach()*wideReachFactor:
+		w.Wides++
+		w.Score += wideRuns
+		b.passOutcome, b.passRuns = stats.OutcomeWide, wideRuns
+		w.Announce(fmt.Sprintf("Wide! +%d", wideRuns))
+		w.Events = append(w.Events, EventWide)
+		// The last ball of the innings has to be bowled again
+		if !w.HasUpcoming {
+			w.PrepareNextDelivery()
+		}
+	case w.Bat.swingSpeed() < leaveSwingSpeed:
+		b.passOutcome = stats.OutcomeLeft
+		w.Announce("Well left")
+		w.Events = append(w.Events, EventLeft)
+	default:
+		b.passOutcome = stats.OutcomeMissed
+	}
+}
+
+// checkOverRate awards penalty runs to the batsman if the over that has just been
+// bowled took longer in play than the mode allows. Time in play is counted in ticks, so
+// pauses and a slow machine don't count against the bowler.
+func (w *World) checkOverRate() {
+	rules := w.Mode.Rules()
+	if rules.MaxOverSeconds <= 0 {
+		return
+	}
+
+	overTime := w.Elapsed() - w.overStartedAt
+	if overTime.Seconds() <= rules.MaxOverSeconds {
+		return
+	}
+
+	w.Score += rules.SlowOverRatePenalty
+	w.Announce(fmt.Sprintf("Slow over rate! +%d penalty runs", rules.SlowOverRatePenalty))
+	if logger.DebugEnabled() {
+		w.logger.Debug("slow over rate", "over_seconds", overTime.Seconds(), "max_over_seconds", rules.MaxOverSeconds)
+	}
+}
+
+// recordBall adds what became of a ball to the innings record
+func (w *World) recordBall(b *Ball, outcome stats.Outcome, runs int) {
+	if b.Number == 0 {
+		return
+	}
+
+	w.Innings.Record(w.ballEvent(b, outcome, runs))
+	w.trackBeaten(b, outcome)
+}
+
+func (w *World) ballEvent(b *Ball, outcome stats.Outcome, runs int) stats.BallEvent {
+	event := stats.BallEvent{
+		Number:       b.Number,
+		DeliveryType: b.Delivery.Type,
+		Speed:        b.Delivery.Speed,
+		Height:       b.Delivery.Height,
+		Outcome:      outcome,
+		Runs:         runs,
+		Lofted:       outcome == stats.OutcomeHit && b.isLofted(),
+		Bowler:       b.Delivery.Bowler,
+		Batter:       w.CurrentBatsman().Name,
+	}
+	if outcome == stats.OutcomeHit {
+		event.Angle = math.Atan2(-b.Velocity.Y, b.Velocity.X)
+		event.Timing = b.timing.Kind()
+		event.TimingFrames = b.timing.frames
+		event.Carry = b.carry
+		event.Shot = b.shot
+	}
+
+	return event
+}
+
+// recordHitWicket records the batsman knocking their own stumps over against the ball they
+// were facing. With none on its way, it goes against the last ball recorded, keeping what
+// that was worth, as a wicket has to fall to a delivery in the ball-by-ball record. Before
+// the first ball there is none to record it against.
+func (w *World) recordHitWicket() {
+	for _, b := range w.Balls {
+		if b.Number > 0 && b.Active && !b.IsHit && !b.passedBat {
+			w.recordBall(b, stats.OutcomeHitWicket, 0)
+			// It has been dealt with, and isn't recorded again when it goes dead
+			b.Number = 0
+			return
+		}
+	}
+	if events := w.Innings.Events(); len(events) > 0 {
+		last := events[len(events)-1]
+		w.Innings.SetOutcome(last.Number, stats.OutcomeHitWicket, last.Runs)
+	}
+}
+
+// nextBatsman clears the field and starts a new batsman walking in after a dismissal
+func (w *World) nextBatsman() {
+	if logger.DebugEnabled() {
+		w.logger.Debug("next batsman in", "wickets", w.wickets, "score", w.Score)
+	}
+	w.Bat = w.newBatAtHome()
+	w.Balls = nil
+	w.burst = w.burst[:0]
+	w.PendingAppeal = nil
+
+	w.walkInTo = w.Bat.Position
+	w.walkInFrom = geometry.Vector{X: w.Width + w.Bat.reach(), Y: w.Bat.Position.Y}
+	w.walkInTicksLeft = walkInTicks
+	w.walkIn()
+}
+
+// walkIn moves the new batsman's bat a step closer to where it will be played from. The
+// stumps are put back up once the batsman arrives.
+func (w *World) walkIn() {
+	w.walkInTicksLeft--
+
+	progress := 1 - float64(w.walkInTicksLeft)/walkInTicks
+	w.Bat.Position = w.walkInFrom.Add(w.walkInTo.Subtract(w.walkInFrom).Scale(progress))
+
+	if w.walkInTicksLeft == 0 {
+		w.Stumps.reset()
+	}
+}
+
+// Announce shows a message over the field for a short while
+func (w *World) Announce(message string) {
+	w.Announcement = message
+	w.AnnouncementTicks = announcementDisplayTicks
+}
+
+// MatchState is the snapshot of the match that modes and mods get to see
+func (w *World) MatchState() MatchState {
+	state := MatchState{
+		Score:          w.Score,
+		Hits:           w.hits,
+		BallsBowled:    w.LegalBalls(),
+		Wickets:        w.wickets,
+		Lofted:         w.loftedHits,
+		Pace:           w.pace,
+		AllOut:         w.allOut,
+		BowlingDone:    w.BowlingComplete(),
+		BallsPerOver:   w.Rules.BallsPerOver,
+		ElapsedSeconds: float64(w.Ticks) / TPS,
+	}
+	if w.Dismissal != notOut {
+		state.LastDismissal = w.Dismissal.String()
+	}
+
+	return state
+}
+
+// Elapsed is the time in play in the innings. It is counted in ticks, as everything else on
+// the field is, so that it follows from the inputs alone.
+func (w *World) Elapsed() time.Duration {
+	return time.Duration(w.Ticks) * time.Second / TPS
+}
+
+// StartInnings begins a fresh match under a different mode, such as a season fixture
+func (w *World) StartInnings(mode Mode) {
+	w.Mode = mode
+	w.MaxBalls = mode.Rules().MaxBalls(w.Rules.BallsPerOver)
+	w.Reset()
+}
+
+// NextInnings begins the match's next innings under a different mode, such as a super over,
+// with randomness that follows on from the match's
+func (w *World) NextInnings(mode Mode) {
+	w.Mode = mode
+	w.MaxBalls = mode.Rules().MaxBalls(w.Rules.BallsPerOver)
+	w.RNG.nextInnings()
+	w.resetInnings()
+}
+
+// Reset begins a new match
+func (w *World) Reset() {
+	w.RNG.restart()
+	w.resetInnings()
+}
+
+// resetInnings clears the field for a fresh innings
+func (w *World) resetInnings() {
+	w.Balls = nil
+	w.Stumps.reset()
+	w.Score = 0
+	w.hits = 0
+	w.loftedHits = 0
+	w.wickets = 0
+	w.Bat = w.newBatAtHome()
+	w.Ticks = 0
+	w.shotGraceTicks = 0
+	w.walkInTicksLeft = 0
+	w.FieldMapTicks = 0
+	w.TimingTicks = 0
+	w.setUpField()
+	w.overStartedAt = 0
+	w.AnnouncementTicks = 0
+	w.BallsBowled = 0
+	w.currentBowler = ""
+	w.Wides = 0
+	w.noBalls = 0
+	w.Innings.Reset()
+	w.Bowler.Reset()
+	w.peakSwing = 0
+	w.pace = 1
+	w.ChaosDue = false
+	w.nextChaosAt = chaosEveryRuns
+	w.FreeHitDue = false
+	w.beatenInARow = 0
+	w.BreakTaken = false
+	w.burst = w.burst[:0]
+	w.Rings = w.Rings[:0]
+	w.PrepareNextDelivery()
+	w.Dismissal = notOut
+	w.allOut = false
+	w.PendingAppeal = nil
+	w.lastDecision = nil
+	w.HawkEye = nil
+	w.Events = w.Events[:0]
+}
@@ -0,0 +1,142 @@
+package sim
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/meghashyamc/cricket2d/deliveries"
+	"github.com/meghashyamc/cricket2d/difficulty"
+	"github.com/meghashyamc/cricket2d/geometry"
+	"github.com/meghashyamc/cricket2d/logger"
+)
+
+const (
+	benchWidth  = 1200
+	benchHeight = 800
+	benchBalls  = 3 // Balls in flight in the steady state, as with a mod spawning extras
+)
+
+// newBenchWorld sets up a headless world like the simulator's, with the normal preset
+func newBenchWorld(tb testing.TB) *World {
+	tb.Helper()
+	logger.SetLevel(slog.LevelWarn)
+
+	preset, err := difficulty.Load(difficulty.Default)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	area := DragArea{Right: benchWidth / 3, Up: benchHeight / 4, Down: benchHeight / 8}
+	rng := NewMatchRand()
+	return NewWorld(benchWidth, benchHeight, preset, NewRandomBowler(preset, rng.Deliveries), EndlessMode{}, area, rng)
+}
+
+// benchBall is a ball in flight towards the bat, part way down the pitch
+func benchBall(w *World) *Ball {
+	b := NewBall(w.Width, w.Height, deliveries.Delivery{Speed: 10, Height: 0.3}, w.Preset.Ball.Gravity)
+	b.Number = 1
+	b.PitchX = w.Width * PitchStart
+	return b
+}
+
+// steadyState puts the world between deliveries, with balls in flight well clear of the bat
+// and nothing due to be bowled
+func steadyState(w *World) []*Ball {
+	w.HasUpcoming = false
+	balls := make([]*Ball, 0, benchBalls)
+	for range benchBalls {
+		b := benchBall(w)
+		w.Balls = append(w.Balls, b)
+		balls = append(balls, b)
+	}
+	return balls
+}
+
+// rewind sends the balls back to where they started, so that the steady state lasts
+func rewind(w *World, balls []*Ball) {
+	for _, b := range balls {
+		b.Position = geometry.Vector{X: w.Width - 1, Y: w.Height * 0.3}
+		b.Velocity = geometry.Vector{X: -10, Y: 0}
+		b.Active = true
+	}
+	w.Balls = append(w.Balls[:0], balls...)
+}
+
+func BenchmarkWorldUpdate(b *testing.B) {
+	w := newBenchWorld(b)
+	bot := NewBotBatsman(1)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if w.allOut || w.BowlingComplete() {
+			w.Reset()
+		}
+		w.Update(bot.Input(w))
+	}
+}
+
+func BenchmarkUpdateBalls(b *testing.B) {
+	w := newBenchWorld(b)
+	balls := steadyState(w)
+
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		if i%40 == 0 {
+			rewind(w, balls)
+		}
+		w.UpdateBalls()
+	}
+}
+
+func BenchmarkBatCollision(b *testing.B) {
+	w := newBenchWorld(b)
+	ball := benchBall(w)
+	ball.Position = w.Bat.Position
+
+	b.ReportAllocs()
+	for b.Loop() {
+		w.Bat.checkCollision(ball)
+	}
+}
+
+func BenchmarkStumpsCollision(b *testing.B) {
+	w := newBenchWorld(b)
+	ball := benchBall(w)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		w.Stumps.CheckCollision(ball, w.Bat)
+	}
+}
+
+func BenchmarkBallPhysics(b *testing.B) {
+	w := newBenchWorld(b)
+	ball := benchBall(w)
+	bounds := geometry.NewRect(-1e9, -1e9, 2e9, 2e9)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		ball.Update(bounds, 0)
+	}
+}
+
+// TestSteadyStateAllocations holds the update loop to its allocation budget: between
+// deliveries, with balls in flight, a tick shouldn't allocate at all
+func TestSteadyStateAllocations(t *testing.T) {
+	w := newBenchWorld(t)
+	balls := steadyState(w)
+	input := BatInput{Cursor: w.Bat.Position}
+
+	// Warm up, so that buffers have grown to their working size
+	for range 2 * maxTrackedPositions {
+		rewind(w, balls)
+		w.Update(input)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		rewind(w, balls)
+		w.Update(input)
+	})
+	if allocs > 0 {
+		t.Errorf("a steady state tick allocates %.1f times, want 0", allocs)
+	}
+}
@@ -0,0 +1,64 @@
+package sound
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Bus is a group of sounds that share a volume
+type Bus int
+
+const (
+	BusSFX Bus = iota
+	BusMusic
+	BusCommentary
+)
+
+const (
+	duckTicks  = ebiten.DefaultTPS / 2 // How long the music stays ducked after another sound plays
+	duckVolume = 0.3                   // Share of its volume the music keeps while ducked
+)
+
+// Volumes are the levels of each bus, from 0 for silent to 1 for full volume
+type Volumes struct {
+	SFX        float64
+	Music      float64
+	Commentary float64
+}
+
+func (v Volumes) of(bus Bus) float64 {
+	switch bus {
+	case BusMusic:
+		return v.Music
+	case BusCommentary:
+		return v.Commentary
+	default:
+		return v.SFX
+	}
+}
+
+// busOf is the bus a clip plays on
+func (m *Manager) busOf(clip Clip) Bus {
+	if bus, ok := m.buses[clip]; ok {
+		return bus
+	}
+	return BusSFX
+}
+
+// priorityOf decides which clips play when too many are asked for at once. The end of the
+// match matters more than a wicket, which matters more than an appeal, a warning or a shot.
+func priorityOf(clip Clip) int {
+	switch clip {
+	case ClipVictory:
+		return 3
+	case ClipOut:
+		return 2
+	case ClipAppeal, ClipSting:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// duckLevel is how much of its volume the music plays at. It dips when another sound plays
+// and comes back up as the ducking wears off.
+func (m *Manager) duckLevel() float64 {
+	return 1 - (1-duckVolume)*float64(m.ducking)/duckTicks
+}
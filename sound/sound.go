@@ -0,0 +1,323 @@
+package sound
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+	"github.com/meghashyamc/cricket2d/logger"
+)
+
+const sampleRate = 44100
+
+// Clip names a sound effect
+type Clip string
+
+const (
+	ClipHit     Clip = "hit"
+	ClipAppeal  Clip = "appeal"
+	ClipOut     Clip = "out"
+	ClipVictory Clip = "victory"
+	ClipSting   Clip = "sting"
+)
+
+const maxSoundsPerTick = 2 // Sound effects asked for on the same tick beyond this are dropped, least important first
+
+// Manager plays the game's sounds. Sound effects are synthesised at start up, so the game
+// doesn't need any audio files, though clips such as spoken commentary can be added.
+type Manager struct {
+	context *audio.Context
+	clips   map[Clip][]byte
+	buses   map[Clip]Bus // For clips that don't play on the SFX bus
+	enabled bool
+	volumes Volumes
+	queued  []Clip // Sounds asked for since the last Update
+	music   []musicStem
+	voice   *audio.Player // The commentary clip playing, so that commentators don't talk over each other
+	ducking int           // Ticks left with the music ducked under other sounds
+	// How tense the music is, from 0 to 1, and how tense it was last asked to be
+	intensity, targetIntensity float64
+	cutTicks                   int     // Ticks left with the music cut after a dismissal
+	musicReturn                float64 // How far the music has come back after a cut, from 0 to 1
+	// Silenced while the game waits in the background
+	suspended bool
+	logger    logger.Logger
+}
+
+// NewManager prepares the sounds. A disabled manager plays nothing, which is also what
+// headless runs want.
+func NewManager(enabled bool, volumes Volumes) *Manager {
+	m := &Manager{
+		clips:       make(map[Clip][]byte),
+		buses:       make(map[Clip]Bus),
+		enabled:     enabled,
+		volumes:     volumes,
+		musicReturn: 1,
+		logger:      logger.New(),
+	}
+	if !enabled {
+		return m
+	}
+
+	m.context = audio.NewContext(sampleRate)
+	m.clips[ClipHit] = synthesizeHit()
+	m.clips[ClipAppeal] = synthesizeAppeal()
+	m.clips[ClipOut] = synthesizeOut()
+	m.clips[ClipVictory] = synthesizeVictory()
+	m.clips[ClipSting] = synthesizeSting()
+
+	return m
+}
+
+// AddWAV decodes a WAV file into a clip that plays on the given bus
+func (m *Manager) AddWAV(clip Clip, bus Bus, data []byte) error {
+	if !m.enabled {
+		return nil
+	}
+
+	stream, err := wav.DecodeF32(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("could not decode %s: %w", clip, err)
+	}
+
+	var source io.Reader = stream
+	if stream.SampleRate() != sampleRate {
+		source = audio.ResampleF32(stream, stream.Length(), stream.SampleRate(), sampleRate)
+	}
+	decoded, err := io.ReadAll(source)
+	if err != nil {
+		return fmt.Errorf("could not decode %s: %w", clip, err)
+	}
+
+	m.clips[clip] = decoded
+	m.buses[clip] = bus
+	return nil
+}
+
+// Play asks for a sound to be played on the next Update, along with any others asked for
+// on the same tick
+func (m *Manager) Play(clip Clip) {
+	if !m.enabled || m.suspended {
+		return
+	}
+
+	if _, ok := m.clips[clip]; !ok {
+		m.logger.Warn("unknown sound clip", "clip", clip)
+		return
+	}
+	if !slices.Contains(m.queued, clip) {
+		m.queued = append(m.queued, clip)
+	}
+}
+
+// Update plays the sounds asked for since the last tick, most important first, and ducks
+// the music under them. Commentary waits for no one, but is dropped while the last clip is
+// still being spoken.
+func (m *Manager) Update() {
+	if !m.enabled || m.suspended {
+		return
+	}
+
+	slices.SortStableFunc(m.queued, func(a, b Clip) int {
+		return priorityOf(b) - priorityOf(a)
+	})
+	effects := 0
+	for _, clip := range m.queued {
+		bus := m.busOf(clip)
+		switch {
+		case bus == BusCommentary && m.voice != nil && m.voice.IsPlaying(),
+			bus != BusCommentary && effects == maxSoundsPerTick:
+			if logger.DebugEnabled() {
+				m.logger.Debug("sound dropped", "clip", clip)
+			}
+			continue
+		}
+
+		player := m.context.NewPlayerF32FromBytes(m.clips[clip])
+		player.SetVolume(m.volumes.of(bus))
+		player.Play()
+		if bus == BusCommentary {
+			m.voice = player
+		} else {
+			effects++
+		}
+		m.ducking = duckTicks
+	}
+	m.queued = m.queued[:0]
+
+	if m.ducking > 0 {
+		m.ducking--
+	}
+	m.updateMusic()
+}
+
+// StartMusic loops the background music until the game ends, every stem together with
+// those the match isn't tense enough for yet silent
+func (m *Manager) StartMusic() {
+	if !m.enabled || m.music != nil {
+		return
+	}
+
+	stems := make([]musicStem, 0, len(musicStems))
+	for _, s := range musicStems {
+		player, err := m.startStem(s)
+		if err != nil {
+			m.logger.Warn("could not start music", "stem", s.name, "error", err)
+			continue
+		}
+		stems = append(stems, musicStem{stem: s, player: player})
+	}
+	for _, s := range stems {
+		s.player.SetVolume(m.volumes.Music * s.level(m.intensity))
+		s.player.Play()
+	}
+	m.music = stems
+}
+
+// Suspend silences the game while it waits in the background, pausing the music and any
+// commentary where they are
+func (m *Manager) Suspend() {
+	if !m.enabled || m.suspended {
+		return
+	}
+
+	m.suspended = true
+	m.queued = m.queued[:0]
+	for _, s := range m.music {
+		s.player.Pause()
+	}
+	if m.voice != nil {
+		m.voice.Pause()
+	}
+}
+
+// Resume carries on from where Suspend left off
+func (m *Manager) Resume() {
+	if !m.enabled || !m.suspended {
+		return
+	}
+
+	m.suspended = false
+	for _, s := range m.music {
+		s.player.Play()
+	}
+	if m.voice != nil {
+		m.voice.Play()
+	}
+}
+
+// synthesizeHit makes the sharp crack of leather on willow
+func synthesizeHit() []byte {
+	const duration = 0.12
+
+	return synthesize(duration, func(t float64) float64 {
+		envelope := math.Exp(-40 * t)
+		return 0.6 * envelope * (0.6*(rand.Float64()*2-1) + 0.4*math.Sin(2*math.Pi*1200*t))
+	})
+}
+
+// synthesizeAppeal makes a rising, crowd-like "howzat" of filtered noise over a tone
+func synthesizeAppeal() []byte {
+	const duration = 0.7
+	smoothedNoise := 0.0
+
+	return synthesize(duration, func(t float64) float64 {
+		smoothedNoise += (rand.Float64()*2 - 1 - smoothedNoise) * 0.2
+		pitch := 300 + 200*t/duration
+		envelope := math.Sin(math.Pi * t / duration)
+		return envelope * (0.5*smoothedNoise + 0.3*math.Sin(2*math.Pi*pitch*t))
+	})
+}
+
+// synthesizeOut makes a short, falling thud
+func synthesizeOut() []byte {
+	const duration = 0.35
+
+	return synthesize(duration, func(t float64) float64 {
+		pitch := 180 - 100*t/duration
+		envelope := math.Exp(-8 * t)
+		return 0.7 * envelope * math.Sin(2*math.Pi*pitch*t)
+	})
+}
+
+// synthesizeVictory makes a rising fanfare, ending on a held chord
+func synthesizeVictory() []byte {
+	const (
+		noteLength = 0.18
+		duration   = 2.0
+	)
+	// C, E, G and the C above, then all of them together
+	notes := []float64{523.25, 659.25, 783.99, 1046.5}
+	chordStart := noteLength * float64(len(notes))
+
+	return synthesize(duration, func(t float64) float64 {
+		if t < chordStart {
+			i := int(t / noteLength)
+			local := t - float64(i)*noteLength
+			return 0.4 * math.Exp(-4*local) * math.Sin(2*math.Pi*notes[i]*t)
+		}
+
+		envelope := math.Exp(-2 * (t - chordStart))
+		chord := 0.0
+		for _, note := range notes {
+			chord += math.Sin(2 * math.Pi * note * t)
+		}
+		return 0.15 * envelope * chord
+	})
+}
+
+// synthesizeSting makes a short, tense stab of two clashing notes, rising, to warn of
+// trouble ahead
+func synthesizeSting() []byte {
+	const duration = 0.6
+
+	return synthesize(duration, func(t float64) float64 {
+		rise := 1 + 0.5*t/duration
+		envelope := math.Exp(-5 * t)
+		return 0.3 * envelope * (math.Sin(2*math.Pi*440*rise*t) + math.Sin(2*math.Pi*466.16*rise*t))
+	})
+}
+
+// synthesizeMusic makes a calm, looping arpeggio to play under the game, the stem the
+// others are layered over
+func synthesizeMusic() []byte {
+	const (
+		noteLength = 0.25
+		duration   = musicLength
+	)
+	// Two bars each of C, A minor, F and G, one note of the chord at a time
+	chords := [][]float64{
+		{261.63, 329.63, 392.00, 329.63},
+		{220.00, 261.63, 329.63, 261.63},
+		{174.61, 220.00, 261.63, 220.00},
+		{196.00, 246.94, 293.66, 246.94},
+	}
+	notesPerChord := int(duration/noteLength) / len(chords)
+
+	return synthesize(duration, func(t float64) float64 {
+		i := int(t / noteLength)
+		chord := chords[i/notesPerChord%len(chords)]
+		local := t - float64(i)*noteLength
+		return 0.12 * math.Exp(-6*local) * math.Sin(2*math.Pi*chord[i%len(chord)]*t)
+	})
+}
+
+// synthesize renders a mono waveform as the stereo 32-bit float samples that audio players expect
+func synthesize(duration float64, wave func(t float64) float64) []byte {
+	samples := int(duration * sampleRate)
+	data := make([]byte, 0, samples*8)
+
+	for i := range samples {
+		value := math.Float32bits(float32(wave(float64(i) / sampleRate)))
+		data = binary.LittleEndian.AppendUint32(data, value) // Left
+		data = binary.LittleEndian.AppendUint32(data, value) // Right
+	}
+
+	return data
+}
@@ -0,0 +1,117 @@
+package sound
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+const (
+	musicLength      = 8.0                       // Seconds every stem loops over, so that they stay together
+	stemFadeRange    = 0.2                       // Intensity over which a stem fades all the way in
+	intensityEase    = 0.02                      // Share of the way to the asked for intensity the music moves each tick
+	musicCutTicks    = 2 * ebiten.DefaultTPS     // Silence after a dismissal
+	musicReturnTicks = 3 * ebiten.DefaultTPS / 2 // How long the music takes to come back after the silence
+)
+
+// stem is a layer of the background music, brought in as the match gets tenser
+type stem struct {
+	name       string
+	from       float64 // Intensity the stem starts fading in at, 0 for one that always plays
+	synthesize func() []byte
+}
+
+// musicStems are the layers of the background music, quietest first. Every one lasts
+// musicLength, so that they loop together.
+var musicStems = []stem{
+	{name: "arpeggio", from: 0, synthesize: synthesizeMusic},
+	{name: "bass", from: 0.3, synthesize: synthesizeBass},
+	{name: "drums", from: 0.6, synthesize: synthesizeDrums},
+}
+
+// musicStem is a stem being played
+type musicStem struct {
+	stem
+	player *audio.Player
+}
+
+// level is how much of the music volume the stem plays at, for the intensity of the music
+func (s musicStem) level(intensity float64) float64 {
+	if s.from == 0 {
+		return 1
+	}
+	return min(max((intensity-s.from)/stemFadeRange, 0), 1)
+}
+
+// SetIntensity asks for the music to be as tense as the level given, from 0 for calm to 1
+// for the most tense. The music eases towards it, bringing layers in or out on the way.
+func (m *Manager) SetIntensity(level float64) {
+	m.targetIntensity = min(max(level, 0), 1)
+}
+
+// CutMusic silences the music for a moment, as a dismissal sinks in, and then brings it
+// back
+func (m *Manager) CutMusic() {
+	m.cutTicks = musicCutTicks
+	m.musicReturn = 0
+}
+
+// updateMusic eases the music towards the intensity asked for and sets the volume of each
+// stem
+func (m *Manager) updateMusic() {
+	m.intensity += (m.targetIntensity - m.intensity) * intensityEase
+
+	switch {
+	case m.cutTicks > 0:
+		m.cutTicks--
+	case m.musicReturn < 1:
+		m.musicReturn = min(m.musicReturn+1.0/musicReturnTicks, 1)
+	}
+
+	volume := m.volumes.Music * m.duckLevel() * m.musicReturn
+	if m.cutTicks > 0 {
+		volume = 0
+	}
+	for _, s := range m.music {
+		s.player.SetVolume(volume * s.level(m.intensity))
+	}
+}
+
+// startStem loops a stem from the top
+func (m *Manager) startStem(s stem) (*audio.Player, error) {
+	data := s.synthesize()
+	return m.context.NewPlayerF32(audio.NewInfiniteLoopF32(bytes.NewReader(data), int64(len(data))))
+}
+
+// synthesizeBass makes a low, plucked root note on every beat, following the arpeggio's
+// chords
+func synthesizeBass() []byte {
+	const beatLength = 0.5
+	// The roots of C, A minor, F and G, an octave or two down
+	roots := []float64{65.41, 55.00, 87.31, 98.00}
+	beatsPerChord := int(musicLength/beatLength) / len(roots)
+
+	return synthesize(musicLength, func(t float64) float64 {
+		i := int(t / beatLength)
+		local := t - float64(i)*beatLength
+		root := roots[i/beatsPerChord%len(roots)]
+		return 0.25 * math.Exp(-5*local) * (math.Sin(2*math.Pi*root*t) + 0.3*math.Sin(2*math.Pi*2*root*t))
+	})
+}
+
+// synthesizeDrums makes a driving beat: a kick on every beat and a hiss of hi-hat on every
+// half beat
+func synthesizeDrums() []byte {
+	const beatLength = 0.5
+
+	return synthesize(musicLength, func(t float64) float64 {
+		beat := math.Mod(t, beatLength)
+		half := math.Mod(t, beatLength/2)
+		kick := math.Exp(-30*beat) * math.Sin(2*math.Pi*(50+60*math.Exp(-40*beat))*beat)
+		hat := math.Exp(-80*half) * (rand.Float64()*2 - 1)
+		return 0.35*kick + 0.08*hat
+	})
+}
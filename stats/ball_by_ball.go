@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+)
+
+// ballByBallVersion is bumped whenever the layout of a ball-by-ball log changes
+const ballByBallVersion = "1.0.0"
+
+// BallByBall is a match's ball-by-ball log, laid out after the Cricsheet JSON format so that
+// tools which read Cricsheet data can read it too. Each delivery also carries the game's
+// own details of the ball, such as its type and speed.
+type BallByBall struct {
+	Meta    BallByBallMeta `json:"meta"`
+	Info    BallByBallInfo `json:"info"`
+	Innings []InningsLog   `json:"innings"`
+}
+
+type BallByBallMeta struct {
+	DataVersion string `json:"data_version"`
+	Created     string `json:"created"` // As YYYY-MM-DD
+}
+
+type BallByBallInfo struct {
+	BallsPerOver int      `json:"balls_per_over"`
+	Dates        []string `json:"dates"`
+	MatchType    string   `json:"match_type"` // The game mode
+	Overs        int      `json:"overs,omitempty"`
+}
+
+// InningsLog is one side's innings, over by over
+type InningsLog struct {
+	Team  string    `json:"team"`
+	Overs []OverLog `json:"overs"`
+}
+
+type OverLog struct {
+	Over       int           `json:"over"` // Counting from 0
+	Deliveries []DeliveryLog `json:"deliveries"`
+}
+
+type DeliveryLog struct {
+	Ball         string         `json:"ball"` // Over and ball, such as 3.2 for the second ball of the fourth over
+	Batter       string         `json:"batter"`
+	Bowler       string         `json:"bowler"`
+	Runs         DeliveryRuns   `json:"runs"`
+	Extras       *DeliveryExtra `json:"extras,omitempty"`
+	Wickets      []WicketLog    `json:"wickets,omitempty"`
+	DeliveryType string         `json:"delivery_type,omitempty"`
+	Speed        float64        `json:"speed"`
+	Outcome      Outcome        `json:"outcome"`
+}
+
+type DeliveryRuns struct {
+	Batter int `json:"batter"`
+	Extras int `json:"extras"`
+	Total  int `json:"total"`
+}
+
+type DeliveryExtra struct {
+	Wides   int `json:"wides,omitempty"`
+	NoBalls int `json:"noballs,omitempty"`
+}
+
+type WicketLog struct {
+	PlayerOut string `json:"player_out"`
+	Kind      string `json:"kind"`
+}
+
+// NewBallByBall starts a log for a match played now
+func NewBallByBall(matchType string, overs, ballsPerOver int, now time.Time) *BallByBall {
+	date := now.Format(time.DateOnly)
+	return &BallByBall{
+		Meta: BallByBallMeta{DataVersion: ballByBallVersion, Created: date},
+		Info: BallByBallInfo{
+			BallsPerOver: ballsPerOver,
+			Dates:        []string{date},
+			MatchType:    matchType,
+			Overs:        overs,
+		},
+		Innings: make([]InningsLog, 0, 2),
+	}
+}
+
+// AddInnings logs a side's innings from its record
+func (b *BallByBall) AddInnings(team string, innings *Innings) {
+	ballsPerOver := b.Info.BallsPerOver
+	log := InningsLog{Team: team, Overs: make([]OverLog, 0)}
+	legal := 0
+	for _, event := range innings.Events() {
+		over := legal / ballsPerOver
+		if len(log.Overs) == 0 || log.Overs[len(log.Overs)-1].Over != over {
+			log.Overs = append(log.Overs, OverLog{Over: over, Deliveries: make([]DeliveryLog, 0, ballsPerOver)})
+		}
+
+		delivery := DeliveryLog{
+			Ball:         fmt.Sprintf("%d.%d", over, legal%ballsPerOver+1),
+			Batter:       event.Batter,
+			Bowler:       event.Bowler,
+			Runs:         DeliveryRuns{Batter: event.Runs, Total: event.Runs},
+			DeliveryType: event.DeliveryType,
+			Speed:        event.Speed,
+			Outcome:      event.Outcome,
+		}
+		if event.Outcome == OutcomeWide {
+			delivery.Runs.Batter, delivery.Runs.Extras = 0, event.Runs
+			delivery.Extras = &DeliveryExtra{Wides: event.Runs}
+		}
+		if event.Outcome == OutcomeNoBall {
+			delivery.Runs.Batter, delivery.Runs.Extras = 0, event.Runs
+			delivery.Extras = &DeliveryExtra{NoBalls: event.Runs}
+		}
+		if event.Outcome.Wicket() {
+			delivery.Wickets = []WicketLog{{PlayerOut: event.Batter, Kind: string(event.Outcome)}}
+		}
+		if event.Outcome.Legal() {
+			legal++
+		}
+
+		current := &log.Overs[len(log.Overs)-1]
+		current.Deliveries = append(current.Deliveries, delivery)
+	}
+
+	b.Innings = append(b.Innings, log)
+}
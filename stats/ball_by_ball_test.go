@@ -0,0 +1,44 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestBallByBallWickets checks that every way of getting out is logged as a wicket, against
+// the batter who was out
+func TestBallByBallWickets(t *testing.T) {
+	innings := NewInningsFrom([]BallEvent{
+		{Number: 1, Outcome: OutcomeHit, Runs: 4, Batter: "A"},
+		{Number: 2, Outcome: OutcomeBowled, Batter: "A"},
+		{Number: 3, Outcome: OutcomeWide, Runs: 1, Batter: "B"},
+		{Number: 4, Outcome: OutcomeLBW, Batter: "B"},
+		{Number: 5, Outcome: OutcomeCaught, Batter: "C"},
+		{Number: 6, Outcome: OutcomeHitWicket, Batter: "D"},
+		{Number: 7, Outcome: OutcomeMissed, Batter: "E"},
+	})
+	log := NewBallByBall("overs", 2, 4, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC))
+	log.AddInnings("Player", innings)
+
+	var got []WicketLog
+	var balls []string
+	for _, over := range log.Innings[0].Overs {
+		for _, delivery := range over.Deliveries {
+			got = append(got, delivery.Wickets...)
+			balls = append(balls, delivery.Ball)
+		}
+	}
+	want := []WicketLog{
+		{PlayerOut: "A", Kind: "bowled"},
+		{PlayerOut: "B", Kind: "lbw"},
+		{PlayerOut: "C", Kind: "caught"},
+		{PlayerOut: "D", Kind: "hit wicket"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got wickets %+v, want %+v", got, want)
+	}
+	if wantBalls := []string{"0.1", "0.2", "0.3", "0.3", "0.4", "1.1", "1.2"}; !reflect.DeepEqual(balls, wantBalls) {
+		t.Errorf("got balls %v, want %v", balls, wantBalls)
+	}
+}
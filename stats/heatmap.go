@@ -0,0 +1,53 @@
+package stats
+
+// Heatmap counts deliveries by the height they arrived at and how fast they were going.
+// Rows run from the top of the screen down and columns from slow to fast.
+type Heatmap struct {
+	counts [][]int
+	max    int
+}
+
+// NewHeatmap bins the given deliveries into a grid. Heights are fractions of the screen
+// height and speeds are spread between minSpeed and maxSpeed, with anything outside those
+// going in the nearest cell.
+func NewHeatmap(events []BallEvent, rows, cols int, minSpeed, maxSpeed float64) Heatmap {
+	h := Heatmap{counts: make([][]int, rows)}
+	for row := range h.counts {
+		h.counts[row] = make([]int, cols)
+	}
+
+	for _, event := range events {
+		row := bin(event.Height, 0, 1, rows)
+		col := bin(event.Speed, minSpeed, maxSpeed, cols)
+		h.counts[row][col]++
+		h.max = max(h.max, h.counts[row][col])
+	}
+
+	return h
+}
+
+func bin(value, low, high float64, bins int) int {
+	if high <= low {
+		return 0
+	}
+	i := int((value - low) / (high - low) * float64(bins))
+	return min(max(i, 0), bins-1)
+}
+
+func (h Heatmap) Rows() int { return len(h.counts) }
+
+func (h Heatmap) Cols() int {
+	if len(h.counts) == 0 {
+		return 0
+	}
+	return len(h.counts[0])
+}
+
+func (h Heatmap) Count(row, col int) int {
+	return h.counts[row][col]
+}
+
+// Max is the count in the busiest cell
+func (h Heatmap) Max() int {
+	return h.max
+}
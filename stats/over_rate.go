@@ -0,0 +1,11 @@
+package stats
+
+import "time"
+
+// BallsPerMinute is the over rate for the given number of balls bowled in the given time
+func BallsPerMinute(balls int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(balls) / elapsed.Minutes()
+}
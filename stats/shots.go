@@ -0,0 +1,81 @@
+package stats
+
+import "math"
+
+// Shot is the cricket shot a hit looks like, going by how the bat met the ball and where
+// the ball went
+type Shot string
+
+const (
+	ShotStraightDrive Shot = "straight drive" // Along the ground, with the bat coming through straight
+	ShotCoverDrive    Shot = "cover drive"    // Along the ground, with the bat turned through the ball
+	ShotPull          Shot = "pull"           // Up and away, off a bat swung across the line
+	ShotCut           Shot = "cut"            // Chopped down into the ground
+)
+
+const (
+	pullMinAngle       = math.Pi / 6   // Shots climbing more steeply than this are pulls
+	cutMaxAngle        = -math.Pi / 18 // Shots going down more steeply than this are cuts
+	coverDriveBatAngle = -0.2          // A bat turned further forward than this at contact drives through the covers
+)
+
+// Shots lists every kind of shot, in the order scorecards show them
+func Shots() []Shot {
+	return []Shot{ShotStraightDrive, ShotCoverDrive, ShotPull, ShotCut}
+}
+
+// ClassifyShot names a shot from the angle of the bat as it met the ball, in radians from
+// the vertical with the forward swing going negative, and the direction the ball went off
+// the bat, in radians with 0 being straight back and positive being up
+func ClassifyShot(batAngle, angle float64) Shot {
+	switch {
+	case angle > pullMinAngle:
+		return ShotPull
+	case angle < cutMaxAngle:
+		return ShotCut
+	case batAngle < coverDriveBatAngle:
+		return ShotCoverDrive
+	default:
+		return ShotStraightDrive
+	}
+}
+
+// ShotTally counts the kinds of shot played in the innings
+func (i *Innings) ShotTally() map[Shot]int {
+	tally := make(map[Shot]int)
+	for _, event := range i.events {
+		if len(event.Shot) > 0 {
+			tally[event.Shot]++
+		}
+	}
+	return tally
+}
+
+// BestShot is the hit of the innings that scored the most, with the longest carry and then
+// the closest timing breaking ties. It reports false if nothing was hit.
+func (i *Innings) BestShot() (BallEvent, bool) {
+	var (
+		best  BallEvent
+		found bool
+	)
+	for _, event := range i.events {
+		if event.Outcome != OutcomeHit {
+			continue
+		}
+		if !found || betterShot(event, best) {
+			best, found = event, true
+		}
+	}
+	return best, found
+}
+
+func betterShot(a, b BallEvent) bool {
+	switch {
+	case a.Runs != b.Runs:
+		return a.Runs > b.Runs
+	case a.Carry != b.Carry:
+		return a.Carry > b.Carry
+	default:
+		return math.Abs(float64(a.TimingFrames)) < math.Abs(float64(b.TimingFrames))
+	}
+}
@@ -0,0 +1,177 @@
+package stats
+
+// Outcome is what became of a delivery
+type Outcome string
+
+const (
+	OutcomeHit       Outcome = "hit"
+	OutcomeMissed    Outcome = "missed"
+	OutcomeBowled    Outcome = "bowled"
+	OutcomeLBW       Outcome = "lbw"
+	OutcomeCaught    Outcome = "caught"
+	OutcomeHitWicket Outcome = "hit wicket" // The batsman knocked their own stumps over
+	OutcomeBlocked   Outcome = "blocked"    // Played with a defensive shot, so no runs
+	OutcomeLeft      Outcome = "left"       // Let through without playing a shot
+	OutcomeWide      Outcome = "wide"       // Out of the batsman's reach, so it doesn't count and gives away a run
+	OutcomeNoBall    Outcome = "no ball"    // A full toss past the batsman above the waist, which doesn't count either
+)
+
+// Legal is true for deliveries that count towards the overs
+func (o Outcome) Legal() bool {
+	return o != OutcomeWide && o != OutcomeNoBall
+}
+
+// Wicket is true for deliveries the batsman got out to
+func (o Outcome) Wicket() bool {
+	switch o {
+	case OutcomeBowled, OutcomeLBW, OutcomeCaught, OutcomeHitWicket:
+		return true
+	}
+	return false
+}
+
+// Dot is true for legal deliveries that the batsman didn't score off
+func (o Outcome) Dot() bool {
+	switch o {
+	case OutcomeMissed, OutcomeBlocked, OutcomeLeft:
+		return true
+	}
+	return false
+}
+
+// BallEvent records a single delivery and what the batsman did with it
+type BallEvent struct {
+	Number       int     `json:"number"` // Position of the ball in the innings, starting at 1
+	DeliveryType string  `json:"delivery_type,omitempty"`
+	Speed        float64 `json:"speed"`
+	Height       float64 `json:"height"`
+	Outcome      Outcome `json:"outcome"`
+	Runs         int     `json:"runs"`
+	Lofted       bool    `json:"lofted"` // The ball went up in the air off the bat
+	Bowler       string  `json:"bowler,omitempty"`
+	Batter       string  `json:"batter,omitempty"`
+	Angle        float64 `json:"angle,omitempty"` // Direction the ball went off the bat in radians, 0 being straight back and positive being up
+	Timing       Timing  `json:"timing,omitempty"`
+	TimingFrames int     `json:"timing_frames,omitempty"` // How early (negative) or late (positive) the shot was
+	Carry        float64 `json:"carry,omitempty"`         // How far a lofted shot went through the air, in metres
+	Shot         Shot    `json:"shot,omitempty"`
+}
+
+// Figures are a bowler's numbers for the innings
+type Figures struct {
+	Bowler  string `json:"bowler"`
+	Balls   int    `json:"balls"` // Legal deliveries bowled
+	Runs    int    `json:"runs"`  // Runs conceded, wides included
+	Wickets int    `json:"wickets"`
+}
+
+// Innings keeps the ball by ball record of an innings
+type Innings struct {
+	events []BallEvent
+}
+
+func NewInnings() *Innings {
+	return &Innings{
+		events: make([]BallEvent, 0),
+	}
+}
+
+func (i *Innings) Record(event BallEvent) {
+	i.events = append(i.events, event)
+}
+
+// Events returns every ball recorded so far, oldest first
+func (i *Innings) Events() []BallEvent {
+	return i.events
+}
+
+// Recent returns up to the last n balls recorded, oldest first
+func (i *Innings) Recent(n int) []BallEvent {
+	if n >= len(i.events) {
+		return i.events
+	}
+	return i.events[len(i.events)-n:]
+}
+
+// ThisOver returns the balls of the over in progress, or of the last over until the next
+// ball is recorded, oldest first
+func (i *Innings) ThisOver(ballsPerOver int) []BallEvent {
+	start, legal := 0, 0
+	for j, event := range i.events {
+		if legal == ballsPerOver {
+			start, legal = j, 0
+		}
+		if event.Outcome.Legal() {
+			legal++
+		}
+	}
+	return i.events[start:]
+}
+
+// BowlerFigures returns the figures of every named bowler, in the order they first bowled
+func (i *Innings) BowlerFigures() []Figures {
+	figures := make([]Figures, 0)
+	index := make(map[string]int)
+
+	for _, event := range i.events {
+		if len(event.Bowler) == 0 {
+			continue
+		}
+		j, ok := index[event.Bowler]
+		if !ok {
+			j = len(figures)
+			index[event.Bowler] = j
+			figures = append(figures, Figures{Bowler: event.Bowler})
+		}
+
+		if event.Outcome.Legal() {
+			figures[j].Balls++
+		}
+		figures[j].Runs += event.Runs
+		if event.Outcome.Wicket() {
+			figures[j].Wickets++
+		}
+	}
+
+	return figures
+}
+
+// LongestCarry is the furthest any lofted shot that wasn't caught carried in the innings,
+// in metres
+func (i *Innings) LongestCarry() float64 {
+	longest := 0.0
+	for _, event := range i.events {
+		if event.Outcome == OutcomeHit && event.Lofted {
+			longest = max(longest, event.Carry)
+		}
+	}
+	return longest
+}
+
+// DotBalls counts the balls recorded so far that nothing was scored off
+func (i *Innings) DotBalls() int {
+	dots := 0
+	for _, event := range i.events {
+		if event.Outcome.Dot() {
+			dots++
+		}
+	}
+	return dots
+}
+
+// SetOutcome changes what became of an already recorded ball, for example when an appeal
+// is upheld. It returns false if there is no such ball.
+func (i *Innings) SetOutcome(number int, outcome Outcome, runs int) bool {
+	for j := len(i.events) - 1; j >= 0; j-- {
+		if i.events[j].Number == number {
+			i.events[j].Outcome = outcome
+			i.events[j].Runs = runs
+			return true
+		}
+	}
+	return false
+}
+
+func (i *Innings) Reset() {
+	i.events = i.events[:0]
+}
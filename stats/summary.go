@@ -0,0 +1,76 @@
+package stats
+
+import "encoding/json"
+
+// Summary is an innings at a glance, for tools that post or keep match results. It marshals
+// to JSON as it is, so that they needn't parse the scorecard.
+type Summary struct {
+	Runs         int             `json:"runs"`  // Extras included
+	Balls        int             `json:"balls"` // Legal deliveries faced
+	Wickets      int             `json:"wickets"`
+	Dismissals   map[Outcome]int `json:"dismissals"` // How the wickets fell
+	Wides        int             `json:"wides"`
+	NoBalls      int             `json:"no_balls"`
+	DotBalls     int             `json:"dot_balls"`
+	RunRate      float64         `json:"run_rate"` // Runs an over
+	LongestCarry float64         `json:"longest_carry"`
+	BestShot     *BallEvent      `json:"best_shot,omitempty"`
+	Shots        map[Shot]int    `json:"shots"`
+	Timing       map[Timing]int  `json:"timing"`
+	Bowlers      []Figures       `json:"bowlers"`
+}
+
+// Summary sums up the innings so far, with overs of the given length
+func (i *Innings) Summary(ballsPerOver int) Summary {
+	s := Summary{
+		DotBalls:     i.DotBalls(),
+		LongestCarry: i.LongestCarry(),
+		Shots:        i.ShotTally(),
+		Timing:       i.TimingDistribution(),
+		Bowlers:      i.BowlerFigures(),
+		Dismissals:   make(map[Outcome]int),
+	}
+	for _, event := range i.events {
+		s.Runs += event.Runs
+		switch event.Outcome {
+		case OutcomeWide:
+			s.Wides++
+		case OutcomeNoBall:
+			s.NoBalls++
+		default:
+			s.Balls++
+		}
+		if event.Outcome.Wicket() {
+			s.Wickets++
+			s.Dismissals[event.Outcome]++
+		}
+	}
+	if s.Balls > 0 {
+		s.RunRate = float64(s.Runs) * float64(ballsPerOver) / float64(s.Balls)
+	}
+	if best, ok := i.BestShot(); ok {
+		s.BestShot = &best
+	}
+	return s
+}
+
+// NewInningsFrom makes an innings of balls already recorded, such as those read back from
+// JSON, oldest first
+func NewInningsFrom(events []BallEvent) *Innings {
+	return &Innings{events: append(make([]BallEvent, 0, len(events)), events...)}
+}
+
+// MarshalJSON writes the innings as its list of balls
+func (i *Innings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.events)
+}
+
+// UnmarshalJSON reads an innings written by MarshalJSON
+func (i *Innings) UnmarshalJSON(data []byte) error {
+	var events []BallEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return err
+	}
+	i.events = events
+	return nil
+}
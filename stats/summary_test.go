@@ -0,0 +1,41 @@
+package stats
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+)
+
+// TestSummaryWickets checks that an innings ending in a hit wicket counts it with the rest
+func TestSummaryWickets(t *testing.T) {
+	innings := NewInningsFrom([]BallEvent{
+		{Number: 1, Outcome: OutcomeHit, Runs: 4},
+		{Number: 2, Outcome: OutcomeBowled},
+		{Number: 3, Outcome: OutcomeWide, Runs: 1},
+		{Number: 4, Outcome: OutcomeCaught},
+		{Number: 5, Outcome: OutcomeMissed},
+		{Number: 6, Outcome: OutcomeHitWicket},
+	})
+	s := innings.Summary(6)
+
+	if s.Runs != 5 || s.Balls != 5 || s.Wides != 1 || s.Wickets != 3 {
+		t.Errorf("got %d runs off %d balls, %d wides, %d wickets, want 5 off 5, 1 wide, 3 wickets",
+			s.Runs, s.Balls, s.Wides, s.Wickets)
+	}
+	want := map[Outcome]int{OutcomeBowled: 1, OutcomeCaught: 1, OutcomeHitWicket: 1}
+	if !maps.Equal(s.Dismissals, want) {
+		t.Errorf("got dismissals %v, want %v", s.Dismissals, want)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read Summary
+	if err := json.Unmarshal(data, &read); err != nil {
+		t.Fatal(err)
+	}
+	if read.Wickets != 3 || !maps.Equal(read.Dismissals, want) {
+		t.Errorf("read back %d wickets, dismissals %v", read.Wickets, read.Dismissals)
+	}
+}
@@ -0,0 +1,27 @@
+package stats
+
+// Timing is how well a shot was timed
+type Timing string
+
+const (
+	TimingEarly   Timing = "early"
+	TimingPerfect Timing = "perfect" // On time and off the middle of the bat
+	TimingOnTime  Timing = "on time" // On time, but away from the middle of the bat
+	TimingLate    Timing = "late"
+)
+
+// Timings lists every kind of timing, earliest first
+func Timings() []Timing {
+	return []Timing{TimingEarly, TimingPerfect, TimingOnTime, TimingLate}
+}
+
+// TimingDistribution counts how the shots in the innings were timed
+func (i *Innings) TimingDistribution() map[Timing]int {
+	distribution := make(map[Timing]int)
+	for _, event := range i.events {
+		if len(event.Timing) > 0 {
+			distribution[event.Timing]++
+		}
+	}
+	return distribution
+}
@@ -0,0 +1,29 @@
+package team
+
+const (
+	StylePace = "pace"
+	StyleSpin = "spin"
+)
+
+// Bowler is a member of the opposition's bowling attack
+type Bowler struct {
+	Name  string
+	Style string  // StylePace or StyleSpin
+	Skill float64 // From 0 to 1
+}
+
+var attack = []Bowler{
+	{Name: "Kumar", Style: StylePace, Skill: 0.8},
+	{Name: "Anderson", Style: StylePace, Skill: 0.9},
+	{Name: "Rashid", Style: StyleSpin, Skill: 0.85},
+	{Name: "Boult", Style: StylePace, Skill: 0.75},
+	{Name: "Lyon", Style: StyleSpin, Skill: 0.7},
+}
+
+// Attack returns the opposition's bowlers, in the order they come on to bowl
+func Attack() []Bowler {
+	bowlers := make([]Bowler, len(attack))
+	copy(bowlers, attack)
+
+	return bowlers
+}
@@ -0,0 +1,89 @@
+package team
+
+import "fmt"
+
+// XISize is how many players make up a team
+const XISize = 11
+
+// Batsman is a player and how they bat. Each rating is a multiplier, with 1 being an
+// ordinary batsman.
+type Batsman struct {
+	Name    string
+	Power   float64 // How hard the ball comes off the bat
+	Timing  float64 // How forgiving the bat is of a ball that is slightly off the middle
+	BatSize float64 // How big a bat the batsman carries
+}
+
+// Standard is the ordinary batsman used when no team has been picked
+func Standard() Batsman {
+	return Batsman{Name: "Batsman", Power: 1, Timing: 1, BatSize: 1}
+}
+
+// The squad to pick an XI from, with specialist batsmen first and tailenders last
+var squad = []Batsman{
+	{Name: "Sharma", Power: 1.1, Timing: 1.25, BatSize: 1},
+	{Name: "Williams", Power: 1.3, Timing: 1, BatSize: 1.05},
+	{Name: "Iyer", Power: 1, Timing: 1.3, BatSize: 0.95},
+	{Name: "Khan", Power: 1.4, Timing: 0.9, BatSize: 1.1},
+	{Name: "Taylor", Power: 1.15, Timing: 1.15, BatSize: 1},
+	{Name: "Patel", Power: 1.2, Timing: 1.05, BatSize: 1},
+	{Name: "Brown", Power: 1.25, Timing: 0.95, BatSize: 1.05},
+	{Name: "Nair", Power: 0.95, Timing: 1.1, BatSize: 1},
+	{Name: "Singh", Power: 1.05, Timing: 0.9, BatSize: 0.95},
+	{Name: "Jones", Power: 0.9, Timing: 0.85, BatSize: 0.95},
+	{Name: "Reddy", Power: 0.85, Timing: 0.8, BatSize: 0.9},
+	{Name: "Ali", Power: 0.8, Timing: 0.75, BatSize: 0.9},
+	{Name: "Smith", Power: 1.1, Timing: 0.8, BatSize: 1},
+	{Name: "Das", Power: 0.75, Timing: 0.7, BatSize: 0.85},
+}
+
+// Squad returns every player available for selection
+func Squad() []Batsman {
+	players := make([]Batsman, len(squad))
+	copy(players, squad)
+
+	return players
+}
+
+// DefaultXI is the first eleven players in the squad
+func DefaultXI() []string {
+	names := make([]string, 0, XISize)
+	for _, player := range squad[:XISize] {
+		names = append(names, player.Name)
+	}
+
+	return names
+}
+
+// Pick returns the named players from the squad, in the order given
+func Pick(names []string) ([]Batsman, error) {
+	if len(names) != XISize {
+		return nil, fmt.Errorf("a team needs %d players, not %d", XISize, len(names))
+	}
+
+	players := make([]Batsman, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		player, ok := find(name)
+		if !ok {
+			return nil, fmt.Errorf("there is no %q in the squad", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%s can't bat twice", name)
+		}
+		seen[name] = true
+		players = append(players, player)
+	}
+
+	return players, nil
+}
+
+func find(name string) (Batsman, bool) {
+	for _, player := range squad {
+		if player.Name == name {
+			return player, true
+		}
+	}
+
+	return Batsman{}, false
+}
@@ -0,0 +1,117 @@
+package twitch
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/meghashyamc/cricket2d/logger"
+)
+
+const (
+	server         = "irc.chat.twitch.tv:6667"
+	dialTimeout    = 10 * time.Second
+	messageBacklog = 256 // Messages beyond this, unread by the game, are dropped
+)
+
+// Message is something said in a channel's chat
+type Message struct {
+	User string
+	Text string
+}
+
+// Chat reads a Twitch channel's chat over IRC
+type Chat struct {
+	conn     net.Conn
+	messages chan Message
+	logger   logger.Logger
+}
+
+// Connect joins a channel's chat. Without a token the chat is read anonymously, which is
+// all the game needs; the nick is then ignored.
+func Connect(channel, nick, token string) (*Chat, error) {
+	channel = strings.ToLower(strings.TrimPrefix(channel, "#"))
+	if len(channel) == 0 {
+		return nil, fmt.Errorf("twitch chat needs a channel")
+	}
+
+	conn, err := net.DialTimeout("tcp", server, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to twitch chat: %w", err)
+	}
+
+	if len(token) == 0 {
+		nick = fmt.Sprintf("justinfan%d", 10000+rand.IntN(90000))
+	} else {
+		fmt.Fprintf(conn, "PASS oauth:%s\r\n", strings.TrimPrefix(token, "oauth:"))
+	}
+	if _, err := fmt.Fprintf(conn, "NICK %s\r\nJOIN #%s\r\n", strings.ToLower(nick), channel); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("could not join twitch chat: %w", err)
+	}
+
+	c := &Chat{
+		conn:     conn,
+		messages: make(chan Message, messageBacklog),
+		logger:   logger.New(),
+	}
+	go c.read()
+
+	c.logger.Info("joined twitch chat", "channel", channel)
+	return c, nil
+}
+
+// Messages delivers the chat's messages until the connection closes
+func (c *Chat) Messages() <-chan Message {
+	return c.messages
+}
+
+func (c *Chat) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Chat) read() {
+	defer close(c.messages)
+
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "PING") {
+			fmt.Fprintf(c.conn, "PONG%s\r\n", strings.TrimPrefix(line, "PING"))
+			continue
+		}
+
+		message, ok := parsePrivmsg(line)
+		if !ok {
+			continue
+		}
+		select {
+		case c.messages <- message:
+		default:
+			// The game is behind on the chat, so this message won't be missed
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		c.logger.Warn("twitch chat connection lost", "error", err)
+	}
+}
+
+// parsePrivmsg reads a chat message from an IRC line such as
+// ":viewer!viewer@viewer.tmi.twitch.tv PRIVMSG #channel :!yorker"
+func parsePrivmsg(line string) (Message, bool) {
+	prefix, rest, ok := strings.Cut(line, " PRIVMSG ")
+	if !ok || !strings.HasPrefix(prefix, ":") {
+		return Message{}, false
+	}
+	user, _, _ := strings.Cut(strings.TrimPrefix(prefix, ":"), "!")
+	_, text, ok := strings.Cut(rest, " :")
+	if !ok {
+		return Message{}, false
+	}
+
+	return Message{User: user, Text: strings.TrimSpace(text)}, true
+}
@@ -0,0 +1,76 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	latestReleaseURL = "https://api.github.com/repos/meghashyamc/cricket2d/releases/latest"
+	changelogLines   = 3  // Lines of the release notes shown with an update
+	changelogWidth   = 70 // Characters kept from each line
+)
+
+// Release is a published version of the game
+type Release struct {
+	Tag       string   // Such as v1.3.0
+	URL       string   // Where to download it
+	Changelog []string // The first few lines of the release notes
+}
+
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+	Body    string `json:"body"`
+}
+
+// CheckForUpdate asks GitHub for the latest release, returning it if it is newer than the
+// running game
+func CheckForUpdate(ctx context.Context, client *http.Client) (Release, bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return Release{}, false, err
+	}
+	request.Header.Set("Accept", "application/vnd.github+json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return Release{}, false, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return Release{}, false, fmt.Errorf("unexpected status checking for updates: %s", response.Status)
+	}
+
+	var latest githubRelease
+	if err := json.NewDecoder(response.Body).Decode(&latest); err != nil {
+		return Release{}, false, fmt.Errorf("could not read latest release: %w", err)
+	}
+	if !newer(latest.TagName, Current()) {
+		return Release{}, false, nil
+	}
+
+	return Release{Tag: latest.TagName, URL: latest.HTMLURL, Changelog: changelog(latest.Body)}, true, nil
+}
+
+// changelog picks the first few lines worth showing out of a release's notes
+func changelog(notes string) []string {
+	lines := make([]string, 0, changelogLines)
+	for _, line := range strings.Split(notes, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(line, "#*- "))
+		if len(line) == 0 {
+			continue
+		}
+		if len(line) > changelogWidth {
+			line = line[:changelogWidth-3] + "..."
+		}
+		lines = append(lines, line)
+		if len(lines) == changelogLines {
+			break
+		}
+	}
+	return lines
+}
@@ -0,0 +1,99 @@
+// Package version describes the build of the game and checks for newer releases.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Set at build time with, for example:
+//
+//	go build -ldflags "-X github.com/meghashyamc/cricket2d/version.Version=v1.2.0 -X github.com/meghashyamc/cricket2d/version.Commit=$(git rev-parse --short HEAD) -X github.com/meghashyamc/cricket2d/version.Date=$(date -u +%F)"
+var (
+	Version = "dev"
+	Commit  = ""
+	Date    = ""
+)
+
+// Current is the version of the running game. Builds installed with go install carry their
+// module version even without ldflags.
+func Current() string {
+	if Version != "dev" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return Version
+}
+
+// IsRelease is true for builds of a tagged version, which are the only ones worth checking
+// for updates
+func IsRelease() bool {
+	_, ok := parse(Current())
+	return ok
+}
+
+// String describes the build for --version
+func String() string {
+	commit, date := Commit, Date
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && commit == "":
+				commit = setting.Value[:min(len(setting.Value), 12)]
+			case setting.Key == "vcs.time" && date == "":
+				date = setting.Value
+			}
+		}
+	}
+
+	description := fmt.Sprintf("cricket2d %s", Current())
+	if commit != "" {
+		description += fmt.Sprintf(" (%s", commit)
+		if date != "" {
+			description += ", " + date
+		}
+		description += ")"
+	}
+	return fmt.Sprintf("%s %s %s/%s", description, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
+
+// newer is true if version a is later than version b
+func newer(a, b string) bool {
+	partsA, okA := parse(a)
+	partsB, okB := parse(b)
+	if !okA || !okB {
+		return false
+	}
+
+	for i := range partsA {
+		if partsA[i] != partsB[i] {
+			return partsA[i] > partsB[i]
+		}
+	}
+	return false
+}
+
+// parse reads a version such as v1.2.3 into its numbers, ignoring any pre-release suffix
+func parse(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(version, "v")
+	version, _, _ = strings.Cut(version, "-")
+
+	fields := strings.Split(version, ".")
+	if len(fields) != len(parts) {
+		return parts, false
+	}
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = number
+	}
+	return parts, true
+}